@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// printDesiredState writes the desired state of the ingresses in the manifest file options.PrintDesiredState to w as JSON.
+func printDesiredState(w io.Writer, options *Options) error {
+	in := os.Stdin
+	if options.PrintDesiredState != "-" {
+		f, err := os.Open(options.PrintDesiredState)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	objects, err := decodeManifests(in)
+	if err != nil {
+		return err
+	}
+	states, err := controller.BuildDesiredStates(&options.ingressCTLConfig, objects)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(states)
+}
+
+// decodeManifests decodes the Kubernetes objects of a multi-document YAML or JSON stream.
+func decodeManifests(r io.Reader) ([]runtime.Object, error) {
+	var objects []runtime.Object
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if isEmptyDocument(doc) {
+			continue
+		}
+		obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(doc, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode manifest due to %v", err)
+		}
+		objects = append(objects, obj)
+	}
+}
+
+// isEmptyDocument tells whether a YAML document holds nothing but whitespace and comments.
+func isEmptyDocument(doc []byte) bool {
+	for _, line := range bytes.Split(doc, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) != 0 && !bytes.HasPrefix(line, []byte("#")) {
+			return false
+		}
+	}
+	return true
+}
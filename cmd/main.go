@@ -32,6 +32,7 @@ import (
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric/collectors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -60,11 +61,18 @@ const (
 func main() {
 	logf.SetLogger(glogr.New())
 	rand.Seed(time.Now().UnixNano())
-	fmt.Println(version.String())
 	options, err := getOptions()
 	if err != nil {
 		glog.Fatal(err)
 	}
+	if options.PrintDesiredState != "" {
+		// stdout is left to the JSON output, so that it can be piped.
+		if err := printDesiredState(os.Stdout, options); err != nil {
+			glog.Fatal(err)
+		}
+		os.Exit(0)
+	}
+	fmt.Println(version.String())
 	if options.ShowVersion {
 		os.Exit(0)
 	}
@@ -89,7 +97,7 @@ func main() {
 	reg.MustRegister(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
 
 	cc := cache.NewConfig(options.SdkCacheDuration)
-	cc.SetCacheTTL(resourcegroupstaggingapi.ServiceName, "GetResources", time.Hour)
+	cc.SetCacheTTL(resourcegroupstaggingapi.ServiceName, "GetResources", options.TaggingCacheDuration)
 	cc.SetCacheTTL(ec2.ServiceName, "DescribeInstanceStatus", time.Minute)
 	reg.MustRegister(cc.NewCacheCollector(collectors.PrometheusNamespace))
 
@@ -103,21 +111,73 @@ func main() {
 	if err != nil {
 		glog.Fatal(err)
 	}
-	if err := controller.Initialize(&options.ingressCTLConfig, mgr, mc, cloud); err != nil {
+	options.ingressCTLConfig.DryRun = options.cloudConfig.DryRun
+	reconciler, err := controller.Initialize(&options.ingressCTLConfig, mgr, mc, cloud)
+	if err != nil {
+		glog.Fatal(err)
+	}
+	if err := mgr.Add(reconciler.ClusterNameCheck()); err != nil {
 		glog.Fatal(err)
 	}
+	if err := mgr.Add(reconciler.OwnershipTagMigration()); err != nil {
+		glog.Fatal(err)
+	}
+	if err := mgr.Add(reconciler.Leadership()); err != nil {
+		glog.Fatal(err)
+	}
+	if err := mgr.Add(reconciler.TargetHealthPoll()); err != nil {
+		glog.Fatal(err)
+	}
+	if options.CleanupOrphaned {
+		if err := mgr.Add(reconciler.OrphanCleanup(options.WatchNamespace)); err != nil {
+			glog.Fatal(err)
+		}
+	}
 
 	mux := http.NewServeMux()
 	if options.ProfilingEnabled {
+		glog.Warningf("serving profiling endpoints under /debug/pprof/ on port %d", options.HealthzPort)
 		registerProfiler(mux)
 	}
-	registerHealthz(mux, aws.NewHealthChecker(cloud))
+	registerHealthz(mux, aws.NewHealthChecker(cloud,
+		options.ingressCTLConfig.FeatureGate.Enabled(config.WAFV2),
+		options.ingressCTLConfig.FeatureGate.Enabled(config.Route53)))
 	registerMetrics(mux, reg)
 	registerHandlers(mux)
+	reg.MustRegister(reconciler.ReconcileAgeCollector())
+	reg.MustRegister(reconciler.CircuitBreakerCollector())
+	reg.MustRegister(reconciler.ManagedResourcesCollector())
+	mux.Handle("/state", reconciler.StateHandler())
+	mux.Handle("/status", reconciler.StatusHandler(options.SyncPeriod))
+	mux.Handle("/target-health", reconciler.TargetHealthHandler(options.TargetHealthSyncPeriod))
+	if options.ForceReconcileTokenFile != "" {
+		token, err := options.forceReconcileToken()
+		if err != nil {
+			glog.Fatal(err)
+		}
+		mux.Handle("/reconcile", reconciler.ForceReconcileHandler(token))
+	}
 	go startHTTPServer(options.HealthzPort, mux)
+	if options.WebhookPort != 0 {
+		webhookMux := http.NewServeMux()
+		webhookMux.Handle("/validate-ingress", reconciler.AdmissionHandler())
+		go startHTTPSServer(options.WebhookPort, webhookMux, options.WebhookCertFile, options.WebhookKeyFile)
+	}
 
-	if err := mgr.Start(signals.SetupSignalHandler()); err != nil {
-		glog.Fatal(err)
+	// Start only runs the reconcile loops once this replica is the leader, and returns an error if leadership is lost.
+	// Either way in-flight reconciles are given the grace period to finish, so AWS resources aren't left half configured.
+	startErr := mgr.Start(signals.SetupSignalHandler())
+	if startErr != nil {
+		glog.Errorf("Controller stopped: %v", startErr)
+	}
+
+	glog.Infof("Waiting up to %v for in-flight reconciles to finish", options.ShutdownGracePeriod)
+	if !reconciler.WaitForInflightReconciles(options.ShutdownGracePeriod) {
+		glog.Warningf("Shutdown grace period of %v elapsed with reconciles still in flight", options.ShutdownGracePeriod)
+	}
+	if startErr != nil {
+		glog.Flush()
+		os.Exit(1)
 	}
 }
 
@@ -192,3 +252,15 @@ func startHTTPServer(port int, mux *http.ServeMux) {
 	}
 	glog.Fatal(server.ListenAndServe())
 }
+
+func startHTTPSServer(port int, mux *http.ServeMux, certFile string, keyFile string) {
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%v", port),
+		Handler:           mux,
+		ReadTimeout:       10 * time.Second,
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      10 * time.Second,
+		IdleTimeout:       120 * time.Second,
+	}
+	glog.Fatal(server.ListenAndServeTLS(certFile, keyFile))
+}
@@ -19,7 +19,9 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"strings"
 	"time"
 
 	"k8s.io/klog"
@@ -28,8 +30,10 @@ import (
 
 	"github.com/spf13/pflag"
 
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/net"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 	apiv1 "k8s.io/api/core/v1"
 )
 
@@ -41,9 +45,13 @@ const (
 	defaultSyncPeriod              = 60 * time.Minute
 	defaultHealthCheckPeriod       = 1 * time.Minute
 	defaultHealthzPort             = 10254
-	defaultProfilingEnabled        = true
+	defaultProfilingEnabled        = false
 	defaultEnableSdkCache          = false
 	defaultSdkCacheDuration        = 5 * time.Minute
+	defaultTaggingCacheDuration    = 1 * time.Hour
+	defaultShutdownGracePeriod     = 20 * time.Second
+	defaultWebhookPort             = 0
+	defaultTargetHealthSyncPeriod  = 1 * time.Minute
 )
 
 // Options defines the commandline interface of this binary
@@ -72,6 +80,32 @@ type Options struct {
 	// aws sdk cache options
 	EnableSdkCache   bool
 	SdkCacheDuration time.Duration
+
+	// TaggingCacheDuration is how long tag lookups through the Resource Groups Tagging API are cached
+	TaggingCacheDuration time.Duration
+
+	// ShutdownGracePeriod is how long to wait for in-flight reconciles after receiving a termination signal
+	ShutdownGracePeriod time.Duration
+
+	// WebhookPort serves the validating admission webhook for ingresses over TLS, it is disabled when 0
+	WebhookPort     int
+	WebhookCertFile string
+	WebhookKeyFile  string
+
+	// TargetHealthSyncPeriod is how long target health served on /target-health is cached
+	TargetHealthSyncPeriod time.Duration
+
+	// CleanupOrphaned deletes LoadBalancers whose ingress was deleted while the controller was down
+	CleanupOrphaned bool
+
+	// PrintDesiredState is a manifest file to print the desired state of the ingresses of instead of running the controller
+	PrintDesiredState string
+
+	// LogFormat is the format ingress reconciles are logged in, text or json
+	LogFormat string
+
+	// ForceReconcileTokenFile holds the bearer token of the /reconcile endpoint, which is disabled when empty
+	ForceReconcileTokenFile string
 }
 
 func (options *Options) BindFlags(fs *pflag.FlagSet) {
@@ -83,10 +117,12 @@ func (options *Options) BindFlags(fs *pflag.FlagSet) {
 		program runs inside a Kubernetes cluster and local discovery is attempted.`)
 	fs.StringVar(&options.KubeConfigFile, "kubeconfig", "",
 		`Path to a kubeconfig file containing authorization and API server information.`)
+	fs.BoolVar(&options.LeaderElection, "enable-leader-election", defaultLeaderElection,
+		`Whether to elect a leader among controller replicas. Only the leader reconciles ingresses, the others wait to take over.`)
 	fs.BoolVar(&options.LeaderElection, "election", defaultLeaderElection,
 		`Whether we do leader election for ingress controller`)
 	fs.StringVar(&options.LeaderElectionID, "election-id", defaultLeaderElectionID,
-		`Namespace of leader-election configmap for ingress controller`)
+		`Name of leader-election configmap for ingress controller`)
 	fs.StringVar(&options.LeaderElectionNamespace, "election-namespace", defaultLeaderElectionNamespace,
 		`Namespace of leader-election configmap for ingress controller. If unspecified, the namespace of this controller pod will be used`)
 	fs.StringVar(&options.WatchNamespace, "watch-namespace", defaultWatchNamespace,
@@ -94,18 +130,43 @@ func (options *Options) BindFlags(fs *pflag.FlagSet) {
 		This includes Ingresses, Services and all configuration resources. All
 		namespaces are watched if this parameter is left empty.`)
 	fs.DurationVar(&options.SyncPeriod, "sync-period", defaultSyncPeriod,
-		`Period at which the controller forces the repopulation of its local object stores.`)
+		`Period at which the controller forces the repopulation of its local object stores, reconciling every ingress.
+		Disabled if 0, in which case ingresses are only reconciled when they, or the objects they depend on, change.`)
 	fs.DurationVar(&options.HealthCheckPeriod, "health-check-period", defaultHealthCheckPeriod,
 		`Period at which the controller executes AWS health checks for its healthz endpoint.`)
 	fs.IntVar(&options.HealthzPort, "healthz-port", defaultHealthzPort,
 		`Port to use for the healthz endpoint.`)
+	fs.BoolVar(&options.ProfilingEnabled, "enable-pprof", defaultProfilingEnabled,
+		`Serve the net/http/pprof profiling endpoints under /debug/pprof/ on the healthz port. They expose the internals of the controller, so only enable them while profiling.`)
 	fs.BoolVar(&options.ProfilingEnabled, "profiling", defaultProfilingEnabled,
 		`Enable profiling via web interface host:port/debug/pprof/`)
 	fs.BoolVar(&options.EnableSdkCache, "aws-cache-enable", defaultEnableSdkCache, "Enables AWS SDK Caching")
 	fs.DurationVar(&options.SdkCacheDuration, "aws-cache-duration", defaultSdkCacheDuration, "Duration of AWS SDK Cache entries, default 5m")
+	fs.DurationVar(&options.TaggingCacheDuration, "aws-cache-tagging-duration", defaultTaggingCacheDuration, "Duration of AWS SDK Cache entries for resource tag lookups, default 1h")
+	fs.DurationVar(&options.ShutdownGracePeriod, "shutdown-grace-period", defaultShutdownGracePeriod,
+		`Maximum time to wait for in-flight reconciles to finish after the controller is asked to stop.`)
+	fs.IntVar(&options.WebhookPort, "webhook-port", defaultWebhookPort,
+		`Port to serve the validating admission webhook for ingresses on, at path /validate-ingress. The webhook is disabled if 0.`)
+	fs.StringVar(&options.WebhookCertFile, "webhook-cert-file", "",
+		`Path to the TLS certificate served by the admission webhook.`)
+	fs.StringVar(&options.WebhookKeyFile, "webhook-key-file", "",
+		`Path to the TLS private key of the admission webhook certificate.`)
+	fs.DurationVar(&options.TargetHealthSyncPeriod, "target-health-sync-period", defaultTargetHealthSyncPeriod,
+		`Period at which the target health served on host:port/target-health is refreshed from AWS.`)
+	fs.BoolVar(&options.CleanupOrphaned, "cleanup-orphaned", false,
+		`Delete, on startup, the LoadBalancers of the cluster whose ingress no longer exists, e.g. because it was deleted while the controller was down.`)
+	fs.StringVar(&options.PrintDesiredState, "print-desired-state", "",
+		`Path to a file of Kubernetes manifests, or - for stdin. If set, prints the LoadBalancers, listeners, rules and targetGroups
+		the controller would configure for the ingresses in it as JSON and exits, without calling AWS or the Kubernetes API server.`)
+	fs.StringVar(&options.LogFormat, "log-format", log.FormatText,
+		`Format of the messages logged while reconciling ingresses, "text" or "json". JSON messages carry the namespace and name of the ingress as fields.`)
+	fs.StringVar(&options.ForceReconcileTokenFile, "force-reconcile-token-file", "",
+		`Path to a file holding a bearer token. If set, POSTing to host:port/reconcile with that token reconciles every ingress right away.`)
 	options.cloudConfig.BindFlags(fs)
 	options.ingressCTLConfig.BindFlags(fs)
 
+	_ = fs.MarkDeprecated("election", `Use --enable-leader-election instead`)
+	_ = fs.MarkDeprecated("profiling", `Use --enable-pprof instead`)
 	_ = fs.MarkDeprecated("aws-sync-period", `No longer used, will be removed in next release`)
 	_ = fs.MarkDeprecated("default-backend-service", `No longer used, will be removed in next release`)
 }
@@ -121,15 +182,74 @@ func (options *Options) BindEnv() error {
 }
 
 func (options *Options) Validate() error {
+	if err := log.SetFormat(options.LogFormat); err != nil {
+		return err
+	}
+	if err := options.cloudConfig.Validate(); err != nil {
+		return err
+	}
+	if options.PrintDesiredState != "" {
+		// nothing gets served or polled when only printing the desired state.
+		return options.ingressCTLConfig.Validate()
+	}
 	if !net.IsPortAvailable(options.HealthzPort) {
 		return fmt.Errorf("port %v is already in use. Please check the flag --healthz-port", options.HealthzPort)
 	}
+	if options.WebhookPort != 0 {
+		if options.WebhookPort == options.HealthzPort {
+			return fmt.Errorf("webhook port must differ from the healthz port %v", options.HealthzPort)
+		}
+		if !net.IsPortAvailable(options.WebhookPort) {
+			return fmt.Errorf("port %v is already in use. Please check the flag --webhook-port", options.WebhookPort)
+		}
+		if options.WebhookCertFile == "" || options.WebhookKeyFile == "" {
+			return fmt.Errorf("--webhook-cert-file and --webhook-key-file are required when --webhook-port is set")
+		}
+	}
+	if options.SyncPeriod < 0 {
+		return fmt.Errorf("sync period must not be negative, got %v", options.SyncPeriod)
+	}
+	if options.TargetHealthSyncPeriod <= 0 {
+		return fmt.Errorf("target health sync period must be positive, got %v", options.TargetHealthSyncPeriod)
+	}
+	// the controller's cache only sees objects within the watched namespace, so the restrict-scheme ConfigMap must live there too.
+	if options.WatchNamespace != apiv1.NamespaceAll && options.ingressCTLConfig.RestrictScheme &&
+		options.ingressCTLConfig.RestrictSchemeNamespace != options.WatchNamespace {
+		return fmt.Errorf("restrict-scheme-namespace must be the watched namespace %v when --watch-namespace is set, got %v",
+			options.WatchNamespace, options.ingressCTLConfig.RestrictSchemeNamespace)
+	}
+	if options.WatchNamespace != apiv1.NamespaceAll && options.ingressCTLConfig.DefaultsConfigMap != "" &&
+		!strings.HasPrefix(options.ingressCTLConfig.DefaultsConfigMap, options.WatchNamespace+"/") {
+		return fmt.Errorf("defaults-configmap must be in the watched namespace %v when --watch-namespace is set, got %v",
+			options.WatchNamespace, options.ingressCTLConfig.DefaultsConfigMap)
+	}
+	if options.ForceReconcileTokenFile != "" {
+		if _, err := options.forceReconcileToken(); err != nil {
+			return err
+		}
+	}
+	if !loadbalancer.IsSSLPolicy(options.ingressCTLConfig.DefaultSSLPolicy) {
+		return fmt.Errorf("unknown SSL policy %v. Please check the flag --default-ssl-policy", options.ingressCTLConfig.DefaultSSLPolicy)
+	}
 	if err := options.ingressCTLConfig.Validate(); err != nil {
 		return err
 	}
 	return nil
 }
 
+// forceReconcileToken reads the bearer token of the /reconcile endpoint from ForceReconcileTokenFile.
+func (options *Options) forceReconcileToken() (string, error) {
+	b, err := ioutil.ReadFile(options.ForceReconcileTokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read --force-reconcile-token-file due to %v", err)
+	}
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return "", fmt.Errorf("--force-reconcile-token-file %v is empty", options.ForceReconcileTokenFile)
+	}
+	return token, nil
+}
+
 func getOptions() (*Options, error) {
 	options := &Options{
 		ingressCTLConfig: config.NewConfiguration(),
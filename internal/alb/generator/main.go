@@ -10,11 +10,17 @@ type NameTagGenerator struct {
 func NewNameTagGenerator(cfg config.Configuration) *NameTagGenerator {
 	return &NameTagGenerator{
 		NameGenerator{
-			ALBNamePrefix: cfg.ALBNamePrefix,
+			ALBNamePrefix:         cfg.ALBNamePrefix,
+			TargetGroupNamePrefix: cfg.TargetGroupNamePrefix,
+			ClusterName:           cfg.ClusterName,
 		},
 		TagGenerator{
 			ClusterName: cfg.ClusterName,
 			DefaultTags: cfg.DefaultTags,
+			defaults:    cfg.Defaults,
+			TagPrefix:   cfg.TagPrefix,
+
+			OwnershipTagValue: cfg.OwnershipTagValue,
 		},
 	}
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/targetgroup"
 )
 
 var _ tg.NameGenerator = (*NameGenerator)(nil)
@@ -18,9 +19,35 @@ var _ sg.NameGenerator = (*NameGenerator)(nil)
 
 type NameGenerator struct {
 	ALBNamePrefix string
+	// TargetGroupNamePrefix replaces ALBNamePrefix in targetGroup names, unless it's empty
+	TargetGroupNamePrefix string
+	ClusterName           string
 }
 
+// NameLB generates the LoadBalancer name for an ingress. It ends with a hash of the cluster name, namespace
+// and ingress name, so that clusters sharing an ALBNamePrefix don't generate the same LoadBalancer name.
 func (gen *NameGenerator) NameLB(namespace string, ingressName string) string {
+	hasher := md5.New()
+	_, _ = hasher.Write([]byte(gen.ClusterName + "/" + namespace + "/" + ingressName))
+	hash := hex.EncodeToString(hasher.Sum(nil))[:8]
+
+	r, _ := regexp.Compile("[[:^alnum:]]")
+	name := fmt.Sprintf("%s-%s-%s",
+		r.ReplaceAllString(gen.ALBNamePrefix, "-"),
+		r.ReplaceAllString(namespace, ""),
+		r.ReplaceAllString(ingressName, ""),
+	)
+	if len(name) > 23 {
+		name = name[:23]
+	}
+	name = name + "-" + hash
+	return name
+}
+
+// LegacyNameLB generates the LoadBalancer name used before NameLB included the cluster name,
+// so that LoadBalancers created under it can still be found.
+// Names of targetGroups and securityGroups are still derived from it, so they remain stable across upgrades.
+func (gen *NameGenerator) LegacyNameLB(namespace string, ingressName string) string {
 	hasher := md5.New()
 	_, _ = hasher.Write([]byte(namespace + ingressName))
 	hash := hex.EncodeToString(hasher.Sum(nil))[:4]
@@ -38,9 +65,14 @@ func (gen *NameGenerator) NameLB(namespace string, ingressName string) string {
 	return name
 }
 
+// NameTG generates the targetGroup name for a backend of an ingress. It's a hash of the service, port, protocol, protocol version,
+// target type and IP address type, the settings a targetGroup can't be modified in place for, so editing the paths or rules of
+// an ingress reuses the targetGroups of the backends it keeps, along with their registered targets. Other settings, e.g. health
+// checks, are modified in place.
+// The hash is still derived from the ALBNamePrefix, so setting a TargetGroupNamePrefix only changes the prefix of the name.
 func (gen *NameGenerator) NameTG(namespace string, ingressName string, serviceName, servicePort string,
-	targetType string, protocol string) string {
-	LBName := gen.NameLB(namespace, ingressName)
+	targetType string, protocol string, protocolVersion string, ipAddressType string) string {
+	LBName := gen.LegacyNameLB(namespace, ingressName)
 
 	hasher := md5.New()
 	_, _ = hasher.Write([]byte(LBName))
@@ -48,14 +80,26 @@ func (gen *NameGenerator) NameTG(namespace string, ingressName string, serviceNa
 	_, _ = hasher.Write([]byte(servicePort))
 	_, _ = hasher.Write([]byte(protocol))
 	_, _ = hasher.Write([]byte(targetType))
+	// HTTP1 is left out, so that targetGroups created before protocol versions were supported keep their names.
+	if protocolVersion != targetgroup.BackendProtocolVersionHTTP1 {
+		_, _ = hasher.Write([]byte(protocolVersion))
+	}
+	// likewise for ipv4, the IP address type of targetGroups created before IP address types were supported.
+	if ipAddressType != targetgroup.IPAddressTypeIPv4 {
+		_, _ = hasher.Write([]byte(ipAddressType))
+	}
 
-	return fmt.Sprintf("%.12s-%.19s", gen.ALBNamePrefix, hex.EncodeToString(hasher.Sum(nil)))
+	prefix := gen.TargetGroupNamePrefix
+	if prefix == "" {
+		prefix = gen.ALBNamePrefix
+	}
+	return fmt.Sprintf("%.12s-%.19s", prefix, hex.EncodeToString(hasher.Sum(nil)))
 }
 
 func (gen *NameGenerator) NameLBSG(namespace string, ingressName string) string {
-	return gen.NameLB(namespace, ingressName)
+	return gen.LegacyNameLB(namespace, ingressName)
 }
 
 func (gen *NameGenerator) NameInstanceSG(namespace string, ingressName string) string {
-	return "instance-" + gen.NameLB(namespace, ingressName)
+	return "instance-" + gen.LegacyNameLB(namespace, ingressName)
 }
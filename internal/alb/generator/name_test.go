@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NameLB(t *testing.T) {
+	gen := NameGenerator{ALBNamePrefix: "prefix", ClusterName: "cluster"}
+	assert.Equal(t, "prefix-ns-ing-3d525fb5", gen.NameLB("ns", "ing"))
+
+	name := gen.NameLB("a-very-long-namespace", "a-very-long-ingress-name")
+	assert.Len(t, name, 32)
+	assert.Equal(t, "prefix-averylongnamespa-", name[:24])
+
+	other := NameGenerator{ALBNamePrefix: "prefix", ClusterName: "other-cluster"}
+	assert.NotEqual(t, name, other.NameLB("a-very-long-namespace", "a-very-long-ingress-name"))
+}
+
+func Test_LegacyNameLB(t *testing.T) {
+	gen := NameGenerator{ALBNamePrefix: "prefix", ClusterName: "cluster"}
+	assert.Equal(t, "prefix-ns-ing-0828", gen.LegacyNameLB("ns", "ing"))
+
+	other := NameGenerator{ALBNamePrefix: "prefix", ClusterName: "other-cluster"}
+	assert.Equal(t, gen.LegacyNameLB("ns", "ing"), other.LegacyNameLB("ns", "ing"))
+	assert.Equal(t, "instance-prefix-ns-ing-0828", gen.NameInstanceSG("ns", "ing"))
+}
+
+func Test_NameTG(t *testing.T) {
+	gen := NameGenerator{ALBNamePrefix: "prefix", ClusterName: "cluster"}
+	name := gen.NameTG("ns", "ing", "service", "80", "instance", "HTTP", "HTTP1", "ipv4")
+	assert.Len(t, name, 26)
+	assert.Equal(t, "prefix-", name[:7])
+	assert.Equal(t, name, gen.NameTG("ns", "ing", "service", "80", "instance", "HTTP", "HTTP1", "ipv4"))
+
+	for _, other := range []string{
+		gen.NameTG("ns", "other-ing", "service", "80", "instance", "HTTP", "HTTP1", "ipv4"),
+		gen.NameTG("ns", "ing", "other-service", "80", "instance", "HTTP", "HTTP1", "ipv4"),
+		gen.NameTG("ns", "ing", "service", "8080", "instance", "HTTP", "HTTP1", "ipv4"),
+		gen.NameTG("ns", "ing", "service", "80", "ip", "HTTP", "HTTP1", "ipv4"),
+		gen.NameTG("ns", "ing", "service", "80", "instance", "HTTPS", "HTTP1", "ipv4"),
+		gen.NameTG("ns", "ing", "service", "80", "instance", "HTTP", "GRPC", "ipv4"),
+		gen.NameTG("ns", "ing", "service", "80", "ip", "HTTP", "HTTP1", "ipv6"),
+	} {
+		assert.NotEqual(t, name, other)
+	}
+
+	tgGen := NameGenerator{ALBNamePrefix: "prefix", TargetGroupNamePrefix: "tg-prefix", ClusterName: "cluster"}
+	tgName := tgGen.NameTG("ns", "ing", "service", "80", "instance", "HTTP", "HTTP1", "ipv4")
+	assert.Equal(t, "tg-prefix-", tgName[:10])
+	assert.Equal(t, name[7:], tgName[10:])
+}
@@ -2,19 +2,34 @@ package generator
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/sg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 )
 
-// Standard tag key names
+// DefaultTagPrefix is the prefix of the standard tag keys unless TagGenerator.TagPrefix overrides it.
+const DefaultTagPrefix = "kubernetes.io"
+
+// Standard tag names, prefixed with the tag prefix to build tag keys.
+const (
+	tagNameClusterName = "cluster-name"
+	tagNameNamespace   = "namespace"
+	tagNameIngressName = "ingress-name"
+	tagNameServiceName = "service-name"
+	tagNameServicePort = "service-port"
+)
+
+// Standard tag key names, with the default tag prefix
 const (
-	TagKeyClusterName = "kubernetes.io/cluster-name"
-	TagKeyNamespace   = "kubernetes.io/namespace"
-	TagKeyIngressName = "kubernetes.io/ingress-name"
-	TagKeyServiceName = "kubernetes.io/service-name"
-	TagKeyServicePort = "kubernetes.io/service-port"
+	TagKeyClusterName = DefaultTagPrefix + "/" + tagNameClusterName
+	TagKeyNamespace   = DefaultTagPrefix + "/" + tagNameNamespace
+	TagKeyIngressName = DefaultTagPrefix + "/" + tagNameIngressName
+	TagKeyServiceName = DefaultTagPrefix + "/" + tagNameServiceName
+	TagKeyServicePort = DefaultTagPrefix + "/" + tagNameServicePort
 )
 
 // Additional Tags used to be forward-compatible with V2 version.
@@ -34,6 +49,23 @@ var _ sg.TagGenerator = (*TagGenerator)(nil)
 type TagGenerator struct {
 	ClusterName string
 	DefaultTags map[string]string
+	// defaults returns the fleet-wide defaults, whose tags replace DefaultTags when set.
+	defaults func() config.Defaults
+
+	// TagPrefix replaces DefaultTagPrefix in the standard tag keys.
+	// Resources are discovered by the same tags they're created with, so changing it orphans existing resources.
+	TagPrefix string
+
+	// OwnershipTagValue is the value of the tags.KeyOwnership tag of every resource, ClusterName if empty.
+	OwnershipTagValue string
+}
+
+// defaultTags returns the tags added to every resource.
+func (gen *TagGenerator) defaultTags() map[string]string {
+	if gen.defaults != nil {
+		return gen.defaults().Tags
+	}
+	return gen.DefaultTags
 }
 
 func (gen *TagGenerator) TagLB(namespace string, ingressName string) map[string]string {
@@ -42,14 +74,49 @@ func (gen *TagGenerator) TagLB(namespace string, ingressName string) map[string]
 	return resTags
 }
 
+// OwnsLB tells whether the LoadBalancer tagged with lbTags belongs to the ingress, rather than to another ingress, another
+// controller or no controller at all. LoadBalancers created before the ownership tag was introduced are told apart by the
+// cluster tags instead, until their next reconcile adds it.
+func (gen *TagGenerator) OwnsLB(namespace string, ingressName string, lbTags map[string]string) bool {
+	if !gen.taggedForIngress(namespace, ingressName, lbTags) {
+		return false
+	}
+	if owner, ok := lbTags[tags.KeyOwnership]; ok {
+		return owner == gen.ownershipTagValue()
+	}
+	return lbTags[V2TagKeyClusterID] == gen.ClusterName || lbTags["kubernetes.io/cluster/"+gen.ClusterName] == "owned"
+}
+
+// taggedForIngress tells whether resTags name the ingress, by its namespace and ingress name tags or by its stack tag.
+func (gen *TagGenerator) taggedForIngress(namespace string, ingressName string, resTags map[string]string) bool {
+	if resTags[gen.tagKey(tagNameNamespace)] == namespace && resTags[gen.tagKey(tagNameIngressName)] == ingressName {
+		return true
+	}
+	return resTags[V2TagKeyStackID] == gen.buildV2StackID(namespace, ingressName)
+}
+
+// ClaimsLB tells whether lbTags mark a LoadBalancer as managed for some ingress, by this or another controller,
+// as opposed to a LoadBalancer created outside of Kubernetes.
+func (gen *TagGenerator) ClaimsLB(lbTags map[string]string) bool {
+	for k := range lbTags {
+		switch {
+		case k == tags.KeyOwnership, k == V2TagKeyClusterID, k == V2TagKeyStackID,
+			k == gen.tagKey(tagNameNamespace), k == gen.tagKey(tagNameIngressName),
+			strings.HasPrefix(k, "kubernetes.io/cluster/"):
+			return true
+		}
+	}
+	return false
+}
+
 func (gen *TagGenerator) TagTGGroup(namespace string, ingressName string) map[string]string {
 	return gen.tagIngressResources(namespace, ingressName)
 }
 
 func (gen *TagGenerator) TagTG(namespace string, ingressName string, serviceName string, servicePort string) map[string]string {
 	resTags := map[string]string{
-		TagKeyServiceName: serviceName,
-		TagKeyServicePort: servicePort,
+		gen.tagKey(tagNameServiceName): serviceName,
+		gen.tagKey(tagNameServicePort): servicePort,
 	}
 	resID := gen.buildV2TargetGroupID(namespace, ingressName, serviceName, servicePort)
 	resTags[V2TagKeyResourceID] = resID
@@ -68,12 +135,12 @@ func (gen *TagGenerator) TagInstanceSG(namespace string, ingressName string) map
 
 func (gen *TagGenerator) tagIngressResources(namespace string, ingressName string) map[string]string {
 	m := make(map[string]string)
-	for label, value := range gen.DefaultTags {
+	for label, value := range gen.defaultTags() {
 		m[label] = value
 	}
 	m["kubernetes.io/cluster/"+gen.ClusterName] = "owned"
-	m[TagKeyNamespace] = namespace
-	m[TagKeyIngressName] = ingressName
+	m[gen.tagKey(tagNameNamespace)] = namespace
+	m[gen.tagKey(tagNameIngressName)] = ingressName
 
 	v2Tags := gen.tagIngressResourcesV2(namespace, ingressName)
 	for label, value := range v2Tags {
@@ -88,7 +155,7 @@ func (gen *TagGenerator) tagIngressResources(namespace string, ingressName strin
 
 func (gen *TagGenerator) tagSGs(namespace string, ingressName string) map[string]string {
 	m := make(map[string]string)
-	for label, value := range gen.DefaultTags {
+	for label, value := range gen.defaultTags() {
 		m[label] = value
 	}
 	// To avoid conflict with core k8s, we don't tag SGs with `kubernetes.io/cluster/clusterName` since
@@ -96,10 +163,10 @@ func (gen *TagGenerator) tagSGs(namespace string, ingressName string) map[string
 	// see https://github.com/kubernetes/kubernetes/blob/e056703ea7474990f5d7c58813082065543187eb/pkg/cloudprovider/providers/aws/aws.go#L3768
 	// A more sensible approach in the future should be change the out-of-tree cloud-provider-aws for more advanced SG discovery mechanism.
 	// we can do it when out-of-tree cloud-provider-aws is stable.
-	m[TagKeyClusterName] = gen.ClusterName
+	m[gen.tagKey(tagNameClusterName)] = gen.ClusterName
 
-	m[TagKeyNamespace] = namespace
-	m[TagKeyIngressName] = ingressName
+	m[gen.tagKey(tagNameNamespace)] = namespace
+	m[gen.tagKey(tagNameIngressName)] = ingressName
 
 	v2Tags := gen.tagIngressResourcesV2(namespace, ingressName)
 	for label, value := range v2Tags {
@@ -114,9 +181,27 @@ func (gen *TagGenerator) tagIngressResourcesV2(namespace string, ingressName str
 	stackID := gen.buildV2StackID(namespace, ingressName)
 	m[V2TagKeyClusterID] = gen.ClusterName
 	m[V2TagKeyStackID] = stackID
+	m[tags.KeyOwnership] = gen.ownershipTagValue()
 	return m
 }
 
+// ownershipTagValue returns the value of the ownership tag.
+func (gen *TagGenerator) ownershipTagValue() string {
+	if gen.OwnershipTagValue == "" {
+		return gen.ClusterName
+	}
+	return gen.OwnershipTagValue
+}
+
+// tagKey returns the key of the standard tag name under the tag prefix.
+func (gen *TagGenerator) tagKey(name string) string {
+	prefix := gen.TagPrefix
+	if prefix == "" {
+		prefix = DefaultTagPrefix
+	}
+	return prefix + "/" + name
+}
+
 // buildV2StackID returns the stack ID that compatible with V2 version.
 func (gen *TagGenerator) buildV2StackID(namespace string, ingressName string) string {
 	return fmt.Sprintf("%s/%s", namespace, ingressName)
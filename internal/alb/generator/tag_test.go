@@ -21,6 +21,7 @@ func Test_TagLB(t *testing.T) {
 		"ingress.k8s.aws/cluster":  "cluster",
 		"ingress.k8s.aws/stack":    "namespace/ingress",
 		"ingress.k8s.aws/resource": "LoadBalancer",
+		"elbv2.k8s.aws/cluster":    "cluster",
 		"key":                      "value",
 	}
 
@@ -41,6 +42,7 @@ func Test_TagTGGroup(t *testing.T) {
 
 		"ingress.k8s.aws/cluster": "cluster",
 		"ingress.k8s.aws/stack":   "namespace/ingress",
+		"elbv2.k8s.aws/cluster":   "cluster",
 		"key":                     "value",
 	}
 
@@ -56,3 +58,126 @@ func Test_TagTG(t *testing.T) {
 	}
 	assert.Equal(t, gen.TagTG("namespace", "ingress", "service", "port"), expected)
 }
+
+func Test_TagPrefix(t *testing.T) {
+	gen := TagGenerator{
+		ClusterName:       "cluster",
+		TagPrefix:         "example.com",
+		OwnershipTagValue: "owner",
+	}
+	assert.Equal(t, map[string]string{
+		"kubernetes.io/cluster/cluster": "owned",
+		"example.com/ingress-name":      "ingress",
+		"example.com/namespace":         "namespace",
+
+		"ingress.k8s.aws/cluster":  "cluster",
+		"ingress.k8s.aws/stack":    "namespace/ingress",
+		"ingress.k8s.aws/resource": "LoadBalancer",
+		"elbv2.k8s.aws/cluster":    "owner",
+	}, gen.TagLB("namespace", "ingress"))
+	assert.Equal(t, map[string]string{
+		"example.com/service-name": "service",
+		"example.com/service-port": "port",
+		"ingress.k8s.aws/resource": "namespace/ingress-service:port",
+	}, gen.TagTG("namespace", "ingress", "service", "port"))
+	assert.Equal(t, map[string]string{
+		"example.com/cluster-name": "cluster",
+		"example.com/ingress-name": "ingress",
+		"example.com/namespace":    "namespace",
+
+		"ingress.k8s.aws/cluster":  "cluster",
+		"ingress.k8s.aws/stack":    "namespace/ingress",
+		"ingress.k8s.aws/resource": "ManagedLBSecurityGroup",
+		"elbv2.k8s.aws/cluster":    "owner",
+	}, gen.TagLBSG("namespace", "ingress"))
+}
+
+func Test_OwnsLB(t *testing.T) {
+	gen := TagGenerator{ClusterName: "cluster", OwnershipTagValue: "owner"}
+	for _, tc := range []struct {
+		Name     string
+		LBTags   map[string]string
+		Expected bool
+	}{
+		{
+			Name:     "tagged for the controller",
+			LBTags:   gen.TagLB("namespace", "ingress"),
+			Expected: true,
+		},
+		{
+			Name: "tagged for another controller of the same cluster name",
+			LBTags: map[string]string{
+				"elbv2.k8s.aws/cluster":   "other-owner",
+				"ingress.k8s.aws/cluster": "cluster",
+				TagKeyNamespace:           "namespace",
+				TagKeyIngressName:         "ingress",
+			},
+		},
+		{
+			Name: "created before the ownership tag",
+			LBTags: map[string]string{
+				"ingress.k8s.aws/cluster": "cluster",
+				TagKeyNamespace:           "namespace",
+				TagKeyIngressName:         "ingress",
+			},
+			Expected: true,
+		},
+		{
+			Name: "created before the ingress.k8s.aws tags",
+			LBTags: map[string]string{
+				"kubernetes.io/cluster/cluster": "owned",
+				TagKeyNamespace:                 "namespace",
+				TagKeyIngressName:               "ingress",
+			},
+			Expected: true,
+		},
+		{
+			Name: "of another ingress",
+			LBTags: map[string]string{
+				"ingress.k8s.aws/cluster": "cluster",
+				TagKeyNamespace:           "namespace",
+				TagKeyIngressName:         "other-ingress",
+			},
+		},
+		{
+			Name: "tagged for another ingress of the controller",
+			LBTags: map[string]string{
+				"elbv2.k8s.aws/cluster":   "owner",
+				"ingress.k8s.aws/cluster": "cluster",
+				"ingress.k8s.aws/stack":   "namespace/other-ingress",
+				TagKeyNamespace:           "namespace",
+				TagKeyIngressName:         "other-ingress",
+			},
+		},
+		{
+			Name: "tagged for the controller by the stack tag only",
+			LBTags: map[string]string{
+				"elbv2.k8s.aws/cluster": "owner",
+				"ingress.k8s.aws/stack": "namespace/ingress",
+			},
+			Expected: true,
+		},
+		{
+			Name:   "tagged for the controller without naming an ingress",
+			LBTags: map[string]string{"elbv2.k8s.aws/cluster": "owner"},
+		},
+		{
+			Name:   "not managed by Kubernetes",
+			LBTags: map[string]string{"team": "payments"},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, gen.OwnsLB("namespace", "ingress", tc.LBTags))
+		})
+	}
+}
+
+func Test_ClaimsLB(t *testing.T) {
+	gen := TagGenerator{ClusterName: "cluster", OwnershipTagValue: "owner"}
+	assert.True(t, gen.ClaimsLB(gen.TagLB("namespace", "ingress")))
+	assert.True(t, gen.ClaimsLB(map[string]string{"elbv2.k8s.aws/cluster": "other-owner"}))
+	assert.True(t, gen.ClaimsLB(map[string]string{"kubernetes.io/cluster/other-cluster": "owned"}))
+	assert.True(t, gen.ClaimsLB(map[string]string{TagKeyIngressName: "other-ingress"}))
+	assert.False(t, gen.ClaimsLB(map[string]string{"team": "payments", "aws:cloudformation:stack-name": "edge"}))
+	assert.False(t, gen.ClaimsLB(nil))
+}
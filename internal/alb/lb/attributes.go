@@ -3,8 +3,11 @@ package lb
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
@@ -20,6 +23,8 @@ const (
 	IdleTimeoutTimeoutSecondsKey      = "idle_timeout.timeout_seconds"
 	RoutingHTTP2EnabledKey            = "routing.http2.enabled"
 	DropInvalidHeaderFieldsEnabledKey = "routing.http.drop_invalid_header_fields.enabled"
+	XFFHeaderProcessingModeKey        = "routing.http.xff_header_processing.mode"
+	XFFClientPortEnabledKey           = "routing.http.xff_client_port.enabled"
 
 	DeletionProtectionEnabled      = false
 	AccessLogsS3Enabled            = false
@@ -28,6 +33,15 @@ const (
 	IdleTimeoutTimeoutSeconds      = 60
 	RoutingHTTP2Enabled            = true
 	DropInvalidHeaderFieldsEnabled = false
+	XFFHeaderProcessingMode        = XFFHeaderProcessingModeAppend
+	XFFClientPortEnabled           = false
+)
+
+// Modes of X-Forwarded-For header processing accepted by ELBV2.
+const (
+	XFFHeaderProcessingModeAppend   = "append"
+	XFFHeaderProcessingModePreserve = "preserve"
+	XFFHeaderProcessingModeRemove   = "remove"
 )
 
 // Attributes represents the desired state of attributes for a load balancer.
@@ -61,6 +75,14 @@ type Attributes struct {
 	// DropInvalidHeaderFieldsEnabled: routing.http.drop_invalid_header_fields.enabled - Indicates if
 	// invalid headers will be dropped. The default is false.
 	DropInvalidHeaderFieldsEnabled bool
+
+	// XFFHeaderProcessingMode: routing.http.xff_header_processing.mode - How the X-Forwarded-For header
+	// is modified before the request is sent to targets. The value is append, preserve or remove. The default is append.
+	XFFHeaderProcessingMode string
+
+	// XFFClientPortEnabled: routing.http.xff_client_port.enabled - Indicates whether the X-Forwarded-For header
+	// preserves the source port the client used to connect. The default is false.
+	XFFClientPortEnabled bool
 }
 
 func NewAttributes(attrs []*elbv2.LoadBalancerAttribute) (a *Attributes, err error) {
@@ -72,20 +94,26 @@ func NewAttributes(attrs []*elbv2.LoadBalancerAttribute) (a *Attributes, err err
 		IdleTimeoutTimeoutSeconds:      IdleTimeoutTimeoutSeconds,
 		RoutingHTTP2Enabled:            RoutingHTTP2Enabled,
 		DropInvalidHeaderFieldsEnabled: DropInvalidHeaderFieldsEnabled,
+		XFFHeaderProcessingMode:        XFFHeaderProcessingMode,
+		XFFClientPortEnabled:           XFFClientPortEnabled,
 	}
+	// every invalid value is reported at once. Unknown attributes alone are reported as InvalidAttribute, which callers
+	// tolerate in the attributes described from AWS.
 	var e error
+	var errs []string
+	var unknown []string
 	for _, attr := range attrs {
 		attrValue := aws.StringValue(attr.Value)
 		switch attrKey := aws.StringValue(attr.Key); attrKey {
 		case DeletionProtectionEnabledKey:
 			a.DeletionProtectionEnabled, err = strconv.ParseBool(attrValue)
 			if err != nil {
-				return a, fmt.Errorf("invalid load balancer attribute value %s=%s", attrKey, attrValue)
+				errs = append(errs, fmt.Sprintf("invalid load balancer attribute value %s=%s", attrKey, attrValue))
 			}
 		case AccessLogsS3EnabledKey:
 			a.AccessLogsS3Enabled, err = strconv.ParseBool(attrValue)
 			if err != nil {
-				return a, fmt.Errorf("invalid load balancer attribute value %s=%s", attrKey, attrValue)
+				errs = append(errs, fmt.Sprintf("invalid load balancer attribute value %s=%s", attrKey, attrValue))
 			}
 		case AccessLogsS3BucketKey:
 			a.AccessLogsS3Bucket = attrValue
@@ -94,32 +122,58 @@ func NewAttributes(attrs []*elbv2.LoadBalancerAttribute) (a *Attributes, err err
 		case IdleTimeoutTimeoutSecondsKey:
 			a.IdleTimeoutTimeoutSeconds, err = strconv.ParseInt(attrValue, 10, 64)
 			if err != nil {
-				return a, fmt.Errorf("invalid load balancer attribute value %s=%s", attrKey, attrValue)
-			}
-			if a.IdleTimeoutTimeoutSeconds < 1 || a.IdleTimeoutTimeoutSeconds > 4000 {
-				return a, fmt.Errorf("%s must be within 1-4000 seconds", attrKey)
+				errs = append(errs, fmt.Sprintf("invalid load balancer attribute value %s=%s", attrKey, attrValue))
+			} else if a.IdleTimeoutTimeoutSeconds < 1 || a.IdleTimeoutTimeoutSeconds > 4000 {
+				errs = append(errs, fmt.Sprintf("%s must be within 1-4000 seconds", attrKey))
 			}
 		case RoutingHTTP2EnabledKey:
 			a.RoutingHTTP2Enabled, err = strconv.ParseBool(attrValue)
 			if err != nil {
-				return a, fmt.Errorf("invalid load balancer attribute value %s=%s", attrKey, attrValue)
+				errs = append(errs, fmt.Sprintf("invalid load balancer attribute value %s=%s", attrKey, attrValue))
 			}
 		case DropInvalidHeaderFieldsEnabledKey:
 			a.DropInvalidHeaderFieldsEnabled, err = strconv.ParseBool(attrValue)
 			if err != nil {
-				return a, fmt.Errorf("invalid load balancer attribute value %s=%s", attrKey, attrValue)
+				errs = append(errs, fmt.Sprintf("invalid load balancer attribute value %s=%s", attrKey, attrValue))
+			}
+		case XFFHeaderProcessingModeKey:
+			switch attrValue {
+			case XFFHeaderProcessingModeAppend, XFFHeaderProcessingModePreserve, XFFHeaderProcessingModeRemove:
+				a.XFFHeaderProcessingMode = attrValue
+			default:
+				errs = append(errs, fmt.Sprintf("%s must be one of %s, %s or %s", attrKey, XFFHeaderProcessingModeAppend, XFFHeaderProcessingModePreserve, XFFHeaderProcessingModeRemove))
+			}
+		case XFFClientPortEnabledKey:
+			a.XFFClientPortEnabled, err = strconv.ParseBool(attrValue)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("invalid load balancer attribute value %s=%s", attrKey, attrValue))
 			}
 		default:
 			e = NewInvalidAttribute(attrKey)
+			unknown = append(unknown, e.Error())
 		}
 	}
-	return a, e
+	if a.AccessLogsS3Enabled && len(a.AccessLogsS3Bucket) == 0 {
+		errs = append(errs, fmt.Sprintf("%s must be specified when %s is true", AccessLogsS3BucketKey, AccessLogsS3EnabledKey))
+	}
+	if len(errs) == 0 {
+		return a, e
+	}
+	return a, fmt.Errorf("%s", strings.Join(append(errs, unknown...), ", "))
 }
 
+// TagDeletionProtection tags a LoadBalancer whose deletion protection the controller enabled from the
+// load-balancer-attributes annotation, so that it's disabled again once the ingress is deleted.
+const TagDeletionProtection = "ingress.k8s.aws/deletion-protection"
+
 // AttributesController provides functionality to manage Attributes
 type AttributesController interface {
 	// Reconcile ensures the load balancer attributes in AWS matches the state specified by the ingress configuration.
 	Reconcile(ctx context.Context, lbArn string, attrs []*elbv2.LoadBalancerAttribute) error
+
+	// DisableDeletionProtection turns deletion protection of the load balancer off if it's on, so the controller can delete it.
+	// It's only meant for LoadBalancers tagged with TagDeletionProtection.
+	DisableDeletionProtection(ctx context.Context, lbArn string) error
 }
 
 // NewAttributesController constructs a new attributes controller
@@ -153,16 +207,56 @@ func (c *attributesController) Reconcile(ctx context.Context, lbArn string, attr
 
 	changeSet := attributesChangeSet(current, desired)
 	if len(changeSet) > 0 {
+		currentValues := make(map[string]string, len(raw.Attributes))
+		for _, attr := range raw.Attributes {
+			currentValues[aws.StringValue(attr.Key)] = aws.StringValue(attr.Value)
+		}
+		for _, attr := range changeSet {
+			albctx.GetLogger(ctx).Debugf("LoadBalancer %v attribute %v needs modification: %v => %v", lbArn, aws.StringValue(attr.Key), currentValues[aws.StringValue(attr.Key)], aws.StringValue(attr.Value))
+		}
 		albctx.GetLogger(ctx).Infof("Modifying ELBV2 attributes to %v.", log.Prettify(changeSet))
 		_, err = c.cloud.ModifyLoadBalancerAttributesWithContext(ctx, &elbv2.ModifyLoadBalancerAttributesInput{
 			LoadBalancerArn: aws.String(lbArn),
 			Attributes:      changeSet,
 		})
 		if err != nil {
-			albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "%s attributes modification failed: %s", lbArn, err.Error())
+			if desired.AccessLogsS3Enabled && isInvalidConfigurationRequest(err) {
+				// ELBV2 writes a test file to the bucket before enabling access logs, and rejects the change when it can't.
+				albctx.GetEventf(ctx)(api.EventTypeWarning, albctx.EventReasonError, "%s access logs could not be enabled, check that the policy of S3 bucket %s allows Elastic Load Balancing to write to it: %s", lbArn, desired.AccessLogsS3Bucket, err.Error())
+				return fmt.Errorf("failed modifying attributes: %s", err)
+			}
+			albctx.GetEventf(ctx)(api.EventTypeWarning, albctx.EventReasonError, "%s attributes modification failed: %s", lbArn, err.Error())
 			return fmt.Errorf("failed modifying attributes: %s", err)
 		}
+		albctx.GetEventf(ctx)(api.EventTypeNormal, albctx.EventReasonModify, "%s attributes modified", lbArn)
+
+	}
+	return nil
+}
+
+func (c *attributesController) DisableDeletionProtection(ctx context.Context, lbArn string) error {
+	raw, err := c.cloud.DescribeLoadBalancerAttributesWithContext(ctx, &elbv2.DescribeLoadBalancerAttributesInput{
+		LoadBalancerArn: aws.String(lbArn),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to retrieve attributes from ELBV2 in AWS: %s", err.Error())
+	}
+	current, err := NewAttributes(raw.Attributes)
+	if err != nil && !IsInvalidAttribute(err) {
+		return fmt.Errorf("failed parsing attributes: %v", err)
+	}
+	if !current.DeletionProtectionEnabled {
+		return nil
+	}
 
+	albctx.GetLogger(ctx).Infof("disabling deletion protection of LoadBalancer %v before deleting it", lbArn)
+	_, err = c.cloud.ModifyLoadBalancerAttributesWithContext(ctx, &elbv2.ModifyLoadBalancerAttributesInput{
+		LoadBalancerArn: aws.String(lbArn),
+		Attributes:      []*elbv2.LoadBalancerAttribute{lbAttribute(DeletionProtectionEnabledKey, "false")},
+	})
+	if err != nil {
+		albctx.GetEventf(ctx)(api.EventTypeWarning, albctx.EventReasonError, "%s deletion protection could not be disabled: %s", lbArn, err.Error())
+		return fmt.Errorf("failed disabling deletion protection: %s", err)
 	}
 	return nil
 }
@@ -200,9 +294,47 @@ func attributesChangeSet(current, desired *Attributes) (changeSet []*elbv2.LoadB
 		changeSet = append(changeSet, lbAttribute(DropInvalidHeaderFieldsEnabledKey, fmt.Sprintf("%v", desired.DropInvalidHeaderFieldsEnabled)))
 	}
 
+	if current.XFFHeaderProcessingMode != desired.XFFHeaderProcessingMode {
+		changeSet = append(changeSet, lbAttribute(XFFHeaderProcessingModeKey, desired.XFFHeaderProcessingMode))
+	}
+
+	if current.XFFClientPortEnabled != desired.XFFClientPortEnabled {
+		changeSet = append(changeSet, lbAttribute(XFFClientPortEnabledKey, fmt.Sprintf("%v", desired.XFFClientPortEnabled)))
+	}
+
 	return
 }
 
+func isInvalidConfigurationRequest(err error) bool {
+	if awsErr, ok := err.(awserr.Error); ok {
+		return awsErr.Code() == elbv2.ErrCodeInvalidConfigurationRequestException
+	}
+	return false
+}
+
+// withDefaultAttributes returns attrs, completed with the defaults whose keys attrs doesn't set.
+func withDefaultAttributes(defaults map[string]string, attrs []*elbv2.LoadBalancerAttribute) []*elbv2.LoadBalancerAttribute {
+	if len(defaults) == 0 {
+		return attrs
+	}
+	set := make(map[string]bool, len(attrs))
+	for _, attr := range attrs {
+		set[aws.StringValue(attr.Key)] = true
+	}
+	keys := make([]string, 0, len(defaults))
+	for k := range defaults {
+		if !set[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	merged := append([]*elbv2.LoadBalancerAttribute{}, attrs...)
+	for _, k := range keys {
+		merged = append(merged, lbAttribute(k, defaults[k]))
+	}
+	return merged
+}
+
 func lbAttribute(k, v string) *elbv2.LoadBalancerAttribute {
 	return &elbv2.LoadBalancerAttribute{Key: aws.String(k), Value: aws.String(v)}
 }
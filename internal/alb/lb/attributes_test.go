@@ -10,6 +10,7 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/stretchr/testify/assert"
 )
@@ -68,11 +69,29 @@ func Test_NewAttributes(t *testing.T) {
 			ok:         false,
 			attributes: []*elbv2.LoadBalancerAttribute{lbAttribute(DropInvalidHeaderFieldsEnabledKey, "falfadssdfdsse")},
 		},
+		{
+			name:       fmt.Sprintf("%v is invalid", XFFHeaderProcessingModeKey),
+			ok:         false,
+			attributes: []*elbv2.LoadBalancerAttribute{lbAttribute(XFFHeaderProcessingModeKey, "overwrite")},
+		},
+		{
+			name:       fmt.Sprintf("%v is invalid", XFFClientPortEnabledKey),
+			ok:         false,
+			attributes: []*elbv2.LoadBalancerAttribute{lbAttribute(XFFClientPortEnabledKey, "falfadssdfdsse")},
+		},
 		{
 			name:       fmt.Sprintf("undefined attribute"),
 			ok:         false,
 			attributes: []*elbv2.LoadBalancerAttribute{lbAttribute("not.real.attribute", "falfadssdfdsse")},
 		},
+		{
+			name: "access logs enabled without bucket",
+			ok:   false,
+			attributes: []*elbv2.LoadBalancerAttribute{
+				lbAttribute(AccessLogsS3EnabledKey, "true"),
+				lbAttribute(AccessLogsS3PrefixKey, "prefix"),
+			},
+		},
 		{
 			name: "non-default attributes",
 			ok:   true,
@@ -84,6 +103,8 @@ func Test_NewAttributes(t *testing.T) {
 				lbAttribute(IdleTimeoutTimeoutSecondsKey, "45"),
 				lbAttribute(RoutingHTTP2EnabledKey, "false"),
 				lbAttribute(DropInvalidHeaderFieldsEnabledKey, "true"),
+				lbAttribute(XFFHeaderProcessingModeKey, "preserve"),
+				lbAttribute(XFFClientPortEnabledKey, "true"),
 			},
 			output: &Attributes{
 				DeletionProtectionEnabled:      true,
@@ -93,6 +114,8 @@ func Test_NewAttributes(t *testing.T) {
 				IdleTimeoutTimeoutSeconds:      45,
 				RoutingHTTP2Enabled:            false,
 				DropInvalidHeaderFieldsEnabled: true,
+				XFFHeaderProcessingMode:        "preserve",
+				XFFClientPortEnabled:           true,
 			},
 		},
 	} {
@@ -114,6 +137,19 @@ func Test_NewAttributes(t *testing.T) {
 	}
 }
 
+func Test_NewAttributes_reportsAllErrors(t *testing.T) {
+	_, err := NewAttributes([]*elbv2.LoadBalancerAttribute{
+		lbAttribute(AccessLogsS3EnabledKey, "true"),
+		lbAttribute(IdleTimeoutTimeoutSecondsKey, "999999"),
+		lbAttribute("not.real.attribute", "true"),
+	})
+	assert.EqualError(t, err, "idle_timeout.timeout_seconds must be within 1-4000 seconds, access_logs.s3.bucket must be specified when access_logs.s3.enabled is true, the load balancer attribute not.real.attribute is not valid")
+	assert.False(t, IsInvalidAttribute(err))
+
+	_, err = NewAttributes([]*elbv2.LoadBalancerAttribute{lbAttribute("not.real.attribute", "true"), lbAttribute("other.attribute", "true")})
+	assert.True(t, IsInvalidAttribute(err))
+}
+
 func Test_attributesChangeSet(t *testing.T) {
 	for _, tc := range []struct {
 		name      string
@@ -168,6 +204,18 @@ func Test_attributesChangeSet(t *testing.T) {
 			b:         MustNewAttributes([]*elbv2.LoadBalancerAttribute{lbAttribute(DropInvalidHeaderFieldsEnabledKey, "true")}),
 			changeSet: []*elbv2.LoadBalancerAttribute{lbAttribute(DropInvalidHeaderFieldsEnabledKey, "true")},
 		},
+		{
+			name:      fmt.Sprintf("a contains default, b contains non-default XFFHeaderProcessingModeKey, make a change"),
+			a:         MustNewAttributes(nil),
+			b:         MustNewAttributes([]*elbv2.LoadBalancerAttribute{lbAttribute(XFFHeaderProcessingModeKey, "remove")}),
+			changeSet: []*elbv2.LoadBalancerAttribute{lbAttribute(XFFHeaderProcessingModeKey, "remove")},
+		},
+		{
+			name:      fmt.Sprintf("a contains default, b contains non-default XFFClientPortEnabledKey, make a change"),
+			a:         MustNewAttributes(nil),
+			b:         MustNewAttributes([]*elbv2.LoadBalancerAttribute{lbAttribute(XFFClientPortEnabledKey, "true")}),
+			changeSet: []*elbv2.LoadBalancerAttribute{lbAttribute(XFFClientPortEnabledKey, "true")},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			changeSet := attributesChangeSet(tc.a, tc.b)
@@ -273,6 +321,47 @@ func TestReconcile(t *testing.T) {
 			},
 			ExpectedError: errors.New("failed modifying attributes: Something unexpected happened"),
 		},
+		{
+			Name: "enable access logs on a bucket ELBV2 can't write to",
+			Attributes: []*elbv2.LoadBalancerAttribute{
+				lbAttribute(AccessLogsS3EnabledKey, "true"),
+				lbAttribute(AccessLogsS3BucketKey, "bucket"),
+			},
+			DescribeLoadBalancerAttributesCall: &DescribeLoadBalancerAttributesCall{
+				LbArn:  aws.String("arn"),
+				Output: &elbv2.DescribeLoadBalancerAttributesOutput{Attributes: defaultAttributes()},
+			},
+			ModifyLoadBalancerAttributesCall: &ModifyLoadBalancerAttributesCall{
+				Input: &elbv2.ModifyLoadBalancerAttributesInput{
+					LoadBalancerArn: aws.String("arn"),
+					Attributes: []*elbv2.LoadBalancerAttribute{
+						lbAttribute(AccessLogsS3EnabledKey, "true"),
+						lbAttribute(AccessLogsS3BucketKey, "bucket"),
+					},
+				},
+				Err: awserr.New(elbv2.ErrCodeInvalidConfigurationRequestException, "Access Denied for bucket: bucket. Please check S3bucket permission", nil),
+			},
+			ExpectedError: errors.New("failed modifying attributes: InvalidConfigurationRequest: Access Denied for bucket: bucket. Please check S3bucket permission"),
+		},
+		{
+			Name:       "disable access logs keeps the bucket unchanged",
+			Attributes: []*elbv2.LoadBalancerAttribute{lbAttribute(AccessLogsS3EnabledKey, "false")},
+			DescribeLoadBalancerAttributesCall: &DescribeLoadBalancerAttributesCall{
+				LbArn: aws.String("arn"),
+				Output: &elbv2.DescribeLoadBalancerAttributesOutput{Attributes: []*elbv2.LoadBalancerAttribute{
+					lbAttribute(AccessLogsS3EnabledKey, "true"),
+					lbAttribute(AccessLogsS3BucketKey, "bucket"),
+				}},
+			},
+			ModifyLoadBalancerAttributesCall: &ModifyLoadBalancerAttributesCall{
+				Input: &elbv2.ModifyLoadBalancerAttributesInput{
+					LoadBalancerArn: aws.String("arn"),
+					Attributes: []*elbv2.LoadBalancerAttribute{
+						lbAttribute(AccessLogsS3EnabledKey, "false"),
+					},
+				},
+			},
+		},
 	} {
 		t.Run(tc.Name, func(t *testing.T) {
 			ctx := context.Background()
@@ -297,3 +386,75 @@ func TestReconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestDisableDeletionProtection(t *testing.T) {
+	lbArn := "arn"
+	for _, tc := range []struct {
+		Name                               string
+		DescribeLoadBalancerAttributesCall *DescribeLoadBalancerAttributesCall
+		ModifyLoadBalancerAttributesCall   *ModifyLoadBalancerAttributesCall
+		ExpectedError                      error
+	}{
+		{
+			Name: "deletion protection already disabled",
+			DescribeLoadBalancerAttributesCall: &DescribeLoadBalancerAttributesCall{
+				LbArn:  aws.String(lbArn),
+				Output: &elbv2.DescribeLoadBalancerAttributesOutput{Attributes: defaultAttributes()},
+			},
+		},
+		{
+			Name: "deletion protection enabled",
+			DescribeLoadBalancerAttributesCall: &DescribeLoadBalancerAttributesCall{
+				LbArn: aws.String(lbArn),
+				Output: &elbv2.DescribeLoadBalancerAttributesOutput{Attributes: []*elbv2.LoadBalancerAttribute{
+					lbAttribute(DeletionProtectionEnabledKey, "true"),
+				}},
+			},
+			ModifyLoadBalancerAttributesCall: &ModifyLoadBalancerAttributesCall{
+				Input: &elbv2.ModifyLoadBalancerAttributesInput{
+					LoadBalancerArn: aws.String(lbArn),
+					Attributes:      []*elbv2.LoadBalancerAttribute{lbAttribute(DeletionProtectionEnabledKey, "false")},
+				},
+			},
+		},
+		{
+			Name: "deletion protection enabled, API throws an error",
+			DescribeLoadBalancerAttributesCall: &DescribeLoadBalancerAttributesCall{
+				LbArn: aws.String(lbArn),
+				Output: &elbv2.DescribeLoadBalancerAttributesOutput{Attributes: []*elbv2.LoadBalancerAttribute{
+					lbAttribute(DeletionProtectionEnabledKey, "true"),
+				}},
+			},
+			ModifyLoadBalancerAttributesCall: &ModifyLoadBalancerAttributesCall{
+				Input: &elbv2.ModifyLoadBalancerAttributesInput{
+					LoadBalancerArn: aws.String(lbArn),
+					Attributes:      []*elbv2.LoadBalancerAttribute{lbAttribute(DeletionProtectionEnabledKey, "false")},
+				},
+				Err: errors.New("AccessDenied"),
+			},
+			ExpectedError: errors.New("failed disabling deletion protection: AccessDenied"),
+		},
+		{
+			Name: "Load Balancer doesn't exist",
+			DescribeLoadBalancerAttributesCall: &DescribeLoadBalancerAttributesCall{
+				LbArn: aws.String(lbArn),
+				Err:   errors.New("ERROR STRING"),
+			},
+			ExpectedError: errors.New("failed to retrieve attributes from ELBV2 in AWS: ERROR STRING"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			cloud.On("DescribeLoadBalancerAttributesWithContext", ctx, &elbv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: tc.DescribeLoadBalancerAttributesCall.LbArn}).Return(tc.DescribeLoadBalancerAttributesCall.Output, tc.DescribeLoadBalancerAttributesCall.Err)
+			if tc.ModifyLoadBalancerAttributesCall != nil {
+				cloud.On("ModifyLoadBalancerAttributesWithContext", ctx, tc.ModifyLoadBalancerAttributesCall.Input).Return(tc.ModifyLoadBalancerAttributesCall.Output, tc.ModifyLoadBalancerAttributesCall.Err)
+			}
+
+			controller := NewAttributesController(cloud)
+			err := controller.DisableDeletionProtection(ctx, lbArn)
+			assert.Equal(t, tc.ExpectedError, err)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
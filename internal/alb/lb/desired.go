@@ -0,0 +1,52 @@
+package lb
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	extensions "k8s.io/api/extensions/v1beta1"
+)
+
+// DesiredLoadBalancer is the LoadBalancer the controller configures for an ingress.
+// Subnets and securityGroups are only listed when annotated, since discovering them takes AWS calls.
+type DesiredLoadBalancer struct {
+	Name              string                         `json:"name"`
+	Scheme            *string                        `json:"scheme"`
+	IPAddressType     *string                        `json:"ipAddressType"`
+	Subnets           []string                       `json:"subnets,omitempty"`
+	SecurityGroups    []string                       `json:"securityGroups,omitempty"`
+	InboundCidrs      []string                       `json:"inboundCidrs,omitempty"`
+	Attributes        []*elbv2.LoadBalancerAttribute `json:"attributes,omitempty"`
+	Route53HostedZone string                         `json:"route53HostedZone,omitempty"`
+	Tags              map[string]string              `json:"tags"`
+}
+
+// BuildDesiredLoadBalancer computes the LoadBalancer of ingress from the objects in store, without calling AWS.
+func BuildDesiredLoadBalancer(store store.Storer, nameTagGen NameTagGenerator, ingress *extensions.Ingress) (DesiredLoadBalancer, error) {
+	ingressAnnos, err := store.GetIngressAnnotations(k8s.MetaNamespaceKey(ingress))
+	if err != nil {
+		return DesiredLoadBalancer{}, err
+	}
+	if _, err := NewAttributes(ingressAnnos.LoadBalancer.Attributes); err != nil {
+		return DesiredLoadBalancer{}, fmt.Errorf("invalid LoadBalancer attributes due to %v", err)
+	}
+
+	controller := &defaultController{
+		store:      store,
+		nameTagGen: nameTagGen,
+	}
+	route53HostedZone := controller.route53HostedZoneOf(ingress)
+	return DesiredLoadBalancer{
+		Name:              nameTagGen.NameLB(ingress.Namespace, ingress.Name),
+		Scheme:            ingressAnnos.LoadBalancer.Scheme,
+		IPAddressType:     ingressAnnos.LoadBalancer.IPAddressType,
+		Subnets:           ingressAnnos.LoadBalancer.Subnets,
+		SecurityGroups:    ingressAnnos.LoadBalancer.SecurityGroups,
+		InboundCidrs:      ingressAnnos.LoadBalancer.InboundCidrs,
+		Attributes:        ingressAnnos.LoadBalancer.Attributes,
+		Route53HostedZone: route53HostedZone,
+		Tags:              controller.buildLBTags(ingress, ingressAnnos, route53HostedZone),
+	}, nil
+}
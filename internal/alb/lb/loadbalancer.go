@@ -8,6 +8,8 @@ import (
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/ls"
@@ -18,6 +20,7 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 	util "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
@@ -27,6 +30,17 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
+// AnnotationLoadBalancerARN is the ARN of an existing LoadBalancer, which the controller adopts if the ingress doesn't
+// have one of its own yet.
+const AnnotationLoadBalancerARN = "load-balancer-arn"
+
+// TagAdopted tags a LoadBalancer the controller adopted rather than created, so that it's detached instead of deleted
+// once the ingress is deleted.
+const TagAdopted = "ingress.k8s.aws/adopted"
+
+// TagName is the tag the AWS console shows as the name of a LoadBalancer, set by the load-balancer-name annotation.
+const TagName = "Name"
+
 // LoadBalancerController manages loadBalancer for ingress objects
 type Controller interface {
 	// Reconcile will make sure an LoadBalancer exists for specified ingress.
@@ -48,6 +62,7 @@ func NewController(
 	wafController := NewWAFController(cloud)
 	wafV2Controller := NewWAFV2Controller(cloud)
 	shieldController := NewShieldController(cloud)
+	route53Controller := NewRoute53Controller(cloud)
 
 	return &defaultController{
 		cloud:                   cloud,
@@ -61,17 +76,25 @@ func NewController(
 		wafController:           wafController,
 		wafV2Controller:         wafV2Controller,
 		shieldController:        shieldController,
+		route53Controller:       route53Controller,
 	}
 }
 
 type loadBalancerConfig struct {
-	Name string
-	Tags map[string]string
+	Name       string
+	LegacyName string
+	Tags       map[string]string
 
 	Type          *string
 	Scheme        *string
 	IpAddressType *string
 	Subnets       []string
+
+	// AdoptARN is the ARN of an existing LoadBalancer to manage, used if there's none under Name or LegacyName.
+	AdoptARN string
+
+	// Route53HostedZone is the hosted zone to create ALIAS records in for the hosts of the ingress, if any.
+	Route53HostedZone string
 }
 
 type defaultController struct {
@@ -87,6 +110,7 @@ type defaultController struct {
 	wafController           WAFController
 	wafV2Controller         WAFV2Controller
 	shieldController        ShieldController
+	route53Controller       Route53Controller
 }
 
 var _ Controller = (*defaultController)(nil)
@@ -94,8 +118,17 @@ var _ Controller = (*defaultController)(nil)
 func (controller *defaultController) Reconcile(ctx context.Context, ingress *extensions.Ingress) (*LoadBalancer, error) {
 	ingressAnnos, err := controller.store.GetIngressAnnotations(k8s.MetaNamespaceKey(ingress))
 	if err != nil {
+		if errors.IsInvalidContent(err) {
+			// invalid annotations are rejected before any AWS call, the event tells which one and why.
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "invalid annotations: %v", err)
+		}
 		return nil, err
 	}
+	attributes := withDefaultAttributes(controller.store.GetConfig().Defaults().LoadBalancerAttributes, ingressAnnos.LoadBalancer.Attributes)
+	// reject invalid attributes before the LoadBalancer gets created, rather than failing afterwards with it half configured.
+	if _, err := NewAttributes(attributes); err != nil {
+		return nil, fmt.Errorf("invalid LoadBalancer attributes due to %v", err)
+	}
 
 	lbConfig, err := controller.buildLBConfig(ctx, ingress, ingressAnnos)
 	if err != nil {
@@ -110,12 +143,12 @@ func (controller *defaultController) Reconcile(ctx context.Context, ingress *ext
 	if err != nil {
 		return nil, err
 	}
-	instance, err := controller.ensureLBInstance(ctx, lbConfig, sgAttachment)
+	instance, err := controller.ensureLBInstance(ctx, ingKey, lbConfig, sgAttachment)
 	if err != nil {
 		return nil, err
 	}
 	lbArn := aws.StringValue(instance.LoadBalancerArn)
-	if err := controller.attrsController.Reconcile(ctx, lbArn, ingressAnnos.LoadBalancer.Attributes); err != nil {
+	if err := controller.attrsController.Reconcile(ctx, lbArn, attributes); err != nil {
 		return nil, fmt.Errorf("failed to reconcile attributes of %v due to %v", lbArn, err)
 	}
 
@@ -141,7 +174,8 @@ func (controller *defaultController) Reconcile(ctx context.Context, ingress *ext
 	if err != nil {
 		return nil, fmt.Errorf("failed to reconcile targetGroups due to %v", err)
 	}
-	if err := controller.lsGroupController.Reconcile(ctx, lbArn, ingress, tgGroup); err != nil {
+	lsGroup, err := controller.lsGroupController.Reconcile(ctx, lbArn, ingress, tgGroup)
+	if err != nil {
 		return nil, fmt.Errorf("failed to reconcile listeners due to %v", err)
 	}
 	if err := controller.tgGroupController.GC(ctx, tgGroup); err != nil {
@@ -151,18 +185,60 @@ func (controller *defaultController) Reconcile(ctx context.Context, ingress *ext
 	if err := controller.sgAssociationController.Reconcile(ctx, ingKey, sgAttachment, instance, tgGroup); err != nil {
 		return nil, fmt.Errorf("failed to reconcile securityGroup associations due to %v", err)
 	}
+	if lbConfig.Route53HostedZone != "" {
+		if err := controller.route53Controller.Reconcile(ctx, lbConfig.Route53HostedZone, ingress, instance); err != nil {
+			return nil, fmt.Errorf("failed to reconcile Route53 records due to %v", err)
+		}
+	}
+
+	tgArns := make([]string, 0, len(tgGroup.TGByBackend))
+	for _, targetGroup := range tgGroup.TGByBackend {
+		tgArns = append(tgArns, targetGroup.Arn)
+	}
+	sort.Strings(tgArns)
 	return &LoadBalancer{
-		Arn:     lbArn,
-		DNSName: aws.StringValue(instance.DNSName),
+		Arn:             lbArn,
+		DNSName:         aws.StringValue(instance.DNSName),
+		TargetGroupARNs: tgArns,
+		ListenerCount:   lsGroup.ListenerCount,
+		RuleCount:       lsGroup.RuleCount,
 	}, nil
 }
 
 func (controller *defaultController) Delete(ctx context.Context, ingressKey types.NamespacedName) error {
 	lbName := controller.nameTagGen.NameLB(ingressKey.Namespace, ingressKey.Name)
-	instance, err := controller.cloud.GetLoadBalancerByName(ctx, lbName)
+	legacyLBName := controller.nameTagGen.LegacyNameLB(ingressKey.Namespace, ingressKey.Name)
+	instance, err := controller.findLBInstance(ctx, ingressKey, lbName, legacyLBName)
+	if err == nil && instance != nil {
+		var owned bool
+		if owned, err = controller.ownsLBInstance(ctx, ingressKey, instance); err == nil && !owned {
+			albctx.GetLogger(ctx).Warnf("not deleting LoadBalancer %v, it isn't owned by this controller", aws.StringValue(instance.LoadBalancerArn))
+			instance = nil
+		}
+	}
+	adopted := false
+	if err == nil && instance == nil {
+		// the LoadBalancer of the ingress may also be found by its tags, e.g. after --alb-name-prefix changed, so only
+		// the adopted tag tells whether the controller created it.
+		instance, err = controller.findTaggedLBInstance(ctx, ingressKey)
+		if err == nil && instance != nil {
+			var tag string
+			tag, err = controller.lbTagOf(ctx, instance, TagAdopted)
+			adopted = tag != ""
+		}
+	}
 	if err != nil {
 		return fmt.Errorf("failed to find existing LoadBalancer due to %v", err)
 	}
+	if instance != nil && adopted {
+		if err = controller.detachLBInstance(ctx, ingressKey, instance); err != nil {
+			return err
+		}
+		if err = controller.sgAssociationController.Detach(ctx, ingressKey); err != nil {
+			return fmt.Errorf("failed to clean up securityGroups due to %v", err)
+		}
+		return nil
+	}
 	if instance != nil {
 		if err = controller.lsGroupController.Delete(ctx, aws.StringValue(instance.LoadBalancerArn)); err != nil {
 			return fmt.Errorf("failed to delete listeners due to %v", err)
@@ -171,6 +247,22 @@ func (controller *defaultController) Delete(ctx context.Context, ingressKey type
 			return fmt.Errorf("failed to GC targetGroups due to %v", err)
 		}
 
+		if controller.store.GetConfig().FeatureGate.Enabled(config.Route53) {
+			if err = controller.deleteRoute53Records(ctx, instance); err != nil {
+				return fmt.Errorf("failed to delete Route53 records due to %v", err)
+			}
+		}
+
+		// deletion protection enabled by anyone but the controller is left alone, the deletion fails until it's disabled.
+		var protected string
+		if protected, err = controller.lbTagOf(ctx, instance, TagDeletionProtection); err != nil {
+			return fmt.Errorf("failed to get tags of LoadBalancer %v due to %v", aws.StringValue(instance.LoadBalancerArn), err)
+		}
+		if protected != "" {
+			if err = controller.attrsController.DisableDeletionProtection(ctx, aws.StringValue(instance.LoadBalancerArn)); err != nil {
+				return err
+			}
+		}
 		albctx.GetLogger(ctx).Infof("deleting LoadBalancer %v", aws.StringValue(instance.LoadBalancerArn))
 		if err = controller.cloud.DeleteLoadBalancerByArn(ctx, aws.StringValue(instance.LoadBalancerArn)); err != nil {
 			return err
@@ -183,11 +275,37 @@ func (controller *defaultController) Delete(ctx context.Context, ingressKey type
 	return nil
 }
 
-func (controller *defaultController) ensureLBInstance(ctx context.Context, lbConfig *loadBalancerConfig, sgAttachment sg.LbAttachmentInfo) (*elbv2.LoadBalancer, error) {
-	instance, err := controller.cloud.GetLoadBalancerByName(ctx, lbConfig.Name)
+func (controller *defaultController) ensureLBInstance(ctx context.Context, ingKey types.NamespacedName, lbConfig *loadBalancerConfig, sgAttachment sg.LbAttachmentInfo) (*elbv2.LoadBalancer, error) {
+	instance, err := controller.findLBInstance(ctx, ingKey, lbConfig.Name, lbConfig.LegacyName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find existing LoadBalancer due to %v", err)
 	}
+	if instance != nil {
+		owned, err := controller.ownsLBInstance(ctx, ingKey, instance)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find existing LoadBalancer due to %v", err)
+		}
+		if !owned {
+			lbArn := aws.StringValue(instance.LoadBalancerArn)
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "LoadBalancer %v isn't owned by this controller, refusing to manage it", lbArn)
+			return nil, fmt.Errorf("LoadBalancer %v isn't owned by this controller, refusing to manage it", lbArn)
+		}
+	}
+	if instance != nil && lbConfig.AdoptARN != "" && lbConfig.AdoptARN != aws.StringValue(instance.LoadBalancerArn) {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "not adopting LoadBalancer %v, the ingress already has LoadBalancer %v", lbConfig.AdoptARN, aws.StringValue(instance.LoadBalancerArn))
+	}
+	if instance == nil && lbConfig.AdoptARN != "" {
+		instance, err = controller.adoptLBInstance(ctx, ingKey, lbConfig)
+		if err != nil {
+			return nil, err
+		}
+		if instance != nil {
+			if err := controller.reconcileLBInstance(ctx, instance, lbConfig); err != nil {
+				return nil, err
+			}
+			return instance, nil
+		}
+	}
 	if instance == nil {
 		instance, err = controller.newLBInstance(ctx, lbConfig, sgAttachment)
 		if err != nil {
@@ -208,6 +326,204 @@ func (controller *defaultController) ensureLBInstance(ctx context.Context, lbCon
 	return instance, nil
 }
 
+// findLBInstance looks up a LoadBalancer by name, falling back to legacyName for LoadBalancers created by earlier releases.
+// A LoadBalancer found under legacyName keeps it until it needs recreation. Legacy names are truncated, so ingresses may
+// share one, and a LoadBalancer found under it is only returned if it belongs to ingKey as per its tags.
+func (controller *defaultController) findLBInstance(ctx context.Context, ingKey types.NamespacedName, name string, legacyName string) (*elbv2.LoadBalancer, error) {
+	instance, err := controller.cloud.GetLoadBalancerByName(ctx, name)
+	if err != nil || instance != nil || legacyName == name {
+		return instance, err
+	}
+	instance, err = controller.cloud.GetLoadBalancerByName(ctx, legacyName)
+	if err != nil || instance == nil {
+		return instance, err
+	}
+	owned, err := controller.ownsLBInstance(ctx, ingKey, instance)
+	if err != nil || !owned {
+		return nil, err
+	}
+	return instance, nil
+}
+
+// ownsLBInstance tells whether instance, found by its name, belongs to ingKey as per its tags. LoadBalancers merely
+// sharing its name, e.g. created outside of Kubernetes with the same prefix, must never be modified nor deleted.
+func (controller *defaultController) ownsLBInstance(ctx context.Context, ingKey types.NamespacedName, instance *elbv2.LoadBalancer) (bool, error) {
+	resp, err := controller.cloud.DescribeELBV2TagsWithContext(ctx, &elbv2.DescribeTagsInput{ResourceArns: []*string{instance.LoadBalancerArn}})
+	if err != nil {
+		return false, fmt.Errorf("failed to get tags of LoadBalancer %v due to %v", aws.StringValue(instance.LoadBalancerArn), err)
+	}
+	curTags := make(map[string]string)
+	for _, desc := range resp.TagDescriptions {
+		for _, tag := range desc.Tags {
+			curTags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+	}
+	return controller.nameTagGen.OwnsLB(ingKey.Namespace, ingKey.Name, curTags), nil
+}
+
+// adoptLBInstance looks up the LoadBalancer lbConfig.AdoptARN refers to, so that the controller manages it instead of
+// creating one. It must be in the cluster's VPC and have the desired scheme, since adopted LoadBalancers are never recreated,
+// and must not be tagged as managed for another ingress or by another controller.
+// Tags already on the LoadBalancer, e.g. from CloudFormation, are kept by merging them into lbConfig.Tags, and
+// LoadBalancers not yet tagged as managed for the ingress are tagged as adopted.
+// It returns nil if AdoptARN refers to a deleted LoadBalancer the controller itself created, so that it gets created again.
+func (controller *defaultController) adoptLBInstance(ctx context.Context, ingKey types.NamespacedName, lbConfig *loadBalancerConfig) (*elbv2.LoadBalancer, error) {
+	instance, err := controller.cloud.GetLoadBalancerByArn(ctx, lbConfig.AdoptARN)
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == elbv2.ErrCodeLoadBalancerNotFoundException {
+		instance, err = nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find LoadBalancer %v to adopt due to %v", lbConfig.AdoptARN, err)
+	}
+	if instance == nil {
+		if name := lbNameOfARN(lbConfig.AdoptARN); name == lbConfig.Name || name == lbConfig.LegacyName {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("LoadBalancer %v to adopt doesn't exist", lbConfig.AdoptARN)
+	}
+	if vpcID := aws.StringValue(instance.VpcId); vpcID != controller.cloud.GetVpcID() {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "refusing to adopt LoadBalancer %v from VPC %v, it must be in the cluster VPC %v", lbConfig.AdoptARN, vpcID, controller.cloud.GetVpcID())
+		return nil, fmt.Errorf("refusing to adopt LoadBalancer %v from VPC %v, it must be in the cluster VPC %v", lbConfig.AdoptARN, vpcID, controller.cloud.GetVpcID())
+	}
+	if !util.DeepEqual(instance.Scheme, lbConfig.Scheme) {
+		return nil, fmt.Errorf("adopted LoadBalancer %v has scheme %v instead of %v, and won't be recreated", lbConfig.AdoptARN, aws.StringValue(instance.Scheme), aws.StringValue(lbConfig.Scheme))
+	}
+
+	resp, err := controller.cloud.DescribeELBV2TagsWithContext(ctx, &elbv2.DescribeTagsInput{ResourceArns: []*string{instance.LoadBalancerArn}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags of LoadBalancer %v to adopt due to %v", lbConfig.AdoptARN, err)
+	}
+	curTags := make(map[string]string)
+	for _, desc := range resp.TagDescriptions {
+		for _, tag := range desc.Tags {
+			curTags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+	}
+	if controller.nameTagGen.ClaimsLB(curTags) && !controller.nameTagGen.OwnsLB(ingKey.Namespace, ingKey.Name, curTags) {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "refusing to adopt LoadBalancer %v, it's tagged as managed for another ingress or cluster", lbConfig.AdoptARN)
+		return nil, fmt.Errorf("refusing to adopt LoadBalancer %v, it's tagged as managed for another ingress or cluster", lbConfig.AdoptARN)
+	}
+	owned := true
+	for k, v := range controller.nameTagGen.TagLB(ingKey.Namespace, ingKey.Name) {
+		if curTags[k] != v {
+			owned = false
+		}
+	}
+	for k, v := range curTags {
+		if _, ok := lbConfig.Tags[k]; !ok {
+			lbConfig.Tags[k] = v
+		}
+	}
+	if !owned {
+		lbConfig.Tags[TagAdopted] = "true"
+		albctx.GetLogger(ctx).Infof("adopting LoadBalancer %v", lbConfig.AdoptARN)
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonCreate, "LoadBalancer %v adopted", lbConfig.AdoptARN)
+	}
+	return instance, nil
+}
+
+// detachLBInstance releases the adopted LoadBalancer instance once ingressKey is deleted, rather than deleting it, since
+// the controller didn't create it: its listeners, rules, targetGroups and Route53 records are deleted, and the tags the
+// controller discovers it by are removed, so it can be adopted again or cleaned up by its creator.
+func (controller *defaultController) detachLBInstance(ctx context.Context, ingressKey types.NamespacedName, instance *elbv2.LoadBalancer) error {
+	lbArn := aws.StringValue(instance.LoadBalancerArn)
+	if err := controller.lsGroupController.Delete(ctx, lbArn); err != nil {
+		return fmt.Errorf("failed to delete listeners due to %v", err)
+	}
+	if err := controller.tgGroupController.Delete(ctx, ingressKey); err != nil {
+		return fmt.Errorf("failed to GC targetGroups due to %v", err)
+	}
+	if controller.store.GetConfig().FeatureGate.Enabled(config.Route53) {
+		if err := controller.deleteRoute53Records(ctx, instance); err != nil {
+			return fmt.Errorf("failed to delete Route53 records due to %v", err)
+		}
+	}
+
+	tagKeys := []string{TagAdopted, TagRoute53HostedZone, TagDeletionProtection}
+	for k := range controller.nameTagGen.TagLB(ingressKey.Namespace, ingressKey.Name) {
+		tagKeys = append(tagKeys, k)
+	}
+	sort.Strings(tagKeys)
+	albctx.GetLogger(ctx).Infof("detaching adopted LoadBalancer %v", lbArn)
+	if _, err := controller.cloud.RemoveELBV2TagsWithContext(ctx, &elbv2.RemoveTagsInput{
+		ResourceArns: []*string{instance.LoadBalancerArn},
+		TagKeys:      aws.StringSlice(tagKeys),
+	}); err != nil {
+		return fmt.Errorf("failed to remove tags of adopted LoadBalancer %v due to %v", lbArn, err)
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonDelete, "LoadBalancer %v detached", lbArn)
+	return nil
+}
+
+// findTaggedLBInstance looks up a LoadBalancer managed for ingressKey by its tags, such as an adopted one.
+func (controller *defaultController) findTaggedLBInstance(ctx context.Context, ingressKey types.NamespacedName) (*elbv2.LoadBalancer, error) {
+	tagFilters := make(map[string][]string)
+	for k, v := range controller.nameTagGen.TagLB(ingressKey.Namespace, ingressKey.Name) {
+		tagFilters[k] = []string{v}
+	}
+	arns, err := controller.cloud.GetResourcesByFilters(ctx, tagFilters, aws.ResourceTypeEnumELBLoadBalancer)
+	if err != nil || len(arns) == 0 {
+		return nil, err
+	}
+	return controller.cloud.GetLoadBalancerByArn(ctx, arns[0])
+}
+
+// deleteRoute53Records deletes the Route53 records of instance, in the hosted zone it's tagged with.
+func (controller *defaultController) deleteRoute53Records(ctx context.Context, instance *elbv2.LoadBalancer) error {
+	zoneID, err := controller.route53HostedZoneTagOf(ctx, instance)
+	if err != nil || zoneID == "" {
+		return err
+	}
+	return controller.route53Controller.Delete(ctx, zoneID, instance)
+}
+
+// deleteStaleRoute53Records deletes the Route53 records of instance in the hosted zone it's tagged with, if that's no
+// longer zoneID, e.g. after the route53-hosted-zone annotation was changed or removed. This has to happen before the
+// tags are reconciled, since they're all that's left of the previous hosted zone.
+func (controller *defaultController) deleteStaleRoute53Records(ctx context.Context, instance *elbv2.LoadBalancer, zoneID string) error {
+	staleZoneID, err := controller.route53HostedZoneTagOf(ctx, instance)
+	if err != nil || staleZoneID == "" || staleZoneID == zoneID {
+		return err
+	}
+	albctx.GetLogger(ctx).Infof("deleting Route53 records of %v in hosted zone %v, it's no longer the hosted zone of the ingress", aws.StringValue(instance.LoadBalancerArn), staleZoneID)
+	return controller.route53Controller.Delete(ctx, staleZoneID, instance)
+}
+
+// route53HostedZoneTagOf returns the hosted zone instance is tagged with, or "" if there's none.
+func (controller *defaultController) route53HostedZoneTagOf(ctx context.Context, instance *elbv2.LoadBalancer) (string, error) {
+	return controller.lbTagOf(ctx, instance, TagRoute53HostedZone)
+}
+
+// lbTagOf returns the value of the tag key of instance, or "" if it has none.
+func (controller *defaultController) lbTagOf(ctx context.Context, instance *elbv2.LoadBalancer, key string) (string, error) {
+	resp, err := controller.cloud.DescribeELBV2TagsWithContext(ctx, &elbv2.DescribeTagsInput{ResourceArns: []*string{instance.LoadBalancerArn}})
+	if err != nil {
+		return "", err
+	}
+	for _, desc := range resp.TagDescriptions {
+		for _, tag := range desc.Tags {
+			if aws.StringValue(tag.Key) == key {
+				return aws.StringValue(tag.Value), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// lbNameOfARN returns the name of the LoadBalancer lbArn refers to, or "" if it isn't a LoadBalancer ARN.
+func lbNameOfARN(lbArn string) string {
+	parsed, err := arn.Parse(lbArn)
+	if err != nil {
+		return ""
+	}
+	// resource is in the form of loadbalancer/app/name/id
+	parts := strings.Split(parsed.Resource, "/")
+	if len(parts) != 4 || parts[0] != "loadbalancer" {
+		return ""
+	}
+	return parts[2]
+}
+
 func (controller *defaultController) newLBInstance(ctx context.Context, lbConfig *loadBalancerConfig, sgAttachment sg.LbAttachmentInfo) (*elbv2.LoadBalancer, error) {
 	albctx.GetLogger(ctx).Infof("creating LoadBalancer %v", lbConfig.Name)
 	resp, err := controller.cloud.CreateLoadBalancerWithContext(ctx, &elbv2.CreateLoadBalancerInput{
@@ -221,13 +537,22 @@ func (controller *defaultController) newLBInstance(ctx context.Context, lbConfig
 	})
 	if err != nil {
 		albctx.GetLogger(ctx).Errorf("failed to create LoadBalancer %v due to %v", lbConfig.Name, err)
-		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "failed to create LoadBalancer %v due to %v", lbConfig.Name, err)
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to create LoadBalancer %v due to %v", lbConfig.Name, err)
 		return nil, err
 	}
 
 	instance := resp.LoadBalancers[0]
-	albctx.GetLogger(ctx).Infof("LoadBalancer %v created, ARN: %v", lbConfig.Name, aws.StringValue(instance.LoadBalancerArn))
-	albctx.GetEventf(ctx)(corev1.EventTypeNormal, "CREATE", "LoadBalancer %v created, ARN: %v", lbConfig.Name, aws.StringValue(instance.LoadBalancerArn))
+	lbArn := aws.StringValue(instance.LoadBalancerArn)
+	albctx.GetLogger(ctx).Infof("LoadBalancer %v created, ARN: %v", lbConfig.Name, lbArn)
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonCreate, "LoadBalancer %v created, ARN: %v", lbConfig.Name, lbArn)
+	// the LoadBalancer may not be found for a little while after its creation, so wait for it rather than failing the reads that
+	// configure it, which would retry the whole reconcile.
+	if err := aws.RetryNotFound(ctx, aws.PostCreateBackoff, func() error {
+		_, err := controller.cloud.GetLoadBalancerByArn(ctx, lbArn)
+		return err
+	}, elbv2.ErrCodeLoadBalancerNotFoundException); err != nil {
+		return nil, fmt.Errorf("LoadBalancer %v isn't visible after its creation due to %v", lbArn, err)
+	}
 	return instance, nil
 }
 
@@ -235,8 +560,10 @@ func (controller *defaultController) recreateLBInstance(ctx context.Context, exi
 	existingLBArn := aws.StringValue(existingInstance.LoadBalancerArn)
 	albctx.GetLogger(ctx).Infof("deleting LoadBalancer %v for recreation", existingLBArn)
 	if err := controller.cloud.DeleteLoadBalancerByArn(ctx, existingLBArn); err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to delete LoadBalancer %v for recreation due to %v", existingLBArn, err)
 		return nil, err
 	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonDelete, "LoadBalancer %v deleted for recreation", existingLBArn)
 	return controller.newLBInstance(ctx, lbConfig, sgAttachment)
 }
 
@@ -248,10 +575,10 @@ func (controller *defaultController) reconcileLBInstance(ctx context.Context, in
 			LoadBalancerArn: instance.LoadBalancerArn,
 			IpAddressType:   lbConfig.IpAddressType,
 		}); err != nil {
-			albctx.GetEventf(ctx)(corev1.EventTypeNormal, "ERROR", "failed to modify IpAddressType of %v due to %v", lbArn, err)
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to modify IpAddressType of %v due to %v", lbArn, err)
 			return fmt.Errorf("failed to modify IpAddressType of %v due to %v", lbArn, err)
 		}
-		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", "IpAddressType of %v modified", lbArn)
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonModify, "IpAddressType of %v modified", lbArn)
 	}
 
 	desiredSubnets := sets.NewString(lbConfig.Subnets...)
@@ -262,11 +589,17 @@ func (controller *defaultController) reconcileLBInstance(ctx context.Context, in
 			LoadBalancerArn: instance.LoadBalancerArn,
 			Subnets:         aws.StringSlice(lbConfig.Subnets),
 		}); err != nil {
-			albctx.GetEventf(ctx)(corev1.EventTypeNormal, "ERROR", "failed to modify Subnets of %v due to %v", lbArn, err)
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to modify Subnets of %v due to %v", lbArn, err)
 			return fmt.Errorf("failed to modify Subnets of %v due to %v", lbArn, err)
 		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonModify, "Subnets of %v modified", lbArn)
 	}
 
+	if controller.store.GetConfig().FeatureGate.Enabled(config.Route53) {
+		if err := controller.deleteStaleRoute53Records(ctx, instance, lbConfig.Route53HostedZone); err != nil {
+			return fmt.Errorf("failed to delete Route53 records of %v in its previous hosted zone due to %v", lbArn, err)
+		}
+	}
 	if err := controller.tagsController.ReconcileELB(ctx, lbArn, lbConfig.Tags); err != nil {
 		return fmt.Errorf("failed to reconcile tags of %v due to %v", lbArn, err)
 	}
@@ -283,26 +616,74 @@ func (controller *defaultController) isLBInstanceNeedRecreation(ctx context.Cont
 }
 
 func (controller *defaultController) buildLBConfig(ctx context.Context, ingress *extensions.Ingress, ingressAnnos *annotations.Ingress) (*loadBalancerConfig, error) {
-	lbTags := controller.nameTagGen.TagLB(ingress.Namespace, ingress.Name)
-	for k, v := range ingressAnnos.Tags.LoadBalancer {
-		lbTags[k] = v
-	}
-	subnets, err := controller.resolveSubnets(ctx, aws.StringValue(ingressAnnos.LoadBalancer.Scheme), ingressAnnos.LoadBalancer.Subnets)
+	scheme := aws.StringValue(ingressAnnos.LoadBalancer.Scheme)
+	subnets, err := controller.resolveSubnets(ctx, scheme, ingressAnnos.LoadBalancer.Subnets)
 	if err != nil {
 		return nil, err
 	}
+	// auto-discovered subnets are already selected by scheme, only explicitly chosen subnets need checking.
+	if len(ingressAnnos.LoadBalancer.Subnets) > 0 {
+		if err := controller.validateSubnets(ctx, scheme, subnets); err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "%v", err)
+			return nil, err
+		}
+	}
+	if aws.StringValue(ingressAnnos.LoadBalancer.IPAddressType) == elbv2.IpAddressTypeDualstack {
+		if err := controller.validateSubnetsIPv6(ctx, subnets); err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "%v", err)
+			return nil, err
+		}
+	}
+
+	var adoptARN string
+	_ = annotations.LoadStringAnnotation(AnnotationLoadBalancerARN, &adoptARN, ingress.Annotations)
 
+	route53HostedZone := controller.route53HostedZoneOf(ingress)
 	return &loadBalancerConfig{
-		Name: controller.nameTagGen.NameLB(ingress.Namespace, ingress.Name),
-		Tags: lbTags,
+		Name:       controller.nameTagGen.NameLB(ingress.Namespace, ingress.Name),
+		LegacyName: controller.nameTagGen.LegacyNameLB(ingress.Namespace, ingress.Name),
+		Tags:       controller.buildLBTags(ingress, ingressAnnos, route53HostedZone),
 
 		Type:          aws.String(elbv2.LoadBalancerTypeEnumApplication),
 		Scheme:        ingressAnnos.LoadBalancer.Scheme,
 		IpAddressType: ingressAnnos.LoadBalancer.IPAddressType,
 		Subnets:       subnets,
+		AdoptARN:      adoptARN,
+
+		Route53HostedZone: route53HostedZone,
 	}, nil
 }
 
+// route53HostedZoneOf returns the hosted zone to create records of ingress in, or "" if there's none.
+func (controller *defaultController) route53HostedZoneOf(ingress *extensions.Ingress) string {
+	var route53HostedZone string
+	if controller.store.GetConfig().FeatureGate.Enabled(config.Route53) {
+		_ = annotations.LoadStringAnnotation(AnnotationRoute53HostedZone, &route53HostedZone, ingress.Annotations)
+	}
+	return route53HostedZone
+}
+
+func (controller *defaultController) buildLBTags(ingress *extensions.Ingress, ingressAnnos *annotations.Ingress, route53HostedZone string) map[string]string {
+	lbTags := make(map[string]string)
+	for k, v := range ingressAnnos.Tags.LoadBalancer {
+		lbTags[k] = v
+	}
+	if displayName := ingressAnnos.LoadBalancer.DisplayName; displayName != nil {
+		lbTags[TagName] = *displayName
+	}
+	// the controller's own tags win over custom ones, since they identify the LoadBalancer as managed by it.
+	for k, v := range controller.nameTagGen.TagLB(ingress.Namespace, ingress.Name) {
+		lbTags[k] = v
+	}
+	if route53HostedZone != "" {
+		lbTags[TagRoute53HostedZone] = route53HostedZone
+	}
+	if attrs, err := NewAttributes(ingressAnnos.LoadBalancer.Attributes); err == nil && attrs.DeletionProtectionEnabled {
+		lbTags[TagDeletionProtection] = "true"
+	}
+	return lbTags
+}
+
 func (controller *defaultController) validateLBConfig(ctx context.Context, ingress *extensions.Ingress, lbConfig *loadBalancerConfig) error {
 	controllerCfg := controller.store.GetConfig()
 	if controllerCfg.RestrictScheme && aws.StringValue(lbConfig.Scheme) == elbv2.LoadBalancerSchemeEnumInternetFacing {
@@ -321,6 +702,84 @@ func (controller *defaultController) validateLBConfig(ctx context.Context, ingre
 	return nil
 }
 
+// subnetTagSelectorPrefix marks an entry of the subnets annotation as a tag selector, in the form of `tag:key=value` or `tag:key`.
+const subnetTagSelectorPrefix = "tag:"
+
+// validateSubnets checks that all of subnets are in the cluster VPC, and that none of them is tagged exclusively for the
+// opposite scheme, e.g. an internet-facing LoadBalancer placed into subnets tagged only with kubernetes.io/role/internal-elb.
+// Subnets without either role tag are accepted.
+func (controller *defaultController) validateSubnets(ctx context.Context, scheme string, subnets []string) error {
+	// subnets are only looked up within the cluster VPC.
+	o, err := controller.cloud.GetSubnetsByNameOrID(ctx, subnets)
+	if err != nil {
+		return err
+	}
+	found := sets.NewString()
+	for _, subnet := range o {
+		found.Insert(aws.StringValue(subnet.SubnetId))
+	}
+	if missing := sets.NewString(subnets...).Difference(found); missing.Len() > 0 {
+		return fmt.Errorf("subnets %v are not in the cluster VPC %v, choose subnets of that VPC or set --aws-vpc-id",
+			strings.Join(missing.List(), ","), controller.cloud.GetVpcID())
+	}
+
+	var wantTag, otherTag string
+	switch scheme {
+	case elbv2.LoadBalancerSchemeEnumInternetFacing:
+		wantTag, otherTag = aws.TagNameSubnetPublicELB, aws.TagNameSubnetInternalELB
+	case elbv2.LoadBalancerSchemeEnumInternal:
+		wantTag, otherTag = aws.TagNameSubnetInternalELB, aws.TagNameSubnetPublicELB
+	default:
+		return nil
+	}
+
+	var mismatched []string
+	for _, subnet := range o {
+		tags := sets.NewString()
+		for _, tag := range subnet.Tags {
+			tags.Insert(aws.StringValue(tag.Key))
+		}
+		if tags.Has(otherTag) && !tags.Has(wantTag) {
+			mismatched = append(mismatched, aws.StringValue(subnet.SubnetId))
+		}
+	}
+	if len(mismatched) > 0 {
+		sort.Strings(mismatched)
+		return fmt.Errorf("subnets %v are tagged %v and cannot be used by a LoadBalancer with scheme %v, tag them %v or choose other subnets",
+			strings.Join(mismatched, ","), otherTag, scheme, wantTag)
+	}
+	return nil
+}
+
+// validateSubnetsIPv6 checks that all of subnets have an IPv6 CIDR block, which dualstack LoadBalancers require.
+func (controller *defaultController) validateSubnetsIPv6(ctx context.Context, subnets []string) error {
+	o, err := controller.cloud.GetSubnetsByNameOrID(ctx, subnets)
+	if err != nil {
+		return err
+	}
+	var ipv4Only []string
+	for _, subnet := range o {
+		if !hasIPv6CidrBlock(subnet) {
+			ipv4Only = append(ipv4Only, aws.StringValue(subnet.SubnetId))
+		}
+	}
+	if len(ipv4Only) > 0 {
+		sort.Strings(ipv4Only)
+		return fmt.Errorf("subnets %v have no IPv6 CIDR block and cannot be used by a LoadBalancer with IP address type %v, associate one or use %v",
+			strings.Join(ipv4Only, ","), elbv2.IpAddressTypeDualstack, elbv2.IpAddressTypeIpv4)
+	}
+	return nil
+}
+
+func hasIPv6CidrBlock(subnet *ec2.Subnet) bool {
+	for _, association := range subnet.Ipv6CidrBlockAssociationSet {
+		if association.Ipv6CidrBlockState != nil && aws.StringValue(association.Ipv6CidrBlockState.State) == ec2.SubnetCidrBlockStateCodeAssociated {
+			return true
+		}
+	}
+	return false
+}
+
 func (controller *defaultController) resolveSubnets(ctx context.Context, scheme string, in []string) ([]string, error) {
 	if len(in) == 0 {
 		subnets, err := controller.clusterSubnets(ctx, scheme)
@@ -330,8 +789,15 @@ func (controller *defaultController) resolveSubnets(ctx context.Context, scheme
 
 	var names []string
 	var subnets []string
+	var explicit []string
+	var selectors []string
 
 	for _, subnet := range in {
+		if strings.HasPrefix(subnet, subnetTagSelectorPrefix) {
+			selectors = append(selectors, subnet)
+			continue
+		}
+		explicit = append(explicit, subnet)
 		if strings.HasPrefix(subnet, "subnet-") {
 			subnets = append(subnets, subnet)
 			continue
@@ -351,13 +817,82 @@ func (controller *defaultController) resolveSubnets(ctx context.Context, scheme
 	}
 
 	sort.Strings(subnets)
-	if len(subnets) != len(in) {
-		return subnets, fmt.Errorf("not all subnets were resolvable, (%v != %v)", strings.Join(in, ","), strings.Join(subnets, ","))
+	if len(subnets) != len(explicit) {
+		return subnets, fmt.Errorf("not all subnets were resolvable, (%v != %v)", strings.Join(explicit, ","), strings.Join(subnets, ","))
+	}
+
+	if len(selectors) > 0 {
+		return controller.withTaggedSubnets(ctx, in, subnets, selectors)
 	}
 
 	return subnets, nil
 }
 
+// withTaggedSubnets adds the subnets matching the tag selectors to the explicit subnets, keeping one subnet per availability
+// zone, since CreateLoadBalancer rejects several. Explicit subnets take precedence over tagged ones in the same zone.
+func (controller *defaultController) withTaggedSubnets(ctx context.Context, in []string, explicit []string, selectors []string) ([]string, error) {
+	tagged, err := controller.subnetsByTags(ctx, selectors)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []*ec2.Subnet
+	if len(explicit) > 0 {
+		o, err := controller.cloud.GetSubnetsByNameOrID(ctx, explicit)
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(o, func(i, j int) bool { return aws.StringValue(o[i].SubnetId) < aws.StringValue(o[j].SubnetId) })
+		candidates = append(candidates, o...)
+	}
+	candidates = append(candidates, tagged...)
+
+	zones := sets.NewString()
+	var out []string
+	for _, subnet := range candidates {
+		if zone := aws.StringValue(subnet.AvailabilityZone); !zones.Has(zone) {
+			zones.Insert(zone)
+			out = append(out, aws.StringValue(subnet.SubnetId))
+		}
+	}
+
+	// an ALB needs subnets in at least two availability zones, AWS would reject the LoadBalancer anyway
+	if zones.Len() < 2 {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "subnets %v resolved to usable subnets in %d availability zones, at least 2 are required", strings.Join(in, ","), zones.Len())
+		return nil, fmt.Errorf("subnets %v resolved to usable subnets in %d availability zones, at least 2 are required", strings.Join(in, ","), zones.Len())
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// subnetsByTags resolves subnet tag selectors into one usable subnet per availability zone.
+func (controller *defaultController) subnetsByTags(ctx context.Context, selectors []string) ([]*ec2.Subnet, error) {
+	tags := make(map[string]string, len(selectors))
+	for _, selector := range selectors {
+		parts := strings.SplitN(strings.TrimPrefix(selector, subnetTagSelectorPrefix), "=", 2)
+		if len(parts[0]) == 0 {
+			return nil, fmt.Errorf("invalid subnet tag selector %v, must be in the form of tag:key=value", selector)
+		}
+		if len(parts) == 2 {
+			tags[parts[0]] = parts[1]
+		} else {
+			tags[parts[0]] = ""
+		}
+	}
+
+	taggedSubnets, err := controller.cloud.GetSubnetsByTags(ctx, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	var useableSubnets []*ec2.Subnet
+	for _, subnet := range taggedSubnets {
+		if subnetIsUsable(subnet, useableSubnets) {
+			useableSubnets = append(useableSubnets, subnet)
+		}
+	}
+	return useableSubnets, nil
+}
+
 func (controller *defaultController) clusterSubnets(ctx context.Context, scheme string) ([]string, error) {
 	var useableSubnets []*ec2.Subnet
 	var out []string
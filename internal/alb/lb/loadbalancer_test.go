@@ -1 +1,852 @@
 package lb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/ls"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/sg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	annoTags "github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/tags"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	ingerrors "github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func subnet(id string, az string) *ec2.Subnet {
+	return &ec2.Subnet{
+		SubnetId:                aws.String(id),
+		AvailabilityZone:        aws.String(az),
+		AvailableIpAddressCount: aws.Int64(100),
+	}
+}
+
+type GetSubnetsByTagsCall struct {
+	Tags    map[string]string
+	Subnets []*ec2.Subnet
+	Err     error
+}
+
+type GetSubnetsByNameOrIDCall struct {
+	NameOrIDs []string
+	Subnets   []*ec2.Subnet
+}
+
+func Test_defaultController_resolveSubnets(t *testing.T) {
+	for _, tc := range []struct {
+		Name                 string
+		Subnets              []string
+		GetSubnetsByTagsCall *GetSubnetsByTagsCall
+		GetSubnetsByNameOrID *GetSubnetsByNameOrIDCall
+		ExpectedSubnets      []string
+		ExpectedError        error
+	}{
+		{
+			Name:            "explicit subnet IDs",
+			Subnets:         []string{"subnet-2", "subnet-1"},
+			ExpectedSubnets: []string{"subnet-1", "subnet-2"},
+		},
+		{
+			Name:    "tag selector picks one subnet per availability zone",
+			Subnets: []string{"tag:kubernetes.io/role/elb=1", "tag:team"},
+			GetSubnetsByTagsCall: &GetSubnetsByTagsCall{
+				Tags:    map[string]string{"kubernetes.io/role/elb": "1", "team": ""},
+				Subnets: []*ec2.Subnet{subnet("subnet-1", "us-west-2a"), subnet("subnet-2", "us-west-2a"), subnet("subnet-3", "us-west-2b")},
+			},
+			ExpectedSubnets: []string{"subnet-1", "subnet-3"},
+		},
+		{
+			Name:    "tag selector combined with explicit subnet IDs",
+			Subnets: []string{"subnet-3", "tag:kubernetes.io/role/elb=1"},
+			GetSubnetsByTagsCall: &GetSubnetsByTagsCall{
+				Tags:    map[string]string{"kubernetes.io/role/elb": "1"},
+				Subnets: []*ec2.Subnet{subnet("subnet-1", "us-west-2a"), subnet("subnet-3", "us-west-2b")},
+			},
+			GetSubnetsByNameOrID: &GetSubnetsByNameOrIDCall{
+				NameOrIDs: []string{"subnet-3"},
+				Subnets:   []*ec2.Subnet{subnet("subnet-3", "us-west-2b")},
+			},
+			ExpectedSubnets: []string{"subnet-1", "subnet-3"},
+		},
+		{
+			Name:    "explicit subnet IDs take precedence over tagged subnets in their availability zone",
+			Subnets: []string{"subnet-4", "tag:kubernetes.io/role/elb=1"},
+			GetSubnetsByTagsCall: &GetSubnetsByTagsCall{
+				Tags:    map[string]string{"kubernetes.io/role/elb": "1"},
+				Subnets: []*ec2.Subnet{subnet("subnet-1", "us-west-2a"), subnet("subnet-3", "us-west-2b")},
+			},
+			GetSubnetsByNameOrID: &GetSubnetsByNameOrIDCall{
+				NameOrIDs: []string{"subnet-4"},
+				Subnets:   []*ec2.Subnet{subnet("subnet-4", "us-west-2b")},
+			},
+			ExpectedSubnets: []string{"subnet-1", "subnet-4"},
+		},
+		{
+			Name:    "explicit and tagged subnets in a single availability zone",
+			Subnets: []string{"subnet-4", "tag:kubernetes.io/role/elb=1"},
+			GetSubnetsByTagsCall: &GetSubnetsByTagsCall{
+				Tags:    map[string]string{"kubernetes.io/role/elb": "1"},
+				Subnets: []*ec2.Subnet{subnet("subnet-3", "us-west-2b")},
+			},
+			GetSubnetsByNameOrID: &GetSubnetsByNameOrIDCall{
+				NameOrIDs: []string{"subnet-4"},
+				Subnets:   []*ec2.Subnet{subnet("subnet-4", "us-west-2b")},
+			},
+			ExpectedError: errors.New("subnets subnet-4,tag:kubernetes.io/role/elb=1 resolved to usable subnets in 1 availability zones, at least 2 are required"),
+		},
+		{
+			Name:    "tag selector resolving to a single availability zone",
+			Subnets: []string{"tag:kubernetes.io/role/elb=1"},
+			GetSubnetsByTagsCall: &GetSubnetsByTagsCall{
+				Tags:    map[string]string{"kubernetes.io/role/elb": "1"},
+				Subnets: []*ec2.Subnet{subnet("subnet-1", "us-west-2a"), subnet("subnet-2", "us-west-2a")},
+			},
+			ExpectedError: errors.New("subnets tag:kubernetes.io/role/elb=1 resolved to usable subnets in 1 availability zones, at least 2 are required"),
+		},
+		{
+			Name:    "tag selector lookup fails",
+			Subnets: []string{"tag:kubernetes.io/role/elb=1"},
+			GetSubnetsByTagsCall: &GetSubnetsByTagsCall{
+				Tags: map[string]string{"kubernetes.io/role/elb": "1"},
+				Err:  errors.New("unable to fetch subnets due to boom"),
+			},
+			ExpectedError: errors.New("unable to fetch subnets due to boom"),
+		},
+		{
+			Name:          "tag selector without key",
+			Subnets:       []string{"tag:=1"},
+			ExpectedError: errors.New("invalid subnet tag selector tag:=1, must be in the form of tag:key=value"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			if tc.GetSubnetsByTagsCall != nil {
+				cloud.On("GetSubnetsByTags", ctx, tc.GetSubnetsByTagsCall.Tags).Return(tc.GetSubnetsByTagsCall.Subnets, tc.GetSubnetsByTagsCall.Err)
+			}
+			if tc.GetSubnetsByNameOrID != nil {
+				cloud.On("GetSubnetsByNameOrID", ctx, tc.GetSubnetsByNameOrID.NameOrIDs).Return(tc.GetSubnetsByNameOrID.Subnets, nil)
+			}
+
+			controller := &defaultController{cloud: cloud}
+			subnets, err := controller.resolveSubnets(ctx, "internet-facing", tc.Subnets)
+			assert.Equal(t, tc.ExpectedError, err)
+			if tc.ExpectedError == nil {
+				assert.Equal(t, tc.ExpectedSubnets, subnets)
+			}
+			cloud.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_defaultController_Reconcile_invalidAttributes(t *testing.T) {
+	for _, tc := range []struct {
+		Name          string
+		Attributes    []*elbv2.LoadBalancerAttribute
+		ExpectedError error
+	}{
+		{
+			Name:          "idle timeout above the AWS limit",
+			Attributes:    []*elbv2.LoadBalancerAttribute{lbAttribute(IdleTimeoutTimeoutSecondsKey, "4001")},
+			ExpectedError: errors.New("invalid LoadBalancer attributes due to idle_timeout.timeout_seconds must be within 1-4000 seconds"),
+		},
+		{
+			Name:          "idle timeout that is not a number",
+			Attributes:    []*elbv2.LoadBalancerAttribute{lbAttribute(IdleTimeoutTimeoutSecondsKey, "5m")},
+			ExpectedError: errors.New("invalid LoadBalancer attributes due to invalid load balancer attribute value idle_timeout.timeout_seconds=5m"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"}}
+			mockStore := &store.MockStorer{}
+			mockStore.On("GetIngressAnnotations", "namespace/ingress").Return(&annotations.Ingress{
+				LoadBalancer: &loadbalancer.Config{Attributes: tc.Attributes},
+			}, nil)
+			mockStore.On("GetConfig").Return(&config.Configuration{})
+			cloud := &mocks.CloudAPI{}
+
+			controller := &defaultController{cloud: cloud, store: mockStore}
+			_, err := controller.Reconcile(context.Background(), ingress)
+			assert.Equal(t, tc.ExpectedError, err)
+			mockStore.AssertExpectations(t)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_defaultController_Reconcile_invalidAnnotations(t *testing.T) {
+	for _, tc := range []struct {
+		Name           string
+		Err            error
+		ExpectedEvents []string
+	}{
+		{
+			Name: "invalid annotation content is reported by an event",
+			Err:  ingerrors.NewInvalidAnnotationContentReason("annotation alb.ingress.kubernetes.io/eip-allocations can't be honored, ALBs don't support static IP addresses"),
+			ExpectedEvents: []string{
+				"Warning ERROR invalid annotations: annotation alb.ingress.kubernetes.io/eip-allocations can't be honored, ALBs don't support static IP addresses",
+			},
+		},
+		{
+			Name: "other errors aren't",
+			Err:  errors.New("no object matching key \"namespace/ingress\" in local store"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"}}
+			var events []string
+			ctx := albctx.SetEventf(context.Background(), func(eventType string, reason string, messageFmt string, args ...interface{}) {
+				events = append(events, eventType+" "+reason+" "+fmt.Sprintf(messageFmt, args...))
+			})
+			mockStore := &store.MockStorer{}
+			mockStore.On("GetIngressAnnotations", "namespace/ingress").Return(nil, tc.Err)
+			cloud := &mocks.CloudAPI{}
+
+			controller := &defaultController{cloud: cloud, store: mockStore}
+			_, err := controller.Reconcile(ctx, ingress)
+			assert.Equal(t, tc.Err, err)
+			assert.Equal(t, tc.ExpectedEvents, events)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
+
+func taggedSubnet(id string, tagKeys ...string) *ec2.Subnet {
+	s := subnet(id, "us-west-2a")
+	for _, k := range tagKeys {
+		s.Tags = append(s.Tags, &ec2.Tag{Key: aws.String(k), Value: aws.String("1")})
+	}
+	return s
+}
+
+func Test_defaultController_validateSubnets(t *testing.T) {
+	for _, tc := range []struct {
+		Name          string
+		Scheme        string
+		Requested     []string
+		Subnets       []*ec2.Subnet
+		ExpectedError error
+	}{
+		{
+			Name:          "subnets outside of the cluster VPC",
+			Scheme:        "internet-facing",
+			Requested:     []string{"subnet-1", "subnet-3", "subnet-2"},
+			Subnets:       []*ec2.Subnet{taggedSubnet("subnet-1", "kubernetes.io/role/elb")},
+			ExpectedError: errors.New("subnets subnet-2,subnet-3 are not in the cluster VPC vpc-1, choose subnets of that VPC or set --aws-vpc-id"),
+		},
+		{
+			Name:    "internet-facing with public and untagged subnets",
+			Scheme:  "internet-facing",
+			Subnets: []*ec2.Subnet{taggedSubnet("subnet-1", "kubernetes.io/role/elb"), taggedSubnet("subnet-2")},
+		},
+		{
+			Name:    "internet-facing with a subnet tagged for both schemes",
+			Scheme:  "internet-facing",
+			Subnets: []*ec2.Subnet{taggedSubnet("subnet-1", "kubernetes.io/role/elb", "kubernetes.io/role/internal-elb")},
+		},
+		{
+			Name:          "internet-facing with internal subnets",
+			Scheme:        "internet-facing",
+			Subnets:       []*ec2.Subnet{taggedSubnet("subnet-2", "kubernetes.io/role/internal-elb"), taggedSubnet("subnet-1", "kubernetes.io/role/internal-elb"), taggedSubnet("subnet-3")},
+			ExpectedError: errors.New("subnets subnet-1,subnet-2 are tagged kubernetes.io/role/internal-elb and cannot be used by a LoadBalancer with scheme internet-facing, tag them kubernetes.io/role/elb or choose other subnets"),
+		},
+		{
+			Name:          "internal with public subnets",
+			Scheme:        "internal",
+			Subnets:       []*ec2.Subnet{taggedSubnet("subnet-1", "kubernetes.io/role/elb")},
+			ExpectedError: errors.New("subnets subnet-1 are tagged kubernetes.io/role/elb and cannot be used by a LoadBalancer with scheme internal, tag them kubernetes.io/role/internal-elb or choose other subnets"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			ids := tc.Requested
+			if ids == nil {
+				for _, s := range tc.Subnets {
+					ids = append(ids, aws.StringValue(s.SubnetId))
+				}
+			}
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetSubnetsByNameOrID", ctx, ids).Return(tc.Subnets, nil)
+			if len(ids) != len(tc.Subnets) {
+				cloud.On("GetVpcID").Return("vpc-1")
+			}
+
+			controller := &defaultController{cloud: cloud}
+			err := controller.validateSubnets(ctx, tc.Scheme, ids)
+			assert.Equal(t, tc.ExpectedError, err)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
+
+func ipv6Subnet(id string, state string) *ec2.Subnet {
+	s := &ec2.Subnet{SubnetId: aws.String(id)}
+	if state != "" {
+		s.Ipv6CidrBlockAssociationSet = []*ec2.SubnetIpv6CidrBlockAssociation{{
+			Ipv6CidrBlock:      aws.String("2600:1f14::/64"),
+			Ipv6CidrBlockState: &ec2.SubnetCidrBlockState{State: aws.String(state)},
+		}}
+	}
+	return s
+}
+
+func Test_defaultController_validateSubnetsIPv6(t *testing.T) {
+	for _, tc := range []struct {
+		Name          string
+		Subnets       []*ec2.Subnet
+		ExpectedError error
+	}{
+		{
+			Name:    "subnets with IPv6 CIDR blocks",
+			Subnets: []*ec2.Subnet{ipv6Subnet("subnet-1", "associated"), ipv6Subnet("subnet-2", "associated")},
+		},
+		{
+			Name:          "subnets without or with disassociated IPv6 CIDR blocks",
+			Subnets:       []*ec2.Subnet{ipv6Subnet("subnet-3", ""), ipv6Subnet("subnet-1", "associated"), ipv6Subnet("subnet-2", "disassociated")},
+			ExpectedError: errors.New("subnets subnet-2,subnet-3 have no IPv6 CIDR block and cannot be used by a LoadBalancer with IP address type dualstack, associate one or use ipv4"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			var ids []string
+			for _, s := range tc.Subnets {
+				ids = append(ids, aws.StringValue(s.SubnetId))
+			}
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetSubnetsByNameOrID", ctx, ids).Return(tc.Subnets, nil)
+
+			controller := &defaultController{cloud: cloud}
+			err := controller.validateSubnetsIPv6(ctx, ids)
+			assert.Equal(t, tc.ExpectedError, err)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
+
+type fakeNameTagGen struct{}
+
+func (fakeNameTagGen) NameLB(namespace string, ingressName string) string {
+	return "prefix-" + namespace + "-" + ingressName
+}
+
+func (fakeNameTagGen) LegacyNameLB(namespace string, ingressName string) string {
+	return "legacy-" + namespace + "-" + ingressName
+}
+
+func (fakeNameTagGen) TagLB(namespace string, ingressName string) map[string]string {
+	return map[string]string{"ingress.k8s.aws/stack": namespace + "/" + ingressName}
+}
+
+func (fakeNameTagGen) OwnsLB(namespace string, ingressName string, lbTags map[string]string) bool {
+	return lbTags["ingress.k8s.aws/stack"] == namespace+"/"+ingressName
+}
+
+func (fakeNameTagGen) ClaimsLB(lbTags map[string]string) bool {
+	_, ok := lbTags["ingress.k8s.aws/stack"]
+	return ok
+}
+
+func Test_defaultController_findLBInstance(t *testing.T) {
+	current := &elbv2.LoadBalancer{LoadBalancerName: aws.String("prefix-ns-ing-3d525fb5")}
+	legacy := &elbv2.LoadBalancer{LoadBalancerName: aws.String("prefix-ns-ing-0828"), LoadBalancerArn: aws.String("legacy-arn")}
+	for _, tc := range []struct {
+		Name             string
+		Current          *elbv2.LoadBalancer
+		Legacy           *elbv2.LoadBalancer
+		LegacyTags       []*elbv2.Tag
+		ExpectLegacyCall bool
+		Expected         *elbv2.LoadBalancer
+	}{
+		{
+			Name:     "found under current name",
+			Current:  current,
+			Expected: current,
+		},
+		{
+			Name:             "found under legacy name",
+			Legacy:           legacy,
+			LegacyTags:       []*elbv2.Tag{{Key: aws.String("ingress.k8s.aws/stack"), Value: aws.String("ns/ing")}},
+			ExpectLegacyCall: true,
+			Expected:         legacy,
+		},
+		{
+			Name:             "legacy name shared with another ingress",
+			Legacy:           legacy,
+			LegacyTags:       []*elbv2.Tag{{Key: aws.String("ingress.k8s.aws/stack"), Value: aws.String("ns/ing-other")}},
+			ExpectLegacyCall: true,
+		},
+		{
+			Name:             "not found",
+			ExpectLegacyCall: true,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetLoadBalancerByName", ctx, "prefix-ns-ing-3d525fb5").Return(tc.Current, nil)
+			if tc.ExpectLegacyCall {
+				cloud.On("GetLoadBalancerByName", ctx, "prefix-ns-ing-0828").Return(tc.Legacy, nil)
+			}
+			if tc.Legacy != nil {
+				cloud.On("DescribeELBV2TagsWithContext", ctx, &elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String("legacy-arn")}}).Return(
+					&elbv2.DescribeTagsOutput{TagDescriptions: []*elbv2.TagDescription{{Tags: tc.LegacyTags}}}, nil)
+			}
+
+			controller := &defaultController{cloud: cloud, nameTagGen: fakeNameTagGen{}}
+			instance, err := controller.findLBInstance(ctx, types.NamespacedName{Namespace: "ns", Name: "ing"}, "prefix-ns-ing-3d525fb5", "prefix-ns-ing-0828")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, instance)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_defaultController_ownsLBInstance(t *testing.T) {
+	instance := &elbv2.LoadBalancer{LoadBalancerArn: aws.String("lb-arn")}
+	for _, tc := range []struct {
+		Name          string
+		CurrentTags   []*elbv2.Tag
+		TagsErr       error
+		Expected      bool
+		ExpectedError error
+	}{
+		{
+			Name:        "LoadBalancer of the ingress",
+			CurrentTags: []*elbv2.Tag{{Key: aws.String("ingress.k8s.aws/stack"), Value: aws.String("ns/ing")}},
+			Expected:    true,
+		},
+		{
+			Name:        "LoadBalancer sharing the name of the ingress's",
+			CurrentTags: []*elbv2.Tag{{Key: aws.String("team"), Value: aws.String("payments")}},
+		},
+		{
+			Name:          "failing to get tags",
+			TagsErr:       errors.New("AccessDenied"),
+			ExpectedError: errors.New("failed to get tags of LoadBalancer lb-arn due to AccessDenied"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			cloud.On("DescribeELBV2TagsWithContext", ctx, &elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String("lb-arn")}}).Return(
+				&elbv2.DescribeTagsOutput{TagDescriptions: []*elbv2.TagDescription{{Tags: tc.CurrentTags}}}, tc.TagsErr)
+
+			controller := &defaultController{cloud: cloud, nameTagGen: fakeNameTagGen{}}
+			owned, err := controller.ownsLBInstance(ctx, types.NamespacedName{Namespace: "ns", Name: "ing"}, instance)
+			assert.Equal(t, tc.ExpectedError, err)
+			assert.Equal(t, tc.Expected, owned)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_defaultController_adoptLBInstance(t *testing.T) {
+	const adoptARN = "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/cfn-lb/50dc6c495c0c9188"
+	const ownARN = "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/prefix-ns-ing/50dc6c495c0c9188"
+	adoptable := &elbv2.LoadBalancer{LoadBalancerArn: aws.String(adoptARN), VpcId: aws.String("vpc-1"), Scheme: aws.String("internal")}
+	for _, tc := range []struct {
+		Name           string
+		AdoptARN       string
+		Instance       *elbv2.LoadBalancer
+		InstanceErr    error
+		CurrentTags    []*elbv2.Tag
+		Expected       *elbv2.LoadBalancer
+		ExpectedTags   map[string]string
+		ExpectedEvents []string
+		ExpectedError  error
+	}{
+		{
+			Name:     "LoadBalancer created by CloudFormation",
+			AdoptARN: adoptARN,
+			Instance: adoptable,
+			CurrentTags: []*elbv2.Tag{
+				{Key: aws.String("aws:cloudformation:stack-name"), Value: aws.String("stack")},
+			},
+			Expected: adoptable,
+			ExpectedTags: map[string]string{
+				"ingress.k8s.aws/stack":         "ns/ing",
+				"ingress.k8s.aws/adopted":       "true",
+				"aws:cloudformation:stack-name": "stack",
+			},
+			ExpectedEvents: []string{"Normal CREATE LoadBalancer " + adoptARN + " adopted"},
+		},
+		{
+			Name:     "LoadBalancer adopted before",
+			AdoptARN: adoptARN,
+			Instance: adoptable,
+			CurrentTags: []*elbv2.Tag{
+				{Key: aws.String("ingress.k8s.aws/stack"), Value: aws.String("ns/ing")},
+				{Key: aws.String("ingress.k8s.aws/adopted"), Value: aws.String("true")},
+			},
+			Expected:     adoptable,
+			ExpectedTags: map[string]string{"ingress.k8s.aws/stack": "ns/ing", "ingress.k8s.aws/adopted": "true"},
+		},
+		{
+			Name:     "LoadBalancer created for the ingress under another name",
+			AdoptARN: adoptARN,
+			Instance: adoptable,
+			CurrentTags: []*elbv2.Tag{
+				{Key: aws.String("ingress.k8s.aws/stack"), Value: aws.String("ns/ing")},
+			},
+			Expected:     adoptable,
+			ExpectedTags: map[string]string{"ingress.k8s.aws/stack": "ns/ing"},
+		},
+		{
+			Name:     "LoadBalancer of another ingress",
+			AdoptARN: adoptARN,
+			Instance: adoptable,
+			CurrentTags: []*elbv2.Tag{
+				{Key: aws.String("ingress.k8s.aws/stack"), Value: aws.String("ns/other")},
+			},
+			ExpectedEvents: []string{"Warning ERROR refusing to adopt LoadBalancer " + adoptARN + ", it's tagged as managed for another ingress or cluster"},
+			ExpectedError:  errors.New("refusing to adopt LoadBalancer " + adoptARN + ", it's tagged as managed for another ingress or cluster"),
+		},
+		{
+			Name:           "LoadBalancer in another VPC",
+			AdoptARN:       adoptARN,
+			Instance:       &elbv2.LoadBalancer{LoadBalancerArn: aws.String(adoptARN), VpcId: aws.String("vpc-2"), Scheme: aws.String("internal")},
+			ExpectedEvents: []string{"Warning ERROR refusing to adopt LoadBalancer " + adoptARN + " from VPC vpc-2, it must be in the cluster VPC vpc-1"},
+			ExpectedError:  errors.New("refusing to adopt LoadBalancer " + adoptARN + " from VPC vpc-2, it must be in the cluster VPC vpc-1"),
+		},
+		{
+			Name:          "LoadBalancer with another scheme",
+			AdoptARN:      adoptARN,
+			Instance:      &elbv2.LoadBalancer{LoadBalancerArn: aws.String(adoptARN), VpcId: aws.String("vpc-1"), Scheme: aws.String("internet-facing")},
+			ExpectedError: errors.New("adopted LoadBalancer " + adoptARN + " has scheme internet-facing instead of internal, and won't be recreated"),
+		},
+		{
+			Name:          "LoadBalancer that doesn't exist",
+			AdoptARN:      adoptARN,
+			InstanceErr:   awserr.New(elbv2.ErrCodeLoadBalancerNotFoundException, "not found", nil),
+			ExpectedError: errors.New("LoadBalancer " + adoptARN + " to adopt doesn't exist"),
+		},
+		{
+			Name:        "deleted LoadBalancer of the ingress itself",
+			AdoptARN:    ownARN,
+			InstanceErr: awserr.New(elbv2.ErrCodeLoadBalancerNotFoundException, "not found", nil),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			var events []string
+			ctx := albctx.SetEventf(context.Background(), func(eventType string, reason string, messageFmt string, args ...interface{}) {
+				events = append(events, eventType+" "+reason+" "+fmt.Sprintf(messageFmt, args...))
+			})
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetLoadBalancerByArn", ctx, tc.AdoptARN).Return(tc.Instance, tc.InstanceErr)
+			cloud.On("GetVpcID").Return("vpc-1")
+			if tc.CurrentTags != nil {
+				cloud.On("DescribeELBV2TagsWithContext", ctx, &elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(tc.AdoptARN)}}).Return(&elbv2.DescribeTagsOutput{
+					TagDescriptions: []*elbv2.TagDescription{{ResourceArn: aws.String(tc.AdoptARN), Tags: tc.CurrentTags}},
+				}, nil)
+			}
+
+			controller := &defaultController{cloud: cloud, nameTagGen: fakeNameTagGen{}}
+			lbConfig := &loadBalancerConfig{
+				Name:       "prefix-ns-ing",
+				LegacyName: "legacy-ns-ing",
+				Tags:       map[string]string{"ingress.k8s.aws/stack": "ns/ing"},
+				Scheme:     aws.String("internal"),
+				AdoptARN:   tc.AdoptARN,
+			}
+			instance, err := controller.adoptLBInstance(ctx, types.NamespacedName{Namespace: "ns", Name: "ing"}, lbConfig)
+			assert.Equal(t, tc.ExpectedError, err)
+			assert.Equal(t, tc.Expected, instance)
+			if tc.ExpectedTags != nil {
+				assert.Equal(t, tc.ExpectedTags, lbConfig.Tags)
+			}
+			assert.Equal(t, tc.ExpectedEvents, events)
+		})
+	}
+}
+
+// tagLBGenerator is a NameTagGenerator whose TagLB tags LoadBalancers with their namespace.
+type tagLBGenerator struct {
+	NameTagGenerator
+}
+
+func (tagLBGenerator) TagLB(namespace string, ingressName string) map[string]string {
+	return map[string]string{"kubernetes.io/namespace": namespace}
+}
+
+func Test_defaultController_buildLBTags_displayName(t *testing.T) {
+	controller := &defaultController{nameTagGen: tagLBGenerator{}}
+	ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ing"}}
+
+	for _, tc := range []struct {
+		Name         string
+		CustomTags   map[string]string
+		DisplayName  *string
+		ExpectedName *string
+	}{
+		{
+			Name: "no Name tag without the annotation",
+		},
+		{
+			Name:         "Name tag from the annotation",
+			DisplayName:  aws.String("Checkout"),
+			ExpectedName: aws.String("Checkout"),
+		},
+		{
+			Name:         "annotation wins over a custom Name tag",
+			CustomTags:   map[string]string{"Name": "custom"},
+			DisplayName:  aws.String("Checkout"),
+			ExpectedName: aws.String("Checkout"),
+		},
+		{
+			Name:         "custom Name tag without the annotation",
+			CustomTags:   map[string]string{"Name": "custom"},
+			ExpectedName: aws.String("custom"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ingressAnnos := &annotations.Ingress{
+				LoadBalancer: &loadbalancer.Config{DisplayName: tc.DisplayName},
+				Tags:         &annoTags.Config{LoadBalancer: tc.CustomTags},
+			}
+			lbTags := controller.buildLBTags(ingress, ingressAnnos, "")
+			name, ok := lbTags[TagName]
+			if tc.ExpectedName == nil {
+				assert.False(t, ok)
+			} else {
+				assert.Equal(t, *tc.ExpectedName, name)
+			}
+			assert.Equal(t, "ns", lbTags["kubernetes.io/namespace"])
+		})
+	}
+}
+
+func Test_defaultController_buildLBTags_deletionProtection(t *testing.T) {
+	controller := &defaultController{nameTagGen: tagLBGenerator{}}
+	ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ing"}}
+
+	for _, tc := range []struct {
+		Name           string
+		Attributes     []*elbv2.LoadBalancerAttribute
+		ExpectedTagged bool
+	}{
+		{
+			Name: "no tag without attributes",
+		},
+		{
+			Name:       "no tag when deletion protection is disabled",
+			Attributes: []*elbv2.LoadBalancerAttribute{lbAttribute(DeletionProtectionEnabledKey, "false")},
+		},
+		{
+			Name:           "tagged when the annotation enables deletion protection",
+			Attributes:     []*elbv2.LoadBalancerAttribute{lbAttribute(DeletionProtectionEnabledKey, "true")},
+			ExpectedTagged: true,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ingressAnnos := &annotations.Ingress{
+				LoadBalancer: &loadbalancer.Config{Attributes: tc.Attributes},
+				Tags:         &annoTags.Config{},
+			}
+			lbTags := controller.buildLBTags(ingress, ingressAnnos, "")
+			_, tagged := lbTags[TagDeletionProtection]
+			assert.Equal(t, tc.ExpectedTagged, tagged)
+		})
+	}
+}
+
+// deletedListeners is an ls.GroupController recording the LoadBalancers whose listeners it deleted.
+type deletedListeners struct {
+	ls.GroupController
+	lbArns []string
+}
+
+func (d *deletedListeners) Delete(ctx context.Context, lbArn string) error {
+	d.lbArns = append(d.lbArns, lbArn)
+	return nil
+}
+
+// deletedTargetGroups is a tg.GroupController recording the ingresses whose targetGroups it deleted.
+type deletedTargetGroups struct {
+	tg.GroupController
+	ingressKeys []types.NamespacedName
+}
+
+func (d *deletedTargetGroups) Delete(ctx context.Context, ingressKey types.NamespacedName) error {
+	d.ingressKeys = append(d.ingressKeys, ingressKey)
+	return nil
+}
+
+func Test_defaultController_detachLBInstance(t *testing.T) {
+	const lbArn = "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/cfn-lb/50dc6c495c0c9188"
+	ctx := context.Background()
+	ingressKey := types.NamespacedName{Namespace: "ns", Name: "ing"}
+	cfg := config.NewConfiguration()
+	mockStore := &store.MockStorer{}
+	mockStore.On("GetConfig").Return(&cfg)
+	cloud := &mocks.CloudAPI{}
+	cloud.On("RemoveELBV2TagsWithContext", ctx, &elbv2.RemoveTagsInput{
+		ResourceArns: []*string{aws.String(lbArn)},
+		TagKeys:      aws.StringSlice([]string{"ingress.k8s.aws/adopted", "ingress.k8s.aws/deletion-protection", "ingress.k8s.aws/route53-hosted-zone", "ingress.k8s.aws/stack"}),
+	}).Return(&elbv2.RemoveTagsOutput{}, nil)
+	lsGroupController := &deletedListeners{}
+	tgGroupController := &deletedTargetGroups{}
+
+	controller := &defaultController{
+		cloud:             cloud,
+		store:             mockStore,
+		nameTagGen:        fakeNameTagGen{},
+		lsGroupController: lsGroupController,
+		tgGroupController: tgGroupController,
+	}
+	err := controller.detachLBInstance(ctx, ingressKey, &elbv2.LoadBalancer{LoadBalancerArn: aws.String(lbArn)})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{lbArn}, lsGroupController.lbArns)
+	assert.Equal(t, []types.NamespacedName{ingressKey}, tgGroupController.ingressKeys)
+	cloud.AssertExpectations(t)
+}
+
+// cleanedUpSecurityGroups is an sg.AssociationController recording how the securityGroups of ingresses were cleaned up.
+type cleanedUpSecurityGroups struct {
+	sg.AssociationController
+	calls []string
+}
+
+func (c *cleanedUpSecurityGroups) Delete(ctx context.Context, ingKey types.NamespacedName) error {
+	c.calls = append(c.calls, "Delete "+ingKey.String())
+	return nil
+}
+
+func (c *cleanedUpSecurityGroups) Detach(ctx context.Context, ingKey types.NamespacedName) error {
+	c.calls = append(c.calls, "Detach "+ingKey.String())
+	return nil
+}
+
+func Test_defaultController_Delete_lbFoundByTags(t *testing.T) {
+	const lbArn = "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/old-prefix-ns-ing/50dc6c495c0c9188"
+	for _, tc := range []struct {
+		Name            string
+		CurrentTags     []*elbv2.Tag
+		ExpectedDeleted bool
+		ExpectedSGCalls []string
+	}{
+		{
+			Name:            "LoadBalancer created under a previous --alb-name-prefix",
+			CurrentTags:     []*elbv2.Tag{{Key: aws.String("ingress.k8s.aws/stack"), Value: aws.String("ns/ing")}},
+			ExpectedDeleted: true,
+			ExpectedSGCalls: []string{"Delete ns/ing"},
+		},
+		{
+			Name: "adopted LoadBalancer",
+			CurrentTags: []*elbv2.Tag{
+				{Key: aws.String("ingress.k8s.aws/stack"), Value: aws.String("ns/ing")},
+				{Key: aws.String("ingress.k8s.aws/adopted"), Value: aws.String("true")},
+			},
+			ExpectedSGCalls: []string{"Detach ns/ing"},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			ingressKey := types.NamespacedName{Namespace: "ns", Name: "ing"}
+			instance := &elbv2.LoadBalancer{LoadBalancerArn: aws.String(lbArn), LoadBalancerName: aws.String("old-prefix-ns-ing")}
+			cfg := config.NewConfiguration()
+			mockStore := &store.MockStorer{}
+			mockStore.On("GetConfig").Return(&cfg)
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetLoadBalancerByName", ctx, "prefix-ns-ing").Return(nil, nil)
+			cloud.On("GetLoadBalancerByName", ctx, "legacy-ns-ing").Return(nil, nil)
+			cloud.On("GetResourcesByFilters", ctx, map[string][]string{"ingress.k8s.aws/stack": {"ns/ing"}}, "elasticloadbalancing:loadbalancer").Return([]string{lbArn}, nil)
+			cloud.On("GetLoadBalancerByArn", ctx, lbArn).Return(instance, nil)
+			cloud.On("DescribeELBV2TagsWithContext", ctx, &elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(lbArn)}}).Return(&elbv2.DescribeTagsOutput{
+				TagDescriptions: []*elbv2.TagDescription{{ResourceArn: aws.String(lbArn), Tags: tc.CurrentTags}},
+			}, nil)
+			if tc.ExpectedDeleted {
+				cloud.On("DeleteLoadBalancerByArn", ctx, lbArn).Return(nil)
+			} else {
+				cloud.On("RemoveELBV2TagsWithContext", ctx, &elbv2.RemoveTagsInput{
+					ResourceArns: []*string{aws.String(lbArn)},
+					TagKeys:      aws.StringSlice([]string{"ingress.k8s.aws/adopted", "ingress.k8s.aws/deletion-protection", "ingress.k8s.aws/route53-hosted-zone", "ingress.k8s.aws/stack"}),
+				}).Return(&elbv2.RemoveTagsOutput{}, nil)
+			}
+			sgAssociationController := &cleanedUpSecurityGroups{}
+
+			controller := &defaultController{
+				cloud:                   cloud,
+				store:                   mockStore,
+				nameTagGen:              fakeNameTagGen{},
+				lsGroupController:       &deletedListeners{},
+				tgGroupController:       &deletedTargetGroups{},
+				sgAssociationController: sgAssociationController,
+			}
+			assert.NoError(t, controller.Delete(ctx, ingressKey))
+			assert.Equal(t, tc.ExpectedSGCalls, sgAssociationController.calls)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
+
+// deletedRoute53Records is a Route53Controller recording the hosted zones it deleted records in.
+type deletedRoute53Records struct {
+	Route53Controller
+	zoneIDs []string
+}
+
+func (d *deletedRoute53Records) Delete(ctx context.Context, zoneID string, instance *elbv2.LoadBalancer) error {
+	d.zoneIDs = append(d.zoneIDs, zoneID)
+	return nil
+}
+
+func Test_defaultController_deleteStaleRoute53Records(t *testing.T) {
+	for _, tc := range []struct {
+		Name            string
+		TaggedZoneID    string
+		ZoneID          string
+		ExpectedZoneIDs []string
+	}{
+		{
+			Name:         "hosted zone unchanged",
+			TaggedZoneID: "Z1",
+			ZoneID:       "Z1",
+		},
+		{
+			Name:            "hosted zone changed",
+			TaggedZoneID:    "Z1",
+			ZoneID:          "Z2",
+			ExpectedZoneIDs: []string{"Z1"},
+		},
+		{
+			Name:            "hosted zone removed",
+			TaggedZoneID:    "Z1",
+			ExpectedZoneIDs: []string{"Z1"},
+		},
+		{
+			Name:   "no previous hosted zone",
+			ZoneID: "Z2",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			var tags []*elbv2.Tag
+			if tc.TaggedZoneID != "" {
+				tags = append(tags, &elbv2.Tag{Key: aws.String(TagRoute53HostedZone), Value: aws.String(tc.TaggedZoneID)})
+			}
+			cloud := &mocks.CloudAPI{}
+			cloud.On("DescribeELBV2TagsWithContext", ctx, &elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String("lb-arn")}}).Return(
+				&elbv2.DescribeTagsOutput{TagDescriptions: []*elbv2.TagDescription{{ResourceArn: aws.String("lb-arn"), Tags: tags}}}, nil)
+			route53Controller := &deletedRoute53Records{}
+
+			controller := &defaultController{cloud: cloud, route53Controller: route53Controller}
+			err := controller.deleteStaleRoute53Records(ctx, &elbv2.LoadBalancer{LoadBalancerArn: aws.String("lb-arn")}, tc.ZoneID)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ExpectedZoneIDs, route53Controller.zoneIDs)
+		})
+	}
+}
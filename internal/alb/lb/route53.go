@@ -0,0 +1,280 @@
+package lb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+)
+
+// AnnotationRoute53HostedZone is the ID of a Route53 hosted zone in which the controller points the hosts of the
+// ingress rules to its LoadBalancer with ALIAS records.
+const AnnotationRoute53HostedZone = "route53-hosted-zone"
+
+// TagRoute53HostedZone tags a LoadBalancer with the hosted zone of its records, so that they're deleted along with it.
+const TagRoute53HostedZone = "ingress.k8s.aws/route53-hosted-zone"
+
+const route53ChangeComment = "managed by aws-alb-ingress-controller"
+
+// Route53Controller manages the Route53 ALIAS records of LoadBalancers.
+type Route53Controller interface {
+	// Reconcile points the hosts of ingress rules within hosted zone zoneID to instance,
+	// and deletes the records of instance in zoneID for hosts the ingress no longer has.
+	Reconcile(ctx context.Context, zoneID string, ingress *extensions.Ingress, instance *elbv2.LoadBalancer) error
+
+	// Delete deletes all records of instance in hosted zone zoneID.
+	Delete(ctx context.Context, zoneID string, instance *elbv2.LoadBalancer) error
+}
+
+func NewRoute53Controller(cloud aws.CloudAPI) Route53Controller {
+	return &defaultRoute53Controller{
+		cloud:   cloud,
+		records: make(map[string]map[recordKey]bool),
+	}
+}
+
+type defaultRoute53Controller struct {
+	cloud aws.CloudAPI
+
+	// records caches the keys of the records managed per hosted zone and LoadBalancer, so that reconciling only
+	// has to look up these and the desired ones instead of listing the whole hosted zone.
+	records      map[string]map[recordKey]bool
+	recordsMutex sync.Mutex
+}
+
+// recordKey identifies a record set by its normalized name and type.
+type recordKey struct {
+	name   string
+	rrType string
+}
+
+func (c *defaultRoute53Controller) Reconcile(ctx context.Context, zoneID string, ingress *extensions.Ingress, instance *elbv2.LoadBalancer) error {
+	zone, err := c.cloud.GetHostedZoneByID(ctx, zoneID)
+	if err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to get Route53 hosted zone %v due to %v", zoneID, err)
+		return fmt.Errorf("failed to get Route53 hosted zone %v due to %v", zoneID, err)
+	}
+	zoneName := normalizeRecordName(aws.StringValue(zone.Name))
+
+	rrTypes := []string{route53.RRTypeA}
+	if aws.StringValue(instance.IpAddressType) == elbv2.IpAddressTypeDualstack {
+		rrTypes = append(rrTypes, route53.RRTypeAaaa)
+	}
+	desired := make(map[recordKey]bool)
+	var desiredKeys []recordKey
+	for _, rule := range ingress.Spec.Rules {
+		host := normalizeRecordName(rule.Host)
+		if host == "" {
+			continue
+		}
+		if host != zoneName && !strings.HasSuffix(host, "."+zoneName) {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "host %v is not within Route53 hosted zone %v (%v), no record is created for it", host, zoneID, zoneName)
+			continue
+		}
+		for _, rrType := range rrTypes {
+			key := recordKey{name: host, rrType: rrType}
+			if !desired[key] {
+				desired[key] = true
+				desiredKeys = append(desiredKeys, key)
+			}
+		}
+	}
+
+	cacheKey := zoneID + "/" + aws.StringValue(instance.LoadBalancerArn)
+	current, err := c.findRecords(ctx, zoneID, cacheKey, desiredKeys, instance)
+	if err != nil {
+		return fmt.Errorf("failed to look up records of Route53 hosted zone %v due to %v", zoneID, err)
+	}
+
+	var changes []*route53.Change
+	for _, key := range desiredKeys {
+		if record := current[key]; record != nil {
+			if !isAliasRecordOf(record, instance) {
+				albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "not overwriting Route53 %v record %v, it doesn't point to LoadBalancer %v", key.rrType, key.name, aws.StringValue(instance.LoadBalancerArn))
+			}
+			continue
+		}
+		changes = append(changes, &route53.Change{
+			Action:            aws.String(route53.ChangeActionUpsert),
+			ResourceRecordSet: aliasRecordOf(key, instance),
+		})
+	}
+	var staleKeys []recordKey
+	for key, record := range current {
+		if record != nil && isAliasRecordOf(record, instance) && !desired[key] {
+			staleKeys = append(staleKeys, key)
+		}
+	}
+	sort.Slice(staleKeys, func(i, j int) bool {
+		return staleKeys[i].name < staleKeys[j].name || (staleKeys[i].name == staleKeys[j].name && staleKeys[i].rrType < staleKeys[j].rrType)
+	})
+	for _, key := range staleKeys {
+		changes = append(changes, &route53.Change{
+			Action:            aws.String(route53.ChangeActionDelete),
+			ResourceRecordSet: current[key],
+		})
+	}
+	if err := c.changeRecords(ctx, zoneID, changes); err != nil {
+		return err
+	}
+
+	managed := make(map[recordKey]bool, len(desiredKeys))
+	for _, key := range desiredKeys {
+		if record := current[key]; record == nil || isAliasRecordOf(record, instance) {
+			managed[key] = true
+		}
+	}
+	c.recordsMutex.Lock()
+	c.records[cacheKey] = managed
+	c.recordsMutex.Unlock()
+	return nil
+}
+
+// findRecords returns the current records for desiredKeys and the records of instance that are no longer desired, with
+// nil for keys without a record. Unless the records managed for cacheKey are known, the hosted zone is listed once to
+// find them.
+func (c *defaultRoute53Controller) findRecords(ctx context.Context, zoneID string, cacheKey string, desiredKeys []recordKey, instance *elbv2.LoadBalancer) (map[recordKey]*route53.ResourceRecordSet, error) {
+	c.recordsMutex.Lock()
+	managed, ok := c.records[cacheKey]
+	c.recordsMutex.Unlock()
+
+	current := make(map[recordKey]*route53.ResourceRecordSet)
+	if !ok {
+		records, err := c.cloud.ListResourceRecordSetsByZone(ctx, zoneID)
+		if err != nil {
+			return nil, err
+		}
+		wanted := make(map[recordKey]bool, len(desiredKeys))
+		for _, key := range desiredKeys {
+			wanted[key] = true
+		}
+		for _, record := range records {
+			if key := recordKeyOf(record); wanted[key] || isAliasRecordOf(record, instance) {
+				current[key] = record
+			}
+		}
+		return current, nil
+	}
+
+	keys := append([]recordKey(nil), desiredKeys...)
+	for key := range managed {
+		keys = append(keys, key)
+	}
+	for _, key := range keys {
+		if _, ok := current[key]; ok {
+			continue
+		}
+		record, err := c.cloud.GetResourceRecordSet(ctx, zoneID, key.name, key.rrType)
+		if err != nil {
+			return nil, err
+		}
+		current[key] = record
+	}
+	return current, nil
+}
+
+func (c *defaultRoute53Controller) Delete(ctx context.Context, zoneID string, instance *elbv2.LoadBalancer) error {
+	records, err := c.cloud.ListResourceRecordSetsByZone(ctx, zoneID)
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == route53.ErrCodeNoSuchHostedZone {
+		c.forget(zoneID, instance)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list records of Route53 hosted zone %v due to %v", zoneID, err)
+	}
+	var changes []*route53.Change
+	for _, record := range records {
+		if isAliasRecordOf(record, instance) {
+			changes = append(changes, &route53.Change{
+				Action:            aws.String(route53.ChangeActionDelete),
+				ResourceRecordSet: record,
+			})
+		}
+	}
+	if err := c.changeRecords(ctx, zoneID, changes); err != nil {
+		return err
+	}
+	c.forget(zoneID, instance)
+	return nil
+}
+
+// forget drops the records cached for instance in hosted zone zoneID.
+func (c *defaultRoute53Controller) forget(zoneID string, instance *elbv2.LoadBalancer) {
+	c.recordsMutex.Lock()
+	defer c.recordsMutex.Unlock()
+	delete(c.records, zoneID+"/"+aws.StringValue(instance.LoadBalancerArn))
+}
+
+// changeRecords applies changes to hosted zone zoneID in a single batch, so that they succeed or fail together.
+func (c *defaultRoute53Controller) changeRecords(ctx context.Context, zoneID string, changes []*route53.Change) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	descs := make([]string, 0, len(changes))
+	for _, change := range changes {
+		key := recordKeyOf(change.ResourceRecordSet)
+		descs = append(descs, fmt.Sprintf("%v %v %v", aws.StringValue(change.Action), key.rrType, key.name))
+	}
+	sort.Strings(descs)
+
+	albctx.GetLogger(ctx).Infof("changing Route53 records in hosted zone %v: %v", zoneID, strings.Join(descs, ", "))
+	if _, err := c.cloud.ChangeResourceRecordSetsWithContext(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(zoneID),
+		ChangeBatch: &route53.ChangeBatch{
+			Comment: aws.String(route53ChangeComment),
+			Changes: changes,
+		},
+	}); err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to change Route53 records in hosted zone %v due to %v", zoneID, err)
+		return fmt.Errorf("failed to change Route53 records in hosted zone %v due to %v", zoneID, err)
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonModify, "Route53 records in hosted zone %v changed: %v", zoneID, strings.Join(descs, ", "))
+	return nil
+}
+
+// aliasRecordOf returns the ALIAS record set pointing key to instance.
+func aliasRecordOf(key recordKey, instance *elbv2.LoadBalancer) *route53.ResourceRecordSet {
+	dnsName := aws.StringValue(instance.DNSName)
+	if aws.StringValue(instance.IpAddressType) == elbv2.IpAddressTypeDualstack {
+		dnsName = "dualstack." + dnsName
+	}
+	return &route53.ResourceRecordSet{
+		Name: aws.String(key.name),
+		Type: aws.String(key.rrType),
+		AliasTarget: &route53.AliasTarget{
+			DNSName:              aws.String(dnsName),
+			HostedZoneId:         instance.CanonicalHostedZoneId,
+			EvaluateTargetHealth: aws.Bool(true),
+		},
+	}
+}
+
+// isAliasRecordOf tells whether record is an A or AAAA ALIAS record pointing to instance.
+func isAliasRecordOf(record *route53.ResourceRecordSet, instance *elbv2.LoadBalancer) bool {
+	rrType := aws.StringValue(record.Type)
+	if record.AliasTarget == nil || (rrType != route53.RRTypeA && rrType != route53.RRTypeAaaa) {
+		return false
+	}
+	target := strings.TrimPrefix(normalizeRecordName(aws.StringValue(record.AliasTarget.DNSName)), "dualstack.")
+	return target == normalizeRecordName(aws.StringValue(instance.DNSName))
+}
+
+func recordKeyOf(record *route53.ResourceRecordSet) recordKey {
+	return recordKey{name: normalizeRecordName(aws.StringValue(record.Name)), rrType: aws.StringValue(record.Type)}
+}
+
+// normalizeRecordName lowercases name and drops its trailing dot. Route53 returns the wildcard as \052, which is
+// turned back into *.
+func normalizeRecordName(name string) string {
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	return strings.Replace(name, `\052`, "*", -1)
+}
@@ -0,0 +1,218 @@
+package lb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	extensions "k8s.io/api/extensions/v1beta1"
+)
+
+func aliasRecord(name string, rrType string, dnsName string) *route53.ResourceRecordSet {
+	return &route53.ResourceRecordSet{
+		Name: aws.String(name),
+		Type: aws.String(rrType),
+		AliasTarget: &route53.AliasTarget{
+			DNSName:              aws.String(dnsName),
+			HostedZoneId:         aws.String("Z35SXDOTRQ7X7K"),
+			EvaluateTargetHealth: aws.Bool(true),
+		},
+	}
+}
+
+func Test_defaultRoute53Controller_Reconcile(t *testing.T) {
+	instance := &elbv2.LoadBalancer{
+		LoadBalancerArn:       aws.String("lb-arn"),
+		DNSName:               aws.String("lb-1.us-west-2.elb.amazonaws.com"),
+		CanonicalHostedZoneId: aws.String("Z35SXDOTRQ7X7K"),
+		IpAddressType:         aws.String(elbv2.IpAddressTypeIpv4),
+	}
+	dualstackInstance := &elbv2.LoadBalancer{
+		LoadBalancerArn:       aws.String("lb-arn"),
+		DNSName:               aws.String("lb-1.us-west-2.elb.amazonaws.com"),
+		CanonicalHostedZoneId: aws.String("Z35SXDOTRQ7X7K"),
+		IpAddressType:         aws.String(elbv2.IpAddressTypeDualstack),
+	}
+	ingress := &extensions.Ingress{Spec: extensions.IngressSpec{Rules: []extensions.IngressRule{
+		{Host: "www.example.com"},
+		{Host: "*.api.example.com"},
+		{Host: "www.example.org"},
+		{},
+	}}}
+
+	for _, tc := range []struct {
+		Name            string
+		Instance        *elbv2.LoadBalancer
+		Records         []*route53.ResourceRecordSet
+		ExpectedChanges []*route53.Change
+		ChangeErr       error
+		ExpectedEvents  []string
+		ExpectedError   error
+	}{
+		{
+			Name:     "records are created for hosts within the zone",
+			Instance: instance,
+			ExpectedChanges: []*route53.Change{
+				{Action: aws.String(route53.ChangeActionUpsert), ResourceRecordSet: aliasRecord("www.example.com", route53.RRTypeA, "lb-1.us-west-2.elb.amazonaws.com")},
+				{Action: aws.String(route53.ChangeActionUpsert), ResourceRecordSet: aliasRecord("*.api.example.com", route53.RRTypeA, "lb-1.us-west-2.elb.amazonaws.com")},
+			},
+			ExpectedEvents: []string{
+				"Warning ERROR host www.example.org is not within Route53 hosted zone Z1 (example.com), no record is created for it",
+				"Normal MODIFY Route53 records in hosted zone Z1 changed: UPSERT A *.api.example.com, UPSERT A www.example.com",
+			},
+		},
+		{
+			Name:     "dualstack LoadBalancers get AAAA records as well",
+			Instance: dualstackInstance,
+			Records: []*route53.ResourceRecordSet{
+				aliasRecord("www.example.com.", route53.RRTypeA, "dualstack.lb-1.us-west-2.elb.amazonaws.com."),
+				aliasRecord(`\052.api.example.com.`, route53.RRTypeA, "dualstack.lb-1.us-west-2.elb.amazonaws.com."),
+			},
+			ExpectedChanges: []*route53.Change{
+				{Action: aws.String(route53.ChangeActionUpsert), ResourceRecordSet: aliasRecord("www.example.com", route53.RRTypeAaaa, "dualstack.lb-1.us-west-2.elb.amazonaws.com")},
+				{Action: aws.String(route53.ChangeActionUpsert), ResourceRecordSet: aliasRecord("*.api.example.com", route53.RRTypeAaaa, "dualstack.lb-1.us-west-2.elb.amazonaws.com")},
+			},
+			ExpectedEvents: []string{
+				"Warning ERROR host www.example.org is not within Route53 hosted zone Z1 (example.com), no record is created for it",
+				"Normal MODIFY Route53 records in hosted zone Z1 changed: UPSERT AAAA *.api.example.com, UPSERT AAAA www.example.com",
+			},
+		},
+		{
+			Name:     "up to date records are left alone, stale ones deleted and foreign ones kept",
+			Instance: instance,
+			Records: []*route53.ResourceRecordSet{
+				aliasRecord("www.example.com.", route53.RRTypeA, "lb-1.us-west-2.elb.amazonaws.com."),
+				aliasRecord(`\052.api.example.com.`, route53.RRTypeA, "other-lb.us-west-2.elb.amazonaws.com."),
+				aliasRecord("old.example.com.", route53.RRTypeA, "lb-1.us-west-2.elb.amazonaws.com."),
+			},
+			ExpectedChanges: []*route53.Change{
+				{Action: aws.String(route53.ChangeActionDelete), ResourceRecordSet: aliasRecord("old.example.com.", route53.RRTypeA, "lb-1.us-west-2.elb.amazonaws.com.")},
+			},
+			ExpectedEvents: []string{
+				"Warning ERROR host www.example.org is not within Route53 hosted zone Z1 (example.com), no record is created for it",
+				"Warning ERROR not overwriting Route53 A record *.api.example.com, it doesn't point to LoadBalancer lb-arn",
+				"Normal MODIFY Route53 records in hosted zone Z1 changed: DELETE A old.example.com",
+			},
+		},
+		{
+			Name:     "failed changes are reported",
+			Instance: instance,
+			Records: []*route53.ResourceRecordSet{
+				aliasRecord(`\052.api.example.com.`, route53.RRTypeA, "lb-1.us-west-2.elb.amazonaws.com."),
+			},
+			ExpectedChanges: []*route53.Change{
+				{Action: aws.String(route53.ChangeActionUpsert), ResourceRecordSet: aliasRecord("www.example.com", route53.RRTypeA, "lb-1.us-west-2.elb.amazonaws.com")},
+			},
+			ChangeErr: errors.New("AccessDenied"),
+			ExpectedEvents: []string{
+				"Warning ERROR host www.example.org is not within Route53 hosted zone Z1 (example.com), no record is created for it",
+				"Warning ERROR failed to change Route53 records in hosted zone Z1 due to AccessDenied",
+			},
+			ExpectedError: errors.New("failed to change Route53 records in hosted zone Z1 due to AccessDenied"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			var events []string
+			ctx := albctx.SetEventf(context.Background(), func(eventType string, reason string, messageFmt string, args ...interface{}) {
+				events = append(events, eventType+" "+reason+" "+fmt.Sprintf(messageFmt, args...))
+			})
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetHostedZoneByID", ctx, "Z1").Return(&route53.HostedZone{Id: aws.String("/hostedzone/Z1"), Name: aws.String("Example.com.")}, nil)
+			cloud.On("ListResourceRecordSetsByZone", ctx, "Z1").Return(tc.Records, nil)
+			if tc.ExpectedChanges != nil {
+				cloud.On("ChangeResourceRecordSetsWithContext", ctx, &route53.ChangeResourceRecordSetsInput{
+					HostedZoneId: aws.String("Z1"),
+					ChangeBatch: &route53.ChangeBatch{
+						Comment: aws.String(route53ChangeComment),
+						Changes: tc.ExpectedChanges,
+					},
+				}).Return(&route53.ChangeResourceRecordSetsOutput{}, tc.ChangeErr)
+			}
+
+			controller := NewRoute53Controller(cloud)
+			err := controller.Reconcile(ctx, "Z1", ingress, tc.Instance)
+			assert.Equal(t, tc.ExpectedError, err)
+			assert.Equal(t, tc.ExpectedEvents, events)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_defaultRoute53Controller_Reconcile_cached(t *testing.T) {
+	ctx := context.Background()
+	instance := &elbv2.LoadBalancer{
+		LoadBalancerArn:       aws.String("lb-arn"),
+		DNSName:               aws.String("lb-1.us-west-2.elb.amazonaws.com"),
+		CanonicalHostedZoneId: aws.String("Z35SXDOTRQ7X7K"),
+		IpAddressType:         aws.String(elbv2.IpAddressTypeIpv4),
+	}
+	www := aliasRecord("www.example.com.", route53.RRTypeA, "lb-1.us-west-2.elb.amazonaws.com.")
+	api := aliasRecord("api.example.com.", route53.RRTypeA, "lb-1.us-west-2.elb.amazonaws.com.")
+	cloud := &mocks.CloudAPI{}
+	cloud.On("GetHostedZoneByID", ctx, "Z1").Return(&route53.HostedZone{Id: aws.String("/hostedzone/Z1"), Name: aws.String("example.com.")}, nil)
+	cloud.On("ListResourceRecordSetsByZone", ctx, "Z1").Return([]*route53.ResourceRecordSet{www, api}, nil).Once()
+	cloud.On("GetResourceRecordSet", ctx, "Z1", "www.example.com", route53.RRTypeA).Return(www, nil)
+	cloud.On("GetResourceRecordSet", ctx, "Z1", "api.example.com", route53.RRTypeA).Return(api, nil)
+	cloud.On("ChangeResourceRecordSetsWithContext", ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String("Z1"),
+		ChangeBatch: &route53.ChangeBatch{
+			Comment: aws.String(route53ChangeComment),
+			Changes: []*route53.Change{{Action: aws.String(route53.ChangeActionDelete), ResourceRecordSet: api}},
+		},
+	}).Return(&route53.ChangeResourceRecordSetsOutput{}, nil).Once()
+
+	controller := NewRoute53Controller(cloud)
+	// the hosted zone is only listed the first time, afterwards only the desired and the managed records are looked up.
+	both := &extensions.Ingress{Spec: extensions.IngressSpec{Rules: []extensions.IngressRule{{Host: "www.example.com"}, {Host: "api.example.com"}}}}
+	assert.NoError(t, controller.Reconcile(ctx, "Z1", both, instance))
+	assert.NoError(t, controller.Reconcile(ctx, "Z1", both, instance))
+	www2 := &extensions.Ingress{Spec: extensions.IngressSpec{Rules: []extensions.IngressRule{{Host: "www.example.com"}}}}
+	assert.NoError(t, controller.Reconcile(ctx, "Z1", www2, instance))
+	cloud.AssertExpectations(t)
+	cloud.AssertNumberOfCalls(t, "ListResourceRecordSetsByZone", 1)
+}
+
+func Test_defaultRoute53Controller_Delete(t *testing.T) {
+	instance := &elbv2.LoadBalancer{
+		LoadBalancerArn: aws.String("lb-arn"),
+		DNSName:         aws.String("lb-1.us-west-2.elb.amazonaws.com"),
+	}
+
+	t.Run("records pointing to the LoadBalancer are deleted", func(t *testing.T) {
+		ctx := context.Background()
+		ours := aliasRecord("www.example.com.", route53.RRTypeA, "dualstack.lb-1.us-west-2.elb.amazonaws.com.")
+		other := aliasRecord("api.example.com.", route53.RRTypeA, "other-lb.us-west-2.elb.amazonaws.com.")
+		cloud := &mocks.CloudAPI{}
+		cloud.On("ListResourceRecordSetsByZone", ctx, "Z1").Return([]*route53.ResourceRecordSet{ours, other}, nil)
+		cloud.On("ChangeResourceRecordSetsWithContext", ctx, &route53.ChangeResourceRecordSetsInput{
+			HostedZoneId: aws.String("Z1"),
+			ChangeBatch: &route53.ChangeBatch{
+				Comment: aws.String(route53ChangeComment),
+				Changes: []*route53.Change{{Action: aws.String(route53.ChangeActionDelete), ResourceRecordSet: ours}},
+			},
+		}).Return(&route53.ChangeResourceRecordSetsOutput{}, nil)
+
+		controller := NewRoute53Controller(cloud)
+		assert.NoError(t, controller.Delete(ctx, "Z1", instance))
+		cloud.AssertExpectations(t)
+	})
+
+	t.Run("deleted hosted zones are ignored", func(t *testing.T) {
+		ctx := context.Background()
+		cloud := &mocks.CloudAPI{}
+		cloud.On("ListResourceRecordSetsByZone", ctx, "Z1").Return(nil, awserr.New(route53.ErrCodeNoSuchHostedZone, "", nil))
+
+		controller := NewRoute53Controller(cloud)
+		assert.NoError(t, controller.Delete(ctx, "Z1", instance))
+		cloud.AssertNotCalled(t, "ChangeResourceRecordSetsWithContext", mock.Anything, mock.Anything)
+	})
+}
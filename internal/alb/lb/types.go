@@ -4,16 +4,33 @@ package lb
 type LoadBalancer struct {
 	Arn     string
 	DNSName string
+
+	// TargetGroupARNs are the targetGroups created for the service backends of the ingress, sorted.
+	TargetGroupARNs []string
+
+	// ListenerCount and RuleCount are the number of listeners of the LoadBalancer and of rules over those listeners.
+	ListenerCount int
+	RuleCount     int
 }
 
 // NameGenerator generates name for loadBalancer resources
 type NameGenerator interface {
 	NameLB(namespace string, ingressName string) string
+
+	// LegacyNameLB generates the name LoadBalancers were created with by earlier releases.
+	LegacyNameLB(namespace string, ingressName string) string
 }
 
 // TagGenerator generates tags for loadBalancer resources
 type TagGenerator interface {
 	TagLB(namespace string, ingressName string) map[string]string
+
+	// OwnsLB tells whether the LoadBalancer tagged with lbTags belongs to the ingress, rather than to another
+	// controller or to no controller at all.
+	OwnsLB(namespace string, ingressName string, lbTags map[string]string) bool
+
+	// ClaimsLB tells whether lbTags mark a LoadBalancer as managed for some ingress, by this or another controller.
+	ClaimsLB(lbTags map[string]string) bool
 }
 
 // NameTagGenerator combines NameGenerator & TagGenerator
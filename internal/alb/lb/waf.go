@@ -2,12 +2,16 @@ package lb
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/waf"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/util/cache"
 )
@@ -52,12 +56,18 @@ func (c *defaultWAFController) Reconcile(ctx context.Context, lbArn string, ing
 		}
 		c.webACLIdForLBCache.Add(lbArn, desiredWebACLId, webACLIdForLBCacheTTL)
 	case desiredWebACLId != "" && currentWebACLId != "" && desiredWebACLId != currentWebACLId:
+		if err := c.validateWebACLId(ctx, desiredWebACLId); err != nil {
+			return err
+		}
 		albctx.GetLogger(ctx).Infof("associate WAF on %v from %v to %v", lbArn, currentWebACLId, desiredWebACLId)
 		if _, err := c.cloud.AssociateWAF(ctx, aws.String(lbArn), aws.String(desiredWebACLId)); err != nil {
 			return errors.Wrapf(err, "failed to associate webACL on LoadBalancer %v", lbArn)
 		}
 		c.webACLIdForLBCache.Add(lbArn, desiredWebACLId, webACLIdForLBCacheTTL)
 	case desiredWebACLId != "" && currentWebACLId == "":
+		if err := c.validateWebACLId(ctx, desiredWebACLId); err != nil {
+			return err
+		}
 		albctx.GetLogger(ctx).Infof("associate WAF on %v to %v", lbArn, desiredWebACLId)
 		if _, err := c.cloud.AssociateWAF(ctx, aws.String(lbArn), aws.String(desiredWebACLId)); err != nil {
 			return errors.Wrapf(err, "failed to associate webACL on LoadBalancer %v", lbArn)
@@ -75,6 +85,23 @@ func (c *defaultWAFController) getDesiredWebACLId(ctx context.Context, ing *exte
 	return webACLId
 }
 
+// validateWebACLId checks that webACLId exists before it gets associated, so that a typo is reported as such
+// rather than as a failed association.
+func (c *defaultWAFController) validateWebACLId(ctx context.Context, webACLId string) error {
+	exists, err := c.cloud.WebACLExists(ctx, aws.String(webACLId))
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == waf.ErrCodeNonexistentItemException {
+		exists, err = false, nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to get webACL %v", webACLId)
+	}
+	if !exists {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "webACL %v doesn't exist", webACLId)
+		return fmt.Errorf("webACL %v doesn't exist", webACLId)
+	}
+	return nil
+}
+
 func (c *defaultWAFController) getCurrentWebACLId(ctx context.Context, lbArn string) (string, error) {
 	cachedWebACLId, exists := c.webACLIdForLBCache.Get(lbArn)
 	if exists {
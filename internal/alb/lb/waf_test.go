@@ -2,10 +2,18 @@ package lb
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/waf"
+	"github.com/aws/aws-sdk-go/service/wafregional"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
 	extensions "k8s.io/api/extensions/v1beta1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/cache"
@@ -64,3 +72,77 @@ func Test_defaultWAFController_getDesiredWebACLId(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultWAFController_Reconcile(t *testing.T) {
+	ing := &extensions.Ingress{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "ingress",
+			Annotations: map[string]string{
+				parser.AnnotationsPrefix + "/web-acl-id": "my-web-acl-id",
+			},
+		},
+	}
+	for _, tc := range []struct {
+		Name              string
+		CurrentWebACLId   string
+		WebACLExistsError error
+		ExpectAssociate   bool
+		ExpectedEvents    []string
+		ExpectedError     string
+	}{
+		{
+			Name:            "existing webACL is associated",
+			ExpectAssociate: true,
+		},
+		{
+			Name:              "missing webACL is reported",
+			WebACLExistsError: awserr.New(waf.ErrCodeNonexistentItemException, "not found", nil),
+			ExpectedEvents:    []string{"Warning ERROR webACL my-web-acl-id doesn't exist"},
+			ExpectedError:     "webACL my-web-acl-id doesn't exist",
+		},
+		{
+			Name:              "missing webACL is reported when replacing another one",
+			CurrentWebACLId:   "other-web-acl-id",
+			WebACLExistsError: awserr.New(waf.ErrCodeNonexistentItemException, "not found", nil),
+			ExpectedEvents:    []string{"Warning ERROR webACL my-web-acl-id doesn't exist"},
+			ExpectedError:     "webACL my-web-acl-id doesn't exist",
+		},
+		{
+			Name:              "failed lookups are returned",
+			WebACLExistsError: errors.New("AccessDenied"),
+			ExpectedError:     "failed to get webACL my-web-acl-id: AccessDenied",
+		},
+		{
+			Name:            "already associated webACL isn't looked up",
+			CurrentWebACLId: "my-web-acl-id",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			var events []string
+			ctx := albctx.SetEventf(context.Background(), func(eventType string, reason string, messageFmt string, args ...interface{}) {
+				events = append(events, eventType+" "+reason+" "+fmt.Sprintf(messageFmt, args...))
+			})
+			cloud := &mocks.CloudAPI{}
+			if tc.CurrentWebACLId != "my-web-acl-id" {
+				cloud.On("WebACLExists", ctx, aws.String("my-web-acl-id")).Return(tc.WebACLExistsError == nil, tc.WebACLExistsError)
+			}
+			if tc.ExpectAssociate {
+				cloud.On("AssociateWAF", ctx, aws.String("lb-arn"), aws.String("my-web-acl-id")).Return(&wafregional.AssociateWebACLOutput{}, nil)
+			}
+			c := &defaultWAFController{
+				cloud:              cloud,
+				webACLIdForLBCache: cache.NewLRUExpireCache(10),
+			}
+			c.webACLIdForLBCache.Add("lb-arn", tc.CurrentWebACLId, webACLIdForLBCacheTTL)
+
+			err := c.Reconcile(ctx, "lb-arn", ing)
+			if tc.ExpectedError != "" {
+				assert.EqualError(t, err, tc.ExpectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.ExpectedEvents, events)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
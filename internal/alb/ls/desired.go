@@ -0,0 +1,70 @@
+package ls
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/auth"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// DesiredListener is the listener the controller configures for a port of an ingress, along with its rules.
+type DesiredListener struct {
+	Port            int64             `json:"port"`
+	Protocol        string            `json:"protocol"`
+	SslPolicy       *string           `json:"sslPolicy,omitempty"`
+	CertificateARNs []string          `json:"certificateARNs,omitempty"`
+	DefaultActions  []*elbv2.Action   `json:"defaultActions"`
+	Rules           []elbv2.Rule      `json:"rules"`
+	Tags            map[string]string `json:"tags,omitempty"`
+}
+
+// BuildDesiredListener computes the listener for options.Port without calling AWS. options.LBArn, options.Instance and options.Rules are ignored.
+// Certificates can't be discovered from ACM offline, so HTTPS listeners without the certificate-arn annotation
+// get a placeholder certificate per TLS host instead.
+func BuildDesiredListener(ctx context.Context, authModule auth.Module, options ReconcileOptions) (DesiredListener, error) {
+	rulesController := &rulesController{authModule: authModule}
+	controller := &defaultController{
+		authModule:      authModule,
+		rulesController: rulesController,
+		certDiscovery:   placeholderCertDiscovery{},
+	}
+	config, err := controller.buildListenerConfig(ctx, options)
+	if err != nil {
+		return DesiredListener{}, err
+	}
+	rules, _, err := rulesController.getDesiredRules(ctx, &elbv2.Listener{Port: config.Port, Protocol: config.Protocol},
+		options.Ingress, options.IngressAnnos, options.TGGroup)
+	if err != nil {
+		return DesiredListener{}, err
+	}
+
+	desired := DesiredListener{
+		Port:           aws.Int64Value(config.Port),
+		Protocol:       aws.StringValue(config.Protocol),
+		SslPolicy:      config.SslPolicy,
+		DefaultActions: redactActions(config.DefaultActions),
+		Rules:          rules,
+		Tags:           config.Tags,
+	}
+	for _, cert := range config.DefaultCertificate {
+		desired.CertificateARNs = append(desired.CertificateARNs, aws.StringValue(cert.CertificateArn))
+	}
+	desired.CertificateARNs = append(desired.CertificateARNs, config.ExtraCertificateARNs...)
+	for i := range desired.Rules {
+		desired.Rules[i].Actions = redactActions(desired.Rules[i].Actions)
+	}
+	return desired, nil
+}
+
+// placeholderCertDiscovery stands in for ACM when computing listeners offline.
+type placeholderCertDiscovery struct{}
+
+func (placeholderCertDiscovery) Discover(ctx context.Context, tlsHosts sets.String) ([]string, error) {
+	var certARNs []string
+	for _, host := range tlsHosts.List() {
+		certARNs = append(certARNs, "<certificate discovered from ACM for "+host+">")
+	}
+	return certARNs, nil
+}
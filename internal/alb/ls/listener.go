@@ -13,13 +13,16 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws/awsutil"
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 	util "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
+	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 )
 
@@ -28,10 +31,6 @@ const (
 	AnnotationCertificateARN = "certificate-arn"
 )
 
-const (
-	DefaultSSLPolicy = "ELBSecurityPolicy-2016-08"
-)
-
 type ReconcileOptions struct {
 	LBArn        string
 	Ingress      *extensions.Ingress
@@ -41,29 +40,37 @@ type ReconcileOptions struct {
 
 	// If instance is specified, reconcile will operate on this instance, otherwise new listener instance will be created.
 	Instance *elbv2.Listener
+
+	// Rules are the rules of the listener, as computed by RulesController.CheckQuota for Port.
+	Rules DesiredRules
 }
 
 type Controller interface {
 	// Reconcile will make sure an AWS listener exists to satisfy requirements specified as options.
-	Reconcile(ctx context.Context, options ReconcileOptions) error
+	// It returns the number of rules on the listener, not counting its default rule.
+	Reconcile(ctx context.Context, options ReconcileOptions) (int, error)
 }
 
-func NewController(cloud aws.CloudAPI, authModule auth.Module) Controller {
+func NewController(store store.Storer, cloud aws.CloudAPI, authModule auth.Module, tagsController tags.Controller) Controller {
 	rulesController := NewRulesController(cloud, authModule)
 	certDiscovery := NewACMCertDiscovery(cloud)
 	return &defaultController{
+		store:           store,
 		cloud:           cloud,
 		authModule:      authModule,
 		rulesController: rulesController,
 		certDiscovery:   certDiscovery,
+		tagsController:  tagsController,
 	}
 }
 
 type defaultController struct {
+	store           store.Storer
 	cloud           aws.CloudAPI
 	authModule      auth.Module
 	rulesController RulesController
 	certDiscovery   CertDiscovery
+	tagsController  tags.Controller
 }
 
 type listenerConfig struct {
@@ -74,68 +81,97 @@ type listenerConfig struct {
 	SslPolicy            *string
 	DefaultCertificate   []*elbv2.Certificate
 	ExtraCertificateARNs []string
+	// MutualAuthentication is nil for listeners that don't authenticate clients by their certificates.
+	MutualAuthentication *elbv2.MutualAuthenticationAttributes
+
+	Tags map[string]string
 }
 
-func (controller *defaultController) Reconcile(ctx context.Context, options ReconcileOptions) error {
+func (controller *defaultController) Reconcile(ctx context.Context, options ReconcileOptions) (int, error) {
 	config, err := controller.buildListenerConfig(ctx, options)
 	if err != nil {
-		return fmt.Errorf("failed to build listener config due to %v", err)
+		return 0, fmt.Errorf("failed to build listener config due to %v", err)
 	}
 
 	instance := options.Instance
 	if instance == nil {
 		if instance, err = controller.newLSInstance(ctx, options.LBArn, config); err != nil {
-			return fmt.Errorf("failed to create listener due to %v", err)
+			return 0, fmt.Errorf("failed to create listener due to %v", err)
 		}
 	} else {
 		if instance, err = controller.reconcileLSInstance(ctx, instance, config); err != nil {
-			return fmt.Errorf("failed to reconcile listener due to %v", err)
+			return 0, fmt.Errorf("failed to reconcile listener due to %v", err)
 		}
 	}
 
+	lsArn := aws.StringValue(instance.ListenerArn)
+	if err := controller.tagsController.ReconcileELB(ctx, lsArn, config.Tags); err != nil {
+		return 0, errors.Wrapf(err, "failed to reconcile tags on listener %v", lsArn)
+	}
+
 	if options.Port.Scheme == elbv2.ProtocolEnumHttps {
-		lsArn := aws.StringValue(instance.ListenerArn)
 		if err := controller.reconcileExtraCertificates(ctx, lsArn, config.ExtraCertificateARNs); err != nil {
-			return errors.Wrapf(err, "failed to reconcile extra certificates on listener %v", lsArn)
+			return 0, errors.Wrapf(err, "failed to reconcile extra certificates on listener %v", lsArn)
 		}
 	}
 
-	if err := controller.rulesController.Reconcile(ctx, instance, options.Ingress, options.IngressAnnos, options.TGGroup); err != nil {
-		return fmt.Errorf("failed to reconcile rules due to %v", err)
+	ruleCount, err := controller.rulesController.Reconcile(ctx, instance, options.Rules)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reconcile rules due to %v", err)
 	}
-	return nil
+	return ruleCount, nil
 }
 
 func (controller *defaultController) newLSInstance(ctx context.Context, lbArn string, config listenerConfig) (*elbv2.Listener, error) {
 	albctx.GetLogger(ctx).Infof("creating listener %v", aws.Int64Value(config.Port))
 	resp, err := controller.cloud.CreateListenerWithContext(ctx, &elbv2.CreateListenerInput{
-		LoadBalancerArn: aws.String(lbArn),
-		Port:            config.Port,
-		Protocol:        config.Protocol,
-		Certificates:    config.DefaultCertificate,
-		SslPolicy:       config.SslPolicy,
-		DefaultActions:  config.DefaultActions,
+		LoadBalancerArn:      aws.String(lbArn),
+		Port:                 config.Port,
+		Protocol:             config.Protocol,
+		Certificates:         config.DefaultCertificate,
+		SslPolicy:            config.SslPolicy,
+		MutualAuthentication: config.MutualAuthentication,
+		DefaultActions:       config.DefaultActions,
 	})
 	if err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to create listener %v on %v due to %v", aws.Int64Value(config.Port), lbArn, err)
 		return nil, err
 	}
-	return resp.Listeners[0], nil
+	instance := resp.Listeners[0]
+	lsArn := aws.StringValue(instance.ListenerArn)
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonCreate, "listener %v created, ARN: %v", aws.Int64Value(config.Port), lsArn)
+	// the listener may not be found for a little while after its creation, so wait for it before its rules are reconciled.
+	if err := aws.RetryNotFound(ctx, aws.PostCreateBackoff, func() error {
+		_, err := controller.cloud.GetRules(ctx, lsArn)
+		return err
+	}, elbv2.ErrCodeListenerNotFoundException); err != nil {
+		return nil, fmt.Errorf("listener %v isn't visible after its creation due to %v", lsArn, err)
+	}
+	return instance, nil
 }
 
 func (controller *defaultController) reconcileLSInstance(ctx context.Context, instance *elbv2.Listener, config listenerConfig) (*elbv2.Listener, error) {
 	if controller.LSInstanceNeedsModification(ctx, instance, config) {
 		albctx.GetLogger(ctx).Infof("modifying listener %v, arn: %v", aws.Int64Value(config.Port), aws.StringValue(instance.ListenerArn))
+		mutualAuthentication := config.MutualAuthentication
+		// a listener only stops authenticating clients once modified to the off mode.
+		if mutualAuthentication == nil && mutualAuthenticationMode(instance.MutualAuthentication) != loadbalancer.MutualAuthenticationModeOff {
+			mutualAuthentication = &elbv2.MutualAuthenticationAttributes{Mode: aws.String(loadbalancer.MutualAuthenticationModeOff)}
+		}
 		output, err := controller.cloud.ModifyListenerWithContext(ctx, &elbv2.ModifyListenerInput{
-			ListenerArn:    instance.ListenerArn,
-			Port:           config.Port,
-			Protocol:       config.Protocol,
-			Certificates:   config.DefaultCertificate,
-			SslPolicy:      config.SslPolicy,
-			DefaultActions: config.DefaultActions,
+			ListenerArn:          instance.ListenerArn,
+			Port:                 config.Port,
+			Protocol:             config.Protocol,
+			Certificates:         config.DefaultCertificate,
+			SslPolicy:            config.SslPolicy,
+			MutualAuthentication: mutualAuthentication,
+			DefaultActions:       config.DefaultActions,
 		})
 		if err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to modify listener %v due to %v", aws.StringValue(instance.ListenerArn), err)
 			return instance, err
 		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonModify, "listener %v modified", aws.StringValue(instance.ListenerArn))
 		return output.Listeners[0], nil
 	}
 	return instance, nil
@@ -144,23 +180,27 @@ func (controller *defaultController) reconcileLSInstance(ctx context.Context, in
 func (controller *defaultController) LSInstanceNeedsModification(ctx context.Context, instance *elbv2.Listener, config listenerConfig) bool {
 	needModification := false
 	if !util.DeepEqual(instance.Port, config.Port) {
-		albctx.GetLogger(ctx).DebugLevelf(1, "listener port needs modification: %v => %v", awsutil.Prettify(instance.Port), awsutil.Prettify(config.Port))
+		albctx.GetLogger(ctx).Debugf("listener port needs modification: %v => %v", awsutil.Prettify(instance.Port), awsutil.Prettify(config.Port))
 		needModification = true
 	}
 	if !util.DeepEqual(instance.Protocol, config.Protocol) {
-		albctx.GetLogger(ctx).DebugLevelf(1, "listener protocol needs modification: %v => %v", awsutil.Prettify(instance.Protocol), awsutil.Prettify(config.Protocol))
+		albctx.GetLogger(ctx).Debugf("listener protocol needs modification: %v => %v", awsutil.Prettify(instance.Protocol), awsutil.Prettify(config.Protocol))
 		needModification = true
 	}
 	if !util.DeepEqual(instance.Certificates, config.DefaultCertificate) {
-		albctx.GetLogger(ctx).DebugLevelf(1, "listener certificates needs modification: %v => %v", awsutil.Prettify(instance.Certificates), awsutil.Prettify(config.DefaultCertificate))
+		albctx.GetLogger(ctx).Debugf("listener certificates needs modification: %v => %v", awsutil.Prettify(instance.Certificates), awsutil.Prettify(config.DefaultCertificate))
 		needModification = true
 	}
 	if !util.DeepEqual(instance.SslPolicy, config.SslPolicy) {
-		albctx.GetLogger(ctx).DebugLevelf(1, "listener sslPolicy needs modification: %v => %v", awsutil.Prettify(instance.SslPolicy), awsutil.Prettify(config.SslPolicy))
+		albctx.GetLogger(ctx).Debugf("listener sslPolicy needs modification: %v => %v", awsutil.Prettify(instance.SslPolicy), awsutil.Prettify(config.SslPolicy))
+		needModification = true
+	}
+	if !mutualAuthenticationMatches(instance.MutualAuthentication, config.MutualAuthentication) {
+		albctx.GetLogger(ctx).Debugf("listener mutualAuthentication needs modification: %v => %v", awsutil.Prettify(instance.MutualAuthentication), awsutil.Prettify(config.MutualAuthentication))
 		needModification = true
 	}
 	if !actionsMatches(instance.DefaultActions, config.DefaultActions) {
-		albctx.GetLogger(ctx).DebugLevelf(1, "listener defaultActions needs modification",
+		albctx.GetLogger(ctx).Debugf("listener defaultActions needs modification: %v => %v",
 			awsutil.Prettify(redactActions(instance.DefaultActions)),
 			awsutil.Prettify(redactActions(config.DefaultActions)))
 		needModification = true
@@ -168,6 +208,37 @@ func (controller *defaultController) LSInstanceNeedsModification(ctx context.Con
 	return needModification
 }
 
+// buildMutualAuthentication returns the mutual authentication of the HTTPS listeners of an ingress, nil if it isn't annotated.
+func buildMutualAuthentication(ingressAnnos *annotations.Ingress) *elbv2.MutualAuthenticationAttributes {
+	if ingressAnnos.LoadBalancer == nil || ingressAnnos.LoadBalancer.MutualAuthentication == nil {
+		return nil
+	}
+	mutualAuthentication := ingressAnnos.LoadBalancer.MutualAuthentication
+	attributes := &elbv2.MutualAuthenticationAttributes{Mode: aws.String(mutualAuthentication.Mode)}
+	if mutualAuthentication.Mode == loadbalancer.MutualAuthenticationModeVerify {
+		attributes.TrustStoreArn = aws.String(mutualAuthentication.TrustStoreArn)
+	}
+	return attributes
+}
+
+// mutualAuthenticationMatches tells whether the current mutual authentication of a listener is the desired one.
+// Only the trust store of the verify mode matters, and a nil mutual authentication is the off mode.
+func mutualAuthenticationMatches(current, desired *elbv2.MutualAuthenticationAttributes) bool {
+	mode := mutualAuthenticationMode(desired)
+	if mutualAuthenticationMode(current) != mode {
+		return false
+	}
+	return mode != loadbalancer.MutualAuthenticationModeVerify || aws.StringValue(current.TrustStoreArn) == aws.StringValue(desired.TrustStoreArn)
+}
+
+// mutualAuthenticationMode returns the mode of mutualAuthentication, listeners without one being in the off mode.
+func mutualAuthenticationMode(mutualAuthentication *elbv2.MutualAuthenticationAttributes) string {
+	if mutualAuthentication == nil || mutualAuthentication.Mode == nil {
+		return loadbalancer.MutualAuthenticationModeOff
+	}
+	return aws.StringValue(mutualAuthentication.Mode)
+}
+
 func (controller *defaultController) reconcileExtraCertificates(ctx context.Context, lsArn string, extraCertificateARNs []string) error {
 	certificates, err := controller.cloud.DescribeListenerCertificates(ctx, lsArn)
 	if err != nil {
@@ -193,8 +264,10 @@ func (controller *defaultController) reconcileExtraCertificates(ctx context.Cont
 				},
 			},
 		}); err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to add certificate %v to listener %v due to %v", certARN, lsArn, err)
 			return err
 		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonModify, "certificate %v added to listener %v", certARN, lsArn)
 	}
 	for certARN := range certificatesToRemove {
 		albctx.GetLogger(ctx).Infof("removing certificate %v from listener %v", certARN, lsArn)
@@ -206,8 +279,10 @@ func (controller *defaultController) reconcileExtraCertificates(ctx context.Cont
 				},
 			},
 		}); err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to remove certificate %v from listener %v due to %v", certARN, lsArn, err)
 			return err
 		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonModify, "certificate %v removed from listener %v", certARN, lsArn)
 	}
 	return nil
 }
@@ -216,14 +291,26 @@ func (controller *defaultController) buildListenerConfig(ctx context.Context, op
 	config := listenerConfig{
 		Port:     aws.Int64(options.Port.Port),
 		Protocol: aws.String(options.Port.Scheme),
+		Tags:     make(map[string]string),
+	}
+	if options.IngressAnnos.Tags != nil {
+		for k, v := range options.IngressAnnos.Tags.LoadBalancer {
+			config.Tags[k] = v
+		}
 	}
 	if options.Port.Scheme == elbv2.ProtocolEnumHttps {
-		sslPolicy := DefaultSSLPolicy
+		controllerCfg := controller.store.GetConfig()
+		sslPolicy := controllerCfg.Defaults().SSLPolicy
 		_ = annotations.LoadStringAnnotation(AnnotationSSLPolicy, &sslPolicy, options.Ingress.Annotations)
 		config.SslPolicy = aws.String(sslPolicy)
 
+		config.MutualAuthentication = buildMutualAuthentication(options.IngressAnnos)
+
 		var certificateARNs []string
 		_ = annotations.LoadStringSliceAnnotation(AnnotationCertificateARN, &certificateARNs, options.Ingress.Annotations)
+		if len(certificateARNs) == 0 && controllerCfg.DefaultCertificateARN != "" {
+			certificateARNs = []string{controllerCfg.DefaultCertificateARN}
+		}
 		if len(certificateARNs) == 0 {
 			certs, err := controller.inferCertARNs(ctx, options.Ingress)
 			if err != nil {
@@ -243,7 +330,10 @@ func (controller *defaultController) buildListenerConfig(ctx context.Context, op
 				CertificateArn: aws.String(certificateARNs[0]),
 			},
 		}
-		config.ExtraCertificateARNs = certificateARNs[1:]
+		// the default certificate is always listed as default by ELBv2, so it must not be attached again as an extra one.
+		extraCertificateARNs := sets.NewString(certificateARNs[1:]...)
+		extraCertificateARNs.Delete(certificateARNs[0])
+		config.ExtraCertificateARNs = extraCertificateARNs.List()
 	}
 
 	actions, err := controller.buildDefaultActions(ctx, options)
@@ -256,11 +346,20 @@ func (controller *defaultController) buildListenerConfig(ctx context.Context, op
 }
 
 func (controller *defaultController) buildDefaultActions(ctx context.Context, options ReconcileOptions) ([]*elbv2.Action, error) {
+	if port := sslRedirectPort(options.Port.Scheme, options.IngressAnnos); port != nil {
+		return []*elbv2.Action{buildSSLRedirectAction(aws.Int64Value(port))}, nil
+	}
 	backend := action.Default404Backend()
+	source := options.Ingress
 	if options.Ingress.Spec.Backend != nil {
 		backend = *options.Ingress.Spec.Backend
+		source = albctx.GetBackendSource(ctx, options.Ingress)
+	} else if i, j, ok := catchAllPath(options.Ingress, options.IngressAnnos); ok {
+		// forwarding the catch-all path of the ingress from the default action rather than from a rule frees up a rule slot.
+		backend = options.Ingress.Spec.Rules[i].HTTP.Paths[j].Backend
+		source = albctx.GetRuleSource(ctx, options.Ingress, i)
 	}
-	authCfg, err := controller.authModule.NewConfig(ctx, options.Ingress, backend, options.Port.Scheme)
+	authCfg, err := controller.authModule.NewConfig(ctx, source, backend, options.Port.Scheme)
 	if err != nil {
 		return nil, err
 	}
@@ -8,28 +8,41 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
+// ListenerGroup summarizes the listeners of a LoadBalancer once reconciled.
+type ListenerGroup struct {
+	// ListenerCount is the number of listeners on the LoadBalancer.
+	ListenerCount int
+
+	// RuleCount is the number of rules over all listeners, not counting their default rules.
+	RuleCount int
+}
+
 type GroupController interface {
 	// Reconcile ensures listeners exists in LB to satisfy ingress requirements.
-	Reconcile(ctx context.Context, lbArn string, ingress *extensions.Ingress, tgGroup tg.TargetGroupGroup) error
+	Reconcile(ctx context.Context, lbArn string, ingress *extensions.Ingress, tgGroup tg.TargetGroupGroup) (ListenerGroup, error)
 
 	// Delete ensures all listeners are deleted
 	Delete(ctx context.Context, lbArn string) error
 }
 
-func NewGroupController(store store.Storer, cloud aws.CloudAPI, authModule auth.Module) GroupController {
-	lsController := NewController(cloud, authModule)
+func NewGroupController(store store.Storer, cloud aws.CloudAPI, authModule auth.Module, tagsController tags.Controller) GroupController {
+	lsController := NewController(store, cloud, authModule, tagsController)
+	rulesController := NewRulesController(cloud, authModule)
 	return &defaultGroupController{
-		cloud:        cloud,
-		store:        store,
-		lsController: lsController,
+		cloud:           cloud,
+		store:           store,
+		lsController:    lsController,
+		rulesController: rulesController,
 	}
 }
 
@@ -37,43 +50,55 @@ type defaultGroupController struct {
 	cloud aws.CloudAPI
 	store store.Storer
 
-	lsController Controller
+	lsController    Controller
+	rulesController RulesController
 }
 
-func (controller *defaultGroupController) Reconcile(ctx context.Context, lbArn string, ingress *extensions.Ingress, tgGroup tg.TargetGroupGroup) error {
+func (controller *defaultGroupController) Reconcile(ctx context.Context, lbArn string, ingress *extensions.Ingress, tgGroup tg.TargetGroupGroup) (ListenerGroup, error) {
 	ingressAnnos, err := controller.store.GetIngressAnnotations(k8s.MetaNamespaceKey(ingress))
 	if err != nil {
-		return err
+		return ListenerGroup{}, err
+	}
+	rulesByPort, err := controller.rulesController.CheckQuota(ctx, ingress, ingressAnnos, tgGroup)
+	if err != nil {
+		return ListenerGroup{}, err
 	}
 	instancesByPort, err := controller.loadListenerInstances(ctx, lbArn)
 	if err != nil {
-		return err
+		return ListenerGroup{}, err
 	}
 
+	lsGroup := ListenerGroup{}
 	portsInUse := sets.NewInt64()
 	for _, port := range ingressAnnos.LoadBalancer.Ports {
 		portsInUse.Insert(port.Port)
 		instance := instancesByPort[port.Port]
-		if err := controller.lsController.Reconcile(ctx, ReconcileOptions{
+		ruleCount, err := controller.lsController.Reconcile(ctx, ReconcileOptions{
 			LBArn:        lbArn,
 			Ingress:      ingress,
 			IngressAnnos: ingressAnnos,
 			Port:         port,
 			TGGroup:      tgGroup,
 			Instance:     instance,
-		}); err != nil {
-			return err
+			Rules:        rulesByPort[port.Port],
+		})
+		if err != nil {
+			return ListenerGroup{}, err
 		}
+		lsGroup.ListenerCount++
+		lsGroup.RuleCount += ruleCount
 	}
 	portsUnsed := sets.Int64KeySet(instancesByPort).Difference(portsInUse)
 	for port := range portsUnsed {
 		instance := instancesByPort[port]
 		albctx.GetLogger(ctx).Infof("deleting listener %v, arn: %v", aws.Int64Value(instance.Port), aws.StringValue(instance.ListenerArn))
 		if err := controller.cloud.DeleteListenersByArn(ctx, aws.StringValue(instance.ListenerArn)); err != nil {
-			return err
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to delete listener %v due to %v", aws.StringValue(instance.ListenerArn), err)
+			return ListenerGroup{}, err
 		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonDelete, "listener %v deleted", aws.StringValue(instance.ListenerArn))
 	}
-	return nil
+	return lsGroup, nil
 }
 
 func (controller *defaultGroupController) Delete(ctx context.Context, lbArn string) error {
@@ -84,8 +109,10 @@ func (controller *defaultGroupController) Delete(ctx context.Context, lbArn stri
 	for _, instance := range instancesByPort {
 		albctx.GetLogger(ctx).Infof("deleting listener %v, arn: %v", aws.Int64Value(instance.Port), aws.StringValue(instance.ListenerArn))
 		if err := controller.cloud.DeleteListenersByArn(ctx, aws.StringValue(instance.ListenerArn)); err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to delete listener %v due to %v", aws.StringValue(instance.ListenerArn), err)
 			return err
 		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonDelete, "listener %v deleted", aws.StringValue(instance.ListenerArn))
 	}
 	return nil
 }
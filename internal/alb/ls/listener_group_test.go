@@ -3,6 +3,7 @@ package ls
 import (
 	"context"
 	"errors"
+	"strconv"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -30,9 +31,10 @@ type ListListenersByLoadBalancerCall struct {
 }
 
 type LSControllerReconcileCall struct {
-	Port     loadbalancer.PortData
-	Instance *elbv2.Listener
-	Err      error
+	Port      loadbalancer.PortData
+	Instance  *elbv2.Listener
+	RuleCount int
+	Err       error
 }
 
 type DeleteListenersByArnCall struct {
@@ -53,8 +55,10 @@ func TestDefaultGroupController_Reconcile(t *testing.T) {
 		Name                            string
 		GetIngressAnnotationsCall       *GetIngressAnnotationsCall
 		ListListenersByLoadBalancerCall *ListListenersByLoadBalancerCall
+		CheckQuotaErr                   error
 		LSControllerReconcileCalls      []LSControllerReconcileCall
 		DeleteListenersByArnCalls       []DeleteListenersByArnCall
+		ExpectedLSGroup                 ListenerGroup
 		ExpectedErr                     error
 	}{
 		{
@@ -95,6 +99,25 @@ func TestDefaultGroupController_Reconcile(t *testing.T) {
 					Instance: nil,
 				},
 			},
+			ExpectedLSGroup: ListenerGroup{ListenerCount: 2},
+		},
+		{
+			Name: "Reconcile failed when the rules exceed the quota",
+			GetIngressAnnotationsCall: &GetIngressAnnotationsCall{
+				Key: "namespace/ingress",
+				IngressAnnos: &annotations.Ingress{
+					LoadBalancer: &loadbalancer.Config{
+						Ports: []loadbalancer.PortData{
+							{
+								Port:   80,
+								Scheme: elbv2.ProtocolEnumHttp,
+							},
+						},
+					},
+				},
+			},
+			CheckQuotaErr: errors.New("CheckQuota"),
+			ExpectedErr:   errors.New("CheckQuota"),
 		},
 		{
 			Name: "Reconcile succeed by modify listeners",
@@ -149,6 +172,7 @@ func TestDefaultGroupController_Reconcile(t *testing.T) {
 					},
 				},
 			},
+			ExpectedLSGroup: ListenerGroup{ListenerCount: 2},
 		},
 		{
 			Name: "Reconcile succeed by create|delete|modify listeners",
@@ -191,13 +215,15 @@ func TestDefaultGroupController_Reconcile(t *testing.T) {
 						ListenerArn: aws.String("lsArn1"),
 						Port:        aws.Int64(80),
 					},
+					RuleCount: 2,
 				},
 				{
 					Port: loadbalancer.PortData{
 						Port:   8080,
 						Scheme: elbv2.ProtocolEnumHttp,
 					},
-					Instance: nil,
+					Instance:  nil,
+					RuleCount: 3,
 				},
 			},
 			DeleteListenersByArnCalls: []DeleteListenersByArnCall{
@@ -205,6 +231,7 @@ func TestDefaultGroupController_Reconcile(t *testing.T) {
 					LSArn: "lsArn2",
 				},
 			},
+			ExpectedLSGroup: ListenerGroup{ListenerCount: 2, RuleCount: 5},
 		},
 		{
 			Name: "Reconcile failed when get ingress annotations",
@@ -330,6 +357,19 @@ func TestDefaultGroupController_Reconcile(t *testing.T) {
 			if tc.GetIngressAnnotationsCall != nil {
 				mockStore.On("GetIngressAnnotations", tc.GetIngressAnnotationsCall.Key).Return(tc.GetIngressAnnotationsCall.IngressAnnos, tc.GetIngressAnnotationsCall.Err)
 			}
+			mockRulesController := &MockRulesController{}
+			// the rules computed for the quota check are the ones each listener is reconciled with.
+			rulesByPort := make(map[int64]DesiredRules)
+			if tc.GetIngressAnnotationsCall != nil && tc.GetIngressAnnotationsCall.Err == nil {
+				for _, port := range tc.GetIngressAnnotationsCall.IngressAnnos.LoadBalancer.Ports {
+					rulesByPort[port.Port] = DesiredRules{Rules: []elbv2.Rule{{Priority: aws.String(strconv.FormatInt(port.Port, 10))}}}
+				}
+				if tc.CheckQuotaErr != nil {
+					mockRulesController.On("CheckQuota", mock.Anything, &ingress, tc.GetIngressAnnotationsCall.IngressAnnos, targetGroup).Return(nil, tc.CheckQuotaErr)
+				} else {
+					mockRulesController.On("CheckQuota", mock.Anything, &ingress, tc.GetIngressAnnotationsCall.IngressAnnos, targetGroup).Return(rulesByPort, nil)
+				}
+			}
 			mockLSController := &MockController{}
 			for _, call := range tc.LSControllerReconcileCalls {
 				mockLSController.On("Reconcile", mock.Anything, ReconcileOptions{
@@ -339,19 +379,23 @@ func TestDefaultGroupController_Reconcile(t *testing.T) {
 					TGGroup:      targetGroup,
 					Port:         call.Port,
 					Instance:     call.Instance,
-				}).Return(call.Err)
+					Rules:        rulesByPort[call.Port.Port],
+				}).Return(call.RuleCount, call.Err)
 			}
 
 			controller := &defaultGroupController{
-				cloud:        cloud,
-				store:        mockStore,
-				lsController: mockLSController,
+				cloud:           cloud,
+				store:           mockStore,
+				lsController:    mockLSController,
+				rulesController: mockRulesController,
 			}
 
-			err := controller.Reconcile(context.Background(), lbArn, &ingress, targetGroup)
+			lsGroup, err := controller.Reconcile(context.Background(), lbArn, &ingress, targetGroup)
 			assert.Equal(t, tc.ExpectedErr, err)
+			assert.Equal(t, tc.ExpectedLSGroup, lsGroup)
 			cloud.AssertExpectations(t)
 			mockStore.AssertExpectations(t)
+			mockRulesController.AssertExpectations(t)
 			mockLSController.AssertExpectations(t)
 		})
 	}
@@ -8,11 +8,15 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/golang/mock/gomock"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	annoTags "github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/auth"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
 	mock_auth "github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks/aws-alb-ingress-controller/ingress/auth"
 	"github.com/stretchr/testify/assert"
@@ -55,6 +59,11 @@ type RulesReconcileCall struct {
 	Err      error
 }
 
+type TagsReconcileCall struct {
+	Tags map[string]string
+	Err  error
+}
+
 func TestDefaultController_Reconcile(t *testing.T) {
 	LBArn := "MyLBArn"
 	for _, tc := range []struct {
@@ -65,6 +74,8 @@ func TestDefaultController_Reconcile(t *testing.T) {
 		TGGroup      tg.TargetGroupGroup
 		Instance     *elbv2.Listener
 		AuthConfig   auth.Config
+		// DefaultCertificateARN is the --default-certificate-arn of the controller
+		DefaultCertificateARN string
 
 		CreateListenerCall               *CreateListenerCall
 		ModifyListenerCall               *ModifyListenerCall
@@ -72,6 +83,7 @@ func TestDefaultController_Reconcile(t *testing.T) {
 		AddListenerCertificatesCalls     []AddListenerCertificatesCall
 		RemoveListenerCertificatesCalls  []RemoveListenerCertificatesCall
 
+		TagsReconcileCall  *TagsReconcileCall
 		RulesReconcileCall *RulesReconcileCall
 		ExpectedError      error
 	}{
@@ -277,6 +289,183 @@ func TestDefaultController_Reconcile(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "Reconcile succeed by creating https listener with the default certificate and SSL policy",
+			Ingress: extensions.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ingress",
+					Namespace: "namespace",
+				},
+				Spec: extensions.IngressSpec{
+					Backend: &extensions.IngressBackend{
+						ServiceName: "service",
+						ServicePort: intstr.FromInt(8443),
+					},
+				},
+			},
+			IngressAnnos:          annotations.Ingress{},
+			DefaultCertificateARN: "defaultCertificateArn",
+			Port: loadbalancer.PortData{
+				Port:   443,
+				Scheme: elbv2.ProtocolEnumHttps,
+			},
+			TGGroup: tg.TargetGroupGroup{
+				TGByBackend: map[extensions.IngressBackend]tg.TargetGroup{
+					{
+						ServiceName: "service",
+						ServicePort: intstr.FromInt(8443),
+					}: {
+						Arn: "tgArn",
+					},
+				},
+			},
+			AuthConfig: auth.Config{
+				Type: auth.TypeNone,
+			},
+
+			CreateListenerCall: &CreateListenerCall{
+				Input: elbv2.CreateListenerInput{
+					LoadBalancerArn: aws.String(LBArn),
+					Certificates: []*elbv2.Certificate{
+						{
+							CertificateArn: aws.String("defaultCertificateArn"),
+						},
+					},
+					SslPolicy: aws.String("ELBSecurityPolicy-2016-08"),
+					Protocol:  aws.String(elbv2.ProtocolEnumHttps),
+					Port:      aws.Int64(443),
+					DefaultActions: []*elbv2.Action{
+						{
+							Order: aws.Int64(1),
+							Type:  aws.String(elbv2.ActionTypeEnumForward),
+							ForwardConfig: &elbv2.ForwardActionConfig{
+								TargetGroupStickinessConfig: &elbv2.TargetGroupStickinessConfig{
+									Enabled: aws.Bool(false),
+								},
+								TargetGroups: []*elbv2.TargetGroupTuple{
+									{TargetGroupArn: aws.String("tgArn"),
+										Weight: aws.Int64(1),
+									},
+								},
+							},
+						},
+					},
+				},
+				Instance: &elbv2.Listener{
+					ListenerArn: aws.String("lsArn"),
+				},
+			},
+			DescribeListenerCertificatesCall: &DescribeListenerCertificatesCall{
+				LSArn: "lsArn",
+				Certificates: []*elbv2.Certificate{
+					{
+						CertificateArn: aws.String("defaultCertificateArn"),
+						IsDefault:      aws.Bool(true),
+					},
+				},
+			},
+			RulesReconcileCall: &RulesReconcileCall{
+				Instance: &elbv2.Listener{
+					ListenerArn: aws.String("lsArn"),
+				},
+			},
+		},
+		{
+			Name: "Reconcile succeed by creating https listener without re-attaching the default certificate",
+			Ingress: extensions.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ingress",
+					Namespace: "namespace",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/ssl-policy":      "sslPolicy",
+						"alb.ingress.kubernetes.io/certificate-arn": "certificateArn,extraArn,certificateArn,extraArn",
+					},
+				},
+				Spec: extensions.IngressSpec{
+					Backend: &extensions.IngressBackend{
+						ServiceName: "service",
+						ServicePort: intstr.FromInt(8443),
+					},
+				},
+			},
+			IngressAnnos: annotations.Ingress{},
+			Port: loadbalancer.PortData{
+				Port:   443,
+				Scheme: elbv2.ProtocolEnumHttps,
+			},
+			TGGroup: tg.TargetGroupGroup{
+				TGByBackend: map[extensions.IngressBackend]tg.TargetGroup{
+					{
+						ServiceName: "service",
+						ServicePort: intstr.FromInt(8443),
+					}: {
+						Arn: "tgArn",
+					},
+				},
+			},
+			AuthConfig: auth.Config{
+				Type: auth.TypeNone,
+			},
+
+			CreateListenerCall: &CreateListenerCall{
+				Input: elbv2.CreateListenerInput{
+					LoadBalancerArn: aws.String(LBArn),
+					Certificates: []*elbv2.Certificate{
+						{
+							CertificateArn: aws.String("certificateArn"),
+						},
+					},
+					SslPolicy: aws.String("sslPolicy"),
+					Protocol:  aws.String(elbv2.ProtocolEnumHttps),
+					Port:      aws.Int64(443),
+					DefaultActions: []*elbv2.Action{
+						{
+							Order: aws.Int64(1),
+							Type:  aws.String(elbv2.ActionTypeEnumForward),
+							ForwardConfig: &elbv2.ForwardActionConfig{
+								TargetGroupStickinessConfig: &elbv2.TargetGroupStickinessConfig{
+									Enabled: aws.Bool(false),
+								},
+								TargetGroups: []*elbv2.TargetGroupTuple{
+									{TargetGroupArn: aws.String("tgArn"),
+										Weight: aws.Int64(1),
+									},
+								},
+							},
+						},
+					},
+				},
+				Instance: &elbv2.Listener{
+					ListenerArn: aws.String("lsArn"),
+				},
+			},
+			DescribeListenerCertificatesCall: &DescribeListenerCertificatesCall{
+				LSArn: "lsArn",
+				Certificates: []*elbv2.Certificate{
+					{
+						CertificateArn: aws.String("certificateArn"),
+						IsDefault:      aws.Bool(true),
+					},
+				},
+			},
+			AddListenerCertificatesCalls: []AddListenerCertificatesCall{
+				{
+					Input: &elbv2.AddListenerCertificatesInput{
+						ListenerArn: aws.String("lsArn"),
+						Certificates: []*elbv2.Certificate{
+							{
+								CertificateArn: aws.String("extraArn"),
+							},
+						},
+					},
+				},
+			},
+			RulesReconcileCall: &RulesReconcileCall{
+				Instance: &elbv2.Listener{
+					ListenerArn: aws.String("lsArn"),
+				},
+			},
+		},
 		{
 			Name: "Reconcile succeed reconcile non-modified existing instance",
 			Ingress: extensions.Ingress{
@@ -540,6 +729,210 @@ func TestDefaultController_Reconcile(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "Reconcile succeed by redirecting existing http listener to https",
+			Ingress: extensions.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ingress",
+					Namespace: "namespace",
+				},
+				Spec: extensions.IngressSpec{
+					Backend: &extensions.IngressBackend{
+						ServiceName: "service",
+						ServicePort: intstr.FromInt(8080),
+					},
+				},
+			},
+			IngressAnnos: annotations.Ingress{
+				LoadBalancer: &loadbalancer.Config{
+					SSLRedirectPort: aws.Int64(443),
+				},
+			},
+			Port: loadbalancer.PortData{
+				Port:   80,
+				Scheme: elbv2.ProtocolEnumHttp,
+			},
+			TGGroup: tg.TargetGroupGroup{
+				TGByBackend: map[extensions.IngressBackend]tg.TargetGroup{
+					{
+						ServiceName: "service",
+						ServicePort: intstr.FromInt(8080),
+					}: {
+						Arn: "tgArn",
+					},
+				},
+			},
+			Instance: &elbv2.Listener{
+				ListenerArn: aws.String("lsArn"),
+				Port:        aws.Int64(80),
+				Protocol:    aws.String(elbv2.ProtocolEnumHttp),
+				DefaultActions: []*elbv2.Action{
+					{
+						Order: aws.Int64(1),
+						Type:  aws.String(elbv2.ActionTypeEnumForward),
+						ForwardConfig: &elbv2.ForwardActionConfig{
+							TargetGroupStickinessConfig: &elbv2.TargetGroupStickinessConfig{
+								Enabled: aws.Bool(false),
+							},
+							TargetGroups: []*elbv2.TargetGroupTuple{
+								{
+									TargetGroupArn: aws.String("tgArn"),
+									Weight:         aws.Int64(1),
+								},
+							},
+						},
+					},
+				},
+			},
+			ModifyListenerCall: &ModifyListenerCall{
+				Input: elbv2.ModifyListenerInput{
+					ListenerArn: aws.String("lsArn"),
+					Port:        aws.Int64(80),
+					Protocol:    aws.String(elbv2.ProtocolEnumHttp),
+					DefaultActions: []*elbv2.Action{
+						{
+							Order: aws.Int64(1),
+							Type:  aws.String(elbv2.ActionTypeEnumRedirect),
+							RedirectConfig: &elbv2.RedirectActionConfig{
+								Host:       aws.String("#{host}"),
+								Path:       aws.String("/#{path}"),
+								Port:       aws.String("443"),
+								Protocol:   aws.String(elbv2.ProtocolEnumHttps),
+								Query:      aws.String("#{query}"),
+								StatusCode: aws.String(elbv2.RedirectActionStatusCodeEnumHttp301),
+							},
+						},
+					},
+				},
+				Instance: &elbv2.Listener{
+					ListenerArn: aws.String("lsArn"),
+					Port:        aws.Int64(80),
+					Protocol:    aws.String(elbv2.ProtocolEnumHttp),
+					DefaultActions: []*elbv2.Action{
+						{
+							Order: aws.Int64(1),
+							Type:  aws.String(elbv2.ActionTypeEnumRedirect),
+							RedirectConfig: &elbv2.RedirectActionConfig{
+								Host:       aws.String("#{host}"),
+								Path:       aws.String("/#{path}"),
+								Port:       aws.String("443"),
+								Protocol:   aws.String(elbv2.ProtocolEnumHttps),
+								Query:      aws.String("#{query}"),
+								StatusCode: aws.String(elbv2.RedirectActionStatusCodeEnumHttp301),
+							},
+						},
+					},
+				},
+			},
+			RulesReconcileCall: &RulesReconcileCall{
+				Instance: &elbv2.Listener{
+					ListenerArn: aws.String("lsArn"),
+					Port:        aws.Int64(80),
+					Protocol:    aws.String(elbv2.ProtocolEnumHttp),
+					DefaultActions: []*elbv2.Action{
+						{
+							Order: aws.Int64(1),
+							Type:  aws.String(elbv2.ActionTypeEnumRedirect),
+							RedirectConfig: &elbv2.RedirectActionConfig{
+								Host:       aws.String("#{host}"),
+								Path:       aws.String("/#{path}"),
+								Port:       aws.String("443"),
+								Protocol:   aws.String(elbv2.ProtocolEnumHttps),
+								Query:      aws.String("#{query}"),
+								StatusCode: aws.String(elbv2.RedirectActionStatusCodeEnumHttp301),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "Reconcile succeed by tagging existing http listener with ingress tags",
+			Ingress: extensions.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ingress",
+					Namespace: "namespace",
+				},
+				Spec: extensions.IngressSpec{
+					Backend: &extensions.IngressBackend{
+						ServiceName: "service",
+						ServicePort: intstr.FromInt(8080),
+					},
+				},
+			},
+			IngressAnnos: annotations.Ingress{
+				Tags: &annoTags.Config{
+					LoadBalancer: map[string]string{"Team": "payments"},
+				},
+			},
+			Port: loadbalancer.PortData{
+				Port:   80,
+				Scheme: elbv2.ProtocolEnumHttp,
+			},
+			TGGroup: tg.TargetGroupGroup{
+				TGByBackend: map[extensions.IngressBackend]tg.TargetGroup{
+					{
+						ServiceName: "service",
+						ServicePort: intstr.FromInt(8080),
+					}: {
+						Arn: "tgArn",
+					},
+				},
+			},
+			AuthConfig: auth.Config{
+				Type: auth.TypeNone,
+			},
+
+			Instance: &elbv2.Listener{
+				ListenerArn: aws.String("lsArn"),
+				Port:        aws.Int64(80),
+				Protocol:    aws.String(elbv2.ProtocolEnumHttp),
+				DefaultActions: []*elbv2.Action{
+					{
+						Order: aws.Int64(1),
+						Type:  aws.String(elbv2.ActionTypeEnumForward),
+						ForwardConfig: &elbv2.ForwardActionConfig{
+							TargetGroupStickinessConfig: &elbv2.TargetGroupStickinessConfig{
+								Enabled: aws.Bool(false),
+							},
+							TargetGroups: []*elbv2.TargetGroupTuple{
+								{
+									TargetGroupArn: aws.String("tgArn"),
+									Weight:         aws.Int64(1),
+								},
+							},
+						},
+					},
+				},
+			},
+			TagsReconcileCall: &TagsReconcileCall{
+				Tags: map[string]string{"Team": "payments"},
+			},
+			RulesReconcileCall: &RulesReconcileCall{
+				Instance: &elbv2.Listener{
+					ListenerArn: aws.String("lsArn"),
+					Port:        aws.Int64(80),
+					Protocol:    aws.String(elbv2.ProtocolEnumHttp),
+					DefaultActions: []*elbv2.Action{
+						{
+							Order: aws.Int64(1),
+							Type:  aws.String(elbv2.ActionTypeEnumForward),
+							ForwardConfig: &elbv2.ForwardActionConfig{
+								TargetGroupStickinessConfig: &elbv2.TargetGroupStickinessConfig{
+									Enabled: aws.Bool(false),
+								},
+								TargetGroups: []*elbv2.TargetGroupTuple{
+									{
+										TargetGroupArn: aws.String("tgArn"),
+										Weight:         aws.Int64(1),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			Name: "Reconcile succeed by modify extra certificates",
 			Ingress: extensions.Ingress{
@@ -982,6 +1375,139 @@ func TestDefaultController_Reconcile(t *testing.T) {
 			},
 			ExpectedError: errors.New("failed to reconcile rules due to RulesReconcileCall"),
 		},
+		{
+			Name: "Reconcile succeed by modifying the default action of http listener to forward to the catch-all path",
+			Ingress: extensions.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ingress",
+					Namespace: "namespace",
+				},
+				Spec: extensions.IngressSpec{
+					Rules: []extensions.IngressRule{
+						{
+							IngressRuleValue: extensions.IngressRuleValue{
+								HTTP: &extensions.HTTPIngressRuleValue{
+									Paths: []extensions.HTTPIngressPath{
+										{
+											Path: "/*",
+											Backend: extensions.IngressBackend{
+												ServiceName: "service",
+												ServicePort: intstr.FromInt(8080),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			IngressAnnos: annotations.Ingress{},
+			Port: loadbalancer.PortData{
+				Port:   80,
+				Scheme: elbv2.ProtocolEnumHttp,
+			},
+			TGGroup: tg.TargetGroupGroup{
+				TGByBackend: map[extensions.IngressBackend]tg.TargetGroup{
+					{
+						ServiceName: "service",
+						ServicePort: intstr.FromInt(8080),
+					}: {
+						Arn: "tgArn",
+					},
+				},
+			},
+			AuthConfig: auth.Config{
+				Type: auth.TypeNone,
+			},
+
+			Instance: &elbv2.Listener{
+				ListenerArn: aws.String("lsArn"),
+				Port:        aws.Int64(80),
+				Protocol:    aws.String(elbv2.ProtocolEnumHttp),
+				DefaultActions: []*elbv2.Action{
+					{
+						Order: aws.Int64(1),
+						Type:  aws.String(elbv2.ActionTypeEnumFixedResponse),
+						FixedResponseConfig: &elbv2.FixedResponseActionConfig{
+							ContentType: aws.String("text/plain"),
+							StatusCode:  aws.String("404"),
+						},
+					},
+				},
+			},
+
+			ModifyListenerCall: &ModifyListenerCall{
+				Input: elbv2.ModifyListenerInput{
+					ListenerArn: aws.String("lsArn"),
+					Port:        aws.Int64(80),
+					Protocol:    aws.String(elbv2.ProtocolEnumHttp),
+					DefaultActions: []*elbv2.Action{
+						{
+							Order: aws.Int64(1),
+							Type:  aws.String(elbv2.ActionTypeEnumForward),
+							ForwardConfig: &elbv2.ForwardActionConfig{
+								TargetGroupStickinessConfig: &elbv2.TargetGroupStickinessConfig{
+									Enabled: aws.Bool(false),
+								},
+								TargetGroups: []*elbv2.TargetGroupTuple{
+									{
+										TargetGroupArn: aws.String("tgArn"),
+										Weight:         aws.Int64(1),
+									},
+								},
+							},
+						},
+					},
+				},
+				Instance: &elbv2.Listener{
+					ListenerArn: aws.String("lsArn"),
+					Port:        aws.Int64(80),
+					Protocol:    aws.String(elbv2.ProtocolEnumHttp),
+					DefaultActions: []*elbv2.Action{
+						{
+							Order: aws.Int64(1),
+							Type:  aws.String(elbv2.ActionTypeEnumForward),
+							ForwardConfig: &elbv2.ForwardActionConfig{
+								TargetGroupStickinessConfig: &elbv2.TargetGroupStickinessConfig{
+									Enabled: aws.Bool(false),
+								},
+								TargetGroups: []*elbv2.TargetGroupTuple{
+									{
+										TargetGroupArn: aws.String("tgArn"),
+										Weight:         aws.Int64(1),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			RulesReconcileCall: &RulesReconcileCall{
+				Instance: &elbv2.Listener{
+					ListenerArn: aws.String("lsArn"),
+					Port:        aws.Int64(80),
+					Protocol:    aws.String(elbv2.ProtocolEnumHttp),
+					DefaultActions: []*elbv2.Action{
+						{
+							Order: aws.Int64(1),
+							Type:  aws.String(elbv2.ActionTypeEnumForward),
+							ForwardConfig: &elbv2.ForwardActionConfig{
+								TargetGroupStickinessConfig: &elbv2.TargetGroupStickinessConfig{
+									Enabled: aws.Bool(false),
+								},
+								TargetGroups: []*elbv2.TargetGroupTuple{
+									{
+										TargetGroupArn: aws.String("tgArn"),
+										Weight:         aws.Int64(1),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	} {
 		t.Run(tc.Name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
@@ -995,6 +1521,9 @@ func TestDefaultController_Reconcile(t *testing.T) {
 						Listeners: []*elbv2.Listener{tc.CreateListenerCall.Instance},
 					}, tc.CreateListenerCall.Err)
 			}
+			if tc.CreateListenerCall != nil && tc.CreateListenerCall.Err == nil {
+				cloud.On("GetRules", ctx, aws.StringValue(tc.CreateListenerCall.Instance.ListenerArn)).Return(nil, nil)
+			}
 			if tc.ModifyListenerCall != nil {
 				cloud.On("ModifyListenerWithContext", ctx, &tc.ModifyListenerCall.Input).Return(
 					&elbv2.ModifyListenerOutput{
@@ -1012,29 +1541,51 @@ func TestDefaultController_Reconcile(t *testing.T) {
 				cloud.On("RemoveListenerCertificates", ctx, call.Input).Return(nil, call.Err)
 			}
 			mockAuthModule := mock_auth.NewMockModule(ctrl)
-			mockAuthModule.EXPECT().NewConfig(gomock.Any(), &tc.Ingress, gomock.Any(), gomock.Any()).Return(tc.AuthConfig, nil)
+			if tc.AuthConfig.Type != "" {
+				mockAuthModule.EXPECT().NewConfig(gomock.Any(), &tc.Ingress, gomock.Any(), gomock.Any()).Return(tc.AuthConfig, nil)
+			}
 
+			mockTagsController := &tags.MockController{}
+			if tc.TagsReconcileCall != nil {
+				mockTagsController.On("ReconcileELB", ctx, "lsArn", tc.TagsReconcileCall.Tags).Return(tc.TagsReconcileCall.Err)
+			} else {
+				mockTagsController.On("ReconcileELB", ctx, mock.Anything, mock.Anything).Return(nil)
+			}
+
+			rules := DesiredRules{Rules: []elbv2.Rule{{Priority: aws.String("1")}}}
 			mockRulesController := &MockRulesController{}
 			if tc.RulesReconcileCall != nil {
-				mockRulesController.On("Reconcile", mock.Anything, tc.RulesReconcileCall.Instance, &tc.Ingress, &tc.IngressAnnos, tc.TGGroup).Return(tc.RulesReconcileCall.Err)
+				mockRulesController.On("Reconcile", mock.Anything, tc.RulesReconcileCall.Instance, rules).Return(1, tc.RulesReconcileCall.Err)
 			}
 
+			cfg := config.NewConfiguration()
+			cfg.DefaultSSLPolicy = "ELBSecurityPolicy-2016-08"
+			cfg.DefaultCertificateARN = tc.DefaultCertificateARN
 			controller := &defaultController{
+				store:           store.NewStatic(&cfg),
 				cloud:           cloud,
 				authModule:      mockAuthModule,
 				rulesController: mockRulesController,
+				tagsController:  mockTagsController,
 			}
-			err := controller.Reconcile(ctx, ReconcileOptions{
+			ruleCount, err := controller.Reconcile(ctx, ReconcileOptions{
 				LBArn:        LBArn,
 				Ingress:      &tc.Ingress,
 				IngressAnnos: &tc.IngressAnnos,
 				Port:         tc.Port,
 				TGGroup:      tc.TGGroup,
 				Instance:     tc.Instance,
+				Rules:        rules,
 			})
 			assert.Equal(t, tc.ExpectedError, err)
+			if tc.ExpectedError == nil {
+				assert.Equal(t, 1, ruleCount)
+			}
 			cloud.AssertExpectations(t)
 			mockRulesController.AssertExpectations(t)
+			if tc.TagsReconcileCall != nil {
+				mockTagsController.AssertExpectations(t)
+			}
 		})
 	}
 }
@@ -1092,3 +1643,74 @@ func Test_uniqueHosts(t *testing.T) {
 		}
 	}
 }
+
+func Test_mutualAuthenticationMatches(t *testing.T) {
+	const trustStoreArn = "arn:aws:elasticloadbalancing:us-west-2:123456789012:truststore/ts/abc"
+	for _, tc := range []struct {
+		name     string
+		current  *elbv2.MutualAuthenticationAttributes
+		desired  *elbv2.MutualAuthenticationAttributes
+		expected bool
+	}{
+		{
+			name:     "no mutual authentication",
+			expected: true,
+		},
+		{
+			name:     "off mode isn't annotated",
+			current:  &elbv2.MutualAuthenticationAttributes{Mode: aws.String("off")},
+			expected: true,
+		},
+		{
+			name:    "annotation removed",
+			current: &elbv2.MutualAuthenticationAttributes{Mode: aws.String("passthrough")},
+		},
+		{
+			name:    "mode changed",
+			current: &elbv2.MutualAuthenticationAttributes{Mode: aws.String("off")},
+			desired: &elbv2.MutualAuthenticationAttributes{Mode: aws.String("passthrough")},
+		},
+		{
+			name:     "same trust store",
+			current:  &elbv2.MutualAuthenticationAttributes{Mode: aws.String("verify"), TrustStoreArn: aws.String(trustStoreArn), IgnoreClientCertificateExpiry: aws.Bool(false)},
+			desired:  &elbv2.MutualAuthenticationAttributes{Mode: aws.String("verify"), TrustStoreArn: aws.String(trustStoreArn)},
+			expected: true,
+		},
+		{
+			name:    "trust store changed",
+			current: &elbv2.MutualAuthenticationAttributes{Mode: aws.String("verify"), TrustStoreArn: aws.String("arn:aws:elasticloadbalancing:us-west-2:123456789012:truststore/ts/def")},
+			desired: &elbv2.MutualAuthenticationAttributes{Mode: aws.String("verify"), TrustStoreArn: aws.String(trustStoreArn)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, mutualAuthenticationMatches(tc.current, tc.desired))
+		})
+	}
+}
+
+func Test_buildMutualAuthentication(t *testing.T) {
+	for _, tc := range []struct {
+		name                 string
+		mutualAuthentication *loadbalancer.MutualAuthentication
+		expected             *elbv2.MutualAuthenticationAttributes
+	}{
+		{
+			name: "not annotated",
+		},
+		{
+			name:                 "passthrough",
+			mutualAuthentication: &loadbalancer.MutualAuthentication{Mode: "passthrough", TrustStoreArn: "ignored"},
+			expected:             &elbv2.MutualAuthenticationAttributes{Mode: aws.String("passthrough")},
+		},
+		{
+			name:                 "verify",
+			mutualAuthentication: &loadbalancer.MutualAuthentication{Mode: "verify", TrustStoreArn: "trust-store-arn"},
+			expected:             &elbv2.MutualAuthenticationAttributes{Mode: aws.String("verify"), TrustStoreArn: aws.String("trust-store-arn")},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ingressAnnos := &annotations.Ingress{LoadBalancer: &loadbalancer.Config{MutualAuthentication: tc.mutualAuthentication}}
+			assert.Equal(t, tc.expected, buildMutualAuthentication(ingressAnnos))
+		})
+	}
+}
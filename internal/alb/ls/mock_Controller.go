@@ -14,15 +14,22 @@ type MockController struct {
 }
 
 // Reconcile provides a mock function with given fields: ctx, options
-func (_m *MockController) Reconcile(ctx context.Context, options ReconcileOptions) error {
+func (_m *MockController) Reconcile(ctx context.Context, options ReconcileOptions) (int, error) {
 	ret := _m.Called(ctx, options)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, ReconcileOptions) error); ok {
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, ReconcileOptions) int); ok {
 		r0 = rf(ctx, options)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ReconcileOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
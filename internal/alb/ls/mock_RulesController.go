@@ -21,16 +21,46 @@ type MockRulesController struct {
 	mock.Mock
 }
 
-// Reconcile provides a mock function with given fields: ctx, listener, ingress, ingressAnnos, tgGroup
-func (_m *MockRulesController) Reconcile(ctx context.Context, listener *elbv2.Listener, ingress *v1beta1.Ingress, ingressAnnos *annotations.Ingress, tgGroup tg.TargetGroupGroup) error {
-	ret := _m.Called(ctx, listener, ingress, ingressAnnos, tgGroup)
+// CheckQuota provides a mock function with given fields: ctx, ingress, ingressAnnos, tgGroup
+func (_m *MockRulesController) CheckQuota(ctx context.Context, ingress *v1beta1.Ingress, ingressAnnos *annotations.Ingress, tgGroup tg.TargetGroupGroup) (map[int64]DesiredRules, error) {
+	ret := _m.Called(ctx, ingress, ingressAnnos, tgGroup)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *elbv2.Listener, *v1beta1.Ingress, *annotations.Ingress, tg.TargetGroupGroup) error); ok {
-		r0 = rf(ctx, listener, ingress, ingressAnnos, tgGroup)
+	var r0 map[int64]DesiredRules
+	if rf, ok := ret.Get(0).(func(context.Context, *v1beta1.Ingress, *annotations.Ingress, tg.TargetGroupGroup) map[int64]DesiredRules); ok {
+		r0 = rf(ctx, ingress, ingressAnnos, tgGroup)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64]DesiredRules)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *v1beta1.Ingress, *annotations.Ingress, tg.TargetGroupGroup) error); ok {
+		r1 = rf(ctx, ingress, ingressAnnos, tgGroup)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reconcile provides a mock function with given fields: ctx, listener, desired
+func (_m *MockRulesController) Reconcile(ctx context.Context, listener *elbv2.Listener, desired DesiredRules) (int, error) {
+	ret := _m.Called(ctx, listener, desired)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, *elbv2.Listener, DesiredRules) int); ok {
+		r0 = rf(ctx, listener, desired)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *elbv2.Listener, DesiredRules) error); ok {
+		r1 = rf(ctx, listener, desired)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
@@ -3,7 +3,9 @@ package ls
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util"
 
@@ -26,8 +28,23 @@ import (
 
 // RulesController provides functionality to manage rules on listeners
 type RulesController interface {
-	// Reconcile ensures the listener rules in AWS match the rules configured in the Ingress resource.
-	Reconcile(ctx context.Context, listener *elbv2.Listener, ingress *extensions.Ingress, ingressAnnos *annotations.Ingress, tgGroup tg.TargetGroupGroup) error
+	// Reconcile ensures the listener rules in AWS match desired, the rules CheckQuota computed for the port of the listener.
+	// It returns the number of rules on the listener, not counting its default rule.
+	Reconcile(ctx context.Context, listener *elbv2.Listener, desired DesiredRules) (int, error)
+
+	// CheckQuota computes the rules configured in the Ingress resource for the listeners of all its ports, and ensures
+	// they fit within the account's quota of rules per LoadBalancer. The rules are returned by port, so that they're
+	// reconciled without being computed again.
+	CheckQuota(ctx context.Context, ingress *extensions.Ingress, ingressAnnos *annotations.Ingress, tgGroup tg.TargetGroupGroup) (map[int64]DesiredRules, error)
+}
+
+// DesiredRules are the rules a listener should have as per its ingress.
+type DesiredRules struct {
+	// Rules are ordered by priority.
+	Rules []elbv2.Rule
+
+	// AuthUnavailable are the priorities of the rules whose authentication config can't be built.
+	AuthUnavailable sets.String
 }
 
 // NewRulesController constructs RulesController
@@ -41,20 +58,71 @@ func NewRulesController(cloud aws.CloudAPI, authModule auth.Module) RulesControl
 type rulesController struct {
 	cloud      aws.CloudAPI
 	authModule auth.Module
+	limits     ruleLimits
 }
 
 // Reconcile modifies AWS resources to match the rules defined in the Ingress
-func (c *rulesController) Reconcile(ctx context.Context, listener *elbv2.Listener, ingress *extensions.Ingress, ingressAnnos *annotations.Ingress, tgGroup tg.TargetGroupGroup) error {
-	desired, err := c.getDesiredRules(ctx, listener, ingress, ingressAnnos, tgGroup)
-	if err != nil {
-		return err
-	}
+func (c *rulesController) Reconcile(ctx context.Context, listener *elbv2.Listener, desired DesiredRules) (int, error) {
 	lsArn := aws.StringValue(listener.ListenerArn)
 	current, err := c.getCurrentRules(ctx, lsArn)
 	if err != nil {
-		return err
+		return 0, err
+	}
+	kept, current, rules := keepRulesOfUnavailableAuth(current, desired.Rules, desired.AuthUnavailable)
+	if err := c.reconcileRules(ctx, lsArn, current, rules); err != nil {
+		return 0, err
+	}
+	return kept + len(rules), nil
+}
+
+// keepRulesOfUnavailableAuth keeps the rules whose authentication config can't be built as they are in AWS, matching
+// them by their conditions, so that e.g. a missing OIDC secret doesn't turn a working rule into a 503. They're left out
+// of both current and desired, and the other desired rules are numbered around their priorities. Only the rules without
+// a counterpart in AWS yet respond 503. It returns the number of rules kept along with the remaining rules.
+func keepRulesOfUnavailableAuth(current []elbv2.Rule, desired []elbv2.Rule, authUnavailable sets.String) (int, []elbv2.Rule, []elbv2.Rule) {
+	if authUnavailable.Len() == 0 {
+		return 0, current, desired
+	}
+	keptPriorities := sets.NewString()
+	var remainingDesired []elbv2.Rule
+	for _, rule := range desired {
+		if authUnavailable.Has(aws.StringValue(rule.Priority)) {
+			if currentRule, ok := ruleWithConditions(current, rule.Conditions, keptPriorities); ok {
+				keptPriorities.Insert(aws.StringValue(currentRule.Priority))
+				continue
+			}
+		}
+		remainingDesired = append(remainingDesired, rule)
+	}
+	if keptPriorities.Len() == 0 {
+		return 0, current, desired
+	}
+
+	var remainingCurrent []elbv2.Rule
+	for _, rule := range current {
+		if !keptPriorities.Has(aws.StringValue(rule.Priority)) {
+			remainingCurrent = append(remainingCurrent, rule)
+		}
+	}
+	priority := 0
+	for i := range remainingDesired {
+		priority++
+		for keptPriorities.Has(strconv.Itoa(priority)) {
+			priority++
+		}
+		remainingDesired[i].Priority = aws.String(strconv.Itoa(priority))
+	}
+	return keptPriorities.Len(), remainingCurrent, remainingDesired
+}
+
+// ruleWithConditions returns the rule of rules with conditions, skipping the rules whose priority is in skipped.
+func ruleWithConditions(rules []elbv2.Rule, conditions []*elbv2.RuleCondition, skipped sets.String) (elbv2.Rule, bool) {
+	for _, rule := range rules {
+		if !skipped.Has(aws.StringValue(rule.Priority)) && conditionsMatches(conditions, rule.Conditions) {
+			return rule, true
+		}
 	}
-	return c.reconcileRules(ctx, lsArn, current, desired)
+	return elbv2.Rule{}, false
 }
 
 func (c *rulesController) reconcileRules(ctx context.Context, lsArn string, current []elbv2.Rule, desired []elbv2.Rule) error {
@@ -73,16 +141,27 @@ func (c *rulesController) reconcileRules(ctx context.Context, lsArn string, curr
 		if _, err := c.cloud.CreateRuleWithContext(ctx, in); err != nil {
 			msg := fmt.Sprintf("failed creating rule %v on %v due to %v", aws.StringValue(rule.Priority), lsArn, err)
 			albctx.GetLogger(ctx).Errorf(msg)
-			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", msg)
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, msg)
 			return fmt.Errorf(msg)
 		}
 
-		msg := fmt.Sprintf("rule %v created with conditions %v", aws.StringValue(rule.Priority), log.Prettify(rule.Conditions))
+		msg := fmt.Sprintf("rule %v created on %v with conditions %v", aws.StringValue(rule.Priority), lsArn, log.Prettify(rule.Conditions))
 		albctx.GetLogger(ctx).Infof(msg)
-		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "CREATE", msg)
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonCreate, msg)
 	}
 
+	currentByPriority := make(map[string]elbv2.Rule, len(current))
+	for _, rule := range current {
+		currentByPriority[aws.StringValue(rule.Priority)] = rule
+	}
 	for _, rule := range modifies {
+		currentRule := currentByPriority[aws.StringValue(rule.Priority)]
+		if !conditionsMatches(rule.Conditions, currentRule.Conditions) {
+			albctx.GetLogger(ctx).Debugf("rule %v conditions needs modification: %v => %v", aws.StringValue(rule.Priority), log.Prettify(currentRule.Conditions), log.Prettify(rule.Conditions))
+		}
+		if !actionsMatches(rule.Actions, currentRule.Actions) {
+			albctx.GetLogger(ctx).Debugf("rule %v actions needs modification: %v => %v", aws.StringValue(rule.Priority), log.Prettify(redactActions(currentRule.Actions)), log.Prettify(redactActions(rule.Actions)))
+		}
 		albctx.GetLogger(ctx).Infof("modifying rule %v on %v", aws.StringValue(rule.Priority), lsArn)
 		in := &elbv2.ModifyRuleInput{
 			Actions:    rule.Actions,
@@ -93,12 +172,12 @@ func (c *rulesController) reconcileRules(ctx context.Context, lsArn string, curr
 		if _, err := c.cloud.ModifyRuleWithContext(ctx, in); err != nil {
 			msg := fmt.Sprintf("failed modifying rule %v on %v due to %v", aws.StringValue(rule.Priority), lsArn, err)
 			albctx.GetLogger(ctx).Errorf(msg)
-			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", msg)
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, msg)
 			return fmt.Errorf(msg)
 		}
 
-		msg := fmt.Sprintf("rule %v modified with conditions %v", aws.StringValue(rule.Priority), log.Prettify(rule.Conditions))
-		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", msg)
+		msg := fmt.Sprintf("rule %v modified with conditions %v, ARN: %v", aws.StringValue(rule.Priority), log.Prettify(rule.Conditions), aws.StringValue(rule.RuleArn))
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonModify, msg)
 		albctx.GetLogger(ctx).Infof(msg)
 	}
 
@@ -109,59 +188,101 @@ func (c *rulesController) reconcileRules(ctx context.Context, lsArn string, curr
 		if _, err := c.cloud.DeleteRuleWithContext(ctx, in); err != nil {
 			msg := fmt.Sprintf("failed deleting rule %v on %v due to %v", aws.StringValue(rule.Priority), lsArn, err)
 			albctx.GetLogger(ctx).Errorf(msg)
-			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", msg)
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, msg)
 			return fmt.Errorf(msg)
 		}
 
-		msg := fmt.Sprintf("rule %v deleted with conditions %v", aws.StringValue(rule.Priority), log.Prettify(rule.Conditions))
-		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "DELETE", msg)
+		msg := fmt.Sprintf("rule %v deleted with conditions %v, ARN: %v", aws.StringValue(rule.Priority), log.Prettify(rule.Conditions), aws.StringValue(rule.RuleArn))
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonDelete, msg)
 		albctx.GetLogger(ctx).Infof(msg)
 	}
 	return nil
 }
 
-func (c *rulesController) getDesiredRules(ctx context.Context, listener *elbv2.Listener, ingress *extensions.Ingress, ingressAnnos *annotations.Ingress, tgGroup tg.TargetGroupGroup) ([]elbv2.Rule, error) {
+// getDesiredRules returns the rules of listener as per ingress, along with the priorities of those whose authentication
+// config can't be built, which respond 503 unless keepRulesOfUnavailableAuth keeps their rule in AWS.
+func (c *rulesController) getDesiredRules(ctx context.Context, listener *elbv2.Listener, ingress *extensions.Ingress, ingressAnnos *annotations.Ingress, tgGroup tg.TargetGroupGroup) ([]elbv2.Rule, sets.String, error) {
 	var output []elbv2.Rule
+	authUnavailable := sets.NewString()
+	if sslRedirectPort(aws.StringValue(listener.Protocol), ingressAnnos) != nil {
+		// the default action of the listener redirects all traffic, any rule would be moot.
+		return output, authUnavailable, nil
+	}
 
-	nextPriority := 1
-	for _, ingressRule := range ingress.Spec.Rules {
+	catchAllRule, catchAllPath, hasCatchAll := catchAllPath(ingress, ingressAnnos)
+	unavailableConditions := sets.NewString()
+	for i, ingressRule := range ingress.Spec.Rules {
 		// Ingress spec allows empty HTTP, and we will 'route all traffic to the default backend'(which relies on default action of listeners)
 		if ingressRule.HTTP == nil {
 			continue
 		}
 
+		if ingressRule.Host != "" {
+			if err := conditions.ValidateHostPattern(normalizeHost(ingressRule.Host)); err != nil {
+				msg := fmt.Sprintf("invalid host of ingress rule due to %v", err)
+				albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, msg)
+				return nil, nil, fmt.Errorf(msg)
+			}
+		}
+
 		seenUnconditionalRedirect := false
 
-		for _, path := range ingressRule.HTTP.Paths {
+		for j, path := range ingressRule.HTTP.Paths {
 			if seenUnconditionalRedirect {
 				// Ignore rules that follow a unconditional redirect, they are moot
 				continue
 			}
-			authCfg, err := c.authModule.NewConfig(ctx, ingress, path.Backend, aws.StringValue(listener.Protocol))
-			if err != nil {
-				return nil, err
+			if hasCatchAll && i == catchAllRule && j == catchAllPath {
+				// the default action of the listener forwards to the catch-all path, see buildDefaultActions.
+				continue
 			}
-			elbActions, err := buildActions(ctx, authCfg, ingressAnnos, path.Backend, tgGroup)
+			var elbActions []*elbv2.Action
+			authCfg, authErr := c.authModule.NewConfig(ctx, albctx.GetRuleSource(ctx, ingress, i), path.Backend, aws.StringValue(listener.Protocol))
+			if authErr != nil {
+				// deny the traffic of this rule only, dropping the rule would let it fall through unauthenticated.
+				albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to build authentication config for backend %v:%v due to %v, keeping the current rule of path %v, or responding 503 to it if there's none",
+					path.Backend.ServiceName, path.Backend.ServicePort.String(), authErr, path.Path)
+				elbActions = []*elbv2.Action{buildAuthUnavailableAction()}
+			} else {
+				var err error
+				elbActions, err = buildActions(ctx, authCfg, ingressAnnos, path.Backend, tgGroup)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+			elbConditions, err := buildConditions(ctx, ingressAnnos, ingressRule, path)
 			if err != nil {
-				return nil, err
+				msg := fmt.Sprintf("failed to build conditions for path %v due to %v", path.Path, err)
+				albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, msg)
+				return nil, nil, fmt.Errorf(msg)
 			}
-			elbConditions := buildConditions(ctx, ingressAnnos, ingressRule, path)
 			elbRule := elbv2.Rule{
 				IsDefault:  aws.Bool(false),
-				Priority:   aws.String(strconv.Itoa(nextPriority)),
 				Actions:    elbActions,
 				Conditions: elbConditions,
 			}
 			if createsRedirectLoop(listener, elbRule) {
 				continue
-			} else if isUnconditionalRedirect(listener, elbRule, ingressRule.Host) {
+			} else if isUnconditionalRedirect(listener, elbRule, normalizeHost(ingressRule.Host)) {
 				seenUnconditionalRedirect = true
 			}
+			if authErr != nil {
+				unavailableConditions.Insert(log.Prettify(elbConditions))
+			}
 			output = append(output, elbRule)
-			nextPriority++
 		}
 	}
-	return output, nil
+
+	if ingressAnnos.Conditions != nil && ingressAnnos.Conditions.RuleOrder == conditions.RuleOrderSpecificity {
+		sortRulesBySpecificity(output)
+	}
+	for i := range output {
+		output[i].Priority = aws.String(strconv.Itoa(i + 1))
+		if unavailableConditions.Has(log.Prettify(output[i].Conditions)) {
+			authUnavailable.Insert(aws.StringValue(output[i].Priority))
+		}
+	}
+	return output, authUnavailable, nil
 }
 
 func (c *rulesController) getCurrentRules(ctx context.Context, listenerArn string) ([]elbv2.Rule, error) {
@@ -235,7 +356,7 @@ func buildActions(ctx context.Context, authCfg auth.Config, ingressAnnos *annota
 }
 
 // buildConditions will build listener rule conditions for specific ingressRule
-func buildConditions(ctx context.Context, ingressAnnos *annotations.Ingress, rule extensions.IngressRule, path extensions.HTTPIngressPath) []*elbv2.RuleCondition {
+func buildConditions(ctx context.Context, ingressAnnos *annotations.Ingress, rule extensions.IngressRule, path extensions.HTTPIngressPath) ([]*elbv2.RuleCondition, error) {
 	var elbConditions []*elbv2.RuleCondition
 
 	hostHeaderConfig := &elbv2.HostHeaderConditionConfig{
@@ -245,16 +366,22 @@ func buildConditions(ctx context.Context, ingressAnnos *annotations.Ingress, rul
 		Values: nil,
 	}
 	if rule.Host != "" {
-		hostHeaderConfig.Values = append(hostHeaderConfig.Values, aws.String(rule.Host))
+		hostHeaderConfig.Values = append(hostHeaderConfig.Values, aws.String(normalizeHost(rule.Host)))
 	}
 	if path.Path != "" {
-		pathPatternConfig.Values = append(pathPatternConfig.Values, aws.String(path.Path))
+		patterns, err := pathPatterns(ingressAnnos.Conditions.PathType, path.Path)
+		if err != nil {
+			return nil, err
+		}
+		pathPatternConfig.Values = append(pathPatternConfig.Values, aws.StringSlice(patterns)...)
 	}
 	annotationConditions := ingressAnnos.Conditions.GetConditions(path.Backend.ServiceName)
 	for _, condition := range annotationConditions {
 		switch aws.StringValue(condition.Field) {
 		case conditions.FieldHostHeader:
-			hostHeaderConfig.Values = append(hostHeaderConfig.Values, condition.HostHeaderConfig.Values...)
+			for _, host := range condition.HostHeaderConfig.Values {
+				hostHeaderConfig.Values = append(hostHeaderConfig.Values, aws.String(normalizeHost(aws.StringValue(host))))
+			}
 		case conditions.FieldPathPattern:
 			pathPatternConfig.Values = append(pathPatternConfig.Values, condition.PathPatternConfig.Values...)
 		case conditions.FieldHTTPRequestMethod:
@@ -316,7 +443,132 @@ func buildConditions(ctx context.Context, ingressAnnos *annotations.Ingress, rul
 			},
 		})
 	}
-	return elbConditions
+	return elbConditions, nil
+}
+
+// catchAllPath returns the indexes of the rule and the path of ingress which match every request the other paths don't,
+// so that the default action of the listener can forward to its backend instead of a rule, freeing up a rule slot.
+// It's only the case of a path without host nor conditions that forwards to a service, when the ingress has no default backend:
+// the last path of the ingress if its rules are ordered as in the ingress, as paths following it would become reachable otherwise,
+// or the first such path anywhere if they are ordered by specificity, as it then sorts last anyway.
+func catchAllPath(ingress *extensions.Ingress, ingressAnnos *annotations.Ingress) (int, int, bool) {
+	if ingress.Spec.Backend != nil {
+		return 0, 0, false
+	}
+	cfg := ingressAnnos.Conditions
+	if cfg == nil {
+		cfg = &conditions.Config{}
+	}
+	bySpecificity := cfg.RuleOrder == conditions.RuleOrderSpecificity
+	lastRule, lastPath := -1, -1
+	for i, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for j, path := range rule.HTTP.Paths {
+			lastRule, lastPath = i, j
+			if bySpecificity && rule.Host == "" && isCatchAll(cfg, path) {
+				return i, j, true
+			}
+		}
+	}
+	if bySpecificity || lastRule < 0 {
+		return 0, 0, false
+	}
+	rule := ingress.Spec.Rules[lastRule]
+	if rule.Host != "" || !isCatchAll(cfg, rule.HTTP.Paths[lastPath]) {
+		return 0, 0, false
+	}
+	return lastRule, lastPath, true
+}
+
+// isCatchAll tells whether path matches every request and forwards to a service, without conditions set by annotation.
+func isCatchAll(cfg *conditions.Config, path extensions.HTTPIngressPath) bool {
+	if action.Use(path.Backend.ServicePort.String()) || len(cfg.GetConditions(path.Backend.ServiceName)) != 0 {
+		return false
+	}
+	switch cfg.PathType {
+	case conditions.PathTypeExact:
+		return path.Path == ""
+	case conditions.PathTypePrefix:
+		return path.Path == "" || path.Path == "/"
+	default:
+		return path.Path == "" || path.Path == "/*"
+	}
+}
+
+// pathPatterns translates path of pathType into the path-pattern values matching it.
+// ImplementationSpecific paths are ALB path patterns already, Exact and Prefix paths are literal so they can't contain wildcards.
+func pathPatterns(pathType string, path string) ([]string, error) {
+	if pathType != conditions.PathTypeExact && pathType != conditions.PathTypePrefix {
+		return []string{path}, nil
+	}
+	if strings.ContainsAny(path, "*?") {
+		return nil, fmt.Errorf("%v path %v can't contain wildcards, use path type %v for path patterns", pathType, path, conditions.PathTypeImplementationSpecific)
+	}
+	if pathType == conditions.PathTypeExact {
+		return []string{path}, nil
+	}
+	// Prefix matches the path itself and every path below it, element by element, so /foo matches /foo/bar but not /foobar.
+	prefix := strings.TrimSuffix(path, "/")
+	if prefix == "" {
+		return []string{"/*"}, nil
+	}
+	return []string{prefix, prefix + "/*"}, nil
+}
+
+// ruleSpecificity ranks how specific the path patterns of a rule are: literal paths before patterns with wildcards,
+// then longer literal prefixes first, then rules with more conditions first.
+type ruleSpecificity struct {
+	literal       bool
+	prefixLen     int
+	conditionsLen int
+}
+
+func specificityOf(rule elbv2.Rule) ruleSpecificity {
+	s := ruleSpecificity{conditionsLen: len(rule.Conditions)}
+	for _, condition := range rule.Conditions {
+		if aws.StringValue(condition.Field) != conditions.FieldPathPattern {
+			continue
+		}
+		s.literal = true
+		for _, pattern := range aws.StringValueSlice(condition.PathPatternConfig.Values) {
+			prefixLen := strings.IndexAny(pattern, "*?")
+			if prefixLen < 0 {
+				prefixLen = len(pattern)
+			} else {
+				s.literal = false
+			}
+			if prefixLen > s.prefixLen {
+				s.prefixLen = prefixLen
+			}
+		}
+	}
+	return s
+}
+
+// sortRulesBySpecificity sorts rules with more specific paths first. Rules ranking the same are sorted by their conditions,
+// so that the priorities of rules only depend on what they match, not on the order of the paths in the ingress.
+func sortRulesBySpecificity(rules []elbv2.Rule) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		si, sj := specificityOf(rules[i]), specificityOf(rules[j])
+		if si.literal != sj.literal {
+			return si.literal
+		}
+		if si.prefixLen != sj.prefixLen {
+			return si.prefixLen > sj.prefixLen
+		}
+		if si.conditionsLen != sj.conditionsLen {
+			return si.conditionsLen > sj.conditionsLen
+		}
+		return log.Prettify(rules[i].Conditions) < log.Prettify(rules[j].Conditions)
+	})
+}
+
+// normalizeHost returns host the way ELBV2 compares it, case insensitive and without the trailing dot of a fully qualified name,
+// so that rules don't flip between spellings of the same host. Wildcards such as *.example.com are kept as they are.
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(host), ".")
 }
 
 // buildAuthAction builds ELB action for specific authCfg.
@@ -364,6 +616,44 @@ func buildAuthAction(ctx context.Context, authCfg auth.Config) *elbv2.Action {
 	return nil
 }
 
+// sslRedirectPort returns the HTTPS port that a listener of protocol redirects all traffic to, or nil if it doesn't redirect.
+func sslRedirectPort(protocol string, ingressAnnos *annotations.Ingress) *int64 {
+	if protocol != elbv2.ProtocolEnumHttp || ingressAnnos.LoadBalancer == nil {
+		return nil
+	}
+	return ingressAnnos.LoadBalancer.SSLRedirectPort
+}
+
+// buildAuthUnavailableAction builds the action of rules whose authentication config can't be built, e.g. for a missing
+// OIDC secret. It fails closed, without affecting the other rules of the listener.
+func buildAuthUnavailableAction() *elbv2.Action {
+	return &elbv2.Action{
+		Type:  aws.String(elbv2.ActionTypeEnumFixedResponse),
+		Order: aws.Int64(1),
+		FixedResponseConfig: &elbv2.FixedResponseActionConfig{
+			ContentType: aws.String("text/plain"),
+			StatusCode:  aws.String("503"),
+			MessageBody: aws.String("authentication is unavailable"),
+		},
+	}
+}
+
+// buildSSLRedirectAction builds an action that permanently redirects requests to HTTPS on port, keeping host, path and query.
+func buildSSLRedirectAction(port int64) *elbv2.Action {
+	return &elbv2.Action{
+		Type:  aws.String(elbv2.ActionTypeEnumRedirect),
+		Order: aws.Int64(1),
+		RedirectConfig: &elbv2.RedirectActionConfig{
+			Host:       aws.String("#{host}"),
+			Path:       aws.String("/#{path}"),
+			Port:       aws.String(strconv.FormatInt(port, 10)),
+			Protocol:   aws.String(elbv2.ProtocolEnumHttps),
+			Query:      aws.String("#{query}"),
+			StatusCode: aws.String(elbv2.RedirectActionStatusCodeEnumHttp301),
+		},
+	}
+}
+
 func buildAnnotationAction(ctx context.Context, action action.Action, tgGroup tg.TargetGroupGroup) (*elbv2.Action, error) {
 	switch aws.StringValue(action.Type) {
 	case elbv2.ActionTypeEnumFixedResponse:
@@ -498,7 +788,7 @@ func createsRedirectLoop(listener *elbv2.Listener, r elbv2.Rule) bool {
 		if len(hosts) != 0 && aws.StringValue(rc.Host) != "#{host}" {
 			hostMatches := false
 			for _, host := range hosts {
-				if aws.StringValue(rc.Host) == host {
+				if normalizeHost(aws.StringValue(rc.Host)) == host {
 					hostMatches = true
 					break
 				}
@@ -63,11 +63,20 @@ func actionMatches(desired *elbv2.Action, current *elbv2.Action) bool {
 	case elbv2.ActionTypeEnumFixedResponse:
 		return reflect.DeepEqual(desired.FixedResponseConfig, current.FixedResponseConfig)
 	case elbv2.ActionTypeEnumForward:
-		return reflect.DeepEqual(desired.ForwardConfig, current.ForwardConfig)
+		return forwardConfigMatches(desired.ForwardConfig, current.ForwardConfig)
 	}
 	return false
 }
 
+// forwardConfigMatches checks whether current forward config matches desired forward config, without comparing the order of target groups.
+func forwardConfigMatches(desired *elbv2.ForwardActionConfig, current *elbv2.ForwardActionConfig) bool {
+	if desired == nil || current == nil {
+		return desired == current
+	}
+	return reflect.DeepEqual(desired.TargetGroupStickinessConfig, current.TargetGroupStickinessConfig) &&
+		sliceMatches(desired.TargetGroups, current.TargetGroups, reflect.DeepEqual)
+}
+
 func conditionMatches(desired *elbv2.RuleCondition, current *elbv2.RuleCondition) bool {
 	if aws.StringValue(desired.Field) != aws.StringValue(current.Field) {
 		return false
@@ -93,7 +102,9 @@ func hostHeaderConditionConfigMatches(desired *elbv2.HostHeaderConditionConfig,
 	if desired == nil || current == nil {
 		return desired == current
 	}
-	return sliceMatches(desired.Values, current.Values, reflect.DeepEqual)
+	return sliceMatches(desired.Values, current.Values, func(i interface{}, j interface{}) bool {
+		return normalizeHost(aws.StringValue(i.(*string))) == normalizeHost(aws.StringValue(j.(*string)))
+	})
 }
 
 func pathPatternConditionConfigMatches(desired *elbv2.PathPatternConditionConfig, current *elbv2.PathPatternConditionConfig) bool {
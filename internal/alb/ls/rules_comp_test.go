@@ -187,6 +187,78 @@ func Test_actionsMatches(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "weighted forward actions matches without target group order",
+			args: args{
+				desired: []*elbv2.Action{
+					{
+						Type: aws.String(elbv2.ActionTypeEnumForward),
+						ForwardConfig: &elbv2.ForwardActionConfig{
+							TargetGroups: []*elbv2.TargetGroupTuple{
+								{TargetGroupArn: aws.String("tg-blue"), Weight: aws.Int64(80)},
+								{TargetGroupArn: aws.String("tg-green"), Weight: aws.Int64(20)},
+							},
+							TargetGroupStickinessConfig: &elbv2.TargetGroupStickinessConfig{
+								Enabled: aws.Bool(false),
+							},
+						},
+						Order: aws.Int64(1),
+					},
+				},
+				current: []*elbv2.Action{
+					{
+						Type: aws.String(elbv2.ActionTypeEnumForward),
+						ForwardConfig: &elbv2.ForwardActionConfig{
+							TargetGroups: []*elbv2.TargetGroupTuple{
+								{TargetGroupArn: aws.String("tg-green"), Weight: aws.Int64(20)},
+								{TargetGroupArn: aws.String("tg-blue"), Weight: aws.Int64(80)},
+							},
+							TargetGroupStickinessConfig: &elbv2.TargetGroupStickinessConfig{
+								Enabled: aws.Bool(false),
+							},
+						},
+						Order: aws.Int64(1),
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "weighted forward actions mismatches when weights change",
+			args: args{
+				desired: []*elbv2.Action{
+					{
+						Type: aws.String(elbv2.ActionTypeEnumForward),
+						ForwardConfig: &elbv2.ForwardActionConfig{
+							TargetGroups: []*elbv2.TargetGroupTuple{
+								{TargetGroupArn: aws.String("tg-blue"), Weight: aws.Int64(50)},
+								{TargetGroupArn: aws.String("tg-green"), Weight: aws.Int64(50)},
+							},
+							TargetGroupStickinessConfig: &elbv2.TargetGroupStickinessConfig{
+								Enabled: aws.Bool(false),
+							},
+						},
+						Order: aws.Int64(1),
+					},
+				},
+				current: []*elbv2.Action{
+					{
+						Type: aws.String(elbv2.ActionTypeEnumForward),
+						ForwardConfig: &elbv2.ForwardActionConfig{
+							TargetGroups: []*elbv2.TargetGroupTuple{
+								{TargetGroupArn: aws.String("tg-green"), Weight: aws.Int64(20)},
+								{TargetGroupArn: aws.String("tg-blue"), Weight: aws.Int64(80)},
+							},
+							TargetGroupStickinessConfig: &elbv2.TargetGroupStickinessConfig{
+								Enabled: aws.Bool(false),
+							},
+						},
+						Order: aws.Int64(1),
+					},
+				},
+			},
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -518,6 +590,32 @@ func Test_sliceMatches(t *testing.T) {
 	}
 }
 
+func Test_hostHeaderConditionConfigMatches(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		desired *elbv2.HostHeaderConditionConfig
+		current *elbv2.HostHeaderConditionConfig
+		want    bool
+	}{
+		{
+			name:    "host condition matches other spellings of the same hosts",
+			desired: &elbv2.HostHeaderConditionConfig{Values: aws.StringSlice([]string{"www.example.com", "*.example.com"})},
+			current: &elbv2.HostHeaderConditionConfig{Values: aws.StringSlice([]string{"*.Example.com", "WWW.example.com."})},
+			want:    true,
+		},
+		{
+			name:    "host condition mismatch if hosts mismatch",
+			desired: &elbv2.HostHeaderConditionConfig{Values: aws.StringSlice([]string{"*.example.com"})},
+			current: &elbv2.HostHeaderConditionConfig{Values: aws.StringSlice([]string{"www.example.com"})},
+			want:    false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, hostHeaderConditionConfigMatches(tc.desired, tc.current))
+		})
+	}
+}
+
 func Test_httpHeaderConditionConfigMatches(t *testing.T) {
 	type args struct {
 		desired *elbv2.HttpHeaderConditionConfig
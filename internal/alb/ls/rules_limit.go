@@ -0,0 +1,102 @@
+package ls
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+)
+
+const (
+	// defaultRulesPerLoadBalancer is the default quota of rules per application LoadBalancer, not counting default rules.
+	defaultRulesPerLoadBalancer = 100
+
+	// rulesPerLoadBalancerLimitName is the name DescribeAccountLimits reports the rule quota under.
+	rulesPerLoadBalancerLimitName = "rules-per-application-load-balancer"
+
+	// ruleLimitCacheTTL is how long the rule quota of an account is cached, it only changes when a quota increase is granted.
+	ruleLimitCacheTTL = time.Hour
+)
+
+type cachedRuleLimit struct {
+	limit   int64
+	fetched time.Time
+}
+
+// ruleLimits caches the rule quota of each account, by the IAM role assumed to manage LoadBalancers in it.
+type ruleLimits struct {
+	mutex  sync.Mutex
+	byRole map[string]cachedRuleLimit
+}
+
+// CheckQuota sums the desired rules over the listeners of all ports of the ingress, since the quota applies to the
+// LoadBalancer as a whole, and rejects them before any listener is changed if they exceed it.
+func (c *rulesController) CheckQuota(ctx context.Context, ingress *extensions.Ingress, ingressAnnos *annotations.Ingress, tgGroup tg.TargetGroupGroup) (map[int64]DesiredRules, error) {
+	rulesByPort := make(map[int64]DesiredRules, len(ingressAnnos.LoadBalancer.Ports))
+	ruleCount := 0
+	for _, port := range ingressAnnos.LoadBalancer.Ports {
+		listener := &elbv2.Listener{Port: aws.Int64(port.Port), Protocol: aws.String(port.Scheme)}
+		rules, authUnavailable, err := c.getDesiredRules(ctx, listener, ingress, ingressAnnos, tgGroup)
+		if err != nil {
+			return nil, err
+		}
+		rulesByPort[port.Port] = DesiredRules{Rules: rules, AuthUnavailable: authUnavailable}
+		ruleCount += len(rules)
+	}
+	// quotas can only be raised, so the account's quota is only looked up when the default one is exceeded.
+	if ruleCount <= defaultRulesPerLoadBalancer {
+		return rulesByPort, nil
+	}
+	if limit := c.rulesPerLoadBalancer(ctx); int64(ruleCount) > limit {
+		msg := fmt.Sprintf("ingress %v needs %v rules over its listeners, more than the %v rules a LoadBalancer can have. Spread its rules over several ingresses or request a higher quota",
+			k8s.MetaNamespaceKey(ingress), ruleCount, limit)
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, msg)
+		return nil, fmt.Errorf(msg)
+	}
+	return rulesByPort, nil
+}
+
+// rulesPerLoadBalancer returns how many rules a LoadBalancer can have in the account it's managed in, including quota increases.
+// It returns the default quota if the quota can't be described.
+func (c *rulesController) rulesPerLoadBalancer(ctx context.Context) int64 {
+	roleARN := albctx.GetIAMRoleARN(ctx)
+	c.limits.mutex.Lock()
+	cached, ok := c.limits.byRole[roleARN]
+	c.limits.mutex.Unlock()
+	if ok && time.Since(cached.fetched) < ruleLimitCacheTTL {
+		return cached.limit
+	}
+
+	// the quota is described without holding the lock, so that reconciles in other accounts aren't held up by it.
+	resp, err := c.cloud.DescribeELBV2AccountLimitsWithContext(ctx, &elbv2.DescribeAccountLimitsInput{})
+	if err != nil {
+		albctx.GetLogger(ctx).Warnf("failed to describe account limits due to %v, assuming the default quota of %v rules per LoadBalancer", err, defaultRulesPerLoadBalancer)
+		return defaultRulesPerLoadBalancer
+	}
+	limit := int64(defaultRulesPerLoadBalancer)
+	for _, l := range resp.Limits {
+		if aws.StringValue(l.Name) != rulesPerLoadBalancerLimitName {
+			continue
+		}
+		if max, err := strconv.ParseInt(aws.StringValue(l.Max), 10, 64); err == nil {
+			limit = max
+		}
+	}
+	c.limits.mutex.Lock()
+	defer c.limits.mutex.Unlock()
+	if c.limits.byRole == nil {
+		c.limits.byRole = make(map[string]cachedRuleLimit)
+	}
+	c.limits.byRole[roleARN] = cachedRuleLimit{limit: limit, fetched: time.Now()}
+	return limit
+}
@@ -0,0 +1,158 @@
+package ls
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/conditions"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/auth"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	mock_auth "github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks/aws-alb-ingress-controller/ingress/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func Test_rulesController_rulesPerLoadBalancer(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		limits        []*elbv2.Limit
+		describeErr   error
+		expectedLimit int64
+		expectedCalls int
+	}{
+		{
+			name: "raised quota is used, and cached",
+			limits: []*elbv2.Limit{
+				{Name: aws.String("target-groups"), Max: aws.String("3000")},
+				{Name: aws.String(rulesPerLoadBalancerLimitName), Max: aws.String("200")},
+			},
+			expectedLimit: 200,
+			expectedCalls: 1,
+		},
+		{
+			name:          "default quota is used when the quota isn't reported",
+			limits:        []*elbv2.Limit{{Name: aws.String("target-groups"), Max: aws.String("3000")}},
+			expectedLimit: defaultRulesPerLoadBalancer,
+			expectedCalls: 1,
+		},
+		{
+			name:          "default quota is used, and not cached, when account limits can't be described",
+			describeErr:   errors.New("AccessDenied"),
+			expectedLimit: defaultRulesPerLoadBalancer,
+			expectedCalls: 2,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			c := &rulesController{cloud: cloud}
+			cloud.On("DescribeELBV2AccountLimitsWithContext", ctx, &elbv2.DescribeAccountLimitsInput{}).Return(&elbv2.DescribeAccountLimitsOutput{Limits: tc.limits}, tc.describeErr).Run(func(mock.Arguments) {
+				// the quota is described without holding the lock of the cache.
+				unlocked := make(chan struct{})
+				go func() {
+					c.limits.mutex.Lock()
+					c.limits.mutex.Unlock()
+					close(unlocked)
+				}()
+				select {
+				case <-unlocked:
+				case <-time.After(time.Second):
+					t.Error("account limits described while holding the lock")
+				}
+			})
+
+			assert.Equal(t, tc.expectedLimit, c.rulesPerLoadBalancer(ctx))
+			assert.Equal(t, tc.expectedLimit, c.rulesPerLoadBalancer(ctx))
+			cloud.AssertNumberOfCalls(t, "DescribeELBV2AccountLimitsWithContext", tc.expectedCalls)
+		})
+	}
+}
+
+func Test_rulesController_CheckQuota(t *testing.T) {
+	backend := extensions.IngressBackend{ServiceName: "service", ServicePort: intstr.FromInt(80)}
+	var rules []extensions.IngressRule
+	for i := 0; i < 60; i++ {
+		rules = append(rules, extensions.IngressRule{
+			Host: fmt.Sprintf("host-%v.example.com", i),
+			IngressRuleValue: extensions.IngressRuleValue{
+				HTTP: &extensions.HTTPIngressRuleValue{
+					Paths: []extensions.HTTPIngressPath{{Backend: backend}},
+				},
+			},
+		})
+	}
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"},
+		Spec:       extensions.IngressSpec{Rules: rules},
+	}
+	tgGroup := tg.TargetGroupGroup{
+		TGByBackend: map[extensions.IngressBackend]tg.TargetGroup{backend: {Arn: "tgArn"}},
+	}
+
+	for _, tc := range []struct {
+		name          string
+		ports         []loadbalancer.PortData
+		limits        []*elbv2.Limit
+		expectedCalls int
+		expectedError error
+	}{
+		{
+			name:  "rules of a single listener within the default quota",
+			ports: []loadbalancer.PortData{{Port: 80, Scheme: elbv2.ProtocolEnumHttp}},
+		},
+		{
+			name:          "rules of all listeners count towards the quota",
+			ports:         []loadbalancer.PortData{{Port: 80, Scheme: elbv2.ProtocolEnumHttp}, {Port: 8080, Scheme: elbv2.ProtocolEnumHttp}},
+			limits:        []*elbv2.Limit{{Name: aws.String(rulesPerLoadBalancerLimitName), Max: aws.String("100")}},
+			expectedCalls: 1,
+			expectedError: errors.New("ingress namespace/ingress needs 120 rules over its listeners, more than the 100 rules a LoadBalancer can have. Spread its rules over several ingresses or request a higher quota"),
+		},
+		{
+			name:          "rules of all listeners within a raised quota",
+			ports:         []loadbalancer.PortData{{Port: 80, Scheme: elbv2.ProtocolEnumHttp}, {Port: 8080, Scheme: elbv2.ProtocolEnumHttp}},
+			limits:        []*elbv2.Limit{{Name: aws.String(rulesPerLoadBalancerLimitName), Max: aws.String("200")}},
+			expectedCalls: 1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			cloud.On("DescribeELBV2AccountLimitsWithContext", ctx, &elbv2.DescribeAccountLimitsInput{}).Return(&elbv2.DescribeAccountLimitsOutput{Limits: tc.limits}, nil)
+			mockAuthModule := mock_auth.NewMockModule(ctrl)
+			// the authentication config of each rule is built once per listener.
+			mockAuthModule.EXPECT().NewConfig(gomock.Any(), ingress, backend, gomock.Any()).Return(auth.Config{Type: auth.TypeNone}, nil).Times(len(rules) * len(tc.ports))
+			c := &rulesController{cloud: cloud, authModule: mockAuthModule}
+			ingressAnnos := &annotations.Ingress{
+				Action:       &action.Config{},
+				Conditions:   &conditions.Config{},
+				LoadBalancer: &loadbalancer.Config{Ports: tc.ports},
+			}
+
+			rulesByPort, err := c.CheckQuota(ctx, ingress, ingressAnnos, tgGroup)
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError == nil {
+				assert.Len(t, rulesByPort, len(tc.ports))
+				for _, port := range tc.ports {
+					assert.Len(t, rulesByPort[port.Port].Rules, len(rules))
+				}
+			}
+			cloud.AssertNumberOfCalls(t, "DescribeELBV2AccountLimitsWithContext", tc.expectedCalls)
+		})
+	}
+}
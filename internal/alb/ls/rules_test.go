@@ -2,15 +2,18 @@ package ls
 
 import (
 	"context"
+	"strconv"
 	"testing"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/conditions"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
 	"github.com/pkg/errors"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/golang/mock/gomock"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/auth"
@@ -18,12 +21,15 @@ import (
 	mock_auth "github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks/aws-alb-ingress-controller/ingress/auth"
 	"github.com/stretchr/testify/assert"
 	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 type AuthNewConfigCall struct {
 	backend extensions.IngressBackend
 	authCfg auth.Config
+	err     error
 }
 
 func Test_rulesController_getDesiredRules(t *testing.T) {
@@ -37,13 +43,14 @@ func Test_rulesController_getDesiredRules(t *testing.T) {
 	}
 
 	for _, tc := range []struct {
-		name               string
-		ingress            extensions.Ingress
-		ingressAnnos       annotations.Ingress
-		tgGroup            tg.TargetGroupGroup
-		authNewConfigCalls []AuthNewConfigCall
-		expected           []elbv2.Rule
-		expectedError      error
+		name                    string
+		ingress                 extensions.Ingress
+		ingressAnnos            annotations.Ingress
+		tgGroup                 tg.TargetGroupGroup
+		authNewConfigCalls      []AuthNewConfigCall
+		expected                []elbv2.Rule
+		expectedAuthUnavailable []string
+		expectedError           error
 	}{
 		{
 			name: "no path with default backend",
@@ -764,6 +771,10 @@ func Test_rulesController_getDesiredRules(t *testing.T) {
 			name: "one path without host/path condition",
 			ingress: extensions.Ingress{
 				Spec: extensions.IngressSpec{
+					Backend: &extensions.IngressBackend{
+						ServiceName: "default-service",
+						ServicePort: intstr.FromInt(80),
+					},
 					Rules: []extensions.IngressRule{
 						{
 							IngressRuleValue: extensions.IngressRuleValue{
@@ -835,6 +846,44 @@ func Test_rulesController_getDesiredRules(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "one path without host/path condition nor default backend",
+			ingress: extensions.Ingress{
+				Spec: extensions.IngressSpec{
+					Rules: []extensions.IngressRule{
+						{
+							IngressRuleValue: extensions.IngressRuleValue{
+								HTTP: &extensions.HTTPIngressRuleValue{
+									Paths: []extensions.HTTPIngressPath{
+										{
+											Path: "/*",
+											Backend: extensions.IngressBackend{
+												ServiceName: "service",
+												ServicePort: intstr.FromString("http"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ingressAnnos: annotations.Ingress{
+				Action: &action.Config{
+					Actions: nil,
+				},
+				Conditions: &conditions.Config{
+					Conditions: nil,
+				},
+			},
+			tgGroup: tg.TargetGroupGroup{
+				TGByBackend: map[extensions.IngressBackend]tg.TargetGroup{
+					{ServiceName: "service", ServicePort: intstr.FromString("http")}: {Arn: "tgArn"},
+				},
+			},
+			expected: nil,
+		},
 		{
 			name: "one path with host condition but without path condition",
 			ingress: extensions.Ingress{
@@ -1713,6 +1762,116 @@ func Test_rulesController_getDesiredRules(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "missing oidc secret denies the traffic of its rule only",
+			ingress: extensions.Ingress{
+				Spec: extensions.IngressSpec{
+					Rules: []extensions.IngressRule{
+						{
+							IngressRuleValue: extensions.IngressRuleValue{
+								HTTP: &extensions.HTTPIngressRuleValue{
+									Paths: []extensions.HTTPIngressPath{
+										{
+											Path: "/secure",
+											Backend: extensions.IngressBackend{
+												ServiceName: "service",
+												ServicePort: intstr.FromInt(80),
+											},
+										},
+										{
+											Path: "/open",
+											Backend: extensions.IngressBackend{
+												ServiceName: "open-service",
+												ServicePort: intstr.FromInt(80),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ingressAnnos: annotations.Ingress{
+				Conditions: &conditions.Config{},
+			},
+			tgGroup: tg.TargetGroupGroup{
+				TGByBackend: map[extensions.IngressBackend]tg.TargetGroup{
+					{ServiceName: "open-service", ServicePort: intstr.FromInt(80)}: {Arn: "tgArn"},
+				},
+			},
+			authNewConfigCalls: []AuthNewConfigCall{
+				{
+					backend: extensions.IngressBackend{
+						ServiceName: "service",
+						ServicePort: intstr.FromInt(80),
+					},
+					err: errors.New("failed to load k8s secret: namespace/oidc-secret"),
+				},
+				{
+					backend: extensions.IngressBackend{
+						ServiceName: "open-service",
+						ServicePort: intstr.FromInt(80),
+					},
+					authCfg: auth.Config{Type: auth.TypeNone},
+				},
+			},
+			expected: []elbv2.Rule{
+				{
+					IsDefault: aws.Bool(false),
+					Priority:  aws.String("1"),
+					Conditions: []*elbv2.RuleCondition{
+						{
+							Field: aws.String(conditions.FieldPathPattern),
+							PathPatternConfig: &elbv2.PathPatternConditionConfig{
+								Values: aws.StringSlice([]string{"/secure"}),
+							},
+						},
+					},
+					Actions: []*elbv2.Action{
+						{
+							Order: aws.Int64(1),
+							Type:  aws.String(elbv2.ActionTypeEnumFixedResponse),
+							FixedResponseConfig: &elbv2.FixedResponseActionConfig{
+								ContentType: aws.String("text/plain"),
+								StatusCode:  aws.String("503"),
+								MessageBody: aws.String("authentication is unavailable"),
+							},
+						},
+					},
+				},
+				{
+					IsDefault: aws.Bool(false),
+					Priority:  aws.String("2"),
+					Conditions: []*elbv2.RuleCondition{
+						{
+							Field: aws.String(conditions.FieldPathPattern),
+							PathPatternConfig: &elbv2.PathPatternConditionConfig{
+								Values: aws.StringSlice([]string{"/open"}),
+							},
+						},
+					},
+					Actions: []*elbv2.Action{
+						{
+							Order: aws.Int64(1),
+							Type:  aws.String(elbv2.ActionTypeEnumForward),
+							ForwardConfig: &elbv2.ForwardActionConfig{
+								TargetGroupStickinessConfig: &elbv2.TargetGroupStickinessConfig{
+									Enabled: aws.Bool(false),
+								},
+								TargetGroups: []*elbv2.TargetGroupTuple{
+									{
+										TargetGroupArn: aws.String("tgArn"),
+										Weight:         aws.Int64(1),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedAuthUnavailable: []string{"1"},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
@@ -1721,7 +1880,7 @@ func Test_rulesController_getDesiredRules(t *testing.T) {
 			cloud := &mocks.CloudAPI{}
 			mockAuthModule := mock_auth.NewMockModule(ctrl)
 			for _, call := range tc.authNewConfigCalls {
-				mockAuthModule.EXPECT().NewConfig(gomock.Any(), &tc.ingress, call.backend, gomock.Any()).Return(call.authCfg, nil)
+				mockAuthModule.EXPECT().NewConfig(gomock.Any(), &tc.ingress, call.backend, gomock.Any()).Return(call.authCfg, call.err)
 			}
 
 			c := &rulesController{
@@ -1729,10 +1888,11 @@ func Test_rulesController_getDesiredRules(t *testing.T) {
 				authModule: mockAuthModule,
 			}
 
-			got, err := c.getDesiredRules(context.Background(), &elbv2.Listener{}, &tc.ingress, &tc.ingressAnnos, tc.tgGroup)
+			got, authUnavailable, err := c.getDesiredRules(context.Background(), &elbv2.Listener{}, &tc.ingress, &tc.ingressAnnos, tc.tgGroup)
 			assert.Equal(t, tc.expected, got)
 			if tc.expectedError == nil {
 				assert.NoError(t, err)
+				assert.Equal(t, sets.NewString(tc.expectedAuthUnavailable...), authUnavailable)
 			} else {
 				assert.EqualError(t, err, tc.expectedError.Error())
 			}
@@ -1740,6 +1900,411 @@ func Test_rulesController_getDesiredRules(t *testing.T) {
 	}
 }
 
+func Test_rulesController_getDesiredRules_hosts(t *testing.T) {
+	backend := extensions.IngressBackend{ServiceName: "service", ServicePort: intstr.FromInt(80)}
+	ingressRule := func(host string) extensions.IngressRule {
+		return extensions.IngressRule{
+			Host: host,
+			IngressRuleValue: extensions.IngressRuleValue{
+				HTTP: &extensions.HTTPIngressRuleValue{
+					Paths: []extensions.HTTPIngressPath{{Backend: backend}},
+				},
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name          string
+		rules         []extensions.IngressRule
+		conditions    map[string][]conditions.RuleCondition
+		expectedHosts [][]string
+		expectedError error
+	}{
+		{
+			name:          "exact hosts",
+			rules:         []extensions.IngressRule{ingressRule("www.example.com"), ingressRule("api.example.com")},
+			expectedHosts: [][]string{{"www.example.com"}, {"api.example.com"}},
+		},
+		{
+			name:          "wildcard hosts are kept as host-header wildcards",
+			rules:         []extensions.IngressRule{ingressRule("*.example.com")},
+			expectedHosts: [][]string{{"*.example.com"}},
+		},
+		{
+			name:  "mixed hosts are lowercased and lose their trailing dot",
+			rules: []extensions.IngressRule{ingressRule("WWW.Example.com."), ingressRule("*.Example.com")},
+			conditions: map[string][]conditions.RuleCondition{
+				"service": {
+					{
+						Field:            aws.String(conditions.FieldHostHeader),
+						HostHeaderConfig: &conditions.HostHeaderConditionConfig{Values: aws.StringSlice([]string{"Static.example.com."})},
+					},
+				},
+			},
+			expectedHosts: [][]string{{"www.example.com", "static.example.com"}, {"*.example.com", "static.example.com"}},
+		},
+		{
+			name:          "hosts AWS doesn't support are rejected",
+			rules:         []extensions.IngressRule{ingressRule("www.example.com"), ingressRule("www_example.com")},
+			expectedError: errors.New(`invalid host of ingress rule due to host "www_example.com" contains '_', only letters, digits, '-', '.', '*' and '?' are supported`),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ingress := extensions.Ingress{Spec: extensions.IngressSpec{Rules: tc.rules}}
+			mockAuthModule := mock_auth.NewMockModule(ctrl)
+			mockAuthModule.EXPECT().NewConfig(gomock.Any(), &ingress, backend, gomock.Any()).Return(auth.Config{Type: auth.TypeNone}, nil).AnyTimes()
+			c := &rulesController{
+				cloud:      &mocks.CloudAPI{},
+				authModule: mockAuthModule,
+			}
+			ingressAnnos := annotations.Ingress{
+				Action:     &action.Config{},
+				Conditions: &conditions.Config{Conditions: tc.conditions},
+			}
+			tgGroup := tg.TargetGroupGroup{
+				TGByBackend: map[extensions.IngressBackend]tg.TargetGroup{backend: {Arn: "tgArn"}},
+			}
+
+			got, _, err := c.getDesiredRules(context.Background(), &elbv2.Listener{}, &ingress, &ingressAnnos, tgGroup)
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+			assert.NoError(t, err)
+			var hosts [][]string
+			for _, rule := range got {
+				for _, condition := range rule.Conditions {
+					if aws.StringValue(condition.Field) == conditions.FieldHostHeader {
+						hosts = append(hosts, aws.StringValueSlice(condition.HostHeaderConfig.Values))
+					}
+				}
+			}
+			assert.Equal(t, tc.expectedHosts, hosts)
+		})
+	}
+}
+
+func Test_rulesController_getDesiredRules_ruleSources(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	backend := extensions.IngressBackend{ServiceName: "service", ServicePort: intstr.FromInt(80)}
+	ingressRule := func(host string) extensions.IngressRule {
+		return extensions.IngressRule{
+			Host: host,
+			IngressRuleValue: extensions.IngressRuleValue{
+				HTTP: &extensions.HTTPIngressRuleValue{
+					Paths: []extensions.HTTPIngressPath{{Backend: backend}},
+				},
+			},
+		}
+	}
+	public := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "public"}}
+	authenticated := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "authenticated"}}
+	merged := extensions.Ingress{Spec: extensions.IngressSpec{Rules: []extensions.IngressRule{ingressRule("public.example.com"), ingressRule("private.example.com")}}}
+	cognito := auth.Config{Type: auth.TypeCognito, IDPCognito: auth.IDPCognito{UserPoolArn: "arn"}}
+
+	// the authentication of each rule is the one of the member of the ingress group that declared it.
+	mockAuthModule := mock_auth.NewMockModule(ctrl)
+	mockAuthModule.EXPECT().NewConfig(gomock.Any(), public, backend, gomock.Any()).Return(auth.Config{Type: auth.TypeNone}, nil)
+	mockAuthModule.EXPECT().NewConfig(gomock.Any(), authenticated, backend, gomock.Any()).Return(cognito, nil)
+	c := &rulesController{
+		cloud:      &mocks.CloudAPI{},
+		authModule: mockAuthModule,
+	}
+	ingressAnnos := annotations.Ingress{
+		Action:     &action.Config{},
+		Conditions: &conditions.Config{},
+	}
+	tgGroup := tg.TargetGroupGroup{
+		TGByBackend: map[extensions.IngressBackend]tg.TargetGroup{backend: {Arn: "tgArn"}},
+	}
+	ctx := albctx.SetRuleSources(context.Background(), albctx.RuleSources{Rules: []*extensions.Ingress{public, authenticated}})
+
+	got, _, err := c.getDesiredRules(ctx, &elbv2.Listener{}, &merged, &ingressAnnos, tgGroup)
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.Len(t, got[0].Actions, 1)
+	assert.Len(t, got[1].Actions, 2)
+	assert.Equal(t, elbv2.ActionTypeEnumAuthenticateCognito, aws.StringValue(got[1].Actions[0].Type))
+}
+
+func Test_rulesController_getDesiredRules_ruleOrder(t *testing.T) {
+	backend := extensions.IngressBackend{ServiceName: "service", ServicePort: intstr.FromInt(80)}
+	ingressRule := func(host string, paths ...string) extensions.IngressRule {
+		rule := extensions.IngressRule{
+			Host:             host,
+			IngressRuleValue: extensions.IngressRuleValue{HTTP: &extensions.HTTPIngressRuleValue{}},
+		}
+		for _, path := range paths {
+			rule.HTTP.Paths = append(rule.HTTP.Paths, extensions.HTTPIngressPath{Path: path, Backend: backend})
+		}
+		return rule
+	}
+
+	for _, tc := range []struct {
+		name             string
+		ruleOrder        string
+		pathType         string
+		rules            []extensions.IngressRule
+		noDefaultBackend bool
+		expectedPaths    [][]string
+	}{
+		{
+			name:          "rules keep the order of the ingress by default",
+			ruleOrder:     conditions.RuleOrderIngress,
+			rules:         []extensions.IngressRule{ingressRule("", "/*", "/api/*", "/api/v1/*")},
+			expectedPaths: [][]string{{"/*"}, {"/api/*"}, {"/api/v1/*"}},
+		},
+		{
+			name:          "more specific paths come first",
+			ruleOrder:     conditions.RuleOrderSpecificity,
+			rules:         []extensions.IngressRule{ingressRule("", "/*", "/api/*", "/health", "/api/v1/*")},
+			expectedPaths: [][]string{{"/health"}, {"/api/v1/*"}, {"/api/*"}, {"/*"}},
+		},
+		{
+			name:          "reordered paths get the same priorities",
+			ruleOrder:     conditions.RuleOrderSpecificity,
+			rules:         []extensions.IngressRule{ingressRule("", "/api/v1/*", "/health", "/*", "/api/*")},
+			expectedPaths: [][]string{{"/health"}, {"/api/v1/*"}, {"/api/*"}, {"/*"}},
+		},
+		{
+			name:          "rules with a host come before the same path without one",
+			ruleOrder:     conditions.RuleOrderSpecificity,
+			rules:         []extensions.IngressRule{ingressRule("", "/*"), ingressRule("b.example.com", "/*"), ingressRule("a.example.com", "/*")},
+			expectedPaths: [][]string{{"a.example.com", "/*"}, {"b.example.com", "/*"}, {"/*"}},
+		},
+		{
+			name:          "prefix paths are ranked by their literal part",
+			ruleOrder:     conditions.RuleOrderSpecificity,
+			pathType:      conditions.PathTypePrefix,
+			rules:         []extensions.IngressRule{ingressRule("", "/", "/api", "/api/v1")},
+			expectedPaths: [][]string{{"/api/v1", "/api/v1/*"}, {"/api", "/api/*"}, {"/*"}},
+		},
+		{
+			name:             "the last catch-all path is left to the default action",
+			ruleOrder:        conditions.RuleOrderIngress,
+			rules:            []extensions.IngressRule{ingressRule("", "/api/*", "/*")},
+			noDefaultBackend: true,
+			expectedPaths:    [][]string{{"/api/*"}},
+		},
+		{
+			name:             "a catch-all path followed by other paths stays a rule",
+			ruleOrder:        conditions.RuleOrderIngress,
+			rules:            []extensions.IngressRule{ingressRule("", "/*", "/api/*")},
+			noDefaultBackend: true,
+			expectedPaths:    [][]string{{"/*"}, {"/api/*"}},
+		},
+		{
+			name:             "a catch-all path anywhere is left to the default action when ordered by specificity",
+			ruleOrder:        conditions.RuleOrderSpecificity,
+			rules:            []extensions.IngressRule{ingressRule("", "/*", "/api/*", "/health")},
+			noDefaultBackend: true,
+			expectedPaths:    [][]string{{"/health"}, {"/api/*"}},
+		},
+		{
+			name:             "a catch-all path with a host stays a rule",
+			ruleOrder:        conditions.RuleOrderIngress,
+			rules:            []extensions.IngressRule{ingressRule("a.example.com", "/*")},
+			noDefaultBackend: true,
+			expectedPaths:    [][]string{{"a.example.com", "/*"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ingress := extensions.Ingress{Spec: extensions.IngressSpec{Rules: tc.rules}}
+			if !tc.noDefaultBackend {
+				ingress.Spec.Backend = &extensions.IngressBackend{ServiceName: "default-service", ServicePort: intstr.FromInt(80)}
+			}
+			mockAuthModule := mock_auth.NewMockModule(ctrl)
+			mockAuthModule.EXPECT().NewConfig(gomock.Any(), &ingress, backend, gomock.Any()).Return(auth.Config{Type: auth.TypeNone}, nil).AnyTimes()
+			c := &rulesController{
+				cloud:      &mocks.CloudAPI{},
+				authModule: mockAuthModule,
+			}
+			ingressAnnos := annotations.Ingress{
+				Action:     &action.Config{},
+				Conditions: &conditions.Config{PathType: tc.pathType, RuleOrder: tc.ruleOrder},
+			}
+			tgGroup := tg.TargetGroupGroup{
+				TGByBackend: map[extensions.IngressBackend]tg.TargetGroup{backend: {Arn: "tgArn"}},
+			}
+
+			got, _, err := c.getDesiredRules(context.Background(), &elbv2.Listener{}, &ingress, &ingressAnnos, tgGroup)
+			assert.NoError(t, err)
+			var paths [][]string
+			for i, rule := range got {
+				assert.Equal(t, strconv.Itoa(i+1), aws.StringValue(rule.Priority))
+				var values []string
+				for _, condition := range rule.Conditions {
+					switch aws.StringValue(condition.Field) {
+					case conditions.FieldHostHeader:
+						values = append(values, aws.StringValueSlice(condition.HostHeaderConfig.Values)...)
+					case conditions.FieldPathPattern:
+						values = append(values, aws.StringValueSlice(condition.PathPatternConfig.Values)...)
+					}
+				}
+				paths = append(paths, values)
+			}
+			assert.Equal(t, tc.expectedPaths, paths)
+		})
+	}
+}
+
+func Test_pathPatterns(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		pathType      string
+		path          string
+		expected      []string
+		expectedError error
+	}{
+		{
+			name:     "ImplementationSpecific path is a path pattern",
+			pathType: conditions.PathTypeImplementationSpecific,
+			path:     "/api/*",
+			expected: []string{"/api/*"},
+		},
+		{
+			name:     "path without path type is a path pattern",
+			path:     "/api/*",
+			expected: []string{"/api/*"},
+		},
+		{
+			name:     "Exact path matches only itself",
+			pathType: conditions.PathTypeExact,
+			path:     "/api",
+			expected: []string{"/api"},
+		},
+		{
+			name:     "Prefix path matches itself and the paths below it",
+			pathType: conditions.PathTypePrefix,
+			path:     "/api",
+			expected: []string{"/api", "/api/*"},
+		},
+		{
+			name:     "Prefix path ignores its trailing slash",
+			pathType: conditions.PathTypePrefix,
+			path:     "/api/",
+			expected: []string{"/api", "/api/*"},
+		},
+		{
+			name:     "Prefix root path matches every path",
+			pathType: conditions.PathTypePrefix,
+			path:     "/",
+			expected: []string{"/*"},
+		},
+		{
+			name:          "Exact path can't contain wildcards",
+			pathType:      conditions.PathTypeExact,
+			path:          "/api/*",
+			expectedError: errors.New("Exact path /api/* can't contain wildcards, use path type ImplementationSpecific for path patterns"),
+		},
+		{
+			name:          "Prefix path can't contain wildcards",
+			pathType:      conditions.PathTypePrefix,
+			path:          "/ap?",
+			expectedError: errors.New("Prefix path /ap? can't contain wildcards, use path type ImplementationSpecific for path patterns"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := pathPatterns(tc.pathType, tc.path)
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+			// AWS may return the values in another order, which mustn't cause the rule to be modified again.
+			reversed := make([]string, len(got))
+			for i := range got {
+				reversed[len(got)-1-i] = got[i]
+			}
+			assert.True(t, pathPatternConditionConfigMatches(
+				&elbv2.PathPatternConditionConfig{Values: aws.StringSlice(got)},
+				&elbv2.PathPatternConditionConfig{Values: aws.StringSlice(reversed)}))
+		})
+	}
+}
+
+func Test_rulesController_getDesiredRules_sslRedirect(t *testing.T) {
+	ingress := extensions.Ingress{
+		Spec: extensions.IngressSpec{
+			Rules: []extensions.IngressRule{
+				{
+					IngressRuleValue: extensions.IngressRuleValue{
+						HTTP: &extensions.HTTPIngressRuleValue{
+							Paths: []extensions.HTTPIngressPath{
+								{
+									Path: "/*",
+									Backend: extensions.IngressBackend{
+										ServiceName: "fixed-response",
+										ServicePort: intstr.FromString(action.UseActionAnnotation),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	ingressAnnos := annotations.Ingress{
+		Action: &action.Config{
+			Actions: map[string]action.Action{
+				"fixed-response": {
+					Type: aws.String(elbv2.ActionTypeEnumFixedResponse),
+					FixedResponseConfig: &action.FixedResponseActionConfig{
+						StatusCode: aws.String("404"),
+					},
+				},
+			},
+		},
+		Conditions: &conditions.Config{},
+		LoadBalancer: &loadbalancer.Config{
+			SSLRedirectPort: aws.Int64(443),
+		},
+	}
+
+	for _, tc := range []struct {
+		name          string
+		protocol      string
+		expectedRules int
+	}{
+		{
+			name:          "http listener redirects through its default action only",
+			protocol:      elbv2.ProtocolEnumHttp,
+			expectedRules: 0,
+		},
+		{
+			name:          "https listener keeps its rules",
+			protocol:      elbv2.ProtocolEnumHttps,
+			expectedRules: 1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAuthModule := mock_auth.NewMockModule(ctrl)
+			mockAuthModule.EXPECT().NewConfig(gomock.Any(), &ingress, gomock.Any(), gomock.Any()).Return(auth.Config{Type: auth.TypeNone}, nil).AnyTimes()
+			c := &rulesController{
+				cloud:      &mocks.CloudAPI{},
+				authModule: mockAuthModule,
+			}
+
+			got, _, err := c.getDesiredRules(context.Background(), &elbv2.Listener{Protocol: aws.String(tc.protocol)}, &ingress, &ingressAnnos, tg.TargetGroupGroup{})
+			assert.NoError(t, err)
+			assert.Len(t, got, tc.expectedRules)
+		})
+	}
+}
+
 type GetRulesCall struct {
 	Output []*elbv2.Rule
 	Error  error
@@ -2575,6 +3140,75 @@ func Test_reconcileRules(t *testing.T) {
 	}
 }
 
+func Test_keepRulesOfUnavailableAuth(t *testing.T) {
+	rule := func(priority string, path string, action *elbv2.Action) elbv2.Rule {
+		return elbv2.Rule{
+			Priority: aws.String(priority),
+			Conditions: []*elbv2.RuleCondition{
+				{
+					Field:             aws.String(conditions.FieldPathPattern),
+					PathPatternConfig: &elbv2.PathPatternConditionConfig{Values: aws.StringSlice([]string{path})},
+				},
+			},
+			Actions: []*elbv2.Action{action},
+		}
+	}
+	authenticate := &elbv2.Action{Type: aws.String(elbv2.ActionTypeEnumAuthenticateOidc)}
+	forward := &elbv2.Action{Type: aws.String(elbv2.ActionTypeEnumForward)}
+	unavailable := buildAuthUnavailableAction()
+
+	for _, tc := range []struct {
+		name            string
+		current         []elbv2.Rule
+		desired         []elbv2.Rule
+		authUnavailable sets.String
+		expectedKept    int
+		expectedCurrent []elbv2.Rule
+		expectedDesired []elbv2.Rule
+	}{
+		{
+			name:            "authentication config of every rule built",
+			current:         []elbv2.Rule{rule("1", "/secure", authenticate)},
+			desired:         []elbv2.Rule{rule("1", "/secure", authenticate)},
+			authUnavailable: sets.NewString(),
+			expectedCurrent: []elbv2.Rule{rule("1", "/secure", authenticate)},
+			expectedDesired: []elbv2.Rule{rule("1", "/secure", authenticate)},
+		},
+		{
+			name:            "rule in AWS is kept as is",
+			current:         []elbv2.Rule{rule("1", "/secure", authenticate), rule("2", "/open", forward)},
+			desired:         []elbv2.Rule{rule("1", "/secure", unavailable), rule("2", "/open", forward)},
+			authUnavailable: sets.NewString("1"),
+			expectedKept:    1,
+			expectedCurrent: []elbv2.Rule{rule("2", "/open", forward)},
+			expectedDesired: []elbv2.Rule{rule("2", "/open", forward)},
+		},
+		{
+			name:            "rule without a counterpart in AWS responds 503",
+			current:         []elbv2.Rule{rule("1", "/open", forward)},
+			desired:         []elbv2.Rule{rule("1", "/secure", unavailable), rule("2", "/open", forward)},
+			authUnavailable: sets.NewString("1"),
+			expectedCurrent: []elbv2.Rule{rule("1", "/open", forward)},
+			expectedDesired: []elbv2.Rule{rule("1", "/secure", unavailable), rule("2", "/open", forward)},
+		},
+		{
+			name:            "other rules are numbered around the kept rule",
+			current:         []elbv2.Rule{rule("1", "/secure", authenticate)},
+			desired:         []elbv2.Rule{rule("1", "/new", forward), rule("2", "/secure", unavailable), rule("3", "/open", forward)},
+			authUnavailable: sets.NewString("2"),
+			expectedKept:    1,
+			expectedDesired: []elbv2.Rule{rule("2", "/new", forward), rule("3", "/open", forward)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			kept, current, desired := keepRulesOfUnavailableAuth(tc.current, tc.desired, tc.authUnavailable)
+			assert.Equal(t, tc.expectedKept, kept)
+			assert.Equal(t, tc.expectedCurrent, current)
+			assert.Equal(t, tc.expectedDesired, desired)
+		})
+	}
+}
+
 func Test_createsRedirectLoop(t *testing.T) {
 	for _, tc := range []struct {
 		name     string
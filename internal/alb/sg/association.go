@@ -10,6 +10,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
@@ -57,6 +58,10 @@ type AssociationController interface {
 	// Delete ensures the SecurityGroup created for LB are deleted.
 	// Also, if managed LB SecurityGroup is used, the SecurityGroups on worker nodes will be adjusted to remove inbound traffic permission from it.
 	Delete(ctx context.Context, ingKey types.NamespacedName) error
+
+	// Detach is Delete for LoadBalancers that outlive their ingress, such as adopted ones. The managed LB SecurityGroup
+	// stays attached to the LoadBalancer, only the inbound traffic permission granted to it on worker nodes is removed.
+	Detach(ctx context.Context, ingKey types.NamespacedName) error
 }
 
 // NewAssociationController constructs a new association controller
@@ -141,6 +146,13 @@ func (c *associationController) Delete(ctx context.Context, ingKey types.Namespa
 	return nil
 }
 
+func (c *associationController) Detach(ctx context.Context, ingKey types.NamespacedName) error {
+	if err := c.instanceAttachmentController.Delete(ctx, ingKey); err != nil {
+		return errors.Wrap(err, "failed to delete instance securityGroup attachment")
+	}
+	return nil
+}
+
 func (c *associationController) reconcileWithExternalSGs(ctx context.Context, ingKey types.NamespacedName, lbInstance *elbv2.LoadBalancer, lbExternalSGIDs []string) error {
 	if err := c.lbAttachmentController.Reconcile(ctx, lbInstance, lbExternalSGIDs); err != nil {
 		return errors.Wrap(err, "failed to reconcile external LoadBalancer securityGroup attachment")
@@ -228,7 +240,12 @@ func (c *associationController) deleteLBManagedSG(ctx context.Context, ingKey ty
 	}
 
 	albctx.GetLogger(ctx).Infof("deleting securityGroup %v:%v", aws.StringValue(sgInstance.GroupName), aws.StringValue(sgInstance.Description))
-	return c.cloud.DeleteSecurityGroupByID(ctx, aws.StringValue(sgInstance.GroupId))
+	if err := c.cloud.DeleteSecurityGroupByID(ctx, aws.StringValue(sgInstance.GroupId)); err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to delete securityGroup %v due to %v", aws.StringValue(sgInstance.GroupName), err)
+		return err
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonDelete, "securityGroup %v deleted", aws.StringValue(sgInstance.GroupName))
+	return nil
 }
 
 func (c *associationController) buildAssociationConfig(ctx context.Context, ingKey types.NamespacedName) (associationConfig, error) {
@@ -10,6 +10,7 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
@@ -108,7 +109,12 @@ func (c *instanceAttachmentControllerV1) Delete(ctx context.Context, ingKey type
 		}
 	}
 	albctx.GetLogger(ctx).Infof("deleting securityGroup %v:%v", aws.StringValue(sgInstance.GroupName), aws.StringValue(sgInstance.Description))
-	return c.cloud.DeleteSecurityGroupByID(ctx, aws.StringValue(sgInstance.GroupId))
+	if err := c.cloud.DeleteSecurityGroupByID(ctx, aws.StringValue(sgInstance.GroupId)); err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to delete securityGroup %v due to %v", aws.StringValue(sgInstance.GroupName), err)
+		return err
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonDelete, "securityGroup %v deleted", aws.StringValue(sgInstance.GroupName))
+	return nil
 }
 
 func (c *instanceAttachmentControllerV1) ensureInstanceSG(ctx context.Context, ingKey types.NamespacedName, lbSGID string, additionalTags map[string]string) (string, error) {
@@ -170,11 +176,15 @@ func (c *instanceAttachmentControllerV1) ensureSGAttachedToENI(ctx context.Conte
 	}
 
 	albctx.GetLogger(ctx).Infof("attaching securityGroup %s to ENI %s", sgID, eniID)
-	_, err := c.cloud.ModifyNetworkInterfaceAttributeWithContext(ctx, &ec2.ModifyNetworkInterfaceAttributeInput{
+	if _, err := c.cloud.ModifyNetworkInterfaceAttributeWithContext(ctx, &ec2.ModifyNetworkInterfaceAttributeInput{
 		NetworkInterfaceId: aws.String(eniID),
 		Groups:             aws.StringSlice(desiredGroups),
-	})
-	return err
+	}); err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to attach securityGroup %v to ENI %v due to %v", sgID, eniID, err)
+		return err
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonModify, "securityGroup %v attached to ENI %v", sgID, eniID)
+	return nil
 }
 
 func (c *instanceAttachmentControllerV1) ensureSGDetachedFromENI(ctx context.Context, sgID string, eniID string, eniInfo ENIInfo) error {
@@ -192,9 +202,13 @@ func (c *instanceAttachmentControllerV1) ensureSGDetachedFromENI(ctx context.Con
 	}
 
 	albctx.GetLogger(ctx).Infof("detaching securityGroup %s from ENI %s", sgID, eniID)
-	_, err := c.cloud.ModifyNetworkInterfaceAttributeWithContext(ctx, &ec2.ModifyNetworkInterfaceAttributeInput{
+	if _, err := c.cloud.ModifyNetworkInterfaceAttributeWithContext(ctx, &ec2.ModifyNetworkInterfaceAttributeInput{
 		NetworkInterfaceId: aws.String(eniID),
 		Groups:             aws.StringSlice(desiredGroups),
-	})
-	return err
+	}); err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to detach securityGroup %v from ENI %v due to %v", sgID, eniID, err)
+		return err
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonModify, "securityGroup %v detached from ENI %v", sgID, eniID)
+	return nil
 }
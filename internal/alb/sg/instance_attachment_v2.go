@@ -11,6 +11,7 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
@@ -194,8 +195,10 @@ func (c *instanceAttachmentControllerV2) ensureLBSGAttachedToInstanceSG(ctx cont
 		GroupId:       instanceSG.GroupId,
 		IpPermissions: inboundPermissions,
 	}); err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to grant inbound permissions to securityGroup %v due to %v", aws.StringValue(instanceSG.GroupId), err)
 		return fmt.Errorf("failed to grant inbound permissions due to %v", err)
 	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonModify, "inbound permissions granted to securityGroup %v", aws.StringValue(instanceSG.GroupId))
 	return nil
 }
 
@@ -218,7 +221,9 @@ func (c *instanceAttachmentControllerV2) ensureLBSGDetachedFromInstanceSG(ctx co
 		GroupId:       instanceSG.GroupId,
 		IpPermissions: inboundPermissions,
 	}); err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to revoke inbound permissions from securityGroup %v due to %v", aws.StringValue(instanceSG.GroupId), err)
 		return fmt.Errorf("failed to revoke inbound permissions due to %v", err)
 	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonModify, "inbound permissions revoked from securityGroup %v", aws.StringValue(instanceSG.GroupId))
 	return nil
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
@@ -28,8 +29,10 @@ func (controller *lbAttachmentController) Reconcile(ctx context.Context, lbInsta
 			LoadBalancerArn: lbInstance.LoadBalancerArn,
 			SecurityGroups:  aws.StringSlice(desiredGroups.List()),
 		}); err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to modify securityGroups of %v due to %v", aws.StringValue(lbInstance.LoadBalancerArn), err)
 			return err
 		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonModify, "securityGroups of %v modified to %v", aws.StringValue(lbInstance.LoadBalancerArn), desiredGroups.List())
 	}
 	return nil
 }
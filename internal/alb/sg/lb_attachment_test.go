@@ -3,9 +3,11 @@ package sg
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
 	"github.com/magiconair/properties/assert"
@@ -24,6 +26,7 @@ func Test_LBAttachmentReconcile(t *testing.T) {
 
 		SetSecurityGroupsWithContextCall *SetSecurityGroupsWithContextCall
 		ExpectedError                    error
+		ExpectedEvents                   []string
 	}{
 		{
 			Name: "reconcile succeed without modify anything",
@@ -46,6 +49,7 @@ func Test_LBAttachmentReconcile(t *testing.T) {
 					SecurityGroups:  aws.StringSlice([]string{"sg-1", "sg-2"}),
 				},
 			},
+			ExpectedEvents: []string{"Normal MODIFY securityGroups of arn modified to [sg-1 sg-2]"},
 		},
 		{
 			Name: "reconcile failed when modify SG",
@@ -61,11 +65,15 @@ func Test_LBAttachmentReconcile(t *testing.T) {
 				},
 				Err: errors.New("SetSecurityGroupsWithContextCall"),
 			},
-			ExpectedError: errors.New("SetSecurityGroupsWithContextCall"),
+			ExpectedError:  errors.New("SetSecurityGroupsWithContextCall"),
+			ExpectedEvents: []string{"Warning ERROR failed to modify securityGroups of arn due to SetSecurityGroupsWithContextCall"},
 		},
 	} {
 		t.Run(tc.Name, func(t *testing.T) {
-			ctx := context.Background()
+			var events []string
+			ctx := albctx.SetEventf(context.Background(), func(eventType string, reason string, messageFmt string, args ...interface{}) {
+				events = append(events, fmt.Sprintf("%v %v %v", eventType, reason, fmt.Sprintf(messageFmt, args...)))
+			})
 			cloud := &mocks.CloudAPI{}
 			if tc.SetSecurityGroupsWithContextCall != nil {
 				cloud.On("SetSecurityGroupsWithContext", ctx, tc.SetSecurityGroupsWithContextCall.Input).Return(nil, tc.SetSecurityGroupsWithContextCall.Err)
@@ -77,6 +85,7 @@ func Test_LBAttachmentReconcile(t *testing.T) {
 
 			err := controller.Reconcile(ctx, &tc.Instance, tc.GroupIDs)
 			assert.Equal(t, err, tc.ExpectedError)
+			assert.Equal(t, events, tc.ExpectedEvents)
 			cloud.AssertExpectations(t)
 		})
 	}
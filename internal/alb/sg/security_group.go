@@ -9,6 +9,7 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // SecurityGroupController manages configuration on securityGroup.
@@ -39,8 +40,10 @@ func (c *securityGroupController) EnsureSGInstanceByName(ctx context.Context, na
 		Description: aws.String(description),
 	})
 	if err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to create securityGroup %v due to %v", name, err)
 		return nil, err
 	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonCreate, "securityGroup %v created, ID: %v", name, aws.StringValue(resp.GroupId))
 	return &ec2.SecurityGroup{
 		GroupId:   resp.GroupId,
 		GroupName: aws.String(name),
@@ -66,8 +69,10 @@ func (c *securityGroupController) reconcileInboundPermissions(ctx context.Contex
 			GroupId:       sgInstance.GroupId,
 			IpPermissions: permissionsToRevoke,
 		}); err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to revoke inbound permissions from securityGroup %v due to %v", aws.StringValue(sgInstance.GroupId), err)
 			return fmt.Errorf("failed to revoke inbound permissions due to %v", err)
 		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonModify, "inbound permissions revoked from securityGroup %v", aws.StringValue(sgInstance.GroupId))
 	}
 
 	permissionsToGrant := diffIPPermissions(inboundPermissions, sgInstance.IpPermissions)
@@ -77,8 +82,10 @@ func (c *securityGroupController) reconcileInboundPermissions(ctx context.Contex
 			GroupId:       sgInstance.GroupId,
 			IpPermissions: permissionsToGrant,
 		}); err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to grant inbound permissions to securityGroup %v due to %v", aws.StringValue(sgInstance.GroupId), err)
 			return fmt.Errorf("failed to grant inbound permissions due to %v", err)
 		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonModify, "inbound permissions granted to securityGroup %v", aws.StringValue(sgInstance.GroupId))
 	}
 
 	return nil
@@ -13,6 +13,10 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 )
 
+// KeyOwnership is the key of the tag the controller discovers the resources it manages by. Its value identifies the
+// controller, so that resources of other controllers, or not managed by Kubernetes at all, are never mistaken for its own.
+const KeyOwnership = "elbv2.k8s.aws/cluster"
+
 // Controller manages tags on a resource
 type Controller interface {
 	// ReconcileELB ensures the tag for ELB resources denoted by arn have specified tags.
@@ -45,7 +49,7 @@ func (c *controller) ReconcileELB(ctx context.Context, arn string, desiredTags m
 			ResourceArns: []*string{aws.String(arn)},
 			Tags:         ConvertToELBV2(modify),
 		}); err != nil {
-			albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "error tagging %s due to %s", arn, err)
+			albctx.GetEventf(ctx)(api.EventTypeWarning, albctx.EventReasonError, "error tagging %s due to %s", arn, err)
 			return err
 		}
 	}
@@ -56,7 +60,7 @@ func (c *controller) ReconcileELB(ctx context.Context, arn string, desiredTags m
 			ResourceArns: []*string{aws.String(arn)},
 			TagKeys:      aws.StringSlice(tagKeys),
 		}); err != nil {
-			albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "error tagging %s due to %s", arn, err)
+			albctx.GetEventf(ctx)(api.EventTypeWarning, albctx.EventReasonError, "error tagging %s due to %s", arn, err)
 			return err
 		}
 	}
@@ -71,7 +75,7 @@ func (c *controller) ReconcileEC2WithCurTags(ctx context.Context, resourceID str
 			Resources: []*string{aws.String(resourceID)},
 			Tags:      ConvertToEC2(modify),
 		}); err != nil {
-			albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "error tagging %s due to %s", resourceID, err)
+			albctx.GetEventf(ctx)(api.EventTypeWarning, albctx.EventReasonError, "error tagging %s due to %s", resourceID, err)
 			return err
 		}
 	}
@@ -81,7 +85,7 @@ func (c *controller) ReconcileEC2WithCurTags(ctx context.Context, resourceID str
 			Resources: []*string{aws.String(resourceID)},
 			Tags:      ConvertToEC2(remove),
 		}); err != nil {
-			albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "error tagging %s due to %s", resourceID, err)
+			albctx.GetEventf(ctx)(api.EventTypeWarning, albctx.EventReasonError, "error tagging %s due to %s", resourceID, err)
 			return err
 		}
 	}
@@ -13,19 +13,24 @@ import (
 )
 
 const (
-	DeregistrationDelayTimeoutSecondsKey = "deregistration_delay.timeout_seconds"
-	SlowStartDurationSecondsKey          = "slow_start.duration_seconds"
-	StickinessEnabledKey                 = "stickiness.enabled"
-	StickinessTypeKey                    = "stickiness.type"
-	StickinessLbCookieDurationSecondsKey = "stickiness.lb_cookie.duration_seconds"
-	LoadBalancingAlgorithmTypeKey        = "load_balancing.algorithm.type"
-
-	DeregistrationDelayTimeoutSeconds = 300
-	SlowStartDurationSeconds          = 0
-	StickinessEnabled                 = false
-	StickinessType                    = "lb_cookie"
-	StickinessLbCookieDurationSeconds = 86400
-	LoadBalancingAlgorithmType        = "round_robin"
+	DeregistrationDelayTimeoutSecondsKey  = "deregistration_delay.timeout_seconds"
+	SlowStartDurationSecondsKey           = "slow_start.duration_seconds"
+	StickinessEnabledKey                  = "stickiness.enabled"
+	StickinessTypeKey                     = "stickiness.type"
+	StickinessLbCookieDurationSecondsKey  = "stickiness.lb_cookie.duration_seconds"
+	StickinessAppCookieNameKey            = "stickiness.app_cookie.cookie_name"
+	StickinessAppCookieDurationSecondsKey = "stickiness.app_cookie.duration_seconds"
+	LoadBalancingAlgorithmTypeKey         = "load_balancing.algorithm.type"
+	ProxyProtocolV2EnabledKey             = "proxy_protocol_v2.enabled"
+
+	DeregistrationDelayTimeoutSeconds  = 300
+	SlowStartDurationSeconds           = 0
+	StickinessEnabled                  = false
+	StickinessType                     = "lb_cookie"
+	StickinessLbCookieDurationSeconds  = 86400
+	StickinessAppCookieName            = ""
+	StickinessAppCookieDurationSeconds = 86400
+	LoadBalancingAlgorithmType         = "round_robin"
 )
 
 // Attributes represents the desired state of attributes for a target group.
@@ -47,8 +52,8 @@ type Attributes struct {
 	// The value is true or false. The default is false.
 	StickinessEnabled bool
 
-	// StickinessType: stickiness.type - The type of sticky sessions. The possible value is
-	// lb_cookie.
+	// StickinessType: stickiness.type - The type of sticky sessions. The possible values are
+	// lb_cookie and app_cookie.
 	StickinessType string
 
 	// StickinessLbCookieDurationSeconds: stickiness.lb_cookie.duration_seconds - The time period, in seconds,
@@ -58,6 +63,15 @@ type Attributes struct {
 	// default value is 1 day (86400 seconds).
 	StickinessLbCookieDurationSeconds int64
 
+	// StickinessAppCookieName: stickiness.app_cookie.cookie_name - The name of the application-based
+	// cookie used for sticky sessions when stickiness.type is app_cookie.
+	StickinessAppCookieName string
+
+	// StickinessAppCookieDurationSeconds: stickiness.app_cookie.duration_seconds - The time period, in seconds,
+	// during which requests from a client should be routed to the same target when stickiness.type is
+	// app_cookie. The range is 1 second to 1 week (604800 seconds). The default value is 1 day (86400 seconds).
+	StickinessAppCookieDurationSeconds int64
+
 	// LoadBalancingAlgorithmType: load_balancing.algorithm.type - The load balancing algorithm determines
 	// how the load balancer selects targets when routing requests. The value is round_robin or
 	// least_outstanding_requests. The default is round_robin.
@@ -66,12 +80,14 @@ type Attributes struct {
 
 func NewAttributes(attrs []*elbv2.TargetGroupAttribute) (a *Attributes, err error) {
 	a = &Attributes{
-		DeregistrationDelayTimeoutSeconds: DeregistrationDelayTimeoutSeconds,
-		SlowStartDurationSeconds:          SlowStartDurationSeconds,
-		StickinessEnabled:                 StickinessEnabled,
-		StickinessType:                    StickinessType,
-		StickinessLbCookieDurationSeconds: StickinessLbCookieDurationSeconds,
-		LoadBalancingAlgorithmType:        LoadBalancingAlgorithmType,
+		DeregistrationDelayTimeoutSeconds:  DeregistrationDelayTimeoutSeconds,
+		SlowStartDurationSeconds:           SlowStartDurationSeconds,
+		StickinessEnabled:                  StickinessEnabled,
+		StickinessType:                     StickinessType,
+		StickinessLbCookieDurationSeconds:  StickinessLbCookieDurationSeconds,
+		StickinessAppCookieName:            StickinessAppCookieName,
+		StickinessAppCookieDurationSeconds: StickinessAppCookieDurationSeconds,
+		LoadBalancingAlgorithmType:         LoadBalancingAlgorithmType,
 	}
 	var e error
 	for _, attr := range attrs {
@@ -100,7 +116,7 @@ func NewAttributes(attrs []*elbv2.TargetGroupAttribute) (a *Attributes, err erro
 			}
 		case StickinessTypeKey:
 			a.StickinessType = attrValue
-			if attrValue != "lb_cookie" {
+			if attrValue != "lb_cookie" && attrValue != "app_cookie" {
 				return a, fmt.Errorf("invalid target group attribute value %s=%s", attrKey, attrValue)
 			}
 		case StickinessLbCookieDurationSecondsKey:
@@ -111,15 +127,42 @@ func NewAttributes(attrs []*elbv2.TargetGroupAttribute) (a *Attributes, err erro
 			if a.StickinessLbCookieDurationSeconds < 1 || a.StickinessLbCookieDurationSeconds > 604800 {
 				return a, fmt.Errorf("%s must be within 1-604800 seconds, not %v", attrKey, attrValue)
 			}
+		case StickinessAppCookieNameKey:
+			a.StickinessAppCookieName = attrValue
+		case StickinessAppCookieDurationSecondsKey:
+			a.StickinessAppCookieDurationSeconds, err = strconv.ParseInt(attrValue, 10, 64)
+			if err != nil {
+				return a, fmt.Errorf("invalid target group attribute value %s=%s", attrKey, attrValue)
+			}
+			if a.StickinessAppCookieDurationSeconds < 1 || a.StickinessAppCookieDurationSeconds > 604800 {
+				return a, fmt.Errorf("%s must be within 1-604800 seconds, not %v", attrKey, attrValue)
+			}
 		case LoadBalancingAlgorithmTypeKey:
 			a.LoadBalancingAlgorithmType = attrValue
 			if attrValue != "round_robin" && attrValue != "least_outstanding_requests" {
+				return a, fmt.Errorf("%s must be round_robin or least_outstanding_requests, not %v", attrKey, attrValue)
+			}
+		case ProxyProtocolV2EnabledKey:
+			// proxy protocol v2 is only available on TCP/TLS target groups of Network Load Balancers,
+			// ELBv2 rejects it on the HTTP/HTTPS target groups this controller manages.
+			enabled, err := strconv.ParseBool(attrValue)
+			if err != nil {
 				return a, fmt.Errorf("invalid target group attribute value %s=%s", attrKey, attrValue)
 			}
+			if enabled {
+				return a, fmt.Errorf("%s is only supported by Network Load Balancer target groups, not by ALB target groups", attrKey)
+			}
 		default:
 			e = NewInvalidAttribute(attrKey)
 		}
 	}
+	if a.StickinessEnabled && a.StickinessType == "app_cookie" && a.StickinessAppCookieName == "" {
+		return a, fmt.Errorf("%s must be specified when %s is app_cookie", StickinessAppCookieNameKey, StickinessTypeKey)
+	}
+	// ELBv2 rejects slow start on targetGroups that route by least outstanding requests.
+	if a.SlowStartDurationSeconds != 0 && a.LoadBalancingAlgorithmType == "least_outstanding_requests" {
+		return a, fmt.Errorf("%s cannot be combined with %s=least_outstanding_requests", SlowStartDurationSecondsKey, LoadBalancingAlgorithmTypeKey)
+	}
 	return a, e
 }
 
@@ -158,15 +201,23 @@ func (c *attributesController) Reconcile(ctx context.Context, tgArn string, attr
 
 	changeSet := attributesChangeSet(current, desired)
 	if len(changeSet) > 0 {
+		currentValues := make(map[string]string, len(raw.Attributes))
+		for _, attr := range raw.Attributes {
+			currentValues[aws.StringValue(attr.Key)] = aws.StringValue(attr.Value)
+		}
+		for _, attr := range changeSet {
+			albctx.GetLogger(ctx).Debugf("TargetGroup %v attribute %v needs modification: %v => %v", tgArn, aws.StringValue(attr.Key), currentValues[aws.StringValue(attr.Key)], aws.StringValue(attr.Value))
+		}
 		albctx.GetLogger(ctx).Infof("Modifying TargetGroup %v attributes to %v.", tgArn, log.Prettify(changeSet))
 		_, err = c.cloud.ModifyTargetGroupAttributesWithContext(ctx, &elbv2.ModifyTargetGroupAttributesInput{
 			TargetGroupArn: aws.String(tgArn),
 			Attributes:     changeSet,
 		})
 		if err != nil {
-			albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "%s attributes modification failed: %s", tgArn, err.Error())
+			albctx.GetEventf(ctx)(api.EventTypeWarning, albctx.EventReasonError, "%s attributes modification failed: %s", tgArn, err.Error())
 			return err
 		}
+		albctx.GetEventf(ctx)(api.EventTypeNormal, albctx.EventReasonModify, "%s attributes modified", tgArn)
 	}
 	return nil
 }
@@ -193,6 +244,14 @@ func attributesChangeSet(a, b *Attributes) (changeSet []*elbv2.TargetGroupAttrib
 		changeSet = append(changeSet, tgAttribute(StickinessLbCookieDurationSecondsKey, fmt.Sprintf("%v", b.StickinessLbCookieDurationSeconds)))
 	}
 
+	if a.StickinessAppCookieName != b.StickinessAppCookieName {
+		changeSet = append(changeSet, tgAttribute(StickinessAppCookieNameKey, b.StickinessAppCookieName))
+	}
+
+	if a.StickinessAppCookieDurationSeconds != b.StickinessAppCookieDurationSeconds {
+		changeSet = append(changeSet, tgAttribute(StickinessAppCookieDurationSecondsKey, fmt.Sprintf("%v", b.StickinessAppCookieDurationSeconds)))
+	}
+
 	if a.LoadBalancingAlgorithmType != b.LoadBalancingAlgorithmType {
 		changeSet = append(changeSet, tgAttribute(LoadBalancingAlgorithmTypeKey, b.LoadBalancingAlgorithmType))
 	}
@@ -138,6 +138,89 @@ func Test_NewAttributes(t *testing.T) {
 			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(StickinessLbCookieDurationSecondsKey, "error")},
 		},
 
+		{
+			name:       "StickinessTypeKey is app_cookie",
+			ok:         true,
+			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(StickinessTypeKey, "app_cookie")},
+			output:     MustNewAttributes([]*elbv2.TargetGroupAttribute{tgAttribute(StickinessTypeKey, "app_cookie")}),
+		},
+		{
+			name: "StickinessTypeKey is app_cookie with a cookie name",
+			ok:   true,
+			attributes: []*elbv2.TargetGroupAttribute{
+				tgAttribute(StickinessEnabledKey, "true"),
+				tgAttribute(StickinessTypeKey, "app_cookie"),
+				tgAttribute(StickinessAppCookieNameKey, "SESSIONID"),
+			},
+			output: MustNewAttributes([]*elbv2.TargetGroupAttribute{
+				tgAttribute(StickinessEnabledKey, "true"),
+				tgAttribute(StickinessTypeKey, "app_cookie"),
+				tgAttribute(StickinessAppCookieNameKey, "SESSIONID"),
+			}),
+		},
+		{
+			name: "StickinessTypeKey is app_cookie without a cookie name",
+			ok:   false,
+			attributes: []*elbv2.TargetGroupAttribute{
+				tgAttribute(StickinessEnabledKey, "true"),
+				tgAttribute(StickinessTypeKey, "app_cookie"),
+			},
+		},
+
+		{
+			name: "SlowStartDurationSecondsKey with round_robin",
+			ok:   true,
+			attributes: []*elbv2.TargetGroupAttribute{
+				tgAttribute(SlowStartDurationSecondsKey, "120"),
+				tgAttribute(LoadBalancingAlgorithmTypeKey, "round_robin"),
+			},
+			output: MustNewAttributes([]*elbv2.TargetGroupAttribute{
+				tgAttribute(SlowStartDurationSecondsKey, "120"),
+				tgAttribute(LoadBalancingAlgorithmTypeKey, "round_robin"),
+			}),
+		},
+		{
+			name: "SlowStartDurationSecondsKey with least_outstanding_requests",
+			ok:   false,
+			attributes: []*elbv2.TargetGroupAttribute{
+				tgAttribute(SlowStartDurationSecondsKey, "120"),
+				tgAttribute(LoadBalancingAlgorithmTypeKey, "least_outstanding_requests"),
+			},
+		},
+		{
+			name: "SlowStartDurationSecondsKey disabled with least_outstanding_requests",
+			ok:   true,
+			attributes: []*elbv2.TargetGroupAttribute{
+				tgAttribute(SlowStartDurationSecondsKey, "0"),
+				tgAttribute(LoadBalancingAlgorithmTypeKey, "least_outstanding_requests"),
+			},
+			output: MustNewAttributes([]*elbv2.TargetGroupAttribute{
+				tgAttribute(LoadBalancingAlgorithmTypeKey, "least_outstanding_requests"),
+			}),
+		},
+
+		{
+			name:       "StickinessAppCookieDurationSecondsKey is default",
+			ok:         true,
+			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(StickinessAppCookieDurationSecondsKey, "86400")},
+			output:     MustNewAttributes([]*elbv2.TargetGroupAttribute{tgAttribute(StickinessAppCookieDurationSecondsKey, "86400")}),
+		},
+		{
+			name:       "StickinessAppCookieDurationSecondsKey is > 604800",
+			ok:         false,
+			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(StickinessAppCookieDurationSecondsKey, "604801")},
+		},
+		{
+			name:       "StickinessAppCookieDurationSecondsKey is < 1",
+			ok:         false,
+			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(StickinessAppCookieDurationSecondsKey, "0")},
+		},
+		{
+			name:       "StickinessAppCookieDurationSecondsKey is not a number",
+			ok:         false,
+			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(StickinessAppCookieDurationSecondsKey, "error")},
+		},
+
 		{
 			name:       "LoadBalancingAlgorithmTypeKey is default",
 			ok:         true,
@@ -150,6 +233,23 @@ func Test_NewAttributes(t *testing.T) {
 			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(LoadBalancingAlgorithmTypeKey, "error")},
 		},
 
+		{
+			name:       "ProxyProtocolV2EnabledKey is false",
+			ok:         true,
+			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(ProxyProtocolV2EnabledKey, "false")},
+			output:     MustNewAttributes(nil),
+		},
+		{
+			name:       "ProxyProtocolV2EnabledKey is true",
+			ok:         false,
+			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(ProxyProtocolV2EnabledKey, "true")},
+		},
+		{
+			name:       "ProxyProtocolV2EnabledKey is non-bool",
+			ok:         false,
+			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(ProxyProtocolV2EnabledKey, "not a bool")},
+		},
+
 		{
 			name:       "Invalid attribute",
 			ok:         false,
@@ -274,6 +374,19 @@ func Test_attributesChangeSet(t *testing.T) {
 			changeSet: []*elbv2.TargetGroupAttribute{tgAttribute(StickinessTypeKey, "lb_cookie")},
 		},
 
+		{
+			name: "StickinessAppCookie: a=default b=nondefault",
+			a:    MustNewAttributes(nil),
+			b: MustNewAttributes([]*elbv2.TargetGroupAttribute{
+				tgAttribute(StickinessAppCookieNameKey, "SESSIONID"),
+				tgAttribute(StickinessAppCookieDurationSecondsKey, "60"),
+			}),
+			changeSet: []*elbv2.TargetGroupAttribute{
+				tgAttribute(StickinessAppCookieNameKey, "SESSIONID"),
+				tgAttribute(StickinessAppCookieDurationSecondsKey, "60"),
+			},
+		},
+
 		{
 			name: "StickinessLbCookieDurationSeconds: a=default b=default",
 			a:    MustNewAttributes([]*elbv2.TargetGroupAttribute{tgAttribute(StickinessLbCookieDurationSecondsKey, "86400")}),
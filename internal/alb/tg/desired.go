@@ -0,0 +1,119 @@
+package tg
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// DesiredTargetGroup is the targetGroup the controller configures for a service backend of an ingress.
+type DesiredTargetGroup struct {
+	Name            string `json:"name"`
+	ServiceName     string `json:"serviceName"`
+	ServicePort     string `json:"servicePort"`
+	TargetType      string `json:"targetType"`
+	Protocol        string `json:"protocol"`
+	ProtocolVersion string `json:"protocolVersion"`
+	IPAddressType   string `json:"ipAddressType"`
+
+	HealthCheckPath            *string `json:"healthCheckPath,omitempty"`
+	HealthCheckPort            string  `json:"healthCheckPort"`
+	HealthCheckProtocol        *string `json:"healthCheckProtocol,omitempty"`
+	HealthCheckIntervalSeconds *int64  `json:"healthCheckIntervalSeconds,omitempty"`
+	HealthCheckTimeoutSeconds  *int64  `json:"healthCheckTimeoutSeconds,omitempty"`
+	HealthyThresholdCount      *int64  `json:"healthyThresholdCount,omitempty"`
+	UnhealthyThresholdCount    *int64  `json:"unhealthyThresholdCount,omitempty"`
+	SuccessCodes               *string `json:"successCodes,omitempty"`
+
+	Attributes []*elbv2.TargetGroupAttribute `json:"attributes,omitempty"`
+	Tags       map[string]string             `json:"tags"`
+}
+
+// BuildDesiredTargetGroups computes the targetGroups of ingress from the objects in store, without calling AWS.
+// As targetGroup ARNs aren't known before the targetGroups are created, the returned TargetGroupGroup
+// holds the name of each targetGroup in place of its ARN.
+func BuildDesiredTargetGroups(store store.Storer, nameTagGen NameTagGenerator, ingress *extensions.Ingress) ([]DesiredTargetGroup, TargetGroupGroup, error) {
+	serviceBackends, externalTGARNs, err := ExtractTargetGroupBackends(ingress)
+	if err != nil {
+		return nil, TargetGroupGroup{}, err
+	}
+	controller := &defaultController{
+		store:      store,
+		nameTagGen: nameTagGen,
+	}
+
+	var desired []DesiredTargetGroup
+	tgByBackend := make(map[extensions.IngressBackend]TargetGroup)
+	for _, backend := range serviceBackends {
+		if _, ok := tgByBackend[backend]; ok {
+			continue
+		}
+		tg, err := controller.buildDesiredTargetGroup(ingress, backend)
+		if err != nil {
+			return nil, TargetGroupGroup{}, err
+		}
+		desired = append(desired, tg)
+		tgByBackend[backend] = TargetGroup{Arn: tg.Name, TargetType: tg.TargetType}
+	}
+	return desired, TargetGroupGroup{
+		TGByBackend:    tgByBackend,
+		externalTGARNs: externalTGARNs,
+		selector:       nameTagGen.TagTGGroup(ingress.Namespace, ingress.Name),
+	}, nil
+}
+
+func (controller *defaultController) buildDesiredTargetGroup(ingress *extensions.Ingress, backend extensions.IngressBackend) (DesiredTargetGroup, error) {
+	ingressAnnos, err := controller.store.GetIngressAnnotations(k8s.MetaNamespaceKey(ingress))
+	if err != nil {
+		return DesiredTargetGroup{}, fmt.Errorf("failed to load ingressAnnotation due to %v", err)
+	}
+	serviceNamespace, err := controller.serviceNamespace(ingress, backend.ServiceName)
+	if err != nil {
+		return DesiredTargetGroup{}, err
+	}
+	serviceKey := types.NamespacedName{Namespace: serviceNamespace, Name: backend.ServiceName}
+	serviceAnnos, err := controller.store.GetServiceAnnotations(serviceKey.String(), ingressAnnos)
+	if err != nil {
+		return DesiredTargetGroup{}, fmt.Errorf("failed to load serviceAnnotation of %v due to %v", serviceKey, err)
+	}
+	if _, err := NewAttributes(serviceAnnos.TargetGroup.Attributes); err != nil {
+		return DesiredTargetGroup{}, fmt.Errorf("invalid targetGroup attributes due to %v", err)
+	}
+
+	protocol := aws.StringValue(serviceAnnos.TargetGroup.BackendProtocol)
+	protocolVersion := aws.StringValue(serviceAnnos.TargetGroup.BackendProtocolVersion)
+	targetType := aws.StringValue(serviceAnnos.TargetGroup.TargetType)
+	ipAddressType := aws.StringValue(serviceAnnos.TargetGroup.IPAddressType)
+	healthCheckPort, err := controller.resolveServiceHealthCheckPort(serviceNamespace, backend.ServiceName, intstr.Parse(*serviceAnnos.HealthCheck.Port), targetType)
+	if err != nil {
+		return DesiredTargetGroup{}, fmt.Errorf("failed to resolve healthcheck port due to %v", err)
+	}
+
+	return DesiredTargetGroup{
+		Name:            controller.tgName(ingress, backend, serviceAnnos, targetType, protocol, protocolVersion, ipAddressType),
+		ServiceName:     backend.ServiceName,
+		ServicePort:     backend.ServicePort.String(),
+		TargetType:      targetType,
+		Protocol:        protocol,
+		ProtocolVersion: protocolVersion,
+		IPAddressType:   ipAddressType,
+
+		HealthCheckPath:            serviceAnnos.HealthCheck.Path,
+		HealthCheckPort:            healthCheckPort,
+		HealthCheckProtocol:        serviceAnnos.HealthCheck.Protocol,
+		HealthCheckIntervalSeconds: serviceAnnos.HealthCheck.IntervalSeconds,
+		HealthCheckTimeoutSeconds:  serviceAnnos.HealthCheck.TimeoutSeconds,
+		HealthyThresholdCount:      serviceAnnos.TargetGroup.HealthyThresholdCount,
+		UnhealthyThresholdCount:    serviceAnnos.TargetGroup.UnhealthyThresholdCount,
+		SuccessCodes:               serviceAnnos.TargetGroup.SuccessCodes,
+
+		Attributes: serviceAnnos.TargetGroup.Attributes,
+		Tags:       controller.buildTags(ingress, backend, ingressAnnos),
+	}, nil
+}
@@ -9,13 +9,13 @@ type MockNameTagGenerator struct {
 	mock.Mock
 }
 
-// NameTG provides a mock function with given fields: namespace, ingressName, serviceName, servicePort, targetType, protocol
-func (_m *MockNameTagGenerator) NameTG(namespace string, ingressName string, serviceName string, servicePort string, targetType string, protocol string) string {
-	ret := _m.Called(namespace, ingressName, serviceName, servicePort, targetType, protocol)
+// NameTG provides a mock function with given fields: namespace, ingressName, serviceName, servicePort, targetType, protocol, protocolVersion, ipAddressType
+func (_m *MockNameTagGenerator) NameTG(namespace string, ingressName string, serviceName string, servicePort string, targetType string, protocol string, protocolVersion string, ipAddressType string) string {
+	ret := _m.Called(namespace, ingressName, serviceName, servicePort, targetType, protocol, protocolVersion, ipAddressType)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string, string, string, string, string, string) string); ok {
-		r0 = rf(namespace, ingressName, serviceName, servicePort, targetType, protocol)
+	if rf, ok := ret.Get(0).(func(string, string, string, string, string, string, string, string) string); ok {
+		r0 = rf(namespace, ingressName, serviceName, servicePort, targetType, protocol, protocolVersion, ipAddressType)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
@@ -4,18 +4,22 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
+	"github.com/aws/aws-sdk-go/aws/awsutil"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/healthcheck"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/targetgroup"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
 	util "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -29,6 +33,9 @@ import (
 // see https://docs.aws.amazon.com/sdk-for-go/api/service/elbv2/#CreateTargetGroupInput
 const targetGroupDefaultPort = 1
 
+// maxDescribeTagsARNs is how many resources DescribeTags accepts at once.
+const maxDescribeTagsARNs = 20
+
 // Controller manages a single targetGroup for specific ingress & ingressBackend.
 type Controller interface {
 	// Reconcile ensures an targetGroup exists for specified backend of ingress.
@@ -67,31 +74,83 @@ func (controller *defaultController) Reconcile(ctx context.Context, ingress *ext
 	if err != nil {
 		return TargetGroup{}, fmt.Errorf("failed to load ingressAnnotation due to %v", err)
 	}
-	serviceKey := types.NamespacedName{Namespace: ingress.Namespace, Name: backend.ServiceName}
+	serviceNamespace, err := controller.serviceNamespace(ingress, backend.ServiceName)
+	if err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "cross-namespace routing not permitted: %v", err)
+		return TargetGroup{}, err
+	}
+	serviceKey := types.NamespacedName{Namespace: serviceNamespace, Name: backend.ServiceName}
 	serviceAnnos, err := controller.store.GetServiceAnnotations(serviceKey.String(), ingressAnnos)
 	if err != nil {
 		return TargetGroup{}, fmt.Errorf("failed to load serviceAnnotation due to %v", err)
 	}
+	// validate attributes up front so an invalid annotation doesn't leave behind a half-configured targetGroup.
+	if _, err := NewAttributes(serviceAnnos.TargetGroup.Attributes); err != nil {
+		return TargetGroup{}, fmt.Errorf("invalid targetGroup attributes due to %v", err)
+	}
 
 	protocol := aws.StringValue(serviceAnnos.TargetGroup.BackendProtocol)
+	protocolVersion := aws.StringValue(serviceAnnos.TargetGroup.BackendProtocolVersion)
 	targetType := aws.StringValue(serviceAnnos.TargetGroup.TargetType)
+	ipAddressType := aws.StringValue(serviceAnnos.TargetGroup.IPAddressType)
+	if err := controller.validateServiceType(ctx, serviceNamespace, backend.ServiceName, targetType); err != nil {
+		return TargetGroup{}, err
+	}
+	if ipAddressType == targetgroup.IPAddressTypeIPv6 {
+		if err := validateIPAddressType(ipAddressType, targetType, aws.StringValue(ingressAnnos.LoadBalancer.IPAddressType)); err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "invalid targetGroup IP address type for service %v: %v", backend.ServiceName, err)
+			return TargetGroup{}, err
+		}
+	}
 
-	healthCheckPort, err := controller.resolveServiceHealthCheckPort(ingress.Namespace, backend.ServiceName, intstr.Parse(*serviceAnnos.HealthCheck.Port), targetType)
+	healthCheckPort, err := controller.resolveServiceHealthCheckPort(serviceNamespace, backend.ServiceName, intstr.Parse(*serviceAnnos.HealthCheck.Port), targetType)
 
 	if err != nil {
 		return TargetGroup{}, fmt.Errorf("failed to resolve healthcheck port due to %v", err)
 	}
 
-	tgName := controller.nameTagGen.NameTG(ingress.Namespace, ingress.Name, backend.ServiceName, backend.ServicePort.String(), targetType, protocol)
+	if name := serviceAnnos.TargetGroup.Name; name != nil {
+		if port, ok := otherServicePortOf(ingress, backend); ok {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "targetGroup name %v of service %v can't be used for both port %v and %v", *name, backend.ServiceName, backend.ServicePort.String(), port)
+			return TargetGroup{}, fmt.Errorf("targetGroup name %v can't be used for more than one port of service %v", *name, backend.ServiceName)
+		}
+	}
+
+	tgName := controller.tgName(ingress, backend, serviceAnnos, targetType, protocol, protocolVersion, ipAddressType)
 	tgInstance, err := controller.findExistingTGInstance(ctx, tgName)
 	if err != nil {
 		return TargetGroup{}, fmt.Errorf("failed to find existing targetGroup due to %v", err)
 	}
+	if tgInstance != nil && serviceAnnos.TargetGroup.Name != nil {
+		// unlike generated names, explicit names may well be taken by targetGroups of other backends, or not managed by the controller at all.
+		backendTags := controller.nameTagGen.TagTG(ingress.Namespace, ingress.Name, backend.ServiceName, backend.ServicePort.String())
+		tagged, err := controller.tgInstancesTaggedFor(ctx, []*elbv2.TargetGroup{tgInstance}, backendTags)
+		if err != nil {
+			return TargetGroup{}, fmt.Errorf("failed to describe tags of targetGroup %v due to %v", tgName, err)
+		}
+		if len(tagged) == 0 {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "targetGroup name %v of service %v is already used by another targetGroup", tgName, backend.ServiceName)
+			return TargetGroup{}, fmt.Errorf("targetGroup name %v is already used by another targetGroup", tgName)
+		}
+		// AWS can't modify these attributes, and the name can't be reused before the targetGroup is deleted, which it can't be
+		// while listeners forward to it. The generated name is used meanwhile, so that GC deletes the targetGroup once
+		// listeners moved on, and the next reconcile takes the name back.
+		if attr := immutableAttributeChangedOf(tgInstance, targetType, protocol, protocolVersion, ipAddressType); attr != "" {
+			generatedName := controller.nameTagGen.NameTG(ingress.Namespace, ingress.Name, backend.ServiceName, backend.ServicePort.String(), targetType, protocol, protocolVersion, ipAddressType)
+			albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonModify, "targetGroup %v is replaced since its %v changed, using name %v until it's deleted", tgName, attr, generatedName)
+			tgName = generatedName
+			if tgInstance, err = controller.findExistingTGInstance(ctx, tgName); err != nil {
+				return TargetGroup{}, fmt.Errorf("failed to find existing targetGroup due to %v", err)
+			}
+		}
+	}
 	if tgInstance == nil {
+		controller.reportRenamedTGInstances(ctx, ingress, backend, tgName)
 		if tgInstance, err = controller.newTGInstance(ctx, tgName, serviceAnnos, healthCheckPort); err != nil {
 			return TargetGroup{}, fmt.Errorf("failed to create targetGroup due to %v", err)
 		}
 	} else {
+		healthCheckPort = controller.keepEquivalentHealthCheckPort(tgInstance, healthCheckPort, serviceNamespace, backend, targetType)
 		if tgInstance, err = controller.reconcileTGInstance(ctx, tgInstance, serviceAnnos, healthCheckPort); err != nil {
 			return TargetGroup{}, fmt.Errorf("failed to modify targetGroup due to %v", err)
 		}
@@ -133,21 +192,25 @@ func (controller *defaultController) newTGInstance(ctx context.Context, name str
 		HealthCheckTimeoutSeconds:  serviceAnnos.HealthCheck.TimeoutSeconds,
 		TargetType:                 serviceAnnos.TargetGroup.TargetType,
 		Protocol:                   serviceAnnos.TargetGroup.BackendProtocol,
-		Matcher:                    &elbv2.Matcher{HttpCode: serviceAnnos.TargetGroup.SuccessCodes},
+		ProtocolVersion:            serviceAnnos.TargetGroup.BackendProtocolVersion,
+		IpAddressType:              serviceAnnos.TargetGroup.IPAddressType,
+		Matcher:                    matcherOf(serviceAnnos),
 		HealthyThresholdCount:      serviceAnnos.TargetGroup.HealthyThresholdCount,
 		UnhealthyThresholdCount:    serviceAnnos.TargetGroup.UnhealthyThresholdCount,
 		Port:                       aws.Int64(targetGroupDefaultPort),
 	})
 	if err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to create targetGroup %v due to %v", name, err)
 		return nil, err
 	}
 	tgInstance := resp.TargetGroups[0]
 	albctx.GetLogger(ctx).Infof("target group %v created: %v", name, aws.StringValue(tgInstance.TargetGroupArn))
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonCreate, "targetGroup %v created, ARN: %v", name, aws.StringValue(tgInstance.TargetGroupArn))
 	return tgInstance, nil
 }
 
 func (controller *defaultController) reconcileTGInstance(ctx context.Context, instance *elbv2.TargetGroup, serviceAnnos *annotations.Service, healthCheckPort string) (*elbv2.TargetGroup, error) {
-	if controller.TGInstanceNeedsModification(ctx, instance, serviceAnnos) {
+	if controller.TGInstanceNeedsModification(ctx, instance, serviceAnnos, healthCheckPort) {
 		albctx.GetLogger(ctx).Infof("modify target group %v", aws.StringValue(instance.TargetGroupArn))
 
 		output, err := controller.cloud.ModifyTargetGroupWithContext(ctx, &elbv2.ModifyTargetGroupInput{
@@ -157,18 +220,178 @@ func (controller *defaultController) reconcileTGInstance(ctx context.Context, in
 			HealthCheckPort:            aws.String(healthCheckPort),
 			HealthCheckProtocol:        serviceAnnos.HealthCheck.Protocol,
 			HealthCheckTimeoutSeconds:  serviceAnnos.HealthCheck.TimeoutSeconds,
-			Matcher:                    &elbv2.Matcher{HttpCode: serviceAnnos.TargetGroup.SuccessCodes},
+			Matcher:                    matcherOf(serviceAnnos),
 			HealthyThresholdCount:      serviceAnnos.TargetGroup.HealthyThresholdCount,
 			UnhealthyThresholdCount:    serviceAnnos.TargetGroup.UnhealthyThresholdCount,
 		})
 		if err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to modify targetGroup %v due to %v", aws.StringValue(instance.TargetGroupArn), err)
 			return instance, err
 		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonModify, "targetGroup %v modified", aws.StringValue(instance.TargetGroupArn))
 		return output.TargetGroups[0], err
 	}
 	return instance, nil
 }
 
+// tgName returns the name of the targetGroup of backend: the target-group-name annotation of its service,
+// or else a name generated from the ingress and backend.
+func (controller *defaultController) tgName(ingress *extensions.Ingress, backend extensions.IngressBackend, serviceAnnos *annotations.Service, targetType string, protocol string, protocolVersion string, ipAddressType string) string {
+	if name := serviceAnnos.TargetGroup.Name; name != nil {
+		return *name
+	}
+	return controller.nameTagGen.NameTG(ingress.Namespace, ingress.Name, backend.ServiceName, backend.ServicePort.String(), targetType, protocol, protocolVersion, ipAddressType)
+}
+
+// immutableAttributeChangedOf returns the first attribute AWS can't modify of instance that differs from the desired one,
+// or "" if none does.
+func immutableAttributeChangedOf(instance *elbv2.TargetGroup, targetType string, protocol string, protocolVersion string, ipAddressType string) string {
+	currentProtocolVersion := aws.StringValue(instance.ProtocolVersion)
+	if currentProtocolVersion == "" {
+		currentProtocolVersion = targetgroup.BackendProtocolVersionHTTP1
+	}
+	currentIPAddressType := aws.StringValue(instance.IpAddressType)
+	if currentIPAddressType == "" {
+		currentIPAddressType = targetgroup.IPAddressTypeIPv4
+	}
+	switch {
+	case !strings.EqualFold(aws.StringValue(instance.TargetType), targetType):
+		return "target type"
+	case !strings.EqualFold(aws.StringValue(instance.Protocol), protocol):
+		return "protocol"
+	case !strings.EqualFold(currentProtocolVersion, protocolVersion):
+		return "protocol version"
+	case !strings.EqualFold(currentIPAddressType, ipAddressType):
+		return "IP address type"
+	}
+	return ""
+}
+
+// otherServicePortOf returns a port of the service of backend that ingress routes to besides the port of backend, if any.
+func otherServicePortOf(ingress *extensions.Ingress, backend extensions.IngressBackend) (string, bool) {
+	backends, _, _ := ExtractTargetGroupBackends(ingress)
+	for _, other := range backends {
+		if other.ServiceName == backend.ServiceName && other.ServicePort.String() != backend.ServicePort.String() {
+			return other.ServicePort.String(), true
+		}
+	}
+	return "", false
+}
+
+// matcherOf returns the health check matcher of the targetGroup of a service: gRPC codes for GRPC targetGroups,
+// HTTP codes otherwise.
+func matcherOf(serviceAnnos *annotations.Service) *elbv2.Matcher {
+	if aws.StringValue(serviceAnnos.TargetGroup.BackendProtocolVersion) == targetgroup.BackendProtocolVersionGRPC {
+		return &elbv2.Matcher{GrpcCode: serviceAnnos.TargetGroup.SuccessCodes}
+	}
+	return &elbv2.Matcher{HttpCode: serviceAnnos.TargetGroup.SuccessCodes}
+}
+
+// reportRenamedTGInstances emits an event for each targetGroup of backend that was described up front under another name than tgName.
+// It is replaced by the targetGroup named tgName, and deleted once no listener forwards to it anymore.
+func (controller *defaultController) reportRenamedTGInstances(ctx context.Context, ingress *extensions.Ingress, backend extensions.IngressBackend, tgName string) {
+	tgByName, ok := ctx.Value(existingTGInstancesKey{}).(map[string]*elbv2.TargetGroup)
+	if !ok || len(tgByName) == 0 {
+		return
+	}
+	backendTags := controller.nameTagGen.TagTG(ingress.Namespace, ingress.Name, backend.ServiceName, backend.ServicePort.String())
+	instances := make([]*elbv2.TargetGroup, 0, len(tgByName))
+	for _, instance := range tgByName {
+		instances = append(instances, instance)
+	}
+	renamed, err := controller.tgInstancesTaggedFor(ctx, instances, backendTags)
+	if err != nil {
+		albctx.GetLogger(ctx).Warnf("failed to describe tags of targetGroups due to %v", err)
+		return
+	}
+	for _, instance := range renamed {
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonModify, "targetGroup %v is renamed to %v, replacing it with a new targetGroup", aws.StringValue(instance.TargetGroupName), tgName)
+	}
+}
+
+// tgInstancesTaggedFor returns the targetGroups among instances that are tagged with all of backendTags.
+func (controller *defaultController) tgInstancesTaggedFor(ctx context.Context, instances []*elbv2.TargetGroup, backendTags map[string]string) ([]*elbv2.TargetGroup, error) {
+	var tagged []*elbv2.TargetGroup
+	for start := 0; start < len(instances); start += maxDescribeTagsARNs {
+		end := start + maxDescribeTagsARNs
+		if end > len(instances) {
+			end = len(instances)
+		}
+		instanceByArn := make(map[string]*elbv2.TargetGroup, end-start)
+		var arns []*string
+		for _, instance := range instances[start:end] {
+			instanceByArn[aws.StringValue(instance.TargetGroupArn)] = instance
+			arns = append(arns, instance.TargetGroupArn)
+		}
+		resp, err := controller.cloud.DescribeELBV2TagsWithContext(ctx, &elbv2.DescribeTagsInput{ResourceArns: arns})
+		if err != nil {
+			return nil, err
+		}
+		for _, desc := range resp.TagDescriptions {
+			tags := make(map[string]string, len(desc.Tags))
+			for _, tag := range desc.Tags {
+				tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+			}
+			if hasTags(tags, backendTags) {
+				tagged = append(tagged, instanceByArn[aws.StringValue(desc.ResourceArn)])
+			}
+		}
+	}
+	return tagged, nil
+}
+
+// hasTags tells whether tags contains every key and value of expected.
+func hasTags(tags map[string]string, expected map[string]string) bool {
+	for k, v := range expected {
+		if value, ok := tags[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// serviceNamespace returns the namespace of the service named serviceName that backends of ingress route to,
+// failing when it's another namespace but ingresses of its namespace may not route to other namespaces.
+func (controller *defaultController) serviceNamespace(ingress *extensions.Ingress, serviceName string) (string, error) {
+	namespace := backend.ServiceNamespace(ingress, serviceName)
+	if namespace != ingress.Namespace && !controller.store.GetConfig().CrossNamespaceBackendsAllowed(ingress.Namespace) {
+		return "", fmt.Errorf("ingresses of namespace %v are not allowed to route to service %v/%v, see --allow-cross-namespace-backends", ingress.Namespace, namespace, serviceName)
+	}
+	return namespace, nil
+}
+
+// validateServiceType ensures the service of a backend with instance target type is exposed on the nodes, warning on the ingress otherwise.
+// Services that can't be found are left to the targets resolution to report.
+func (controller *defaultController) validateServiceType(ctx context.Context, namespace string, serviceName string, targetType string) error {
+	if targetType != elbv2.TargetTypeEnumInstance {
+		return nil
+	}
+	service, err := controller.store.GetService(namespace + "/" + serviceName)
+	if err != nil {
+		return nil
+	}
+	if service.Spec.Type != corev1.ServiceTypeNodePort && service.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "service %v must be NodePort for instance target type, use a NodePort service or target-type ip", serviceName)
+		return fmt.Errorf("%v service is not of type NodePort or LoadBalancer and target-type is instance", serviceName)
+	}
+	return nil
+}
+
+// validateIPAddressType ensures ipv6 targetGroups are only requested for ip targets behind dualstack LoadBalancers,
+// as ipv6 targetGroups can't be attached to ipv4 LoadBalancers nor register instances.
+func validateIPAddressType(ipAddressType string, targetType string, lbIPAddressType string) error {
+	if ipAddressType != targetgroup.IPAddressTypeIPv6 {
+		return nil
+	}
+	if targetType != elbv2.TargetTypeEnumIp {
+		return fmt.Errorf("targetGroup IP address type %v requires target-type %v", ipAddressType, elbv2.TargetTypeEnumIp)
+	}
+	if lbIPAddressType != elbv2.IpAddressTypeDualstack {
+		return fmt.Errorf("targetGroup IP address type %v requires a LoadBalancer IP address type of %v, got %v", ipAddressType, elbv2.IpAddressTypeDualstack, lbIPAddressType)
+	}
+	return nil
+}
+
 // resolveServiceHealthCheckPort checks if the service-port annotation is a string. If so, it tries to look up a port with the same name
 // on the service and use that port's NodePort as the health check port.
 func (controller *defaultController) resolveServiceHealthCheckPort(namespace string, serviceName string, servicePortAnnotation intstr.IntOrString, targetType string) (string, error) {
@@ -206,49 +429,121 @@ func (controller *defaultController) resolveServiceHealthCheckPort(namespace str
 
 }
 
-func (controller *defaultController) TGInstanceNeedsModification(ctx context.Context, instance *elbv2.TargetGroup, serviceAnnos *annotations.Service) bool {
+// keepEquivalentHealthCheckPort returns the current health check port of instance instead of healthCheckPort when
+// both are the same port: traffic-port, and the port traffic of backend is sent to on its targets.
+// That way switching the annotation between them doesn't modify the targetGroup.
+func (controller *defaultController) keepEquivalentHealthCheckPort(instance *elbv2.TargetGroup, healthCheckPort string, namespace string, backend extensions.IngressBackend, targetType string) string {
+	current := aws.StringValue(instance.HealthCheckPort)
+	if current == healthCheckPort || (current != healthcheck.DefaultPort && healthCheckPort != healthcheck.DefaultPort) {
+		return healthCheckPort
+	}
+	trafficPort := controller.resolveTrafficPort(namespace, backend, targetType)
+	if trafficPort != "" && (current == trafficPort || healthCheckPort == trafficPort) {
+		return current
+	}
+	return healthCheckPort
+}
+
+// resolveTrafficPort returns the port traffic of backend is sent to on its targets: the NodePort of its service port
+// for instance targets, or its target port for ip targets. It returns "" when that port isn't known to be a number.
+func (controller *defaultController) resolveTrafficPort(namespace string, backend extensions.IngressBackend, targetType string) string {
+	service, err := controller.store.GetService(namespace + "/" + backend.ServiceName)
+	if err != nil {
+		return ""
+	}
+	servicePort, err := k8s.LookupServicePort(service, backend.ServicePort)
+	if err != nil {
+		return ""
+	}
+	if targetType == elbv2.TargetTypeEnumInstance {
+		if servicePort.NodePort == 0 {
+			return ""
+		}
+		return strconv.Itoa(int(servicePort.NodePort))
+	}
+	switch {
+	case servicePort.TargetPort.Type != intstr.Int:
+		return ""
+	case servicePort.TargetPort.IntVal == 0:
+		// the target port defaults to the service port.
+		return strconv.Itoa(int(servicePort.Port))
+	default:
+		return servicePort.TargetPort.String()
+	}
+}
+
+func (controller *defaultController) TGInstanceNeedsModification(ctx context.Context, instance *elbv2.TargetGroup, serviceAnnos *annotations.Service, healthCheckPort string) bool {
 	needsChange := false
+	tgName := aws.StringValue(instance.TargetGroupName)
 	if !util.DeepEqual(instance.HealthCheckPath, serviceAnnos.HealthCheck.Path) {
 		needsChange = true
+		albctx.GetLogger(ctx).Debugf("targetGroup %v healthCheckPath needs modification: %v => %v", tgName, awsutil.Prettify(instance.HealthCheckPath), awsutil.Prettify(serviceAnnos.HealthCheck.Path))
 	}
-	if !util.DeepEqual(instance.HealthCheckPort, serviceAnnos.HealthCheck.Port) {
+	// the annotation may name a service port, so the port it resolved to is compared.
+	if aws.StringValue(instance.HealthCheckPort) != healthCheckPort {
 		needsChange = true
+		albctx.GetLogger(ctx).Debugf("targetGroup %v healthCheckPort needs modification: %v => %v", tgName, aws.StringValue(instance.HealthCheckPort), healthCheckPort)
 	}
 	if !util.DeepEqual(instance.HealthCheckProtocol, serviceAnnos.HealthCheck.Protocol) {
 		needsChange = true
+		albctx.GetLogger(ctx).Debugf("targetGroup %v healthCheckProtocol needs modification: %v => %v", tgName, awsutil.Prettify(instance.HealthCheckProtocol), awsutil.Prettify(serviceAnnos.HealthCheck.Protocol))
 	}
 	if !util.DeepEqual(instance.HealthCheckIntervalSeconds, serviceAnnos.HealthCheck.IntervalSeconds) {
 		needsChange = true
+		albctx.GetLogger(ctx).Debugf("targetGroup %v healthCheckIntervalSeconds needs modification: %v => %v", tgName, awsutil.Prettify(instance.HealthCheckIntervalSeconds), awsutil.Prettify(serviceAnnos.HealthCheck.IntervalSeconds))
 	}
 	if !util.DeepEqual(instance.HealthCheckTimeoutSeconds, serviceAnnos.HealthCheck.TimeoutSeconds) {
 		needsChange = true
+		albctx.GetLogger(ctx).Debugf("targetGroup %v healthCheckTimeoutSeconds needs modification: %v => %v", tgName, awsutil.Prettify(instance.HealthCheckTimeoutSeconds), awsutil.Prettify(serviceAnnos.HealthCheck.TimeoutSeconds))
 	}
-	if !util.DeepEqual(instance.Matcher.HttpCode, serviceAnnos.TargetGroup.SuccessCodes) {
+	if matcher := matcherOf(serviceAnnos); !util.DeepEqual(instance.Matcher.HttpCode, matcher.HttpCode) || !util.DeepEqual(instance.Matcher.GrpcCode, matcher.GrpcCode) {
 		needsChange = true
+		albctx.GetLogger(ctx).Debugf("targetGroup %v successCodes needs modification: %v => %v", tgName, awsutil.Prettify(instance.Matcher), awsutil.Prettify(matcher))
 	}
 	if !util.DeepEqual(instance.HealthyThresholdCount, serviceAnnos.TargetGroup.HealthyThresholdCount) {
 		needsChange = true
+		albctx.GetLogger(ctx).Debugf("targetGroup %v healthyThresholdCount needs modification: %v => %v", tgName, awsutil.Prettify(instance.HealthyThresholdCount), awsutil.Prettify(serviceAnnos.TargetGroup.HealthyThresholdCount))
 	}
 	if !util.DeepEqual(instance.UnhealthyThresholdCount, serviceAnnos.TargetGroup.UnhealthyThresholdCount) {
 		needsChange = true
+		albctx.GetLogger(ctx).Debugf("targetGroup %v unhealthyThresholdCount needs modification: %v => %v", tgName, awsutil.Prettify(instance.UnhealthyThresholdCount), awsutil.Prettify(serviceAnnos.TargetGroup.UnhealthyThresholdCount))
 	}
 	return needsChange
 }
 
 func (controller *defaultController) buildTags(ingress *extensions.Ingress, backend extensions.IngressBackend, ingressAnnos *annotations.Ingress) map[string]string {
 	tgTags := make(map[string]string)
-	for k, v := range controller.nameTagGen.TagTGGroup(ingress.Namespace, ingress.Name) {
+	// custom tags go first, so they can't override the tags the controller finds its targetGroups by.
+	for k, v := range ingressAnnos.Tags.LoadBalancer {
 		tgTags[k] = v
 	}
-	for k, v := range controller.nameTagGen.TagTG(ingress.Namespace, ingress.Name, backend.ServiceName, backend.ServicePort.String()) {
+	for k, v := range controller.nameTagGen.TagTGGroup(ingress.Namespace, ingress.Name) {
 		tgTags[k] = v
 	}
-	for k, v := range ingressAnnos.Tags.LoadBalancer {
+	for k, v := range controller.nameTagGen.TagTG(ingress.Namespace, ingress.Name, backend.ServiceName, backend.ServicePort.String()) {
 		tgTags[k] = v
 	}
 	return tgTags
 }
 
+// existingTGInstancesKey is the context key of the targetGroups described up front by the GroupController.
+type existingTGInstancesKey struct{}
+
+// withExistingTGInstances returns a context carrying tgByName, the targetGroups of an ingress by name.
+func withExistingTGInstances(ctx context.Context, tgByName map[string]*elbv2.TargetGroup) context.Context {
+	if tgByName == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, existingTGInstancesKey{}, tgByName)
+}
+
 func (controller *defaultController) findExistingTGInstance(ctx context.Context, tgName string) (*elbv2.TargetGroup, error) {
+	// targetGroups missing from the ones described up front may still exist without the tags they're found by, e.g. when
+	// created by earlier releases, so they're looked up by name anyway.
+	if tgByName, ok := ctx.Value(existingTGInstancesKey{}).(map[string]*elbv2.TargetGroup); ok {
+		if instance, ok := tgByName[tgName]; ok {
+			return instance, nil
+		}
+	}
 	return controller.cloud.GetTargetGroupByName(ctx, tgName)
 }
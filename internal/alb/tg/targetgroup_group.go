@@ -3,6 +3,7 @@ package tg
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -20,6 +21,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// maxConcurrentTGReconciles bounds how many targetGroups of an ingress are reconciled in parallel.
+const maxConcurrentTGReconciles = 5
+
 // GroupController manages all target groups for one ingress.
 type GroupController interface {
 	// Reconcile ensures AWS an targetGroup exists for each backend in ingress.
@@ -60,21 +64,49 @@ type defaultGroupController struct {
 }
 
 func (controller *defaultGroupController) Reconcile(ctx context.Context, ingress *extensions.Ingress) (TargetGroupGroup, error) {
-	tgByBackend := make(map[extensions.IngressBackend]TargetGroup)
-
 	serviceBackends, externalTGARNs, err := ExtractTargetGroupBackends(ingress)
 	if err != nil {
 		return TargetGroupGroup{}, err
 	}
+	var backends []extensions.IngressBackend
+	seen := make(map[extensions.IngressBackend]bool)
 	for _, backend := range serviceBackends {
-		if _, ok := tgByBackend[backend]; ok {
-			continue
-		}
-		if tgByBackend[backend], err = controller.tgController.Reconcile(ctx, ingress, backend); err != nil {
-			return TargetGroupGroup{}, err
+		if !seen[backend] {
+			seen[backend] = true
+			backends = append(backends, backend)
 		}
 	}
+
 	selector := controller.nameTagGen.TagTGGroup(ingress.Namespace, ingress.Name)
+	if len(backends) > 0 {
+		ctx = withExistingTGInstances(ctx, controller.findExistingTGInstances(ctx, selector))
+	}
+
+	// targetGroups are reconciled in parallel, the first error in backend order is reported.
+	tgs := make([]TargetGroup, len(backends))
+	errs := make([]error, len(backends))
+	sem := make(chan struct{}, maxConcurrentTGReconciles)
+	var wg sync.WaitGroup
+	for i := range backends {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			tgs[i], errs[i] = controller.tgController.Reconcile(ctx, ingress, backends[i])
+		}(i)
+	}
+	wg.Wait()
+
+	tgByBackend := make(map[extensions.IngressBackend]TargetGroup, len(backends))
+	for i, backend := range backends {
+		if errs[i] != nil {
+			return TargetGroupGroup{}, errs[i]
+		}
+		tgByBackend[backend] = tgs[i]
+	}
 	return TargetGroupGroup{
 		TGByBackend:    tgByBackend,
 		externalTGARNs: externalTGARNs,
@@ -82,6 +114,34 @@ func (controller *defaultGroupController) Reconcile(ctx context.Context, ingress
 	}, nil
 }
 
+// findExistingTGInstances describes the targetGroups tagged with selector in batches, so that reconciling each targetGroup
+// doesn't need a DescribeTargetGroups call of its own. It returns nil if they can't be described, e.g. when one was just deleted,
+// in which case each targetGroup is looked up by name instead.
+func (controller *defaultGroupController) findExistingTGInstances(ctx context.Context, selector map[string]string) map[string]*elbv2.TargetGroup {
+	tagFilters := make(map[string][]string)
+	for k, v := range selector {
+		tagFilters[k] = []string{v}
+	}
+	arns, err := controller.cloud.GetResourcesByFilters(ctx, tagFilters, aws.ResourceTypeEnumELBTargetGroup)
+	if err != nil {
+		albctx.GetLogger(ctx).Warnf("failed to get targetGroups due to %v, looking them up one by one", err)
+		return nil
+	}
+	if len(arns) == 0 {
+		return map[string]*elbv2.TargetGroup{}
+	}
+	instances, err := controller.cloud.GetTargetGroupsByArns(ctx, arns)
+	if err != nil {
+		albctx.GetLogger(ctx).Warnf("failed to describe targetGroups due to %v, looking them up one by one", err)
+		return nil
+	}
+	tgByName := make(map[string]*elbv2.TargetGroup, len(instances))
+	for _, instance := range instances {
+		tgByName[aws.StringValue(instance.TargetGroupName)] = instance
+	}
+	return tgByName
+}
+
 func (controller *defaultGroupController) GC(ctx context.Context, tgGroup TargetGroupGroup) error {
 	tagFilters := make(map[string][]string)
 	for k, v := range tgGroup.selector {
@@ -93,7 +153,7 @@ func (controller *defaultGroupController) GC(ctx context.Context, tgGroup Target
 	for _, tg := range tgGroup.TGByBackend {
 		usedServiceTGARNs.Insert(tg.Arn)
 	}
-	arns, err := controller.cloud.GetResourcesByFilters(tagFilters, aws.ResourceTypeEnumELBTargetGroup)
+	arns, err := controller.cloud.GetResourcesByFilters(ctx, tagFilters, aws.ResourceTypeEnumELBTargetGroup)
 	if err != nil {
 		return fmt.Errorf("failed to get targetGroups due to %v", err)
 	}
@@ -108,8 +168,10 @@ func (controller *defaultGroupController) GC(ctx context.Context, tgGroup Target
 		albctx.GetLogger(ctx).Infof("deleting target group %v", arn)
 		controller.tgController.StopReconcilingPodConditionStatus(arn)
 		if err := controller.cloud.DeleteTargetGroupByArn(ctx, arn); err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, albctx.EventReasonError, "failed to delete targetGroup %v due to %v", arn, err)
 			return fmt.Errorf("failed to delete targetGroup due to %v", err)
 		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, albctx.EventReasonDelete, "targetGroup %v deleted", arn)
 	}
 	return nil
 }
@@ -3,9 +3,11 @@ package tg
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
@@ -496,6 +498,11 @@ func TestDefaultGroupController_Reconcile(t *testing.T) {
 					Err: errors.New("TGReconcileCall"),
 				},
 			},
+			TagTGGroupCall: &TagTGGroupCall{
+				Namespace:   "namespace",
+				IngressName: "ingress",
+				Tags:        map[string]string{"key1": "value1", "key2": "value2"},
+			},
 			ExpectedError: errors.New("TGReconcileCall"),
 		},
 	} {
@@ -505,6 +512,9 @@ func TestDefaultGroupController_Reconcile(t *testing.T) {
 			if tc.TagTGGroupCall != nil {
 				mockNameTagGen.On("TagTGGroup", tc.TagTGGroupCall.Namespace, tc.TagTGGroupCall.IngressName).Return(tc.TagTGGroupCall.Tags)
 			}
+			if len(tc.TGReconcileCalls) > 0 {
+				cloud.On("GetResourcesByFilters", mock.Anything, map[string][]string{"key1": {"value1"}, "key2": {"value2"}}, aws.ResourceTypeEnumELBTargetGroup).Return(nil, nil)
+			}
 
 			mockTGController := &MockController{}
 			for _, call := range tc.TGReconcileCalls {
@@ -540,6 +550,7 @@ func TestDefaultGroupController_GC(t *testing.T) {
 		TGGroup                     TargetGroupGroup
 		GetResourcesByFiltersCall   *GetResourcesByFiltersCall
 		DeleteTargetGroupByArnCalls []DeleteTargetGroupByArnCall
+		ExpectedEvents              []string
 		ExpectedError               error
 	}{
 		{
@@ -566,6 +577,7 @@ func TestDefaultGroupController_GC(t *testing.T) {
 					Arn: "arn3",
 				},
 			},
+			ExpectedEvents: []string{"Normal DELETE targetGroup arn2 deleted", "Normal DELETE targetGroup arn3 deleted"},
 		},
 		{
 			Name: "GC succeeds without deleting externalTargetArn even it's created by controller",
@@ -589,6 +601,10 @@ func TestDefaultGroupController_GC(t *testing.T) {
 					Arn: "arn2",
 				},
 			},
+			ExpectedEvents: []string{
+				"Warning Warning targetGroup created for k8s service should be referenced by serviceName and servicePort instead of TargetGroupARN: arn3",
+				"Normal DELETE targetGroup arn2 deleted",
+			},
 		},
 		{
 			Name: "GC failed when fetch current targetGroups",
@@ -633,10 +649,13 @@ func TestDefaultGroupController_GC(t *testing.T) {
 			ExpectedError: errors.New("failed to delete targetGroup due to DeleteTargetGroupByArnCall"),
 		},
 	} {
-		ctx := context.Background()
+		var events []string
+		ctx := albctx.SetEventf(context.Background(), func(eventType string, reason string, messageFmt string, args ...interface{}) {
+			events = append(events, fmt.Sprintf("%v %v %v", eventType, reason, fmt.Sprintf(messageFmt, args...)))
+		})
 		cloud := &mocks.CloudAPI{}
 		if tc.GetResourcesByFiltersCall != nil {
-			cloud.On("GetResourcesByFilters", tc.GetResourcesByFiltersCall.TagFilters, tc.GetResourcesByFiltersCall.ResourceType).Return(tc.GetResourcesByFiltersCall.Arns, tc.GetResourcesByFiltersCall.Err)
+			cloud.On("GetResourcesByFilters", ctx, tc.GetResourcesByFiltersCall.TagFilters, tc.GetResourcesByFiltersCall.ResourceType).Return(tc.GetResourcesByFiltersCall.Arns, tc.GetResourcesByFiltersCall.Err)
 		}
 		for _, call := range tc.DeleteTargetGroupByArnCalls {
 			cloud.On("DeleteTargetGroupByArn", ctx, call.Arn).Return(call.Err)
@@ -653,8 +672,11 @@ func TestDefaultGroupController_GC(t *testing.T) {
 			tgController: mockTGController,
 		}
 
-		err := controller.GC(context.Background(), tc.TGGroup)
+		err := controller.GC(ctx, tc.TGGroup)
 		assert.Equal(t, tc.ExpectedError, err)
+		if tc.ExpectedEvents != nil {
+			assert.ElementsMatch(t, tc.ExpectedEvents, events)
+		}
 		cloud.AssertExpectations(t)
 		mockNameTagGen.AssertExpectations(t)
 		mockTGController.AssertExpectations(t)
@@ -744,7 +766,7 @@ func TestDefaultGroupController_Delete(t *testing.T) {
 		ctx := context.Background()
 		cloud := &mocks.CloudAPI{}
 		if tc.GetResourcesByFiltersCall != nil {
-			cloud.On("GetResourcesByFilters", tc.GetResourcesByFiltersCall.TagFilters, tc.GetResourcesByFiltersCall.ResourceType).Return(tc.GetResourcesByFiltersCall.Arns, tc.GetResourcesByFiltersCall.Err)
+			cloud.On("GetResourcesByFilters", ctx, tc.GetResourcesByFiltersCall.TagFilters, tc.GetResourcesByFiltersCall.ResourceType).Return(tc.GetResourcesByFiltersCall.Arns, tc.GetResourcesByFiltersCall.Err)
 		}
 		for _, call := range tc.DeleteTargetGroupByArnCalls {
 			cloud.On("DeleteTargetGroupByArn", ctx, call.Arn).Return(call.Err)
@@ -771,3 +793,150 @@ func TestDefaultGroupController_Delete(t *testing.T) {
 		mockTGController.AssertExpectations(t)
 	}
 }
+
+func TestDefaultGroupController_Reconcile_existingTargetGroups(t *testing.T) {
+	backend1 := extensions.IngressBackend{ServiceName: "service1", ServicePort: intstr.FromInt(80)}
+	backend2 := extensions.IngressBackend{ServiceName: "service2", ServicePort: intstr.FromInt(80)}
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "ingress", Namespace: "namespace"},
+		Spec: extensions.IngressSpec{
+			Rules: []extensions.IngressRule{{
+				IngressRuleValue: extensions.IngressRuleValue{
+					HTTP: &extensions.HTTPIngressRuleValue{
+						Paths: []extensions.HTTPIngressPath{{Path: "/path1", Backend: backend1}, {Path: "/path2", Backend: backend2}},
+					},
+				},
+			}},
+		},
+	}
+	tg1 := &elbv2.TargetGroup{TargetGroupArn: aws.String("arn1"), TargetGroupName: aws.String("name1")}
+	tg2 := &elbv2.TargetGroup{TargetGroupArn: aws.String("arn2"), TargetGroupName: aws.String("name2")}
+
+	for _, tc := range []struct {
+		Name                      string
+		GetResourcesByFiltersErr  error
+		GetTargetGroupsByArnsCall bool
+		GetTargetGroupsByArnsErr  error
+		ExpectedExistingTGs       map[string]*elbv2.TargetGroup
+	}{
+		{
+			Name:                      "targetGroups described in a batch",
+			GetTargetGroupsByArnsCall: true,
+			ExpectedExistingTGs:       map[string]*elbv2.TargetGroup{"name1": tg1, "name2": tg2},
+		},
+		{
+			Name:                     "tag lookup fails",
+			GetResourcesByFiltersErr: errors.New("GetResourcesByFilters"),
+		},
+		{
+			Name:                      "describe fails",
+			GetTargetGroupsByArnsCall: true,
+			GetTargetGroupsByArnsErr:  errors.New("GetTargetGroupsByArns"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetResourcesByFilters", mock.Anything, map[string][]string{"key": {"value"}}, aws.ResourceTypeEnumELBTargetGroup).Return([]string{"arn1", "arn2"}, tc.GetResourcesByFiltersErr)
+			if tc.GetTargetGroupsByArnsCall {
+				cloud.On("GetTargetGroupsByArns", mock.Anything, []string{"arn1", "arn2"}).Return([]*elbv2.TargetGroup{tg1, tg2}, tc.GetTargetGroupsByArnsErr)
+			}
+			mockNameTagGen := &MockNameTagGenerator{}
+			mockNameTagGen.On("TagTGGroup", "namespace", "ingress").Return(map[string]string{"key": "value"})
+
+			hasExistingTGs := mock.MatchedBy(func(ctx context.Context) bool {
+				tgByName, _ := ctx.Value(existingTGInstancesKey{}).(map[string]*elbv2.TargetGroup)
+				return assert.ObjectsAreEqual(tc.ExpectedExistingTGs, tgByName)
+			})
+			mockTGController := &MockController{}
+			mockTGController.On("Reconcile", hasExistingTGs, ingress, backend1).Return(TargetGroup{Arn: "arn1"}, nil)
+			mockTGController.On("Reconcile", hasExistingTGs, ingress, backend2).Return(TargetGroup{Arn: "arn2"}, nil)
+
+			controller := &defaultGroupController{
+				cloud:        cloud,
+				nameTagGen:   mockNameTagGen,
+				tgController: mockTGController,
+			}
+			tgGroup, err := controller.Reconcile(context.Background(), ingress)
+			assert.NoError(t, err)
+			assert.Equal(t, map[extensions.IngressBackend]TargetGroup{backend1: {Arn: "arn1"}, backend2: {Arn: "arn2"}}, tgGroup.TGByBackend)
+			cloud.AssertExpectations(t)
+			mockTGController.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultGroupController_pathChangesKeepTargetGroups(t *testing.T) {
+	backend1 := extensions.IngressBackend{ServiceName: "service1", ServicePort: intstr.FromInt(80)}
+	backend2 := extensions.IngressBackend{ServiceName: "service2", ServicePort: intstr.FromInt(80)}
+	tgByBackend := map[extensions.IngressBackend]TargetGroup{backend1: {Arn: "arn1"}, backend2: {Arn: "arn2"}}
+	ingressWithPaths := func(paths ...extensions.HTTPIngressPath) *extensions.Ingress {
+		return &extensions.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "ingress", Namespace: "namespace"},
+			Spec: extensions.IngressSpec{
+				Rules: []extensions.IngressRule{{
+					IngressRuleValue: extensions.IngressRuleValue{
+						HTTP: &extensions.HTTPIngressRuleValue{Paths: paths},
+					},
+				}},
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		Name            string
+		Ingress         *extensions.Ingress
+		ExpectedDeletes []string
+	}{
+		{
+			Name: "adding a path to an existing backend keeps every targetGroup",
+			Ingress: ingressWithPaths(
+				extensions.HTTPIngressPath{Path: "/path1", Backend: backend1},
+				extensions.HTTPIngressPath{Path: "/path2", Backend: backend2},
+				extensions.HTTPIngressPath{Path: "/path3", Backend: backend1},
+			),
+		},
+		{
+			Name: "removing one of the paths of a backend keeps its targetGroup",
+			Ingress: ingressWithPaths(
+				extensions.HTTPIngressPath{Path: "/path2", Backend: backend2},
+				extensions.HTTPIngressPath{Path: "/path3", Backend: backend1},
+			),
+		},
+		{
+			Name: "removing the last path of a backend only deletes its targetGroup",
+			Ingress: ingressWithPaths(
+				extensions.HTTPIngressPath{Path: "/path3", Backend: backend1},
+			),
+			ExpectedDeletes: []string{"arn2"},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetResourcesByFilters", ctx, map[string][]string{"key": {"value"}}, aws.ResourceTypeEnumELBTargetGroup).Return([]string{"arn1", "arn2"}, nil)
+			cloud.On("GetTargetGroupsByArns", ctx, []string{"arn1", "arn2"}).Return([]*elbv2.TargetGroup{}, nil)
+			mockNameTagGen := &MockNameTagGenerator{}
+			mockNameTagGen.On("TagTGGroup", "namespace", "ingress").Return(map[string]string{"key": "value"})
+			mockTGController := &MockController{}
+			mockTGController.On("Reconcile", mock.Anything, tc.Ingress, mock.Anything).Return(
+				func(_ context.Context, _ *extensions.Ingress, backend extensions.IngressBackend) TargetGroup {
+					return tgByBackend[backend]
+				}, nil)
+			for _, arn := range tc.ExpectedDeletes {
+				mockTGController.On("StopReconcilingPodConditionStatus", arn).Return()
+				cloud.On("DeleteTargetGroupByArn", ctx, arn).Return(nil)
+			}
+
+			controller := &defaultGroupController{
+				cloud:        cloud,
+				nameTagGen:   mockNameTagGen,
+				tgController: mockTGController,
+			}
+			tgGroup, err := controller.Reconcile(ctx, tc.Ingress)
+			assert.NoError(t, err)
+			assert.NoError(t, controller.GC(ctx, tgGroup))
+			cloud.AssertExpectations(t)
+			mockTGController.AssertExpectations(t)
+		})
+	}
+}
@@ -47,7 +47,10 @@ type NameTGCall struct {
 	ServicePort string
 	TargetType  string
 	Protocol    string
-	TGName      string
+	// ProtocolVersion and IPAddressType are left empty by the service annotations of most cases.
+	ProtocolVersion string
+	IPAddressType   string
+	TGName          string
 }
 
 type TagTGGroupCall struct {
@@ -260,6 +263,7 @@ func TestDefaultController_Reconcile(t *testing.T) {
 				Key: "namespace/service",
 				service: &corev1.Service{
 					Spec: corev1.ServiceSpec{
+						Type: corev1.ServiceTypeNodePort,
 						Ports: []corev1.ServicePort{
 							{
 								Name:     "foo",
@@ -767,6 +771,37 @@ func TestDefaultController_Reconcile(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:    "Reconcile failed when the service is ClusterIP for target-type=instance",
+			Ingress: ingress,
+			Backend: ingressBackend,
+			GetServiceCall: &GetServiceCall{
+				Key: "namespace/service",
+				service: &corev1.Service{
+					Spec: corev1.ServiceSpec{
+						Type: corev1.ServiceTypeClusterIP,
+					},
+				},
+			},
+			GetIngressAnnotationsCall: &GetIngressAnnotationsCall{
+				Key:          "namespace/ingress",
+				IngressAnnos: &annotations.Ingress{Tags: &annoTags.Config{}},
+			},
+			GetServiceAnnotationsCall: &GetServiceAnnotationsCall{
+				Key:          "namespace/service",
+				IngressAnnos: &annotations.Ingress{Tags: &annoTags.Config{}},
+				ServiceAnnos: &annotations.Service{
+					HealthCheck: &healthcheck.Config{
+						Port: aws.String("traffic-port"),
+					},
+					TargetGroup: &targetgroup.Config{
+						BackendProtocol: aws.String("HTTP"),
+						TargetType:      aws.String("instance"),
+					},
+				},
+			},
+			ExpectedError: errors.New("service service is not of type NodePort or LoadBalancer and target-type is instance"),
+		},
 		{
 			Name:    "Reconcile failed when fetching existing instance",
 			Ingress: ingress,
@@ -1217,6 +1252,42 @@ func TestDefaultController_Reconcile(t *testing.T) {
 			},
 			ExpectedError: errors.New("failed to reconcile targetGroup targets due to TargetsReconcileCall"),
 		},
+		{
+			Name:    "Reconcile fails before creating instance when attributes are invalid",
+			Ingress: ingress,
+			Backend: ingressBackend,
+			GetIngressAnnotationsCall: &GetIngressAnnotationsCall{
+				Key:          "namespace/ingress",
+				IngressAnnos: &annotations.Ingress{Tags: &annoTags.Config{}},
+			},
+			GetServiceAnnotationsCall: &GetServiceAnnotationsCall{
+				Key:          "namespace/service",
+				IngressAnnos: &annotations.Ingress{Tags: &annoTags.Config{}},
+				ServiceAnnos: &annotations.Service{
+					HealthCheck: &healthcheck.Config{
+						Path:            aws.String("/ping"),
+						Port:            aws.String("8080"),
+						Protocol:        aws.String("HTTP"),
+						IntervalSeconds: aws.Int64(10),
+						TimeoutSeconds:  aws.Int64(60),
+					},
+					TargetGroup: &targetgroup.Config{
+						BackendProtocol:         aws.String("HTTP"),
+						TargetType:              aws.String("ip"),
+						SuccessCodes:            aws.String("80"),
+						HealthyThresholdCount:   aws.Int64(8),
+						UnhealthyThresholdCount: aws.Int64(5),
+						Attributes: []*elbv2.TargetGroupAttribute{
+							{
+								Key:   aws.String("deregistration_delay.timeout"),
+								Value: aws.String("30"),
+							},
+						},
+					},
+				},
+			},
+			ExpectedError: errors.New("invalid targetGroup attributes due to the target group attribute deregistration_delay.timeout is not valid"),
+		},
 		{
 			Name:    "GetIngressAnnotations returns error",
 			Ingress: ingress,
@@ -1274,7 +1345,7 @@ func TestDefaultController_Reconcile(t *testing.T) {
 
 			mockNameTagGen := &MockNameTagGenerator{}
 			if tc.NameTGCall != nil {
-				mockNameTagGen.On("NameTG", tc.NameTGCall.Namespace, tc.NameTGCall.IngressName, tc.NameTGCall.ServiceName, tc.NameTGCall.ServicePort, tc.NameTGCall.TargetType, tc.NameTGCall.Protocol).Return(tc.NameTGCall.TGName)
+				mockNameTagGen.On("NameTG", tc.NameTGCall.Namespace, tc.NameTGCall.IngressName, tc.NameTGCall.ServiceName, tc.NameTGCall.ServicePort, tc.NameTGCall.TargetType, tc.NameTGCall.Protocol, tc.NameTGCall.ProtocolVersion, tc.NameTGCall.IPAddressType).Return(tc.NameTGCall.TGName)
 			}
 			if tc.TagTGCall != nil {
 				mockNameTagGen.On("TagTG", tc.TagTGGroupCall.Namespace, tc.TagTGGroupCall.IngressName, tc.TagTGCall.ServiceName, tc.TagTGCall.ServicePort).Return(tc.TagTGCall.Tags)
@@ -1323,3 +1394,290 @@ func TestDefaultController_Reconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultController_keepEquivalentHealthCheckPort(t *testing.T) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "service"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: "http", Port: 80, TargetPort: intstr.FromInt(8080), NodePort: 30080},
+				{Name: "named", Port: 443, TargetPort: intstr.FromString("https")},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		Name            string
+		CurrentPort     string
+		HealthCheckPort string
+		ServicePort     intstr.IntOrString
+		TargetType      string
+		ExpectedPort    string
+	}{
+		{
+			Name:            "unchanged port",
+			CurrentPort:     "traffic-port",
+			HealthCheckPort: "traffic-port",
+			ServicePort:     intstr.FromInt(80),
+			TargetType:      elbv2.TargetTypeEnumIp,
+			ExpectedPort:    "traffic-port",
+		},
+		{
+			Name:            "traffic port is the target port of ip targets",
+			CurrentPort:     "traffic-port",
+			HealthCheckPort: "8080",
+			ServicePort:     intstr.FromInt(80),
+			TargetType:      elbv2.TargetTypeEnumIp,
+			ExpectedPort:    "traffic-port",
+		},
+		{
+			Name:            "traffic port is the NodePort of instance targets",
+			CurrentPort:     "30080",
+			HealthCheckPort: "traffic-port",
+			ServicePort:     intstr.FromString("http"),
+			TargetType:      elbv2.TargetTypeEnumInstance,
+			ExpectedPort:    "30080",
+		},
+		{
+			Name:            "another port than the traffic port",
+			CurrentPort:     "traffic-port",
+			HealthCheckPort: "9090",
+			ServicePort:     intstr.FromInt(80),
+			TargetType:      elbv2.TargetTypeEnumIp,
+			ExpectedPort:    "9090",
+		},
+		{
+			Name:            "named target ports aren't resolved",
+			CurrentPort:     "traffic-port",
+			HealthCheckPort: "8443",
+			ServicePort:     intstr.FromInt(443),
+			TargetType:      elbv2.TargetTypeEnumIp,
+			ExpectedPort:    "8443",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			mockStore := &store.MockStorer{}
+			mockStore.On("GetService", "namespace/service").Return(service, nil)
+			controller := &defaultController{store: mockStore}
+
+			port := controller.keepEquivalentHealthCheckPort(&elbv2.TargetGroup{HealthCheckPort: aws.String(tc.CurrentPort)}, tc.HealthCheckPort,
+				"namespace", extensions.IngressBackend{ServiceName: "service", ServicePort: tc.ServicePort}, tc.TargetType)
+			assert.Equal(t, tc.ExpectedPort, port)
+		})
+	}
+}
+
+func Test_validateIPAddressType(t *testing.T) {
+	for _, tc := range []struct {
+		Name            string
+		IPAddressType   string
+		TargetType      string
+		LBIPAddressType string
+		ExpectedError   error
+	}{
+		{
+			Name:            "ipv4 targetGroup",
+			IPAddressType:   targetgroup.IPAddressTypeIPv4,
+			TargetType:      elbv2.TargetTypeEnumInstance,
+			LBIPAddressType: elbv2.IpAddressTypeIpv4,
+		},
+		{
+			Name:            "ipv6 targetGroup of instance targets",
+			IPAddressType:   targetgroup.IPAddressTypeIPv6,
+			TargetType:      elbv2.TargetTypeEnumInstance,
+			LBIPAddressType: elbv2.IpAddressTypeDualstack,
+			ExpectedError:   errors.New("targetGroup IP address type ipv6 requires target-type ip"),
+		},
+		{
+			Name:            "ipv6 targetGroup behind an ipv4 LoadBalancer",
+			IPAddressType:   targetgroup.IPAddressTypeIPv6,
+			TargetType:      elbv2.TargetTypeEnumIp,
+			LBIPAddressType: elbv2.IpAddressTypeIpv4,
+			ExpectedError:   errors.New("targetGroup IP address type ipv6 requires a LoadBalancer IP address type of dualstack, got ipv4"),
+		},
+		{
+			Name:            "ipv6 targetGroup behind a dualstack LoadBalancer",
+			IPAddressType:   targetgroup.IPAddressTypeIPv6,
+			TargetType:      elbv2.TargetTypeEnumIp,
+			LBIPAddressType: elbv2.IpAddressTypeDualstack,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.ExpectedError, validateIPAddressType(tc.IPAddressType, tc.TargetType, tc.LBIPAddressType))
+		})
+	}
+}
+
+func TestDefaultController_tgInstancesTaggedFor(t *testing.T) {
+	backendTags := map[string]string{"service-name": "service", "service-port": "80"}
+	tg1 := &elbv2.TargetGroup{TargetGroupArn: aws.String("tg-1"), TargetGroupName: aws.String("echoserver")}
+	tg2 := &elbv2.TargetGroup{TargetGroupArn: aws.String("tg-2"), TargetGroupName: aws.String("other")}
+
+	cloud := &mocks.CloudAPI{}
+	cloud.On("DescribeELBV2TagsWithContext", mock.Anything, &elbv2.DescribeTagsInput{ResourceArns: aws.StringSlice([]string{"tg-1", "tg-2"})}).Return(&elbv2.DescribeTagsOutput{
+		TagDescriptions: []*elbv2.TagDescription{
+			{
+				ResourceArn: aws.String("tg-1"),
+				Tags: []*elbv2.Tag{
+					{Key: aws.String("service-name"), Value: aws.String("service")},
+					{Key: aws.String("service-port"), Value: aws.String("80")},
+					{Key: aws.String("team"), Value: aws.String("web")},
+				},
+			},
+			{
+				ResourceArn: aws.String("tg-2"),
+				Tags: []*elbv2.Tag{
+					{Key: aws.String("service-name"), Value: aws.String("service")},
+					{Key: aws.String("service-port"), Value: aws.String("443")},
+				},
+			},
+		},
+	}, nil)
+
+	controller := &defaultController{cloud: cloud}
+	tagged, err := controller.tgInstancesTaggedFor(context.Background(), []*elbv2.TargetGroup{tg1, tg2}, backendTags)
+	assert.NoError(t, err)
+	assert.Equal(t, []*elbv2.TargetGroup{tg1}, tagged)
+	cloud.AssertExpectations(t)
+}
+
+func Test_matcherOf(t *testing.T) {
+	for _, tc := range []struct {
+		Name            string
+		ProtocolVersion string
+		SuccessCodes    string
+		ExpectedMatcher *elbv2.Matcher
+	}{
+		{
+			Name:            "HTTP1 targetGroups match HTTP codes",
+			ProtocolVersion: targetgroup.BackendProtocolVersionHTTP1,
+			SuccessCodes:    "200-299",
+			ExpectedMatcher: &elbv2.Matcher{HttpCode: aws.String("200-299")},
+		},
+		{
+			Name:            "HTTP2 targetGroups match HTTP codes",
+			ProtocolVersion: targetgroup.BackendProtocolVersionHTTP2,
+			SuccessCodes:    "200",
+			ExpectedMatcher: &elbv2.Matcher{HttpCode: aws.String("200")},
+		},
+		{
+			Name:            "GRPC targetGroups match gRPC codes",
+			ProtocolVersion: targetgroup.BackendProtocolVersionGRPC,
+			SuccessCodes:    "0,12",
+			ExpectedMatcher: &elbv2.Matcher{GrpcCode: aws.String("0,12")},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			serviceAnnos := &annotations.Service{
+				TargetGroup: &targetgroup.Config{
+					BackendProtocolVersion: aws.String(tc.ProtocolVersion),
+					SuccessCodes:           aws.String(tc.SuccessCodes),
+				},
+			}
+			assert.Equal(t, tc.ExpectedMatcher, matcherOf(serviceAnnos))
+		})
+	}
+}
+
+func Test_immutableAttributeChangedOf(t *testing.T) {
+	instance := &elbv2.TargetGroup{
+		TargetType: aws.String(elbv2.TargetTypeEnumIp),
+		Protocol:   aws.String(elbv2.ProtocolEnumHttp),
+	}
+	for _, tc := range []struct {
+		Name            string
+		TargetType      string
+		Protocol        string
+		ProtocolVersion string
+		IPAddressType   string
+		ExpectedAttr    string
+	}{
+		{
+			Name:            "unchanged targetGroup with defaulted protocol version and IP address type",
+			TargetType:      elbv2.TargetTypeEnumIp,
+			Protocol:        elbv2.ProtocolEnumHttp,
+			ProtocolVersion: targetgroup.BackendProtocolVersionHTTP1,
+			IPAddressType:   targetgroup.IPAddressTypeIPv4,
+		},
+		{
+			Name:            "changed target type",
+			TargetType:      elbv2.TargetTypeEnumInstance,
+			Protocol:        elbv2.ProtocolEnumHttp,
+			ProtocolVersion: targetgroup.BackendProtocolVersionHTTP1,
+			IPAddressType:   targetgroup.IPAddressTypeIPv4,
+			ExpectedAttr:    "target type",
+		},
+		{
+			Name:            "changed protocol",
+			TargetType:      elbv2.TargetTypeEnumIp,
+			Protocol:        elbv2.ProtocolEnumHttps,
+			ProtocolVersion: targetgroup.BackendProtocolVersionHTTP1,
+			IPAddressType:   targetgroup.IPAddressTypeIPv4,
+			ExpectedAttr:    "protocol",
+		},
+		{
+			Name:            "changed protocol version",
+			TargetType:      elbv2.TargetTypeEnumIp,
+			Protocol:        elbv2.ProtocolEnumHttp,
+			ProtocolVersion: targetgroup.BackendProtocolVersionGRPC,
+			IPAddressType:   targetgroup.IPAddressTypeIPv4,
+			ExpectedAttr:    "protocol version",
+		},
+		{
+			Name:            "changed IP address type",
+			TargetType:      elbv2.TargetTypeEnumIp,
+			Protocol:        elbv2.ProtocolEnumHttp,
+			ProtocolVersion: targetgroup.BackendProtocolVersionHTTP1,
+			IPAddressType:   targetgroup.IPAddressTypeIPv6,
+			ExpectedAttr:    "IP address type",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.ExpectedAttr, immutableAttributeChangedOf(instance, tc.TargetType, tc.Protocol, tc.ProtocolVersion, tc.IPAddressType))
+		})
+	}
+}
+
+func Test_otherServicePortOf(t *testing.T) {
+	backend := extensions.IngressBackend{ServiceName: "service", ServicePort: intstr.FromInt(80)}
+	for _, tc := range []struct {
+		Name         string
+		Paths        []extensions.IngressBackend
+		ExpectedPort string
+		ExpectedOK   bool
+	}{
+		{
+			Name: "service routed to on a single port",
+			Paths: []extensions.IngressBackend{
+				backend,
+				backend,
+				{ServiceName: "other", ServicePort: intstr.FromInt(443)},
+			},
+		},
+		{
+			Name: "service routed to on two ports",
+			Paths: []extensions.IngressBackend{
+				backend,
+				{ServiceName: "service", ServicePort: intstr.FromString("https")},
+			},
+			ExpectedPort: "https",
+			ExpectedOK:   true,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			var paths []extensions.HTTPIngressPath
+			for _, path := range tc.Paths {
+				paths = append(paths, extensions.HTTPIngressPath{Backend: path})
+			}
+			ingress := &extensions.Ingress{
+				Spec: extensions.IngressSpec{
+					Rules: []extensions.IngressRule{
+						{IngressRuleValue: extensions.IngressRuleValue{HTTP: &extensions.HTTPIngressRuleValue{Paths: paths}}},
+					},
+				},
+			}
+			port, ok := otherServicePortOf(ingress, backend)
+			assert.Equal(t, tc.ExpectedPort, port)
+			assert.Equal(t, tc.ExpectedOK, ok)
+		})
+	}
+}
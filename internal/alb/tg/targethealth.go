@@ -2,6 +2,7 @@ package tg
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
@@ -31,6 +32,7 @@ type targetGroupWatch struct {
 	interval           chan int64
 	targetsToReconcile chan []*elbv2.TargetDescription
 	cancel             context.CancelFunc
+	done               <-chan struct{}
 }
 
 type targetGroupWatches map[string]*targetGroupWatch
@@ -43,6 +45,7 @@ func newTargetGroupWatch(ctx context.Context, ingress *extensions.Ingress, backe
 		interval:           make(chan int64),
 		targetsToReconcile: make(chan []*elbv2.TargetDescription),
 		cancel:             cancel,
+		done:               ctx.Done(),
 	}, ctx
 }
 
@@ -70,7 +73,10 @@ type targetHealthController struct {
 	store            store.Storer
 	endpointResolver backend.EndpointResolver
 	client           client.Client
-	tgWatches        targetGroupWatches
+
+	// tgWatchesMutex guards tgWatches, since target groups are reconciled by concurrent workers
+	tgWatchesMutex sync.Mutex
+	tgWatches      targetGroupWatches
 }
 
 // SyncTargetsForReconciliation starts a go routine for reconciling pod condition statuses for the given targets in the background until they are healthy in the target group
@@ -87,15 +93,18 @@ func (c *targetHealthController) SyncTargetsForReconciliation(ctx context.Contex
 	// create, update or remove targetGroupWatch for this target group;
 	// a targetGroupWatch exists as long as there are targets in the target group whose pod condition statuses need to be reconciled;
 	// while the targetGroupWatch exists, a go routine regularly monitors the target health of the targets in the target group and updates the pod condition status for the corresponding pods
+	c.tgWatchesMutex.Lock()
 	tgWatch, ok := c.tgWatches[t.TgArn]
 	if ok {
 		if len(targetsToReconcile) == 0 {
 			tgWatch.cancel()
 			delete(c.tgWatches, t.TgArn)
+			c.tgWatchesMutex.Unlock()
 			return nil
 		}
 	} else {
 		if len(targetsToReconcile) == 0 {
+			c.tgWatchesMutex.Unlock()
 			return nil
 		}
 
@@ -106,14 +115,26 @@ func (c *targetHealthController) SyncTargetsForReconciliation(ctx context.Contex
 		// start watching target health in target group and updating pod condition status
 		go c.reconcilePodConditionsLoop(ctx, t.TgArn, tgWatch, readinessConditionTypes...)
 	}
-	tgWatch.interval <- c.ingressTargetHealthReconciliationInterval(t.Backend.ServiceName, t.Ingress)
-	tgWatch.targetsToReconcile <- targetsToReconcile
+	c.tgWatchesMutex.Unlock()
+
+	// the watch may be stopped concurrently, in which case nobody is receiving anymore.
+	select {
+	case tgWatch.interval <- c.ingressTargetHealthReconciliationInterval(t.Backend.ServiceName, t.Ingress):
+	case <-tgWatch.done:
+		return nil
+	}
+	select {
+	case tgWatch.targetsToReconcile <- targetsToReconcile:
+	case <-tgWatch.done:
+	}
 
 	return nil
 }
 
 // StopReconcilingPodConditionStatus stops a running go routine (if there is any) which was started to reconcile pod condition statuses in the background for a specific target group
 func (c *targetHealthController) StopReconcilingPodConditionStatus(tgArn string) {
+	c.tgWatchesMutex.Lock()
+	defer c.tgWatchesMutex.Unlock()
 	tgWatch, ok := c.tgWatches[tgArn]
 	if ok {
 		tgWatch.cancel()
@@ -178,7 +199,7 @@ func (c *targetHealthController) reconcilePodConditionsLoop(ctx context.Context,
 				targetsToReconcile = notReadyTargets
 			} else {
 				logger.Errorf("Failed to reconcile pod condition status: %v", err)
-				albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "Error reconciling pod condition status via target group %s: %s", tgArn, err.Error())
+				albctx.GetEventf(ctx)(api.EventTypeWarning, albctx.EventReasonError, "Error reconciling pod condition status via target group %s: %s", tgArn, err.Error())
 			}
 
 		case <-ctx.Done():
@@ -280,7 +301,7 @@ func (c *targetHealthController) filterTargetsNeedingReconciliation(t *Targets,
 func (c *targetHealthController) ingressTargetHealthReconciliationInterval(serviceName string, ingress *extensions.Ingress) int64 {
 	ingressAnnos, err := c.store.GetIngressAnnotations(k8s.MetaNamespaceKey(ingress))
 	if err == nil {
-		serviceKey := types.NamespacedName{Namespace: ingress.Namespace, Name: serviceName}
+		serviceKey := types.NamespacedName{Namespace: backend.ServiceNamespace(ingress, serviceName), Name: serviceName}
 		serviceAnnos, err := c.store.GetServiceAnnotations(serviceKey.String(), ingressAnnos)
 		if err == nil {
 			return *serviceAnnos.HealthCheck.IntervalSeconds
@@ -83,7 +83,7 @@ func (c *targetsController) Reconcile(ctx context.Context, t *Targets) error {
 		// with target type == node, a 1:1 mapping between ALB target and pod is only possible if hostPort is used, which is discouraged
 		if err := c.healthController.SyncTargetsForReconciliation(ctx, t, desired); err != nil {
 			albctx.GetLogger(ctx).Errorf("Error syncing targets in target group %v for pod condition status reconciliation: %v", t.TgArn, err.Error())
-			albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "Error syncing targets in target group %s for pod condition status reconciliation: %s", t.TgArn, err.Error())
+			albctx.GetEventf(ctx)(api.EventTypeWarning, albctx.EventReasonError, "Error syncing targets in target group %s for pod condition status reconciliation: %s", t.TgArn, err.Error())
 			return err
 		}
 	}
@@ -98,7 +98,7 @@ func (c *targetsController) Reconcile(ctx context.Context, t *Targets) error {
 
 		if _, err := c.cloud.RegisterTargetsWithContext(ctx, in); err != nil {
 			albctx.GetLogger(ctx).Errorf("Error adding targets to %v: %v", t.TgArn, err.Error())
-			albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "Error adding targets to target group %s: %s", t.TgArn, err.Error())
+			albctx.GetEventf(ctx)(api.EventTypeWarning, albctx.EventReasonError, "Error adding targets to target group %s: %s", t.TgArn, err.Error())
 			return err
 		}
 		// TODO add Add events ?
@@ -119,7 +119,7 @@ func (c *targetsController) Reconcile(ctx context.Context, t *Targets) error {
 
 		if _, err := c.cloud.DeregisterTargetsWithContext(ctx, in); err != nil {
 			albctx.GetLogger(ctx).Errorf("Error removing targets from %v: %v", t.TgArn, err.Error())
-			albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "Error removing targets from target group %s: %s", t.TgArn, err.Error())
+			albctx.GetEventf(ctx)(api.EventTypeWarning, albctx.EventReasonError, "Error removing targets from target group %s: %s", t.TgArn, err.Error())
 			return err
 		}
 		// TODO add Delete events ?
@@ -132,6 +132,8 @@ func (c *targetsController) StopReconcilingPodConditionStatus(tgArn string) {
 	c.healthController.StopReconcilingPodConditionStatus(tgArn)
 }
 
+// getCurrentTargets describes the targets registered to the targetGroup rather than remembering them between reconciles,
+// so the first reconcile after a restart of the controller leaves unchanged targets registered.
 func (c *targetsController) getCurrentTargets(ctx context.Context, TgArn string) ([]*elbv2.TargetDescription, error) {
 	opts := &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(TgArn)}
 	resp, err := c.cloud.DescribeTargetHealthWithContext(ctx, opts)
@@ -305,6 +305,69 @@ func Test_TargetsReconcile(t *testing.T) {
 
 	}
 }
+
+// Test_TargetsReconcile_restart reconciles unchanged targets with a new controller each time, like after restarts of the
+// controller, which must leave their registrations alone.
+func Test_TargetsReconcile_restart(t *testing.T) {
+	tgArn := "arn:"
+	backend := &extensions.IngressBackend{ServiceName: "name", ServicePort: intstr.FromInt(123)}
+
+	for _, tc := range []struct {
+		Name       string
+		TargetType string
+		Registered []*elbv2.TargetHealthDescription
+		Resolved   []*elbv2.TargetDescription
+	}{
+		{
+			Name:       "instance targets",
+			TargetType: elbv2.TargetTypeEnumInstance,
+			Registered: []*elbv2.TargetHealthDescription{
+				{Target: newTdWithAZ("i-1", 30080, "us-west-2a"), TargetHealth: newTh(elbv2.TargetHealthStateEnumHealthy)},
+				{Target: newTdWithAZ("i-2", 30080, "us-west-2b"), TargetHealth: newTh(elbv2.TargetHealthStateEnumUnhealthy)},
+			},
+			Resolved: []*elbv2.TargetDescription{newTd("i-2", 30080), newTd("i-1", 30080)},
+		},
+		{
+			Name:       "ip targets, in and out of the VPC",
+			TargetType: elbv2.TargetTypeEnumIp,
+			Registered: []*elbv2.TargetHealthDescription{
+				{Target: newTdWithAZ("192.168.0.1", 8080, "us-west-2a"), TargetHealth: newTh(elbv2.TargetHealthStateEnumInitial)},
+				{Target: newTdWithAZ("10.0.0.1", 8080, "all"), TargetHealth: newTh(elbv2.TargetHealthStateEnumHealthy)},
+			},
+			Resolved: []*elbv2.TargetDescription{newTd("192.168.0.1", 8080), newTd("10.0.0.1", 8080)},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			for restart := 0; restart < 2; restart++ {
+				ingress := dummy.NewIngress()
+				endpointResolver := &mocks.EndpointResolver{}
+				var resolved []*elbv2.TargetDescription
+				for _, td := range tc.Resolved {
+					resolved = append(resolved, newTd(aws.StringValue(td.Id), aws.Int64Value(td.Port)))
+				}
+				endpointResolver.On("Resolve", ingress, backend, tc.TargetType).Return(resolved, nil)
+				endpointResolver.On("ReverseResolve", ingress, backend, mock.Anything).Return(make([]*corev1.Pod, len(resolved)), nil)
+
+				cloud := &mocks.CloudAPI{}
+				cloud.On("DescribeTargetHealthWithContext", ctx, &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(tgArn)}).Return(
+					&elbv2.DescribeTargetHealthOutput{TargetHealthDescriptions: tc.Registered}, nil)
+				cloud.On("GetVpcWithContext", ctx).Return(&ec2.Vpc{
+					CidrBlockAssociationSet: []*ec2.VpcCidrBlockAssociation{{CidrBlock: aws.String("192.168.0.0/24")}},
+				}, nil)
+
+				healthController := NewTargetHealthController(cloud, &store.MockStorer{}, endpointResolver, testclient.NewFakeClient())
+				controller := NewTargetsController(cloud, endpointResolver, healthController)
+				err := controller.Reconcile(ctx, &Targets{TgArn: tgArn, Ingress: ingress, Backend: backend, TargetType: tc.TargetType})
+
+				assert.NoError(t, err)
+				cloud.AssertNotCalled(t, "RegisterTargetsWithContext", mock.Anything, mock.Anything)
+				cloud.AssertNotCalled(t, "DeregisterTargetsWithContext", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
 func Test_targetChangeSets(t *testing.T) {
 	for _, tc := range []struct {
 		name      string
@@ -25,9 +25,9 @@ type TargetGroupGroup struct {
 // NameGenerator provides name generation functionality for tg package.
 type NameGenerator interface {
 	// NameTG generates name for targetGroups.
-	// Note: targetType & protocol is included here to ensure we'll create new targetGroups if one of them changed(they cannot be modified)
+	// Note: targetType, protocol, protocolVersion & ipAddressType is included here to ensure we'll create new targetGroups if one of them changed(they cannot be modified)
 	NameTG(namespace string, ingressName string, serviceName, servicePort string,
-		targetType string, protocol string) string
+		targetType string, protocol string, protocolVersion string, ipAddressType string) string
 }
 
 // TagGenerator provides tag generation functionality for tg package.
@@ -6,17 +6,28 @@ import (
 
 	"github.com/golang/glog"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	extensions "k8s.io/api/extensions/v1beta1"
 )
 
 type contextKey string
 
 var (
-	contextKeyEventf = contextKey("Eventf")
-	contextKeyLogger = contextKey("Logger")
+	contextKeyEventf      = contextKey("Eventf")
+	contextKeyLogger      = contextKey("Logger")
+	contextKeyIAMRoleARN  = contextKey("IAMRoleARN")
+	contextKeyRuleSources = contextKey("RuleSources")
 )
 
 type Eventf func(string, string, string, ...interface{})
 
+// Reasons of the events recorded against an ingress when its AWS resources change.
+const (
+	EventReasonCreate = "CREATE"
+	EventReasonModify = "MODIFY"
+	EventReasonDelete = "DELETE"
+	EventReasonError  = "ERROR"
+)
+
 func missingEventf(eventType, reason, format string, vals ...interface{}) {
 	f := fmt.Sprintf("Event function missing. Type(%v) Reason(%v): %v", eventType, reason, format)
 	glog.Errorf(f, vals...)
@@ -44,3 +55,46 @@ func GetLogger(ctx context.Context) *log.Logger {
 	}
 	return logger
 }
+
+// SetIAMRoleARN sets the IAM role assumed for AWS API calls made on behalf of the ingress being reconciled.
+func SetIAMRoleARN(ctx context.Context, roleARN string) context.Context {
+	return context.WithValue(ctx, contextKeyIAMRoleARN, roleARN)
+}
+
+// GetIAMRoleARN returns the IAM role to assume for AWS API calls, or "" to use the controller's own credentials.
+func GetIAMRoleARN(ctx context.Context) string {
+	roleARN, _ := ctx.Value(contextKeyIAMRoleARN).(string)
+	return roleARN
+}
+
+// RuleSources tells which member of an ingress group declared each rule and the default backend of the ingress the
+// group is merged into, since the authentication annotations of a member only apply to its own rules.
+type RuleSources struct {
+	// Rules holds the member that declared each rule of the merged ingress, by index.
+	Rules []*extensions.Ingress
+	// Backend is the member that declared the default backend of the merged ingress, if any.
+	Backend *extensions.Ingress
+}
+
+// SetRuleSources sets the members of the ingress group being reconciled that declared its rules.
+func SetRuleSources(ctx context.Context, sources RuleSources) context.Context {
+	return context.WithValue(ctx, contextKeyRuleSources, sources)
+}
+
+// GetRuleSource returns the ingress that declared the i-th rule of ingress: the member of its ingress group set by
+// SetRuleSources, or else ingress itself.
+func GetRuleSource(ctx context.Context, ingress *extensions.Ingress, i int) *extensions.Ingress {
+	if sources, ok := ctx.Value(contextKeyRuleSources).(RuleSources); ok && i < len(sources.Rules) && sources.Rules[i] != nil {
+		return sources.Rules[i]
+	}
+	return ingress
+}
+
+// GetBackendSource returns the ingress that declared the default backend of ingress: the member of its ingress group
+// set by SetRuleSources, or else ingress itself.
+func GetBackendSource(ctx context.Context, ingress *extensions.Ingress) *extensions.Ingress {
+	if sources, ok := ctx.Value(contextKeyRuleSources).(RuleSources); ok && sources.Backend != nil {
+		return sources.Backend
+	}
+	return ingress
+}
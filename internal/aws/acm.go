@@ -49,7 +49,7 @@ func (c *Cloud) ACMAvailable() bool {
 // Apply a filter to the query using the status parameter
 func (c *Cloud) ListCertificates(ctx context.Context, input *acm.ListCertificatesInput) ([]*acm.CertificateSummary, error) {
 	var certSummaries []*acm.CertificateSummary
-	if err := c.acm.ListCertificatesPagesWithContext(ctx, input, func(output *acm.ListCertificatesOutput, _ bool) bool {
+	if err := c.forRole(ctx).acm.ListCertificatesPagesWithContext(ctx, input, func(output *acm.ListCertificatesOutput, _ bool) bool {
 		certSummaries = append(certSummaries, output.CertificateSummaryList...)
 		return true
 	}); err != nil {
@@ -60,7 +60,7 @@ func (c *Cloud) ListCertificates(ctx context.Context, input *acm.ListCertificate
 }
 
 func (c *Cloud) DescribeCertificate(ctx context.Context, certArn string) (*acm.CertificateDetail, error) {
-	resp, err := c.acm.DescribeCertificateWithContext(ctx, &acm.DescribeCertificateInput{
+	resp, err := c.forRole(ctx).acm.DescribeCertificateWithContext(ctx, &acm.DescribeCertificateInput{
 		CertificateArn: aws.String(certArn),
 	})
 	if err != nil {
@@ -2,8 +2,12 @@ package aws
 
 import (
 	"fmt"
+	"sync"
 
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -18,12 +22,15 @@ import (
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
 	"github.com/aws/aws-sdk-go/service/shield"
 	"github.com/aws/aws-sdk-go/service/shield/shieldiface"
 	"github.com/aws/aws-sdk-go/service/wafregional"
 	"github.com/aws/aws-sdk-go/service/wafregional/wafregionaliface"
 	"github.com/aws/aws-sdk-go/service/wafv2"
 	"github.com/aws/aws-sdk-go/service/wafv2/wafv2iface"
+	"github.com/golang/glog"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
 	"github.com/ticketmaster/aws-sdk-go-cache/cache"
 )
@@ -34,6 +41,7 @@ type CloudAPI interface {
 	ELBV2API
 	IAMAPI
 	ResourceGroupsTaggingAPIAPI
+	Route53API
 	ShieldAPI
 	WAFRegionalAPI
 	WAFV2API
@@ -53,8 +61,14 @@ type Cloud struct {
 	iam         iamiface.IAMAPI
 	shield      shieldiface.ShieldAPI
 	rgt         resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
+	route53     route53iface.Route53API
 	wafregional wafregionaliface.WAFRegionalAPI
 	wafv2       wafv2iface.WAFV2API
+
+	// newRoleSession creates the session used for ingresses that assume an IAM role, it is nil for Clouds of assumed roles.
+	newRoleSession  func(roleARN string) *session.Session
+	roleCloudsMutex sync.Mutex
+	roleClouds      map[string]*Cloud
 }
 
 // Initialize the global AWS clients.
@@ -78,24 +92,50 @@ func New(cfg CloudConfig, clusterName string, mc metric.Collector, ce bool, cc *
 		}
 		cfg.Region = region
 	}
+	glog.Infof("managing AWS resources in region %v", cfg.Region)
 
-	awsCfg := aws.NewConfig().WithRegion(cfg.Region).WithSTSRegionalEndpoint(endpoints.RegionalSTSEndpoint).WithMaxRetries(cfg.APIMaxRetries)
+	awsCfg := aws.NewConfig().WithRegion(cfg.Region).WithSTSRegionalEndpoint(endpoints.RegionalSTSEndpoint)
+	awsCfg = request.WithRetryer(awsCfg, newRetryer(cfg))
 	awsSession := NewSession(awsCfg, cfg.APIDebug, mc, ce, cc)
+	if cfg.DryRun {
+		glog.Warningf("Running in dry-run mode, AWS resources will not be created, modified or deleted")
+		AddDryRunHandler(awsSession)
+	}
 	return &Cloud{
-		cfg.VpcID,
-		cfg.Region,
-		clusterName,
-		acm.New(awsSession),
-		ec2.New(awsSession),
-		elbv2.New(awsSession),
-		iam.New(awsSession),
-		shield.New(awsSession, &aws.Config{Region: aws.String("us-east-1")}),
-		resourcegroupstaggingapi.New(awsSession),
-		wafregional.New(awsSession),
-		wafv2.New(awsSession),
+		vpcID:       cfg.VpcID,
+		region:      cfg.Region,
+		clusterName: clusterName,
+		acm:         acm.New(awsSession),
+		ec2:         ec2.New(awsSession),
+		elbv2:       elbv2.New(awsSession),
+		iam:         iam.New(awsSession),
+		shield:      shield.New(awsSession, &aws.Config{Region: aws.String("us-east-1")}),
+		rgt:         resourcegroupstaggingapi.New(awsSession),
+		route53:     route53.New(awsSession),
+		wafregional: wafregional.New(awsSession),
+		wafv2:       wafv2.New(awsSession),
+		newRoleSession: func(roleARN string) *session.Session {
+			roleCfg := awsCfg.Copy().WithCredentials(stscreds.NewCredentials(awsSession, roleARN))
+			roleSession := NewSession(roleCfg, cfg.APIDebug, mc, ce, cc)
+			if cfg.DryRun {
+				AddDryRunHandler(roleSession)
+			}
+			return roleSession
+		},
 	}, nil
 }
 
+// newRetryer returns a retryer that backs off exponentially with jitter, using a longer base delay when AWS throttles us.
+func newRetryer(cfg CloudConfig) request.Retryer {
+	return client.DefaultRetryer{
+		NumMaxRetries:    cfg.APIMaxRetries,
+		MinRetryDelay:    cfg.APIRetryBaseDelay,
+		MinThrottleDelay: cfg.APIThrottleBaseDelay,
+		MaxRetryDelay:    cfg.APIRetryMaxDelay,
+		MaxThrottleDelay: cfg.APIRetryMaxDelay,
+	}
+}
+
 func (c *Cloud) GetClusterName() string {
 	return c.clusterName
 }
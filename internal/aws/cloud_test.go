@@ -2,8 +2,12 @@ package aws
 
 import (
 	"net/http"
+	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/stretchr/testify/assert"
 )
 
 func newReq(data interface{}, err error) *request.Request {
@@ -14,3 +18,58 @@ func newReq(data interface{}, err error) *request.Request {
 		Error:       err,
 	}
 }
+
+func Test_newRetryer(t *testing.T) {
+	retryer := newRetryer(CloudConfig{
+		APIMaxRetries:        5,
+		APIRetryBaseDelay:    10 * time.Millisecond,
+		APIThrottleBaseDelay: 100 * time.Millisecond,
+		APIRetryMaxDelay:     time.Second,
+	})
+	assert.Equal(t, 5, retryer.MaxRetries())
+
+	for _, tc := range []struct {
+		Name       string
+		Err        error
+		RetryCount int
+		MinDelay   time.Duration
+		MaxDelay   time.Duration
+	}{
+		{
+			Name:       "first retry of a failed call",
+			Err:        awserr.New("InternalFailure", "", nil),
+			RetryCount: 0,
+			MinDelay:   10 * time.Millisecond,
+			MaxDelay:   20 * time.Millisecond,
+		},
+		{
+			Name:       "third retry of a failed call",
+			Err:        awserr.New("InternalFailure", "", nil),
+			RetryCount: 2,
+			MinDelay:   40 * time.Millisecond,
+			MaxDelay:   80 * time.Millisecond,
+		},
+		{
+			Name:       "first retry of a throttled call",
+			Err:        awserr.New("Throttling", "", nil),
+			RetryCount: 0,
+			MinDelay:   100 * time.Millisecond,
+			MaxDelay:   200 * time.Millisecond,
+		},
+		{
+			Name:       "backoff is capped by the max delay",
+			Err:        awserr.New("Throttling", "", nil),
+			RetryCount: 4,
+			MinDelay:   500 * time.Millisecond,
+			MaxDelay:   time.Second,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := newReq(nil, tc.Err)
+			req.HTTPResponse = &http.Response{StatusCode: http.StatusBadRequest, Header: http.Header{}}
+			req.RetryCount = tc.RetryCount
+			delay := retryer.RetryRules(req)
+			assert.True(t, delay >= tc.MinDelay && delay < tc.MaxDelay, "delay %v not in [%v, %v)", delay, tc.MinDelay, tc.MaxDelay)
+		})
+	}
+}
@@ -3,19 +3,29 @@ package aws
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strconv"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/golang/glog"
 	"github.com/spf13/pflag"
 )
 
 const (
-	defaultVpcID         = ""
-	defaultRegion        = ""
-	defaultAPIMaxRetries = 10
-	defaultAPIDebug      = false
+	defaultVpcID                = ""
+	defaultRegion               = ""
+	defaultAPIMaxRetries        = 10
+	defaultAPIRetryBaseDelay    = 30 * time.Millisecond
+	defaultAPIThrottleBaseDelay = 500 * time.Millisecond
+	defaultAPIRetryMaxDelay     = 60 * time.Second
+	defaultAPIDebug             = false
+	defaultDryRun               = false
 )
 
+// regionPattern matches AWS region names, e.g. us-west-2, us-gov-east-1 or cn-north-1.
+var regionPattern = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-\d+$`)
+
 // configuration for cloud
 type CloudConfig struct {
 	VpcID  string
@@ -23,17 +33,49 @@ type CloudConfig struct {
 
 	APIMaxRetries int
 	APIDebug      bool
+
+	// APIRetryBaseDelay and APIThrottleBaseDelay are the initial delays of the exponential backoff
+	// for failed and throttled AWS API calls, APIRetryMaxDelay caps the backoff for both.
+	APIRetryBaseDelay    time.Duration
+	APIThrottleBaseDelay time.Duration
+	APIRetryMaxDelay     time.Duration
+
+	// DryRun skips AWS API calls that would change resources, logging them instead.
+	DryRun bool
 }
 
 func (cfg *CloudConfig) BindFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&cfg.VpcID, "aws-vpc-id", defaultVpcID,
 		`AWS VPC ID for the kubernetes cluster`)
 	fs.StringVar(&cfg.Region, "aws-region", defaultRegion,
-		`AWS Region for the kubernetes cluster`)
+		`AWS Region of the managed resources, which may differ from the one of the kubernetes cluster. Defaults to the AWS_REGION environment variable, or else the region of the node from ec2Metadata`)
 	fs.IntVar(&cfg.APIMaxRetries, "aws-max-retries", defaultAPIMaxRetries,
 		`Maximum number of times to retry the AWS API.`)
+	fs.DurationVar(&cfg.APIRetryBaseDelay, "aws-retry-base-delay", defaultAPIRetryBaseDelay,
+		`Initial delay before retrying a failed AWS API call, doubled with jitter on each retry`)
+	fs.DurationVar(&cfg.APIThrottleBaseDelay, "aws-throttle-base-delay", defaultAPIThrottleBaseDelay,
+		`Initial delay before retrying a throttled AWS API call, doubled with jitter on each retry`)
+	fs.DurationVar(&cfg.APIRetryMaxDelay, "aws-retry-max-delay", defaultAPIRetryMaxDelay,
+		`Maximum delay between retries of an AWS API call`)
 	fs.BoolVar(&cfg.APIDebug, "aws-api-debug", defaultAPIDebug,
 		`Enable debug logging of AWS API`)
+	fs.BoolVar(&cfg.DryRun, "dry-run", defaultDryRun,
+		`Log AWS API calls that would change resources instead of making them`)
+}
+
+// Validate checks Region, if set, is formatted like an AWS region. Regions unknown to the AWS SDK are only warned about,
+// as they may have been launched after its release.
+func (cfg *CloudConfig) Validate() error {
+	if cfg.Region == "" {
+		return nil
+	}
+	if !regionPattern.MatchString(cfg.Region) {
+		return fmt.Errorf("region %q is not an AWS region, e.g. us-west-2", cfg.Region)
+	}
+	if _, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), cfg.Region); !ok {
+		glog.Warningf("region %v is unknown to the AWS SDK, check --aws-region if AWS API calls fail", cfg.Region)
+	}
+	return nil
 }
 
 func (cfg *CloudConfig) BindEnv() error {
@@ -42,8 +84,8 @@ func (cfg *CloudConfig) BindEnv() error {
 		cfg.VpcID = s
 	}
 
-	if s, ok := os.LookupEnv("AWS_REGION"); ok {
-		glog.Warningf("Environment variable configuration is deprecated, switch to the --aws-region flag.")
+	// AWS_REGION is also read by the AWS CLI and SDKs, so it's only a fallback for the flag.
+	if s, ok := os.LookupEnv("AWS_REGION"); ok && cfg.Region == "" {
 		cfg.Region = s
 	}
 
@@ -0,0 +1,44 @@
+package aws
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloudConfig_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		Region        string
+		ExpectedError error
+	}{
+		{Region: ""},
+		{Region: "us-west-2"},
+		{Region: "us-gov-east-1"},
+		{Region: "cn-north-1"},
+		{Region: "ap-southeast-3"},
+		{Region: "us-west", ExpectedError: errors.New(`region "us-west" is not an AWS region, e.g. us-west-2`)},
+		{Region: "US-WEST-2", ExpectedError: errors.New(`region "US-WEST-2" is not an AWS region, e.g. us-west-2`)},
+		{Region: "us-west-2a", ExpectedError: errors.New(`region "us-west-2a" is not an AWS region, e.g. us-west-2`)},
+	} {
+		t.Run(tc.Region, func(t *testing.T) {
+			cfg := CloudConfig{Region: tc.Region}
+			assert.Equal(t, tc.ExpectedError, cfg.Validate())
+		})
+	}
+}
+
+func TestCloudConfig_BindEnv_region(t *testing.T) {
+	defer os.Unsetenv("AWS_REGION")
+	os.Setenv("AWS_REGION", "eu-west-1")
+
+	cfg := CloudConfig{}
+	assert.NoError(t, cfg.BindEnv())
+	assert.Equal(t, "eu-west-1", cfg.Region)
+
+	// the flag takes precedence.
+	cfg = CloudConfig{Region: "us-west-2"}
+	assert.NoError(t, cfg.BindEnv())
+	assert.Equal(t, "us-west-2", cfg.Region)
+}
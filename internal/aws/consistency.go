@@ -0,0 +1,39 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// PostCreateBackoff bounds how long reads of a resource created moments ago are retried while the eventually
+// consistent ELBV2 API doesn't find it yet: 5 attempts, over about 15 seconds.
+var PostCreateBackoff = wait.Backoff{Duration: time.Second, Factor: 2, Steps: 5}
+
+// RetryNotFound calls read until it doesn't fail with an error of one of notFoundCodes, waiting as per backoff
+// between attempts. It returns the error of the last attempt once backoff is exhausted or ctx is done.
+func RetryNotFound(ctx context.Context, backoff wait.Backoff, read func() error, notFoundCodes ...string) error {
+	var lastErr error
+	err := wait.ExponentialBackoff(backoff, func() (bool, error) {
+		lastErr = read()
+		if lastErr == nil {
+			return true, nil
+		}
+		awsErr, ok := lastErr.(awserr.Error)
+		if !ok || ctx.Err() != nil {
+			return false, lastErr
+		}
+		for _, code := range notFoundCodes {
+			if awsErr.Code() == code {
+				return false, nil
+			}
+		}
+		return false, lastErr
+	})
+	if err == wait.ErrWaitTimeout {
+		return lastErr
+	}
+	return err
+}
@@ -0,0 +1,63 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestRetryNotFound(t *testing.T) {
+	notFound := awserr.New(elbv2.ErrCodeLoadBalancerNotFoundException, "not found", nil)
+	for _, tc := range []struct {
+		Name             string
+		Errors           []error
+		ExpectedAttempts int
+		ExpectedError    error
+	}{
+		{
+			Name:             "found right away",
+			Errors:           []error{nil},
+			ExpectedAttempts: 1,
+		},
+		{
+			Name:             "found once visible",
+			Errors:           []error{notFound, notFound, nil},
+			ExpectedAttempts: 3,
+		},
+		{
+			Name:             "never visible",
+			Errors:           []error{notFound, notFound, notFound, notFound},
+			ExpectedAttempts: 3,
+			ExpectedError:    notFound,
+		},
+		{
+			Name:             "other AWS errors aren't retried",
+			Errors:           []error{awserr.New("AccessDenied", "denied", nil)},
+			ExpectedAttempts: 1,
+			ExpectedError:    awserr.New("AccessDenied", "denied", nil),
+		},
+		{
+			Name:             "other errors aren't retried",
+			Errors:           []error{errors.New("read")},
+			ExpectedAttempts: 1,
+			ExpectedError:    errors.New("read"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			attempts := 0
+			err := RetryNotFound(context.Background(), wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}, func() error {
+				err := tc.Errors[attempts]
+				attempts++
+				return err
+			}, elbv2.ErrCodeLoadBalancerNotFoundException)
+			assert.Equal(t, tc.ExpectedError, err)
+			assert.Equal(t, tc.ExpectedAttempts, attempts)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package aws
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -26,6 +27,9 @@ const (
 type EC2API interface {
 	GetSubnetsByNameOrID(context.Context, []string) ([]*ec2.Subnet, error)
 
+	// GetSubnetsByTags retrieves the subnets within vpc carrying all of the tags, an empty tag value matches any value
+	GetSubnetsByTags(context.Context, map[string]string) ([]*ec2.Subnet, error)
+
 	// StatusEC2 validates EC2 connectivity
 	StatusEC2() func() error
 
@@ -164,6 +168,43 @@ func (c *Cloud) GetSubnetsByNameOrID(ctx context.Context, nameOrIDs []string) (s
 	return
 }
 
+func (c *Cloud) GetSubnetsByTags(ctx context.Context, tags map[string]string) ([]*ec2.Subnet, error) {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var filters []*ec2.Filter
+	for _, key := range keys {
+		if tags[key] == "" {
+			filters = append(filters, &ec2.Filter{
+				Name:   aws.String("tag-key"),
+				Values: []*string{aws.String(key)},
+			})
+			continue
+		}
+		filters = append(filters, &ec2.Filter{
+			Name:   aws.String("tag:" + key),
+			Values: []*string{aws.String(tags[key])},
+		})
+	}
+	filters = append(filters, &ec2.Filter{
+		Name:   aws.String("vpc-id"),
+		Values: []*string{aws.String(c.vpcID)},
+	})
+
+	var result []*ec2.Subnet
+	err := c.ec2.DescribeSubnetsPagesWithContext(ctx, &ec2.DescribeSubnetsInput{Filters: filters}, func(output *ec2.DescribeSubnetsOutput, _ bool) bool {
+		result = append(result, output.Subnets...)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch subnets due to %v", err)
+	}
+	return result, nil
+}
+
 func (c *Cloud) GetClusterSubnets(tagSubnetType string) ([]*ec2.Subnet, error) {
 	in := &ec2.DescribeSubnetsInput{Filters: []*ec2.Filter{
 		{
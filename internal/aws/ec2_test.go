@@ -268,3 +268,68 @@ func TestCloud_GetClusterSubnets(t *testing.T) {
 		})
 	}
 }
+
+func TestCloud_GetSubnetsByTags(t *testing.T) {
+	vpcID := "vpc-id"
+	subnet1 := &ec2.Subnet{SubnetId: aws.String("subnet-1")}
+	subnet2 := &ec2.Subnet{SubnetId: aws.String("subnet-2")}
+
+	for _, tc := range []struct {
+		Name                 string
+		Tags                 map[string]string
+		ExpectedFilters      []*ec2.Filter
+		DescribeSubnetsPages []*ec2.DescribeSubnetsOutput
+		DescribeSubnetsError error
+		ExpectedResult       []*ec2.Subnet
+		ExpectedError        error
+	}{
+		{
+			Name: "tag values and tag keys",
+			Tags: map[string]string{"kubernetes.io/role/elb": "1", "team": ""},
+			ExpectedFilters: []*ec2.Filter{
+				{Name: aws.String("tag:kubernetes.io/role/elb"), Values: aws.StringSlice([]string{"1"})},
+				{Name: aws.String("tag-key"), Values: aws.StringSlice([]string{"team"})},
+				{Name: aws.String("vpc-id"), Values: aws.StringSlice([]string{vpcID})},
+			},
+			DescribeSubnetsPages: []*ec2.DescribeSubnetsOutput{
+				{Subnets: []*ec2.Subnet{subnet1}},
+				{Subnets: []*ec2.Subnet{subnet2}},
+			},
+			ExpectedResult: []*ec2.Subnet{subnet1, subnet2},
+		},
+		{
+			Name: "Error from API call",
+			Tags: map[string]string{"kubernetes.io/role/elb": "1"},
+			ExpectedFilters: []*ec2.Filter{
+				{Name: aws.String("tag:kubernetes.io/role/elb"), Values: aws.StringSlice([]string{"1"})},
+				{Name: aws.String("vpc-id"), Values: aws.StringSlice([]string{vpcID})},
+			},
+			DescribeSubnetsError: errors.New("Some API error"),
+			ExpectedError:        errors.New("unable to fetch subnets due to Some API error"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			svc := &mocks.EC2API{}
+			svc.On("DescribeSubnetsPagesWithContext",
+				ctx,
+				&ec2.DescribeSubnetsInput{Filters: tc.ExpectedFilters},
+				mock.AnythingOfType("func(*ec2.DescribeSubnetsOutput, bool) bool"),
+			).Return(tc.DescribeSubnetsError).Run(func(args mock.Arguments) {
+				arg := args.Get(2).(func(*ec2.DescribeSubnetsOutput, bool) bool)
+				for i, page := range tc.DescribeSubnetsPages {
+					arg(page, i == len(tc.DescribeSubnetsPages)-1)
+				}
+			})
+
+			cloud := &Cloud{
+				vpcID: vpcID,
+				ec2:   svc,
+			}
+			subnets, err := cloud.GetSubnetsByTags(ctx, tc.Tags)
+			assert.Equal(t, tc.ExpectedResult, subnets)
+			assert.Equal(t, tc.ExpectedError, err)
+			svc.AssertExpectations(t)
+		})
+	}
+}
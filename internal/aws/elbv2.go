@@ -13,6 +13,9 @@ import (
 type ELBV2API interface {
 	StatusELBV2() func() error
 
+	// ProbeELBV2 validates ELBV2 connectivity, giving up once ctx is done
+	ProbeELBV2(context.Context) error
+
 	GetRules(context.Context, string) ([]*elbv2.Rule, error)
 
 	// ListListenersByLoadBalancer gets all listeners for loadbalancer.
@@ -36,6 +39,9 @@ type ELBV2API interface {
 	// GetTargetGroupByName retrieve TargetGroup instance by name
 	GetTargetGroupByName(context.Context, string) (*elbv2.TargetGroup, error)
 
+	// GetTargetGroupsByArns retrieve TargetGroup instances by arns, in as few calls as the API allows
+	GetTargetGroupsByArns(context.Context, []string) ([]*elbv2.TargetGroup, error)
+
 	// DeleteTargetGroupByArn deletes TargetGroup instance by arn
 	DeleteTargetGroupByArn(context.Context, string) error
 
@@ -58,6 +64,8 @@ type ELBV2API interface {
 	SetIpAddressTypeWithContext(context.Context, *elbv2.SetIpAddressTypeInput) (*elbv2.SetIpAddressTypeOutput, error)
 	SetSubnetsWithContext(context.Context, *elbv2.SetSubnetsInput) (*elbv2.SetSubnetsOutput, error)
 
+	DescribeELBV2AccountLimitsWithContext(context.Context, *elbv2.DescribeAccountLimitsInput) (*elbv2.DescribeAccountLimitsOutput, error)
+
 	DescribeELBV2TagsWithContext(context.Context, *elbv2.DescribeTagsInput) (*elbv2.DescribeTagsOutput, error)
 	AddELBV2TagsWithContext(context.Context, *elbv2.AddTagsInput) (*elbv2.AddTagsOutput, error)
 	RemoveELBV2TagsWithContext(context.Context, *elbv2.RemoveTagsInput) (*elbv2.RemoveTagsOutput, error)
@@ -68,73 +76,76 @@ type ELBV2API interface {
 }
 
 func (c *Cloud) DescribeTargetGroupAttributesWithContext(ctx context.Context, i *elbv2.DescribeTargetGroupAttributesInput) (*elbv2.DescribeTargetGroupAttributesOutput, error) {
-	return c.elbv2.DescribeTargetGroupAttributesWithContext(ctx, i)
+	return c.forRole(ctx).elbv2.DescribeTargetGroupAttributesWithContext(ctx, i)
 }
 
 func (c *Cloud) ModifyTargetGroupAttributesWithContext(ctx context.Context, i *elbv2.ModifyTargetGroupAttributesInput) (*elbv2.ModifyTargetGroupAttributesOutput, error) {
-	return c.elbv2.ModifyTargetGroupAttributesWithContext(ctx, i)
+	return c.forRole(ctx).elbv2.ModifyTargetGroupAttributesWithContext(ctx, i)
 }
 func (c *Cloud) CreateTargetGroupWithContext(ctx context.Context, i *elbv2.CreateTargetGroupInput) (*elbv2.CreateTargetGroupOutput, error) {
 	if i.VpcId == nil {
 		i.VpcId = aws.String(c.vpcID)
 	}
-	return c.elbv2.CreateTargetGroupWithContext(ctx, i)
+	return c.forRole(ctx).elbv2.CreateTargetGroupWithContext(ctx, i)
 }
 
 func (c *Cloud) ModifyTargetGroupWithContext(ctx context.Context, i *elbv2.ModifyTargetGroupInput) (*elbv2.ModifyTargetGroupOutput, error) {
-	return c.elbv2.ModifyTargetGroupWithContext(ctx, i)
+	return c.forRole(ctx).elbv2.ModifyTargetGroupWithContext(ctx, i)
 }
 
 func (c *Cloud) RegisterTargetsWithContext(ctx context.Context, i *elbv2.RegisterTargetsInput) (*elbv2.RegisterTargetsOutput, error) {
-	return c.elbv2.RegisterTargetsWithContext(ctx, i)
+	return c.forRole(ctx).elbv2.RegisterTargetsWithContext(ctx, i)
 }
 func (c *Cloud) DeregisterTargetsWithContext(ctx context.Context, i *elbv2.DeregisterTargetsInput) (*elbv2.DeregisterTargetsOutput, error) {
-	return c.elbv2.DeregisterTargetsWithContext(ctx, i)
+	return c.forRole(ctx).elbv2.DeregisterTargetsWithContext(ctx, i)
 }
 func (c *Cloud) DescribeTargetHealthWithContext(ctx context.Context, i *elbv2.DescribeTargetHealthInput) (*elbv2.DescribeTargetHealthOutput, error) {
-	return c.elbv2.DescribeTargetHealthWithContext(ctx, i)
+	return c.forRole(ctx).elbv2.DescribeTargetHealthWithContext(ctx, i)
 }
 func (c *Cloud) CreateRuleWithContext(ctx context.Context, i *elbv2.CreateRuleInput) (*elbv2.CreateRuleOutput, error) {
-	return c.elbv2.CreateRuleWithContext(ctx, i)
+	return c.forRole(ctx).elbv2.CreateRuleWithContext(ctx, i)
 }
 func (c *Cloud) ModifyRuleWithContext(ctx context.Context, i *elbv2.ModifyRuleInput) (*elbv2.ModifyRuleOutput, error) {
-	return c.elbv2.ModifyRuleWithContext(ctx, i)
+	return c.forRole(ctx).elbv2.ModifyRuleWithContext(ctx, i)
 }
 func (c *Cloud) DeleteRuleWithContext(ctx context.Context, i *elbv2.DeleteRuleInput) (*elbv2.DeleteRuleOutput, error) {
-	return c.elbv2.DeleteRuleWithContext(ctx, i)
+	return c.forRole(ctx).elbv2.DeleteRuleWithContext(ctx, i)
 }
 func (c *Cloud) SetSecurityGroupsWithContext(ctx context.Context, i *elbv2.SetSecurityGroupsInput) (*elbv2.SetSecurityGroupsOutput, error) {
-	return c.elbv2.SetSecurityGroupsWithContext(ctx, i)
+	return c.forRole(ctx).elbv2.SetSecurityGroupsWithContext(ctx, i)
 }
 func (c *Cloud) CreateListenerWithContext(ctx context.Context, i *elbv2.CreateListenerInput) (*elbv2.CreateListenerOutput, error) {
-	return c.elbv2.CreateListenerWithContext(ctx, i)
+	return c.forRole(ctx).elbv2.CreateListenerWithContext(ctx, i)
 }
 func (c *Cloud) ModifyListenerWithContext(ctx context.Context, i *elbv2.ModifyListenerInput) (*elbv2.ModifyListenerOutput, error) {
-	return c.elbv2.ModifyListenerWithContext(ctx, i)
+	return c.forRole(ctx).elbv2.ModifyListenerWithContext(ctx, i)
 }
 func (c *Cloud) DescribeLoadBalancerAttributesWithContext(ctx context.Context, i *elbv2.DescribeLoadBalancerAttributesInput) (*elbv2.DescribeLoadBalancerAttributesOutput, error) {
-	return c.elbv2.DescribeLoadBalancerAttributesWithContext(ctx, i)
+	return c.forRole(ctx).elbv2.DescribeLoadBalancerAttributesWithContext(ctx, i)
 }
 func (c *Cloud) ModifyLoadBalancerAttributesWithContext(ctx context.Context, i *elbv2.ModifyLoadBalancerAttributesInput) (*elbv2.ModifyLoadBalancerAttributesOutput, error) {
-	return c.elbv2.ModifyLoadBalancerAttributesWithContext(ctx, i)
+	return c.forRole(ctx).elbv2.ModifyLoadBalancerAttributesWithContext(ctx, i)
 }
 func (c *Cloud) CreateLoadBalancerWithContext(ctx context.Context, i *elbv2.CreateLoadBalancerInput) (*elbv2.CreateLoadBalancerOutput, error) {
-	return c.elbv2.CreateLoadBalancerWithContext(ctx, i)
+	return c.forRole(ctx).elbv2.CreateLoadBalancerWithContext(ctx, i)
 }
 func (c *Cloud) SetIpAddressTypeWithContext(ctx context.Context, i *elbv2.SetIpAddressTypeInput) (*elbv2.SetIpAddressTypeOutput, error) {
-	return c.elbv2.SetIpAddressTypeWithContext(ctx, i)
+	return c.forRole(ctx).elbv2.SetIpAddressTypeWithContext(ctx, i)
 }
 func (c *Cloud) SetSubnetsWithContext(ctx context.Context, i *elbv2.SetSubnetsInput) (*elbv2.SetSubnetsOutput, error) {
-	return c.elbv2.SetSubnetsWithContext(ctx, i)
+	return c.forRole(ctx).elbv2.SetSubnetsWithContext(ctx, i)
+}
+func (c *Cloud) DescribeELBV2AccountLimitsWithContext(ctx context.Context, i *elbv2.DescribeAccountLimitsInput) (*elbv2.DescribeAccountLimitsOutput, error) {
+	return c.forRole(ctx).elbv2.DescribeAccountLimitsWithContext(ctx, i)
 }
 func (c *Cloud) DescribeELBV2TagsWithContext(ctx context.Context, i *elbv2.DescribeTagsInput) (*elbv2.DescribeTagsOutput, error) {
-	return c.elbv2.DescribeTagsWithContext(ctx, i)
+	return c.forRole(ctx).elbv2.DescribeTagsWithContext(ctx, i)
 }
 func (c *Cloud) AddELBV2TagsWithContext(ctx context.Context, i *elbv2.AddTagsInput) (*elbv2.AddTagsOutput, error) {
-	return c.elbv2.AddTagsWithContext(ctx, i)
+	return c.forRole(ctx).elbv2.AddTagsWithContext(ctx, i)
 }
 func (c *Cloud) RemoveELBV2TagsWithContext(ctx context.Context, i *elbv2.RemoveTagsInput) (*elbv2.RemoveTagsOutput, error) {
-	return c.elbv2.RemoveTagsWithContext(ctx, i)
+	return c.forRole(ctx).elbv2.RemoveTagsWithContext(ctx, i)
 }
 
 func (c *Cloud) DescribeListenerCertificates(ctx context.Context, lsArn string) ([]*elbv2.Certificate, error) {
@@ -143,7 +154,7 @@ func (c *Cloud) DescribeListenerCertificates(ctx context.Context, lsArn string)
 	p := request.Pagination{
 		EndPageOnSameToken: true,
 		NewRequest: func() (*request.Request, error) {
-			req, _ := c.elbv2.DescribeListenerCertificatesRequest(&elbv2.DescribeListenerCertificatesInput{
+			req, _ := c.forRole(ctx).elbv2.DescribeListenerCertificatesRequest(&elbv2.DescribeListenerCertificatesInput{
 				ListenerArn: aws.String(lsArn),
 			})
 			req.SetContext(ctx)
@@ -159,11 +170,11 @@ func (c *Cloud) DescribeListenerCertificates(ctx context.Context, lsArn string)
 }
 
 func (c *Cloud) AddListenerCertificates(ctx context.Context, i *elbv2.AddListenerCertificatesInput) (*elbv2.AddListenerCertificatesOutput, error) {
-	return c.elbv2.AddListenerCertificatesWithContext(ctx, i)
+	return c.forRole(ctx).elbv2.AddListenerCertificatesWithContext(ctx, i)
 }
 
 func (c *Cloud) RemoveListenerCertificates(ctx context.Context, i *elbv2.RemoveListenerCertificatesInput) (*elbv2.RemoveListenerCertificatesOutput, error) {
-	return c.elbv2.RemoveListenerCertificatesWithContext(ctx, i)
+	return c.forRole(ctx).elbv2.RemoveListenerCertificatesWithContext(ctx, i)
 }
 
 func (c *Cloud) GetRules(ctx context.Context, listenerArn string) ([]*elbv2.Rule, error) {
@@ -172,7 +183,7 @@ func (c *Cloud) GetRules(ctx context.Context, listenerArn string) ([]*elbv2.Rule
 	p := request.Pagination{
 		EndPageOnSameToken: true,
 		NewRequest: func() (*request.Request, error) {
-			req, _ := c.elbv2.DescribeRulesRequest(&elbv2.DescribeRulesInput{ListenerArn: aws.String(listenerArn)})
+			req, _ := c.forRole(ctx).elbv2.DescribeRulesRequest(&elbv2.DescribeRulesInput{ListenerArn: aws.String(listenerArn)})
 			req.SetContext(ctx)
 			return req, nil
 		},
@@ -187,18 +198,23 @@ func (c *Cloud) GetRules(ctx context.Context, listenerArn string) ([]*elbv2.Rule
 // StatusELBV2 validates ELBV2 connectivity
 func (c *Cloud) StatusELBV2() func() error {
 	return func() error {
-		in := &elbv2.DescribeLoadBalancersInput{PageSize: aws.Int64(1)}
+		return c.ProbeELBV2(context.TODO())
+	}
+}
 
-		if _, err := c.elbv2.DescribeLoadBalancersWithContext(context.TODO(), in); err != nil {
-			return fmt.Errorf("[elbv2.DescribeLoadBalancersWithContext]: %v", err)
-		}
-		return nil
+// ProbeELBV2 validates ELBV2 connectivity, giving up once ctx is done
+func (c *Cloud) ProbeELBV2(ctx context.Context) error {
+	in := &elbv2.DescribeLoadBalancersInput{PageSize: aws.Int64(1)}
+
+	if _, err := c.elbv2.DescribeLoadBalancersWithContext(ctx, in); err != nil {
+		return fmt.Errorf("[elbv2.DescribeLoadBalancersWithContext]: %v", err)
 	}
+	return nil
 }
 
 func (c *Cloud) ListListenersByLoadBalancer(ctx context.Context, lbArn string) ([]*elbv2.Listener, error) {
 	var listeners []*elbv2.Listener
-	err := c.elbv2.DescribeListenersPagesWithContext(ctx,
+	err := c.forRole(ctx).elbv2.DescribeListenersPagesWithContext(ctx,
 		&elbv2.DescribeListenersInput{LoadBalancerArn: aws.String(lbArn)},
 		func(p *elbv2.DescribeListenersOutput, lastPage bool) bool {
 			if p == nil {
@@ -215,14 +231,14 @@ func (c *Cloud) ListListenersByLoadBalancer(ctx context.Context, lbArn string) (
 }
 
 func (c *Cloud) DeleteListenersByArn(ctx context.Context, lsArn string) error {
-	_, err := c.elbv2.DeleteListenerWithContext(ctx, &elbv2.DeleteListenerInput{
+	_, err := c.forRole(ctx).elbv2.DeleteListenerWithContext(ctx, &elbv2.DeleteListenerInput{
 		ListenerArn: aws.String(lsArn),
 	})
 	return err
 }
 
 func (c *Cloud) GetLoadBalancerByArn(ctx context.Context, arn string) (*elbv2.LoadBalancer, error) {
-	loadBalancers, err := c.describeLoadBalancersHelper(&elbv2.DescribeLoadBalancersInput{
+	loadBalancers, err := c.describeLoadBalancersHelper(ctx, &elbv2.DescribeLoadBalancersInput{
 		LoadBalancerArns: []*string{aws.String(arn)},
 	})
 	if err != nil {
@@ -235,7 +251,7 @@ func (c *Cloud) GetLoadBalancerByArn(ctx context.Context, arn string) (*elbv2.Lo
 }
 
 func (c *Cloud) GetLoadBalancerByName(ctx context.Context, name string) (*elbv2.LoadBalancer, error) {
-	loadBalancers, err := c.describeLoadBalancersHelper(&elbv2.DescribeLoadBalancersInput{
+	loadBalancers, err := c.describeLoadBalancersHelper(ctx, &elbv2.DescribeLoadBalancersInput{
 		Names: []*string{aws.String(name)},
 	})
 	if err != nil {
@@ -253,14 +269,14 @@ func (c *Cloud) GetLoadBalancerByName(ctx context.Context, name string) (*elbv2.
 }
 
 func (c *Cloud) DeleteLoadBalancerByArn(ctx context.Context, arn string) error {
-	_, err := c.elbv2.DeleteLoadBalancerWithContext(ctx, &elbv2.DeleteLoadBalancerInput{
+	_, err := c.forRole(ctx).elbv2.DeleteLoadBalancerWithContext(ctx, &elbv2.DeleteLoadBalancerInput{
 		LoadBalancerArn: aws.String(arn),
 	})
 	return err
 }
 
 func (c *Cloud) GetTargetGroupByArn(ctx context.Context, arn string) (*elbv2.TargetGroup, error) {
-	targetGroups, err := c.describeTargetGroupsHelper(&elbv2.DescribeTargetGroupsInput{
+	targetGroups, err := c.describeTargetGroupsHelper(ctx, &elbv2.DescribeTargetGroupsInput{
 		TargetGroupArns: []*string{aws.String(arn)},
 	})
 	if err != nil {
@@ -272,9 +288,31 @@ func (c *Cloud) GetTargetGroupByArn(ctx context.Context, arn string) (*elbv2.Tar
 	return targetGroups[0], nil
 }
 
+// describeTargetGroupsBatchSize is the number of targetGroup ARNs described per DescribeTargetGroups call.
+const describeTargetGroupsBatchSize = 20
+
+// GetTargetGroupsByArns retrieve TargetGroup instances by arns, describing them in batches
+func (c *Cloud) GetTargetGroupsByArns(ctx context.Context, arns []string) ([]*elbv2.TargetGroup, error) {
+	var result []*elbv2.TargetGroup
+	for start := 0; start < len(arns); start += describeTargetGroupsBatchSize {
+		end := start + describeTargetGroupsBatchSize
+		if end > len(arns) {
+			end = len(arns)
+		}
+		targetGroups, err := c.describeTargetGroupsHelper(ctx, &elbv2.DescribeTargetGroupsInput{
+			TargetGroupArns: aws.StringSlice(arns[start:end]),
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, targetGroups...)
+	}
+	return result, nil
+}
+
 // GetTargetGroupByName retrieve TargetGroup instance by name
 func (c *Cloud) GetTargetGroupByName(ctx context.Context, name string) (*elbv2.TargetGroup, error) {
-	targetGroups, err := c.describeTargetGroupsHelper(&elbv2.DescribeTargetGroupsInput{
+	targetGroups, err := c.describeTargetGroupsHelper(ctx, &elbv2.DescribeTargetGroupsInput{
 		Names: []*string{aws.String(name)},
 	})
 	if err != nil {
@@ -293,15 +331,15 @@ func (c *Cloud) GetTargetGroupByName(ctx context.Context, name string) (*elbv2.T
 
 // DeleteTargetGroupByArn deletes TargetGroup instance by arn
 func (c *Cloud) DeleteTargetGroupByArn(ctx context.Context, arn string) error {
-	_, err := c.elbv2.DeleteTargetGroupWithContext(ctx, &elbv2.DeleteTargetGroupInput{
+	_, err := c.forRole(ctx).elbv2.DeleteTargetGroupWithContext(ctx, &elbv2.DeleteTargetGroupInput{
 		TargetGroupArn: aws.String(arn),
 	})
 	return err
 }
 
 // describeLoadBalancersHelper is an helper to handle pagination in describeLoadBalancers call
-func (c *Cloud) describeLoadBalancersHelper(input *elbv2.DescribeLoadBalancersInput) (result []*elbv2.LoadBalancer, err error) {
-	err = c.elbv2.DescribeLoadBalancersPages(input, func(output *elbv2.DescribeLoadBalancersOutput, _ bool) bool {
+func (c *Cloud) describeLoadBalancersHelper(ctx context.Context, input *elbv2.DescribeLoadBalancersInput) (result []*elbv2.LoadBalancer, err error) {
+	err = c.forRole(ctx).elbv2.DescribeLoadBalancersPagesWithContext(ctx, input, func(output *elbv2.DescribeLoadBalancersOutput, _ bool) bool {
 		if output == nil {
 			return false
 		}
@@ -312,8 +350,8 @@ func (c *Cloud) describeLoadBalancersHelper(input *elbv2.DescribeLoadBalancersIn
 }
 
 // describeTargetGroupsHelper is an helper t handle pagination in describeTargetGroups call
-func (c *Cloud) describeTargetGroupsHelper(input *elbv2.DescribeTargetGroupsInput) (result []*elbv2.TargetGroup, err error) {
-	err = c.elbv2.DescribeTargetGroupsPages(input, func(output *elbv2.DescribeTargetGroupsOutput, _ bool) bool {
+func (c *Cloud) describeTargetGroupsHelper(ctx context.Context, input *elbv2.DescribeTargetGroupsInput) (result []*elbv2.TargetGroup, err error) {
+	err = c.forRole(ctx).elbv2.DescribeTargetGroupsPagesWithContext(ctx, input, func(output *elbv2.DescribeTargetGroupsOutput, _ bool) bool {
 		if output == nil {
 			return false
 		}
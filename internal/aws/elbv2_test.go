@@ -3,6 +3,7 @@ package aws
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -217,13 +218,14 @@ func TestCloud_GetLoadBalancerByArn(t *testing.T) {
 			ctx := context.Background()
 			elbv2svc := &mocks.ELBV2API{}
 
-			elbv2svc.On("DescribeLoadBalancersPages",
+			elbv2svc.On("DescribeLoadBalancersPagesWithContext",
+				ctx,
 				&elbv2.DescribeLoadBalancersInput{
 					LoadBalancerArns: []*string{aws.String(tc.LbArn)},
 				},
 				mock.AnythingOfType("func(*elbv2.DescribeLoadBalancersOutput, bool) bool"),
 			).Return(tc.DescribeLoadBalancersPagesError).Run(func(args mock.Arguments) {
-				arg := args.Get(1).(func(*elbv2.DescribeLoadBalancersOutput, bool) bool)
+				arg := args.Get(2).(func(*elbv2.DescribeLoadBalancersOutput, bool) bool)
 				arg(tc.DescribeLoadBalancersPagesOutput, false)
 			})
 			// })
@@ -289,13 +291,14 @@ func TestCloud_GetLoadBalancerByName(t *testing.T) {
 			ctx := context.Background()
 			elbv2svc := &mocks.ELBV2API{}
 
-			elbv2svc.On("DescribeLoadBalancersPages",
+			elbv2svc.On("DescribeLoadBalancersPagesWithContext",
+				ctx,
 				&elbv2.DescribeLoadBalancersInput{
 					Names: []*string{aws.String(tc.LbName)},
 				},
 				mock.AnythingOfType("func(*elbv2.DescribeLoadBalancersOutput, bool) bool"),
 			).Return(tc.DescribeLoadBalancersPagesError).Run(func(args mock.Arguments) {
-				arg := args.Get(1).(func(*elbv2.DescribeLoadBalancersOutput, bool) bool)
+				arg := args.Get(2).(func(*elbv2.DescribeLoadBalancersOutput, bool) bool)
 				arg(tc.DescribeLoadBalancersPagesOutput, false)
 			})
 			// })
@@ -375,13 +378,14 @@ func TestCloud_GetTargetGroupByArn(t *testing.T) {
 			ctx := context.Background()
 			elbv2svc := &mocks.ELBV2API{}
 
-			elbv2svc.On("DescribeTargetGroupsPages",
+			elbv2svc.On("DescribeTargetGroupsPagesWithContext",
+				ctx,
 				&elbv2.DescribeTargetGroupsInput{
 					TargetGroupArns: []*string{aws.String(tc.TgArn)},
 				},
 				mock.AnythingOfType("func(*elbv2.DescribeTargetGroupsOutput, bool) bool"),
 			).Return(tc.DescribeTargetGroupsPagesError).Run(func(args mock.Arguments) {
-				arg := args.Get(1).(func(output *elbv2.DescribeTargetGroupsOutput, _ bool) bool)
+				arg := args.Get(2).(func(output *elbv2.DescribeTargetGroupsOutput, _ bool) bool)
 				arg(tc.DescribeTargetGroupsPagesOutput, false)
 			})
 			// })
@@ -396,6 +400,75 @@ func TestCloud_GetTargetGroupByArn(t *testing.T) {
 	}
 }
 
+func TestCloud_GetTargetGroupsByArns(t *testing.T) {
+	var arns []string
+	var targetGroups []*elbv2.TargetGroup
+	for i := 0; i < 45; i++ {
+		arns = append(arns, fmt.Sprintf("tgArn%d", i))
+		targetGroups = append(targetGroups, &elbv2.TargetGroup{TargetGroupArn: aws.String(arns[i])})
+	}
+	for _, tc := range []struct {
+		Name                 string
+		TgArns               []string
+		ExpectedBatches      [][]string
+		BatchError           error
+		ExpectedTargetGroups []*elbv2.TargetGroup
+		ExpectedError        error
+	}{
+		{
+			Name: "no arns",
+		},
+		{
+			Name:                 "arns in a single batch",
+			TgArns:               arns[:3],
+			ExpectedBatches:      [][]string{arns[:3]},
+			ExpectedTargetGroups: targetGroups[:3],
+		},
+		{
+			Name:                 "arns across batches",
+			TgArns:               arns,
+			ExpectedBatches:      [][]string{arns[:20], arns[20:40], arns[40:]},
+			ExpectedTargetGroups: targetGroups,
+		},
+		{
+			Name:            "API error",
+			TgArns:          arns[:3],
+			ExpectedBatches: [][]string{arns[:3]},
+			BatchError:      awserr.New(elbv2.ErrCodeTargetGroupNotFoundException, "not found", nil),
+			ExpectedError:   awserr.New(elbv2.ErrCodeTargetGroupNotFoundException, "not found", nil),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			elbv2svc := &mocks.ELBV2API{}
+			offset := 0
+			for _, batch := range tc.ExpectedBatches {
+				output := &elbv2.DescribeTargetGroupsOutput{}
+				if tc.BatchError == nil {
+					output.TargetGroups = targetGroups[offset : offset+len(batch)]
+				}
+				offset += len(batch)
+				elbv2svc.On("DescribeTargetGroupsPagesWithContext",
+					ctx,
+					&elbv2.DescribeTargetGroupsInput{TargetGroupArns: aws.StringSlice(batch)},
+					mock.AnythingOfType("func(*elbv2.DescribeTargetGroupsOutput, bool) bool"),
+				).Return(tc.BatchError).Run(func(args mock.Arguments) {
+					arg := args.Get(2).(func(output *elbv2.DescribeTargetGroupsOutput, _ bool) bool)
+					arg(output, true)
+				})
+			}
+
+			cloud := &Cloud{
+				elbv2: elbv2svc,
+			}
+			result, err := cloud.GetTargetGroupsByArns(ctx, tc.TgArns)
+			assert.Equal(t, tc.ExpectedTargetGroups, result)
+			assert.Equal(t, tc.ExpectedError, err)
+			elbv2svc.AssertExpectations(t)
+		})
+	}
+}
+
 func TestCloud_GetTargetGroupByName(t *testing.T) {
 	tg1 := &elbv2.TargetGroup{TargetGroupName: aws.String("name1")}
 	tg2 := &elbv2.TargetGroup{TargetGroupName: aws.String("name2")}
@@ -447,13 +520,14 @@ func TestCloud_GetTargetGroupByName(t *testing.T) {
 			ctx := context.Background()
 			elbv2svc := &mocks.ELBV2API{}
 
-			elbv2svc.On("DescribeTargetGroupsPages",
+			elbv2svc.On("DescribeTargetGroupsPagesWithContext",
+				ctx,
 				&elbv2.DescribeTargetGroupsInput{
 					Names: []*string{aws.String(tc.TgName)},
 				},
 				mock.AnythingOfType("func(*elbv2.DescribeTargetGroupsOutput, bool) bool"),
 			).Return(tc.DescribeTargetGroupsPagesError).Run(func(args mock.Arguments) {
-				arg := args.Get(1).(func(*elbv2.DescribeTargetGroupsOutput, bool) bool)
+				arg := args.Get(2).(func(*elbv2.DescribeTargetGroupsOutput, bool) bool)
 				arg(tc.DescribeTargetGroupsPagesOutput, false)
 			})
 			// })
@@ -866,6 +940,27 @@ func TestCloud_SetSubnetsWithContext(t *testing.T) {
 	})
 }
 
+func TestCloud_DescribeELBV2AccountLimitsWithContext(t *testing.T) {
+	t.Run("apiwrapper", func(t *testing.T) {
+		ctx := context.Background()
+		svc := &mocks.ELBV2API{}
+
+		i := &elbv2.DescribeAccountLimitsInput{}
+		o := &elbv2.DescribeAccountLimitsOutput{}
+		var e error
+
+		svc.On("DescribeAccountLimitsWithContext", ctx, i).Return(o, e)
+		cloud := &Cloud{
+			elbv2: svc,
+		}
+
+		a, b := cloud.DescribeELBV2AccountLimitsWithContext(ctx, i)
+		assert.Equal(t, o, a)
+		assert.Equal(t, b, e)
+		svc.AssertExpectations(t)
+	})
+}
+
 func TestCloud_DescribeELBV2TagsWithContext(t *testing.T) {
 	t.Run("apiwrapper", func(t *testing.T) {
 		ctx := context.Background()
@@ -11,12 +11,19 @@ type HealthChecker struct {
 	healthCheckFuncs []func() error
 }
 
-// Constructs a new healthChecker
-func NewHealthChecker(cloud CloudAPI) *HealthChecker {
+// Constructs a new healthChecker.
+// WAFV2 and Route53 connectivity are only checked when wafV2Enabled and route53Enabled are set, since the controller won't call them otherwise.
+func NewHealthChecker(cloud CloudAPI, wafV2Enabled bool, route53Enabled bool) *HealthChecker {
 	healthCheckFuncs := []func() error{cloud.StatusEC2(), cloud.StatusIAM()}
 	if cloud.ACMAvailable() {
 		healthCheckFuncs = append(healthCheckFuncs, cloud.StatusACM())
 	}
+	if wafV2Enabled {
+		healthCheckFuncs = append(healthCheckFuncs, cloud.StatusWAFV2())
+	}
+	if route53Enabled {
+		healthCheckFuncs = append(healthCheckFuncs, cloud.StatusRoute53())
+	}
 
 	return &HealthChecker{
 		healthCheckFuncs: healthCheckFuncs,
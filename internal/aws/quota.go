@@ -0,0 +1,51 @@
+package aws
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+// QuotaResource is a kind of AWS resource limited by a service quota.
+type QuotaResource struct {
+	// Type labels the resource in metrics, e.g. "loadbalancer".
+	Type string
+	// Description names the resource in events, e.g. "load balancers".
+	Description string
+}
+
+// quotaExceededCodes maps the error codes AWS fails requests with once a service quota is reached to the resource it limits.
+var quotaExceededCodes = map[string]QuotaResource{
+	elbv2.ErrCodeTooManyLoadBalancersException:                     {"loadbalancer", "load balancers"},
+	elbv2.ErrCodeTooManyListenersException:                         {"listener", "listeners"},
+	elbv2.ErrCodeTooManyRulesException:                             {"rule", "listener rules"},
+	elbv2.ErrCodeTooManyActionsException:                           {"action", "listener rule actions"},
+	elbv2.ErrCodeTooManyTargetGroupsException:                      {"targetgroup", "target groups"},
+	elbv2.ErrCodeTooManyUniqueTargetGroupsPerLoadBalancerException: {"targetgroup", "target groups per load balancer"},
+	elbv2.ErrCodeTooManyTargetsException:                           {"target", "targets"},
+	elbv2.ErrCodeTooManyRegistrationsForTargetIdException:          {"target", "target registrations"},
+	elbv2.ErrCodeTooManyCertificatesException:                      {"certificate", "listener certificates"},
+	elbv2.ErrCodeTooManyTagsException:                              {"tag", "tags"},
+	"SecurityGroupLimitExceeded":                                   {"securitygroup", "security groups"},
+	"RulesPerSecurityGroupLimitExceeded":                           {"securitygroup-rule", "security group rules"},
+}
+
+// QuotaExceeded tells whether err is due to an AWS service quota being reached, and which resource it limits.
+// Reconcile errors wrap AWS errors with %v, so besides AWS errors, err may be an error whose message contains one.
+func QuotaExceeded(err error) (QuotaResource, bool) {
+	if err == nil {
+		return QuotaResource{}, false
+	}
+	if awsErr, ok := err.(awserr.Error); ok {
+		resource, ok := quotaExceededCodes[awsErr.Code()]
+		return resource, ok
+	}
+	msg := err.Error()
+	for code, resource := range quotaExceededCodes {
+		if strings.Contains(msg, code+": ") {
+			return resource, true
+		}
+	}
+	return QuotaResource{}, false
+}
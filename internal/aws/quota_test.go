@@ -0,0 +1,57 @@
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaExceeded(t *testing.T) {
+	tooManyLBs := awserr.New(elbv2.ErrCodeTooManyLoadBalancersException, "The maximum number of load balancers has been reached", nil)
+	for _, tc := range []struct {
+		Name             string
+		Err              error
+		ExpectedResource QuotaResource
+		ExpectedOK       bool
+	}{
+		{
+			Name: "no error",
+		},
+		{
+			Name:             "quota error",
+			Err:              tooManyLBs,
+			ExpectedResource: QuotaResource{"loadbalancer", "load balancers"},
+			ExpectedOK:       true,
+		},
+		{
+			Name:             "quota error with a request ID",
+			Err:              withRequestID(awserr.NewRequestFailure(awserr.New("SecurityGroupLimitExceeded", "limit exceeded", nil), 400, "id")),
+			ExpectedResource: QuotaResource{"securitygroup", "security groups"},
+			ExpectedOK:       true,
+		},
+		{
+			Name:             "wrapped quota error",
+			Err:              fmt.Errorf("failed to reconcile LoadBalancer due to %v", tooManyLBs),
+			ExpectedResource: QuotaResource{"loadbalancer", "load balancers"},
+			ExpectedOK:       true,
+		},
+		{
+			Name: "other AWS error",
+			Err:  awserr.New(elbv2.ErrCodeLoadBalancerNotFoundException, "not found", nil),
+		},
+		{
+			Name: "other error",
+			Err:  errors.New("failed to resolve subnets"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			resource, ok := QuotaExceeded(tc.Err)
+			assert.Equal(t, tc.ExpectedResource, resource)
+			assert.Equal(t, tc.ExpectedOK, ok)
+		})
+	}
+}
@@ -15,34 +15,26 @@ const (
 
 type ResourceGroupsTaggingAPIAPI interface {
 	// GetResourcesByFilters fetches resources ARNs by tagFilters and 0 or more resourceTypesFilters
-	GetResourcesByFilters(tagFilters map[string][]string, resourceTypeFilters ...string) ([]string, error)
+	GetResourcesByFilters(ctx context.Context, tagFilters map[string][]string, resourceTypeFilters ...string) ([]string, error)
+
+	// GetResourceTagsByFilters fetches the tags of resources by tagFilters and 0 or more resourceTypesFilters, keyed by resource ARN
+	GetResourceTagsByFilters(ctx context.Context, tagFilters map[string][]string, resourceTypeFilters ...string) (map[string]map[string]string, error)
 
 	TagResourcesWithContext(context.Context, *resourcegroupstaggingapi.TagResourcesInput) (*resourcegroupstaggingapi.TagResourcesOutput, error)
 	UntagResourcesWithContext(context.Context, *resourcegroupstaggingapi.UntagResourcesInput) (*resourcegroupstaggingapi.UntagResourcesOutput, error)
 }
 
 func (c *Cloud) TagResourcesWithContext(ctx context.Context, i *resourcegroupstaggingapi.TagResourcesInput) (*resourcegroupstaggingapi.TagResourcesOutput, error) {
-	return c.rgt.TagResourcesWithContext(ctx, i)
+	return c.forRole(ctx).rgt.TagResourcesWithContext(ctx, i)
 }
 func (c *Cloud) UntagResourcesWithContext(ctx context.Context, i *resourcegroupstaggingapi.UntagResourcesInput) (*resourcegroupstaggingapi.UntagResourcesOutput, error) {
-	return c.rgt.UntagResourcesWithContext(ctx, i)
+	return c.forRole(ctx).rgt.UntagResourcesWithContext(ctx, i)
 }
 
-func (c *Cloud) GetResourcesByFilters(tagFilters map[string][]string, resourceTypeFilters ...string) ([]string, error) {
-	var awsTagFilters []*resourcegroupstaggingapi.TagFilter
-	for k, v := range tagFilters {
-		awsTagFilters = append(awsTagFilters, &resourcegroupstaggingapi.TagFilter{
-			Key:    aws.String(k),
-			Values: aws.StringSlice(v),
-		})
-	}
-	req := &resourcegroupstaggingapi.GetResourcesInput{
-		ResourceTypeFilters: aws.StringSlice(resourceTypeFilters),
-		TagFilters:          awsTagFilters,
-	}
-
+func (c *Cloud) GetResourcesByFilters(ctx context.Context, tagFilters map[string][]string, resourceTypeFilters ...string) ([]string, error) {
+	req := buildGetResourcesInput(tagFilters, resourceTypeFilters)
 	var result []string
-	err := c.rgt.GetResourcesPages(req, func(output *resourcegroupstaggingapi.GetResourcesOutput, b bool) bool {
+	err := c.forRole(ctx).rgt.GetResourcesPagesWithContext(ctx, req, func(output *resourcegroupstaggingapi.GetResourcesOutput, b bool) bool {
 		if output == nil {
 			return false
 		}
@@ -53,3 +45,39 @@ func (c *Cloud) GetResourcesByFilters(tagFilters map[string][]string, resourceTy
 	})
 	return result, err
 }
+
+func (c *Cloud) GetResourceTagsByFilters(ctx context.Context, tagFilters map[string][]string, resourceTypeFilters ...string) (map[string]map[string]string, error) {
+	req := buildGetResourcesInput(tagFilters, resourceTypeFilters)
+	result := make(map[string]map[string]string)
+	err := c.forRole(ctx).rgt.GetResourcesPagesWithContext(ctx, req, func(output *resourcegroupstaggingapi.GetResourcesOutput, b bool) bool {
+		if output == nil {
+			return false
+		}
+		for _, i := range output.ResourceTagMappingList {
+			tags := make(map[string]string, len(i.Tags))
+			for _, tag := range i.Tags {
+				tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+			}
+			result[aws.StringValue(i.ResourceARN)] = tags
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func buildGetResourcesInput(tagFilters map[string][]string, resourceTypeFilters []string) *resourcegroupstaggingapi.GetResourcesInput {
+	var awsTagFilters []*resourcegroupstaggingapi.TagFilter
+	for k, v := range tagFilters {
+		awsTagFilters = append(awsTagFilters, &resourcegroupstaggingapi.TagFilter{
+			Key:    aws.String(k),
+			Values: aws.StringSlice(v),
+		})
+	}
+	return &resourcegroupstaggingapi.GetResourcesInput{
+		ResourceTypeFilters: aws.StringSlice(resourceTypeFilters),
+		TagFilters:          awsTagFilters,
+	}
+}
@@ -123,21 +123,64 @@ func TestCloud_GetResourcesByFilters(t *testing.T) {
 		t.Run(tc.Name, func(t *testing.T) {
 			rgtsvc := &mocks.ResourceGroupsTaggingAPIAPI{}
 
-			rgtsvc.On("GetResourcesPages",
+			rgtsvc.On("GetResourcesPagesWithContext",
+				context.Background(),
 				tc.GetResourcesInput,
 				mock.AnythingOfType("func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool"),
 			).Return(tc.GetResourcesError).Run(func(args mock.Arguments) {
-				arg := args.Get(1).(func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool)
+				arg := args.Get(2).(func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool)
 				arg(tc.GetResourcesOutput, false)
 			})
 
 			cloud := &Cloud{
 				rgt: rgtsvc,
 			}
-			arns, err := cloud.GetResourcesByFilters(tc.TagFilters, tc.ResourceTypeFilters...)
+			arns, err := cloud.GetResourcesByFilters(context.Background(), tc.TagFilters, tc.ResourceTypeFilters...)
 			assert.Equal(t, tc.ExpectedResult, arns)
 			assert.Equal(t, tc.ExpectedError, err)
 			rgtsvc.AssertExpectations(t)
 		})
 	}
 }
+
+func TestCloud_GetResourceTagsByFilters(t *testing.T) {
+	t.Run("tags are keyed by ARN", func(t *testing.T) {
+		rgtsvc := &mocks.ResourceGroupsTaggingAPIAPI{}
+		rgtsvc.On("GetResourcesPagesWithContext",
+			context.Background(),
+			&resourcegroupstaggingapi.GetResourcesInput{
+				ResourceTypeFilters: []*string{aws.String(ResourceTypeEnumELBLoadBalancer)},
+				TagFilters:          []*resourcegroupstaggingapi.TagFilter{{Key: aws.String("key"), Values: []*string{aws.String("val")}}},
+			},
+			mock.AnythingOfType("func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool"),
+		).Return(nil).Run(func(args mock.Arguments) {
+			arg := args.Get(2).(func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool)
+			arg(&resourcegroupstaggingapi.GetResourcesOutput{
+				ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
+					{ResourceARN: aws.String("arn1"), Tags: []*resourcegroupstaggingapi.Tag{{Key: aws.String("key"), Value: aws.String("val")}}},
+					{ResourceARN: aws.String("arn2")},
+				},
+			}, true)
+		})
+
+		cloud := &Cloud{
+			rgt: rgtsvc,
+		}
+		tags, err := cloud.GetResourceTagsByFilters(context.Background(), map[string][]string{"key": {"val"}}, ResourceTypeEnumELBLoadBalancer)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]map[string]string{"arn1": {"key": "val"}, "arn2": {}}, tags)
+		rgtsvc.AssertExpectations(t)
+	})
+
+	t.Run("API throws an error", func(t *testing.T) {
+		rgtsvc := &mocks.ResourceGroupsTaggingAPIAPI{}
+		rgtsvc.On("GetResourcesPagesWithContext", mock.Anything, mock.Anything, mock.Anything).Return(awserr.New(request.ErrCodeResponseTimeout, "timeout", nil))
+
+		cloud := &Cloud{
+			rgt: rgtsvc,
+		}
+		tags, err := cloud.GetResourceTagsByFilters(context.Background(), nil, ResourceTypeEnumELBLoadBalancer)
+		assert.Nil(t, tags)
+		assert.Equal(t, awserr.New(request.ErrCodeResponseTimeout, "timeout", nil), err)
+	})
+}
@@ -0,0 +1,52 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/shield"
+	"github.com/aws/aws-sdk-go/service/wafregional"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+)
+
+// forRole returns the Cloud to use for the IAM role set in ctx by albctx.SetIAMRoleARN, or c itself if there is none.
+// The LoadBalancer related clients of the returned Cloud assume the role, while EC2 and IAM calls keep using the
+// controller's own credentials, since the VPC, its subnets and the worker nodes belong to the controller's account.
+// Route53 calls keep them as well, so that records for LoadBalancers of other accounts live in the cluster's hosted zones.
+// Clouds are cached per role, and their credentials are refreshed by STS before they expire.
+func (c *Cloud) forRole(ctx context.Context) *Cloud {
+	roleARN := albctx.GetIAMRoleARN(ctx)
+	if roleARN == "" || c.newRoleSession == nil {
+		return c
+	}
+
+	c.roleCloudsMutex.Lock()
+	defer c.roleCloudsMutex.Unlock()
+	if roleCloud, ok := c.roleClouds[roleARN]; ok {
+		return roleCloud
+	}
+	roleSession := c.newRoleSession(roleARN)
+	roleCloud := &Cloud{
+		vpcID:       c.vpcID,
+		region:      c.region,
+		clusterName: c.clusterName,
+		acm:         acm.New(roleSession),
+		ec2:         c.ec2,
+		elbv2:       elbv2.New(roleSession),
+		iam:         c.iam,
+		shield:      shield.New(roleSession, &aws.Config{Region: aws.String("us-east-1")}),
+		rgt:         resourcegroupstaggingapi.New(roleSession),
+		route53:     c.route53,
+		wafregional: wafregional.New(roleSession),
+		wafv2:       wafv2.New(roleSession),
+	}
+	if c.roleClouds == nil {
+		c.roleClouds = make(map[string]*Cloud)
+	}
+	c.roleClouds[roleARN] = roleCloud
+	return roleCloud
+}
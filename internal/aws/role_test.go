@@ -0,0 +1,35 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloud_forRole(t *testing.T) {
+	var assumed []string
+	c := &Cloud{
+		vpcID: "vpc-1",
+		newRoleSession: func(roleARN string) *session.Session {
+			assumed = append(assumed, roleARN)
+			return session.Must(session.NewSession(aws.NewConfig().WithRegion("us-west-2")))
+		},
+	}
+
+	assert.True(t, c == c.forRole(context.Background()))
+	assert.True(t, c == c.forRole(albctx.SetIAMRoleARN(context.Background(), "")))
+
+	ctxA := albctx.SetIAMRoleARN(context.Background(), "arn:aws:iam::123456789012:role/a")
+	ctxB := albctx.SetIAMRoleARN(context.Background(), "arn:aws:iam::123456789012:role/b")
+	roleCloudA := c.forRole(ctxA)
+	assert.False(t, c == roleCloudA)
+	assert.Equal(t, "vpc-1", roleCloudA.GetVpcID())
+	assert.True(t, roleCloudA == c.forRole(ctxA))
+	assert.True(t, roleCloudA == roleCloudA.forRole(ctxB))
+	assert.False(t, roleCloudA == c.forRole(ctxB))
+	assert.Equal(t, []string{"arn:aws:iam::123456789012:role/a", "arn:aws:iam::123456789012:role/b"}, assumed)
+}
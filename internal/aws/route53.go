@@ -0,0 +1,85 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+)
+
+type Route53API interface {
+	// StatusRoute53 validates Route53 connectivity
+	StatusRoute53() func() error
+
+	// GetHostedZoneByID returns the hosted zone zoneID.
+	GetHostedZoneByID(ctx context.Context, zoneID string) (*route53.HostedZone, error)
+
+	// ListResourceRecordSetsByZone returns all record sets of the hosted zone zoneID.
+	ListResourceRecordSetsByZone(ctx context.Context, zoneID string) ([]*route53.ResourceRecordSet, error)
+
+	// GetResourceRecordSet returns the record set of type rrType named name in the hosted zone zoneID, or nil if
+	// there's none.
+	GetResourceRecordSet(ctx context.Context, zoneID string, name string, rrType string) (*route53.ResourceRecordSet, error)
+
+	ChangeResourceRecordSetsWithContext(ctx context.Context, input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error)
+}
+
+// StatusRoute53 validates Route53 connectivity
+func (c *Cloud) StatusRoute53() func() error {
+	return func() error {
+		in := &route53.ListHostedZonesInput{MaxItems: aws.String("1")}
+
+		if _, err := c.route53.ListHostedZonesWithContext(context.TODO(), in); err != nil {
+			return fmt.Errorf("[route53.ListHostedZonesWithContext]: %v", err)
+		}
+		return nil
+	}
+}
+
+func (c *Cloud) GetHostedZoneByID(ctx context.Context, zoneID string) (*route53.HostedZone, error) {
+	resp, err := c.route53.GetHostedZoneWithContext(ctx, &route53.GetHostedZoneInput{Id: aws.String(zoneID)})
+	if err != nil {
+		return nil, err
+	}
+	return resp.HostedZone, nil
+}
+
+func (c *Cloud) ListResourceRecordSetsByZone(ctx context.Context, zoneID string) ([]*route53.ResourceRecordSet, error) {
+	var result []*route53.ResourceRecordSet
+	err := c.route53.ListResourceRecordSetsPagesWithContext(ctx, &route53.ListResourceRecordSetsInput{HostedZoneId: aws.String(zoneID)},
+		func(output *route53.ListResourceRecordSetsOutput, _ bool) bool {
+			result = append(result, output.ResourceRecordSets...)
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *Cloud) GetResourceRecordSet(ctx context.Context, zoneID string, name string, rrType string) (*route53.ResourceRecordSet, error) {
+	resp, err := c.route53.ListResourceRecordSetsWithContext(ctx, &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(zoneID),
+		StartRecordName: aws.String(name),
+		StartRecordType: aws.String(rrType),
+		MaxItems:        aws.String("1"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Route53 lists records starting at name and rrType, so the first one is only ours if it matches both.
+	// It returns the names with a trailing dot and the wildcard as \052.
+	for _, record := range resp.ResourceRecordSets {
+		recordName := strings.Replace(strings.TrimSuffix(strings.ToLower(aws.StringValue(record.Name)), "."), `\052`, "*", -1)
+		if recordName == strings.TrimSuffix(strings.ToLower(name), ".") && aws.StringValue(record.Type) == rrType {
+			return record, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *Cloud) ChangeResourceRecordSetsWithContext(ctx context.Context, input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+	return c.route53.ChangeResourceRecordSetsWithContext(ctx, input)
+}
@@ -0,0 +1,39 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/stretchr/testify/assert"
+)
+
+type pagedRoute53 struct {
+	route53iface.Route53API
+	pages []*route53.ListResourceRecordSetsOutput
+}
+
+func (r *pagedRoute53) ListResourceRecordSetsPagesWithContext(_ aws.Context, _ *route53.ListResourceRecordSetsInput, fn func(*route53.ListResourceRecordSetsOutput, bool) bool, _ ...request.Option) error {
+	for i, page := range r.pages {
+		if !fn(page, i == len(r.pages)-1) {
+			break
+		}
+	}
+	return nil
+}
+
+func TestCloud_ListResourceRecordSetsByZone(t *testing.T) {
+	recordA := &route53.ResourceRecordSet{Name: aws.String("a.example.com."), Type: aws.String(route53.RRTypeA)}
+	recordB := &route53.ResourceRecordSet{Name: aws.String("b.example.com."), Type: aws.String(route53.RRTypeA)}
+	cloud := &Cloud{route53: &pagedRoute53{pages: []*route53.ListResourceRecordSetsOutput{
+		{ResourceRecordSets: []*route53.ResourceRecordSet{recordA}, IsTruncated: aws.Bool(true)},
+		{ResourceRecordSets: []*route53.ResourceRecordSet{recordB}},
+	}}}
+
+	records, err := cloud.ListResourceRecordSetsByZone(context.Background(), "Z1")
+	assert.NoError(t, err)
+	assert.Equal(t, []*route53.ResourceRecordSet{recordA, recordB}, records)
+}
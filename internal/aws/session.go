@@ -2,10 +2,14 @@ package aws
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/golang/glog"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
@@ -13,6 +17,15 @@ import (
 	"github.com/ticketmaster/aws-sdk-go-cache/cache"
 )
 
+// ErrCodeDryRun is the error code returned for mutating AWS API calls skipped in dry-run mode.
+const ErrCodeDryRun = "DryRun"
+
+// mutatingOperationPrefixes are prefixes of AWS operation names that change resources.
+var mutatingOperationPrefixes = []string{
+	"Add", "Associate", "Authorize", "Change", "Create", "Delete", "Deregister", "Disassociate",
+	"Modify", "Put", "Register", "Remove", "Revoke", "Set", "Tag", "Untag",
+}
+
 // NewSession returns an AWS session based off of the provided AWS config
 func NewSession(awsconfig *aws.Config, AWSDebug bool, mc metric.Collector, ce bool, cc *cache.Config) *session.Session {
 	session, err := session.NewSession(awsconfig)
@@ -24,9 +37,17 @@ func NewSession(awsconfig *aws.Config, AWSDebug bool, mc metric.Collector, ce bo
 	if ce {
 		// Adds caching to session if cache is enabled
 		cache.AddCaching(session, cc)
+		addTaggingCacheFlush(session, cc)
 	}
+	// Retry handlers run after every failed attempt, once the error response is unmarshalled.
+	session.Handlers.Retry.PushFront(func(r *request.Request) {
+		r.Error = withRequestID(r.Error)
+	})
 	session.Handlers.Retry.PushFront(func(r *request.Request) {
 		mc.IncAPIRetryCount(prometheus.Labels{"service": r.ClientInfo.ServiceName, "operation": r.Operation.Name})
+		if r.IsErrorThrottle() {
+			mc.IncAPIThrottleCount(prometheus.Labels{"service": r.ClientInfo.ServiceName, "operation": r.Operation.Name})
+		}
 	})
 
 	session.Handlers.Send.PushFront(func(r *request.Request) {
@@ -37,8 +58,12 @@ func NewSession(awsconfig *aws.Config, AWSDebug bool, mc metric.Collector, ce bo
 	})
 
 	session.Handlers.Complete.PushFront(func(r *request.Request) {
+		mc.ObserveAPILatency(prometheus.Labels{"service": r.ClientInfo.ServiceName, "operation": r.Operation.Name}, time.Since(r.Time))
 		if r.Error != nil {
 			mc.IncAPIErrorCount(prometheus.Labels{"service": r.ClientInfo.ServiceName, "operation": r.Operation.Name})
+			if resource, ok := QuotaExceeded(r.Error); ok {
+				mc.IncAWSQuotaExceeded(resource.Type)
+			}
 			if AWSDebug {
 				glog.ErrorDepth(4, fmt.Sprintf("Failed request: %s/%s, Payload: %s, Error: %s", r.ClientInfo.ServiceName, r.Operation.Name, log.Prettify(r.Params), r.Error))
 			}
@@ -50,3 +75,94 @@ func NewSession(awsconfig *aws.Config, AWSDebug bool, mc metric.Collector, ce bo
 	})
 	return session
 }
+
+// taggingCacheFlushOperations are the operations that change the result of tag lookups, by creating or deleting tagged
+// resources or by changing their tags.
+var taggingCacheFlushOperations = map[string]bool{
+	"CreateLoadBalancer":  true,
+	"DeleteLoadBalancer":  true,
+	"CreateTargetGroup":   true,
+	"DeleteTargetGroup":   true,
+	"AddTags":             true,
+	"RemoveTags":          true,
+	"CreateSecurityGroup": true,
+	"DeleteSecurityGroup": true,
+	"CreateTags":          true,
+	"DeleteTags":          true,
+}
+
+// addTaggingCacheFlush drops cached tag lookups whenever a resource is created, deleted or retagged.
+// The cache only flushes them on tagging operations by itself, so a newly created LoadBalancer or TargetGroup
+// would stay invisible to tag lookups until the cached result expires. Other mutating calls, e.g. registering targets
+// on every endpoint change, keep the cache.
+// The cache is flushed once the call succeeded, since a tag lookup made while it's in flight may cache the former result.
+func addTaggingCacheFlush(session *session.Session, cc *cache.Config) {
+	session.Handlers.Complete.PushBack(func(r *request.Request) {
+		if r.Error != nil || r.ClientInfo.ServiceName == resourcegroupstaggingapi.ServiceName || !taggingCacheFlushOperations[r.Operation.Name] {
+			return
+		}
+		cc.FlushCache(resourcegroupstaggingapi.ServiceName)
+	})
+}
+
+// dryRunMessage ends the message of the errors of calls skipped in dry-run mode.
+const dryRunMessage = "skipped in dry-run mode"
+
+// AddDryRunHandler makes session skip AWS API calls that would change resources.
+// The skipped call is logged with its payload and fails with ErrCodeDryRun, so reconcile stops before acting on a result it never got.
+func AddDryRunHandler(session *session.Session) {
+	session.Handlers.Validate.PushFront(func(r *request.Request) {
+		if !isMutatingOperation(r.Operation.Name) {
+			return
+		}
+		glog.InfoDepth(4, fmt.Sprintf("Dry-run, skipping request: %s/%s, Payload: %s", r.ClientInfo.ServiceName, r.Operation.Name, log.Prettify(r.Params)))
+		r.Error = awserr.New(ErrCodeDryRun, fmt.Sprintf("%s/%s %s", r.ClientInfo.ServiceName, r.Operation.Name, dryRunMessage), nil)
+	})
+}
+
+// DryRun tells whether err is due to a call skipped in dry-run mode. Reconcile errors wrap AWS errors with %v, so
+// besides AWS errors, err may be an error whose message contains one.
+func DryRun(err error) bool {
+	if err == nil {
+		return false
+	}
+	if awsErr, ok := err.(awserr.Error); ok {
+		return awsErr.Code() == ErrCodeDryRun
+	}
+	return strings.Contains(err.Error(), dryRunMessage)
+}
+
+// requestIDError formats a failed AWS request on a single line that ends with its request ID, so the ID survives
+// into the logs and ingress events the error ends up in, and can be quoted when opening an AWS support case.
+type requestIDError struct {
+	awserr.RequestFailure
+}
+
+func (e requestIDError) Error() string {
+	msg := fmt.Sprintf("%s: %s (status code: %d, request id: %s)", e.Code(), e.Message(), e.StatusCode(), e.RequestID())
+	if e.OrigErr() != nil {
+		msg = fmt.Sprintf("%s, caused by: %v", msg, e.OrigErr())
+	}
+	return msg
+}
+
+// withRequestID returns err as a requestIDError if it's a failed AWS request that has a request ID.
+func withRequestID(err error) error {
+	reqErr, ok := err.(awserr.RequestFailure)
+	if !ok || reqErr.RequestID() == "" {
+		return err
+	}
+	if _, ok := reqErr.(requestIDError); ok {
+		return err
+	}
+	return requestIDError{reqErr}
+}
+
+func isMutatingOperation(operation string) bool {
+	for _, prefix := range mutatingOperationPrefixes {
+		if strings.HasPrefix(operation, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,275 @@
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/ticketmaster/aws-sdk-go-cache/cache"
+)
+
+func Test_isMutatingOperation(t *testing.T) {
+	for _, tc := range []struct {
+		Operation string
+		Expected  bool
+	}{
+		{Operation: "CreateLoadBalancer", Expected: true},
+		{Operation: "ModifyTargetGroupAttributes", Expected: true},
+		{Operation: "DeregisterTargets", Expected: true},
+		{Operation: "SetSecurityGroups", Expected: true},
+		{Operation: "AuthorizeSecurityGroupIngress", Expected: true},
+		{Operation: "TagResources", Expected: true},
+		{Operation: "ChangeResourceRecordSets", Expected: true},
+		{Operation: "PutBucketPolicy", Expected: true},
+		{Operation: "DescribeLoadBalancers", Expected: false},
+		{Operation: "GetResources", Expected: false},
+		{Operation: "ListCertificates", Expected: false},
+	} {
+		t.Run(tc.Operation, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, isMutatingOperation(tc.Operation))
+		})
+	}
+}
+
+func TestAddDryRunHandler(t *testing.T) {
+	sess := session.Must(session.NewSession(aws.NewConfig().
+		WithRegion("us-west-2").
+		WithCredentials(credentials.NewStaticCredentials("id", "secret", ""))))
+	AddDryRunHandler(sess)
+
+	_, err := elbv2.New(sess).CreateLoadBalancer(&elbv2.CreateLoadBalancerInput{Name: aws.String("lb")})
+	awsErr, ok := err.(awserr.Error)
+	assert.True(t, ok)
+	assert.Equal(t, ErrCodeDryRun, awsErr.Code())
+	assert.Equal(t, "elasticloadbalancing/CreateLoadBalancer skipped in dry-run mode", awsErr.Message())
+}
+
+func TestAddDryRunHandler_route53(t *testing.T) {
+	sess := session.Must(session.NewSession(aws.NewConfig().
+		WithRegion("us-west-2").
+		WithCredentials(credentials.NewStaticCredentials("id", "secret", ""))))
+	AddDryRunHandler(sess)
+
+	_, err := route53.New(sess).ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String("Z1"),
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: []*route53.Change{
+				{
+					Action: aws.String(route53.ChangeActionUpsert),
+					ResourceRecordSet: &route53.ResourceRecordSet{
+						Name: aws.String("echoserver.example.com"),
+						Type: aws.String(route53.RRTypeA),
+						AliasTarget: &route53.AliasTarget{
+							DNSName:              aws.String("lb.example.com"),
+							HostedZoneId:         aws.String("Z2"),
+							EvaluateTargetHealth: aws.Bool(false),
+						},
+					},
+				},
+			},
+		},
+	})
+	awsErr, ok := err.(awserr.Error)
+	assert.True(t, ok)
+	assert.Equal(t, ErrCodeDryRun, awsErr.Code())
+	assert.Equal(t, "route53/ChangeResourceRecordSets skipped in dry-run mode", awsErr.Message())
+}
+
+// elbv2Response writes an empty successful response to the elbv2 query API call r.
+func elbv2Response(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+	action := r.Form.Get("Action")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(fmt.Sprintf("<%[1]sResponse><%[1]sResult></%[1]sResult></%[1]sResponse>", action)))
+}
+
+func TestDryRun(t *testing.T) {
+	skipped := awserr.New(ErrCodeDryRun, "elasticloadbalancing/CreateLoadBalancer skipped in dry-run mode", nil)
+	assert.True(t, DryRun(skipped))
+	assert.True(t, DryRun(fmt.Errorf("failed to create LoadBalancer due to %v", skipped)))
+	assert.False(t, DryRun(awserr.New("ValidationError", "invalid name", nil)))
+	assert.False(t, DryRun(errors.New("failed to create LoadBalancer")))
+	assert.False(t, DryRun(nil))
+}
+
+func TestNewSession_taggingCacheFlush(t *testing.T) {
+	var getResourcesCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.Header.Get("X-Amz-Target"), ".GetResources") {
+			elbv2Response(w, r)
+			return
+		}
+		atomic.AddInt32(&getResourcesCalls, 1)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	cc := cache.NewConfig(time.Hour)
+	sess := NewSession(aws.NewConfig().
+		WithRegion("us-west-2").
+		WithEndpoint(server.URL).
+		WithMaxRetries(0).
+		WithCredentials(credentials.NewStaticCredentials("id", "secret", "")), false, metric.DummyCollector{}, true, cc)
+	rgt := resourcegroupstaggingapi.New(sess)
+
+	getResources := func() {
+		_, err := rgt.GetResources(&resourcegroupstaggingapi.GetResourcesInput{})
+		assert.NoError(t, err)
+	}
+
+	getResources()
+	getResources()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&getResourcesCalls), "tag lookup should be served from cache")
+
+	_, _ = elbv2.New(sess).DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{})
+	getResources()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&getResourcesCalls), "read-only calls should keep the cache")
+
+	_, _ = elbv2.New(sess).RegisterTargets(&elbv2.RegisterTargetsInput{TargetGroupArn: aws.String("tg"), Targets: []*elbv2.TargetDescription{{Id: aws.String("i-1")}}})
+	getResources()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&getResourcesCalls), "registering targets should keep the cache")
+
+	_, err := elbv2.New(sess).CreateLoadBalancer(&elbv2.CreateLoadBalancerInput{Name: aws.String("lb")})
+	assert.NoError(t, err)
+	getResources()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&getResourcesCalls), "creating a resource should flush the cache")
+}
+
+func TestNewSession_taggingCacheFlushAfterCompletion(t *testing.T) {
+	var getResourcesCalls int32
+	created := make(chan struct{})
+	creating := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.Header.Get("X-Amz-Target"), ".GetResources") {
+			close(creating)
+			<-created
+			elbv2Response(w, r)
+			return
+		}
+		atomic.AddInt32(&getResourcesCalls, 1)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	cc := cache.NewConfig(time.Hour)
+	sess := NewSession(aws.NewConfig().
+		WithRegion("us-west-2").
+		WithEndpoint(server.URL).
+		WithMaxRetries(0).
+		WithCredentials(credentials.NewStaticCredentials("id", "secret", "")), false, metric.DummyCollector{}, true, cc)
+	rgt := resourcegroupstaggingapi.New(sess)
+
+	done := make(chan error)
+	go func() {
+		_, err := elbv2.New(sess).CreateLoadBalancer(&elbv2.CreateLoadBalancerInput{Name: aws.String("lb")})
+		done <- err
+	}()
+	<-creating
+	// a tag lookup made while the LoadBalancer is being created caches a result without it.
+	_, err := rgt.GetResources(&resourcegroupstaggingapi.GetResourcesInput{})
+	assert.NoError(t, err)
+	close(created)
+	assert.NoError(t, <-done)
+
+	_, err = rgt.GetResources(&resourcegroupstaggingapi.GetResourcesInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&getResourcesCalls), "the cache should be flushed once the LoadBalancer is created")
+}
+
+// latencyCollector records the operations whose AWS API latency was observed.
+type latencyCollector struct {
+	metric.DummyCollector
+	operations []string
+}
+
+func (c *latencyCollector) ObserveAPILatency(l prometheus.Labels, d time.Duration) {
+	c.operations = append(c.operations, l["service"]+"/"+l["operation"])
+}
+
+func TestNewSession_apiLatency(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	mc := &latencyCollector{}
+	sess := NewSession(aws.NewConfig().
+		WithRegion("us-west-2").
+		WithEndpoint(server.URL).
+		WithMaxRetries(0).
+		WithCredentials(credentials.NewStaticCredentials("id", "secret", "")), false, mc, false, nil)
+
+	_, err := resourcegroupstaggingapi.New(sess).GetResources(&resourcegroupstaggingapi.GetResourcesInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tagging/GetResources"}, mc.operations)
+}
+
+func TestNewSession_requestID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Amzn-Requestid", "4a4b1b5c-9b7d-11e9-a2a3-2a2ae2dbcce4")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"__type":"InvalidParameterException","Message":"PaginationToken is invalid"}`))
+	}))
+	defer server.Close()
+
+	sess := NewSession(aws.NewConfig().
+		WithRegion("us-west-2").
+		WithEndpoint(server.URL).
+		WithMaxRetries(0).
+		WithCredentials(credentials.NewStaticCredentials("id", "secret", "")), false, metric.DummyCollector{}, false, nil)
+
+	_, err := resourcegroupstaggingapi.New(sess).GetResources(&resourcegroupstaggingapi.GetResourcesInput{})
+	reqErr, ok := err.(awserr.RequestFailure)
+	assert.True(t, ok)
+	assert.Equal(t, "InvalidParameterException", reqErr.Code())
+	assert.Equal(t, "4a4b1b5c-9b7d-11e9-a2a3-2a2ae2dbcce4", reqErr.RequestID())
+	assert.Equal(t, "InvalidParameterException: PaginationToken is invalid (status code: 400, request id: 4a4b1b5c-9b7d-11e9-a2a3-2a2ae2dbcce4)", err.Error())
+}
+
+func Test_withRequestID(t *testing.T) {
+	for _, tc := range []struct {
+		Name     string
+		Err      error
+		Expected string
+	}{
+		{
+			Name:     "errors without request id are kept",
+			Err:      awserr.New("DryRun", "skipped", nil),
+			Expected: "DryRun: skipped",
+		},
+		{
+			Name:     "request failures end with their request id",
+			Err:      awserr.NewRequestFailure(awserr.New("Throttling", "Rate exceeded", nil), 400, "req-1"),
+			Expected: "Throttling: Rate exceeded (status code: 400, request id: req-1)",
+		},
+		{
+			Name:     "request failures with a cause",
+			Err:      awserr.NewRequestFailure(awserr.New("RequestError", "send request failed", errors.New("connection reset")), 500, "req-2"),
+			Expected: "RequestError: send request failed (status code: 500, request id: req-2), caused by: connection reset",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := withRequestID(tc.Err)
+			assert.Equal(t, tc.Expected, err.Error())
+			assert.Equal(t, err, withRequestID(err))
+		})
+	}
+}
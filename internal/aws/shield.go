@@ -24,11 +24,11 @@ func (c *Cloud) ShieldAvailable(ctx context.Context) (bool, error) {
 }
 
 func (c *Cloud) GetSubscriptionStatus(ctx context.Context) (*shield.GetSubscriptionStateOutput, error) {
-	return c.shield.GetSubscriptionStateWithContext(ctx, &shield.GetSubscriptionStateInput{})
+	return c.forRole(ctx).shield.GetSubscriptionStateWithContext(ctx, &shield.GetSubscriptionStateInput{})
 }
 
 func (c *Cloud) GetProtection(ctx context.Context, resourceArn *string) (*shield.Protection, error) {
-	result, err := c.shield.DescribeProtectionWithContext(ctx, &shield.DescribeProtectionInput{
+	result, err := c.forRole(ctx).shield.DescribeProtectionWithContext(ctx, &shield.DescribeProtectionInput{
 		ResourceArn: resourceArn,
 	})
 
@@ -44,14 +44,14 @@ func (c *Cloud) GetProtection(ctx context.Context, resourceArn *string) (*shield
 }
 
 func (c *Cloud) CreateProtection(ctx context.Context, resourceArn *string, protectionName *string) (*shield.CreateProtectionOutput, error) {
-	return c.shield.CreateProtectionWithContext(ctx, &shield.CreateProtectionInput{
+	return c.forRole(ctx).shield.CreateProtectionWithContext(ctx, &shield.CreateProtectionInput{
 		Name:        protectionName,
 		ResourceArn: resourceArn,
 	})
 }
 
 func (c *Cloud) DeleteProtection(ctx context.Context, protectionID *string) (*shield.DeleteProtectionOutput, error) {
-	return c.shield.DeleteProtectionWithContext(ctx, &shield.DeleteProtectionInput{
+	return c.forRole(ctx).shield.DeleteProtectionWithContext(ctx, &shield.DeleteProtectionInput{
 		ProtectionId: protectionID,
 	})
 }
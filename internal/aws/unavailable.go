@@ -0,0 +1,66 @@
+package aws
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// unavailableCodes are the error codes AWS fails requests with when it can't be reached or throttles them,
+// rather than because the request itself is wrong.
+var unavailableCodes = map[string]bool{
+	request.ErrCodeRequestError:      true,
+	request.ErrCodeResponseTimeout:   true,
+	"RequestTimeout":                 true,
+	"RequestTimeoutException":        true,
+	"InternalError":                  true,
+	"InternalFailure":                true,
+	"ServiceUnavailable":             true,
+	"Throttling":                     true,
+	"ThrottlingException":            true,
+	"ThrottledException":             true,
+	"RequestLimitExceeded":           true,
+	"RequestThrottled":               true,
+	"RequestThrottledException":      true,
+	"TooManyRequestsException":       true,
+	"EC2ThrottledException":          true,
+	"PriorRequestNotComplete":        true,
+	"TransactionInProgressException": true,
+}
+
+// unavailableStatusCode matches the HTTP status code of a wrapped awserr.RequestFailure.
+var unavailableStatusCode = regexp.MustCompile(`status code: (\d{3})`)
+
+// Unavailable tells whether err is due to AWS failing or throttling requests: a 5xx or 429 response, a transport
+// failure or a throttling error code. Reconcile errors wrap AWS errors with %v, so besides AWS errors, err may be an
+// error whose message contains one.
+func Unavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if reqErr, ok := err.(awserr.RequestFailure); ok && unavailableStatus(reqErr.StatusCode()) {
+		return true
+	}
+	if awsErr, ok := err.(awserr.Error); ok {
+		return unavailableCodes[awsErr.Code()]
+	}
+	msg := err.Error()
+	for code := range unavailableCodes {
+		if strings.Contains(msg, code+": ") {
+			return true
+		}
+	}
+	for _, match := range unavailableStatusCode.FindAllStringSubmatch(msg, -1) {
+		if statusCode, err := strconv.Atoi(match[1]); err == nil && unavailableStatus(statusCode) {
+			return true
+		}
+	}
+	return false
+}
+
+func unavailableStatus(statusCode int) bool {
+	return statusCode >= 500 || statusCode == 429
+}
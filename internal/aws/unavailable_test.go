@@ -0,0 +1,65 @@
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnavailable(t *testing.T) {
+	throttled := awserr.New("RequestLimitExceeded", "Request limit exceeded.", nil)
+	for _, tc := range []struct {
+		Name       string
+		Err        error
+		ExpectedOK bool
+	}{
+		{
+			Name: "no error",
+		},
+		{
+			Name:       "throttling error",
+			Err:        throttled,
+			ExpectedOK: true,
+		},
+		{
+			Name:       "wrapped throttling error",
+			Err:        fmt.Errorf("failed to reconcile LoadBalancer due to %v", throttled),
+			ExpectedOK: true,
+		},
+		{
+			Name:       "transport error",
+			Err:        awserr.New("RequestError", "send request failed", errors.New("connection refused")),
+			ExpectedOK: true,
+		},
+		{
+			Name:       "server error",
+			Err:        awserr.NewRequestFailure(awserr.New("Unknown", "bad gateway", nil), 502, "id"),
+			ExpectedOK: true,
+		},
+		{
+			Name:       "wrapped too many requests error",
+			Err:        fmt.Errorf("failed to reconcile LoadBalancer due to %v", awserr.NewRequestFailure(awserr.New("Unknown", "slow down", nil), 429, "id")),
+			ExpectedOK: true,
+		},
+		{
+			Name: "client error",
+			Err:  awserr.NewRequestFailure(awserr.New("AccessDenied", "not authorized", nil), 403, "id"),
+		},
+		{
+			Name: "wrapped client error",
+			Err:  fmt.Errorf("failed to reconcile LoadBalancer due to %v", awserr.NewRequestFailure(awserr.New(elbv2.ErrCodeLoadBalancerNotFoundException, "not found", nil), 400, "id")),
+		},
+		{
+			Name: "other error",
+			Err:  errors.New("failed to resolve subnets"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.ExpectedOK, Unavailable(tc.Err))
+		})
+	}
+}
@@ -21,7 +21,7 @@ type WAFRegionalAPI interface {
 
 // WebACLExists checks whether the provided ID existing in AWS.
 func (c *Cloud) WebACLExists(ctx context.Context, webACLId *string) (bool, error) {
-	_, err := c.wafregional.GetWebACLWithContext(ctx, &waf.GetWebACLInput{
+	_, err := c.forRole(ctx).wafregional.GetWebACLWithContext(ctx, &waf.GetWebACLInput{
 		WebACLId: webACLId,
 	})
 
@@ -34,7 +34,7 @@ func (c *Cloud) WebACLExists(ctx context.Context, webACLId *string) (bool, error
 
 // GetWebACLSummary return associated summary for resource.
 func (c *Cloud) GetWebACLSummary(ctx context.Context, resourceArn *string) (*waf.WebACLSummary, error) {
-	result, err := c.wafregional.GetWebACLForResourceWithContext(ctx, &wafregional.GetWebACLForResourceInput{
+	result, err := c.forRole(ctx).wafregional.GetWebACLForResourceWithContext(ctx, &wafregional.GetWebACLForResourceInput{
 		ResourceArn: resourceArn,
 	})
 
@@ -47,7 +47,7 @@ func (c *Cloud) GetWebACLSummary(ctx context.Context, resourceArn *string) (*waf
 
 // AssociateWAF WAF ACL to resource.
 func (c *Cloud) AssociateWAF(ctx context.Context, resourceArn *string, webACLId *string) (*wafregional.AssociateWebACLOutput, error) {
-	result, err := c.wafregional.AssociateWebACLWithContext(ctx, &wafregional.AssociateWebACLInput{
+	result, err := c.forRole(ctx).wafregional.AssociateWebACLWithContext(ctx, &wafregional.AssociateWebACLInput{
 		ResourceArn: resourceArn,
 		WebACLId:    webACLId,
 	})
@@ -61,7 +61,7 @@ func (c *Cloud) AssociateWAF(ctx context.Context, resourceArn *string, webACLId
 
 // DisassociateWAF WAF ACL from resource.
 func (c *Cloud) DisassociateWAF(ctx context.Context, resourceArn *string) (*wafregional.DisassociateWebACLOutput, error) {
-	result, err := c.wafregional.DisassociateWebACLWithContext(ctx, &wafregional.DisassociateWebACLInput{
+	result, err := c.forRole(ctx).wafregional.DisassociateWebACLWithContext(ctx, &wafregional.DisassociateWebACLInput{
 		ResourceArn: resourceArn,
 	})
 
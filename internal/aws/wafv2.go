@@ -2,19 +2,39 @@ package aws
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/wafv2"
 )
 
 type WAFV2API interface {
+	// StatusWAFV2 validates WAFV2 connectivity
+	StatusWAFV2() func() error
+
 	GetWAFV2WebACLSummary(ctx context.Context, webACLId *string) (*wafv2.WebACL, error)
 	AssociateWAFV2(ctx context.Context, resourceArn *string, webACLId *string) (*wafv2.AssociateWebACLOutput, error)
 	DisassociateWAFV2(ctx context.Context, resourceArn *string) (*wafv2.DisassociateWebACLOutput, error)
 }
 
+// StatusWAFV2 validates WAFV2 connectivity
+func (c *Cloud) StatusWAFV2() func() error {
+	return func() error {
+		in := &wafv2.ListWebACLsInput{
+			Scope: aws.String(wafv2.ScopeRegional),
+			Limit: aws.Int64(1),
+		}
+
+		if _, err := c.wafv2.ListWebACLsWithContext(context.TODO(), in); err != nil {
+			return fmt.Errorf("[wafv2.ListWebACLsWithContext]: %v", err)
+		}
+		return nil
+	}
+}
+
 // GetWAFV2WebACLSummary return associated summary for resource.
 func (c *Cloud) GetWAFV2WebACLSummary(ctx context.Context, resourceArn *string) (*wafv2.WebACL, error) {
-	result, err := c.wafv2.GetWebACLForResourceWithContext(ctx, &wafv2.GetWebACLForResourceInput{
+	result, err := c.forRole(ctx).wafv2.GetWebACLForResourceWithContext(ctx, &wafv2.GetWebACLForResourceInput{
 		ResourceArn: resourceArn,
 	})
 
@@ -27,7 +47,7 @@ func (c *Cloud) GetWAFV2WebACLSummary(ctx context.Context, resourceArn *string)
 
 // AssociateWAFV2 WAF ACL to resource.
 func (c *Cloud) AssociateWAFV2(ctx context.Context, resourceArn *string, webACLARN *string) (*wafv2.AssociateWebACLOutput, error) {
-	result, err := c.wafv2.AssociateWebACLWithContext(ctx, &wafv2.AssociateWebACLInput{
+	result, err := c.forRole(ctx).wafv2.AssociateWebACLWithContext(ctx, &wafv2.AssociateWebACLInput{
 		ResourceArn: resourceArn,
 		WebACLArn:   webACLARN,
 	})
@@ -41,7 +61,7 @@ func (c *Cloud) AssociateWAFV2(ctx context.Context, resourceArn *string, webACLA
 
 // DisassociateWAFV2 WAFv2 ACL from resource.
 func (c *Cloud) DisassociateWAFV2(ctx context.Context, resourceArn *string) (*wafv2.DisassociateWebACLOutput, error) {
-	result, err := c.wafv2.DisassociateWebACLWithContext(ctx, &wafv2.DisassociateWebACLInput{
+	result, err := c.forRole(ctx).wafv2.DisassociateWebACLWithContext(ctx, &wafv2.DisassociateWebACLInput{
 		ResourceArn: resourceArn,
 	})
 
@@ -135,3 +135,36 @@ func TestCloud_DisassociateWAFV2(t *testing.T) {
 		})
 	}
 }
+
+func TestCloud_StatusWAFV2(t *testing.T) {
+	for _, tc := range []struct {
+		Name                        string
+		ListWebACLsWithContextError error
+		ExpectedError               error
+	}{
+		{
+			Name: "No error from API",
+		},
+		{
+			Name:                        "Error from API",
+			ListWebACLsWithContextError: errors.New("api query failed"),
+			ExpectedError:               errors.New("[wafv2.ListWebACLsWithContext]: api query failed"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			wafsvc := &mocks.WAFV2API{}
+			wafsvc.On("ListWebACLsWithContext", context.TODO(), &wafv2.ListWebACLsInput{
+				Scope: aws.String(wafv2.ScopeRegional),
+				Limit: aws.Int64(1),
+			}).Return(&wafv2.ListWebACLsOutput{}, tc.ListWebACLsWithContextError)
+
+			cloud := &Cloud{
+				wafv2: wafsvc,
+			}
+
+			err := cloud.StatusWAFV2()()
+			assert.Equal(t, tc.ExpectedError, err)
+			wafsvc.AssertExpectations(t)
+		})
+	}
+}
@@ -120,6 +120,26 @@ func TestInvalidIngressActions(t *testing.T) {
 			actionJSON:  `{"Type": "fixed-response"}`,
 			expectedErr: "missing FixedResponseConfig",
 		},
+		{
+			name:        "should error if StatusCode absent for FixedResponseConfig",
+			actionJSON:  `{"Type": "fixed-response", "FixedResponseConfig": {"ContentType": "text/plain"}}`,
+			expectedErr: "invalid FixedResponseConfig: StatusCode is required",
+		},
+		{
+			name:        "should error if StatusCode of FixedResponseConfig is 3XX",
+			actionJSON:  `{"Type": "fixed-response", "FixedResponseConfig": {"StatusCode": "302"}}`,
+			expectedErr: "invalid FixedResponseConfig: StatusCode must be 2XX, 4XX or 5XX, got 302",
+		},
+		{
+			name:        "should error if StatusCode of FixedResponseConfig isn't a number",
+			actionJSON:  `{"Type": "fixed-response", "FixedResponseConfig": {"StatusCode": "HTTP_503"}}`,
+			expectedErr: "invalid FixedResponseConfig: StatusCode must be 2XX, 4XX or 5XX, got HTTP_503",
+		},
+		{
+			name:        "should error if ContentType of FixedResponseConfig is unsupported",
+			actionJSON:  `{"Type": "fixed-response", "FixedResponseConfig": {"StatusCode": "503", "ContentType": "image/png"}}`,
+			expectedErr: "invalid FixedResponseConfig: unsupported ContentType: image/png",
+		},
 		{
 			name:        "should error if RedirectConfig absent for redirect action",
 			actionJSON:  `{"Type": "redirect"}`,
@@ -140,6 +160,21 @@ func TestInvalidIngressActions(t *testing.T) {
 			actionJSON:  `{"Type": "forward", "TargetGroupArn": "tg-1", "ForwardConfig": {"TargetGroups": [{"TargetGroupArn": "tg-2", "weight": 10}]}}`,
 			expectedErr: "precisely one of TargetGroupArn and ForwardConfig can be specified",
 		},
+		{
+			name:        "should error if weight of a target group is out of range",
+			actionJSON:  `{"Type": "forward", "ForwardConfig": {"TargetGroups": [{"ServiceName": "blue", "ServicePort": "80", "Weight": 1000}, {"ServiceName": "green", "ServicePort": "80", "Weight": 0}]}}`,
+			expectedErr: "invalid ForwardConfig: invalid TargetGroupTuple: weight must be between 0 and 999, got 1000",
+		},
+		{
+			name:        "should error if more than 5 target groups are specified",
+			actionJSON:  `{"Type": "forward", "ForwardConfig": {"TargetGroups": [{"TargetGroupArn": "tg-1", "Weight": 1}, {"TargetGroupArn": "tg-2", "Weight": 1}, {"TargetGroupArn": "tg-3", "Weight": 1}, {"TargetGroupArn": "tg-4", "Weight": 1}, {"TargetGroupArn": "tg-5", "Weight": 1}, {"TargetGroupArn": "tg-6", "Weight": 1}]}}`,
+			expectedErr: "invalid ForwardConfig: at most 5 target groups can be specified",
+		},
+		{
+			name:        "should error if stickiness duration is out of range",
+			actionJSON:  `{"Type": "forward", "ForwardConfig": {"TargetGroups": [{"TargetGroupArn": "tg-1"}], "TargetGroupStickinessConfig": {"Enabled": true, "DurationSeconds": 0}}}`,
+			expectedErr: "invalid ForwardConfig: stickiness DurationSeconds must be between 1 and 604800, got 0",
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			ing := dummy.NewIngress()
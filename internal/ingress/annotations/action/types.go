@@ -1,6 +1,8 @@
 package action
 
 import (
+	"strconv"
+
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/pkg/errors"
@@ -23,6 +25,35 @@ type FixedResponseActionConfig struct {
 	StatusCode *string
 }
 
+// fixedResponseContentTypes are the content types ELBv2 accepts for fixed responses.
+var fixedResponseContentTypes = map[string]bool{
+	"text/plain":             true,
+	"text/css":               true,
+	"text/html":              true,
+	"application/javascript": true,
+	"application/json":       true,
+}
+
+// fixedResponseMaxMessageBodyLength is the longest message body ELBv2 accepts for fixed responses.
+const fixedResponseMaxMessageBodyLength = 1024
+
+func (c *FixedResponseActionConfig) validate() error {
+	if c.StatusCode == nil {
+		return errors.New("StatusCode is required")
+	}
+	statusCode, err := strconv.Atoi(*c.StatusCode)
+	if err != nil || !((statusCode >= 200 && statusCode <= 299) || (statusCode >= 400 && statusCode <= 599)) {
+		return errors.Errorf("StatusCode must be 2XX, 4XX or 5XX, got %v", *c.StatusCode)
+	}
+	if c.ContentType != nil && !fixedResponseContentTypes[*c.ContentType] {
+		return errors.Errorf("unsupported ContentType: %v", *c.ContentType)
+	}
+	if c.MessageBody != nil && len(*c.MessageBody) > fixedResponseMaxMessageBodyLength {
+		return errors.Errorf("MessageBody must be at most %v characters", fixedResponseMaxMessageBodyLength)
+	}
+	return nil
+}
+
 // Information about an redirect action
 type RedirectActionConfig struct {
 	// The hostname. This component is not percent-encoded. The hostname can contain
@@ -112,6 +143,9 @@ func (t *TargetGroupTuple) validate() error {
 	if t.ServiceName != nil && t.ServicePort == nil {
 		return errors.New("missing ServicePort")
 	}
+	if t.Weight != nil && (*t.Weight < 0 || *t.Weight > 999) {
+		return errors.Errorf("weight must be between 0 and 999, got %v", *t.Weight)
+	}
 	return nil
 }
 
@@ -125,7 +159,16 @@ type ForwardActionConfig struct {
 	TargetGroups []*TargetGroupTuple
 }
 
+// forwardMaxTargetGroups is the most target groups ELBv2 accepts in a single forward action.
+const forwardMaxTargetGroups = 5
+
 func (c *ForwardActionConfig) validate() error {
+	if len(c.TargetGroups) > forwardMaxTargetGroups {
+		return errors.Errorf("at most %v target groups can be specified", forwardMaxTargetGroups)
+	}
+	if sc := c.TargetGroupStickinessConfig; sc != nil && sc.DurationSeconds != nil && (*sc.DurationSeconds < 1 || *sc.DurationSeconds > 604800) {
+		return errors.Errorf("stickiness DurationSeconds must be between 1 and 604800, got %v", *sc.DurationSeconds)
+	}
 	for _, t := range c.TargetGroups {
 		if err := t.validate(); err != nil {
 			return errors.Wrap(err, "invalid TargetGroupTuple")
@@ -175,6 +218,9 @@ func (a *Action) validate() error {
 		if a.FixedResponseConfig == nil {
 			return errors.New("missing FixedResponseConfig")
 		}
+		if err := a.FixedResponseConfig.validate(); err != nil {
+			return errors.Wrap(err, "invalid FixedResponseConfig")
+		}
 	case elbv2.ActionTypeEnumRedirect:
 		if a.RedirectConfig == nil {
 			return errors.New("missing RedirectConfig")
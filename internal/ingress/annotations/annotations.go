@@ -21,6 +21,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/golang/glog"
 	"github.com/imdario/mergo"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
@@ -69,8 +70,10 @@ func NewIngressDummy() *Ingress {
 type Service Ingress
 
 // Merge build a new service annotation by merge in ingress annotation
+// gRPC targetGroups default to gRPC health checks: the default health check path and success codes are replaced by
+// their gRPC counterparts.
 func (s *Service) Merge(b *Ingress, cfg *config.Configuration) *Service {
-	return &Service{
+	merged := &Service{
 		ObjectMeta:   s.ObjectMeta,
 		Action:       s.Action,
 		Conditions:   s.Conditions,
@@ -80,6 +83,15 @@ func (s *Service) Merge(b *Ingress, cfg *config.Configuration) *Service {
 		HealthCheck:  s.HealthCheck.Merge(b.HealthCheck, cfg),
 		TargetGroup:  s.TargetGroup.Merge(b.TargetGroup, cfg),
 	}
+	if aws.StringValue(merged.TargetGroup.BackendProtocolVersion) == targetgroup.BackendProtocolVersionGRPC {
+		if aws.StringValue(merged.HealthCheck.Path) == healthcheck.DefaultPath {
+			merged.HealthCheck.Path = aws.String(healthcheck.DefaultGRPCPath)
+		}
+		if aws.StringValue(merged.TargetGroup.SuccessCodes) == targetgroup.DefaultSuccessCodes {
+			merged.TargetGroup.SuccessCodes = aws.String(targetgroup.DefaultGRPCSuccessCodes)
+		}
+	}
+	return merged
 }
 
 func NewServiceDummy() *Service {
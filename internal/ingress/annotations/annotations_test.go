@@ -275,6 +275,33 @@ func TestMerge(t *testing.T) {
 				},
 			},
 		},
+		{
+			Source: &Service{
+				HealthCheck: &healthcheck.Config{
+					Path: aws.String(healthcheck.DefaultPath),
+				},
+				TargetGroup: &targetgroup.Config{
+					BackendProtocolVersion: aws.String(targetgroup.BackendProtocolVersionGRPC),
+					SuccessCodes:           aws.String(targetgroup.DefaultSuccessCodes),
+				},
+			},
+			Target: &Ingress{
+				HealthCheck: &healthcheck.Config{},
+				TargetGroup: &targetgroup.Config{},
+			},
+			Config: &config.Configuration{
+				DefaultTargetType: "instance",
+			},
+			ExpectedResult: &Service{
+				HealthCheck: &healthcheck.Config{
+					Path: aws.String(healthcheck.DefaultGRPCPath),
+				},
+				TargetGroup: &targetgroup.Config{
+					BackendProtocolVersion: aws.String(targetgroup.BackendProtocolVersionGRPC),
+					SuccessCodes:           aws.String(targetgroup.DefaultGRPCSuccessCodes),
+				},
+			},
+		},
 	} {
 		actualResult := tc.Source.Merge(tc.Target, tc.Config)
 		assert.Equal(t, tc.ExpectedResult, actualResult)
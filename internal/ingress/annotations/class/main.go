@@ -17,6 +17,8 @@ limitations under the License.
 package class
 
 import (
+	"strings"
+
 	extensions "k8s.io/api/extensions/v1beta1"
 )
 
@@ -28,11 +30,17 @@ const (
 )
 
 // If watchIngressClass is empty, then both ingress without class annotation or with class annotation specified as `alb` will be matched.
-// If watchIngressClass is not empty, then only ingress with class annotation specified as watchIngressClass will be matched
+// If watchIngressClass is not empty, then only ingress with class annotation specified as watchIngressClass will be matched.
+// watchIngressClass may be a comma separated list of classes, e.g. "alb,alb-internal", to match ingresses of any of them.
 func IsValidIngress(ingressClass string, ingress *extensions.Ingress) bool {
 	actualIngressClass := ingress.GetAnnotations()[annotationKubernetesIngressClass]
 	if ingressClass == "" {
 		return actualIngressClass == "" || actualIngressClass == defaultIngressClass
 	}
-	return actualIngressClass == ingressClass
+	for _, class := range strings.Split(ingressClass, ",") {
+		if strings.TrimSpace(class) == actualIngressClass {
+			return true
+		}
+	}
+	return false
 }
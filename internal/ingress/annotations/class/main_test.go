@@ -154,6 +154,36 @@ func TestIsValidIngress(t *testing.T) {
 			},
 			ExpectedValid: true,
 		},
+		{
+			Name:         "IngressClass set to a list, matches ingress of any class in the list",
+			IngressClass: "alb, alb-internal",
+			Ingress: extensions.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{annotationKubernetesIngressClass: "alb-internal"},
+				},
+			},
+			ExpectedValid: true,
+		},
+		{
+			Name:         "IngressClass set to a list, don't match ingress of other classes",
+			IngressClass: "alb,alb-internal",
+			Ingress: extensions.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{annotationKubernetesIngressClass: "alb-public"},
+				},
+			},
+			ExpectedValid: false,
+		},
+		{
+			Name:         "IngressClass set to a list, don't match ingress without ingressClass",
+			IngressClass: "alb,alb-internal",
+			Ingress: extensions.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{},
+				},
+			},
+			ExpectedValid: false,
+		},
 	} {
 		t.Run(tc.Name, func(t *testing.T) {
 			actualValid := IsValidIngress(tc.IngressClass, &tc.Ingress)
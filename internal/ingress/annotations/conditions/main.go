@@ -2,15 +2,39 @@ package conditions
 
 import (
 	"encoding/json"
+	"fmt"
 
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 )
 
 const UseConditionAnnotation = "use-annotation"
 
+// Path types of ingress paths, with the semantics of the pathType field of newer Ingress APIs.
+const (
+	PathTypeImplementationSpecific = "ImplementationSpecific"
+	PathTypeExact                  = "Exact"
+	PathTypePrefix                 = "Prefix"
+)
+
+// Orders the rules of an ingress are given priorities in.
+const (
+	// RuleOrderIngress keeps the order of the paths in the ingress spec.
+	RuleOrderIngress = "ingress"
+	// RuleOrderSpecificity puts rules with more specific paths first, whatever their order in the ingress spec.
+	RuleOrderSpecificity = "specificity"
+)
+
 type Config struct {
 	Conditions map[string][]RuleCondition
+
+	// PathType tells how the paths of the ingress rules are matched.
+	// The extensions/v1beta1 Ingress watched by the controller has no pathType field, so it's set by annotation for the whole ingress.
+	PathType string
+
+	// RuleOrder tells how the rules of the ingress are ordered, RuleOrderIngress or RuleOrderSpecificity.
+	RuleOrder string
 }
 
 // NewParser creates a new target group annotation parser
@@ -23,11 +47,30 @@ type conditionsParser struct {
 
 // Parse parses the annotations contained in the resource
 func (p *conditionsParser) Parse(ing parser.AnnotationInterface) (interface{}, error) {
+	pathType, err := parser.GetStringAnnotation("path-type", ing)
+	if err != nil {
+		pathType = aws.String(PathTypeImplementationSpecific)
+	}
+	switch *pathType {
+	case PathTypeImplementationSpecific, PathTypeExact, PathTypePrefix:
+	default:
+		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("path type must be one of `%v`, `%v` or `%v`", PathTypeImplementationSpecific, PathTypeExact, PathTypePrefix))
+	}
+	ruleOrder, err := parser.GetStringAnnotation("rule-order", ing)
+	if err != nil {
+		ruleOrder = aws.String(RuleOrderIngress)
+	}
+	switch *ruleOrder {
+	case RuleOrderIngress, RuleOrderSpecificity:
+	default:
+		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("rule order must be `%v` or `%v`", RuleOrderIngress, RuleOrderSpecificity))
+	}
+
 	conditionsByName := make(map[string][]RuleCondition)
 	annos, err := parser.GetStringAnnotations("conditions", ing)
 	if err != nil {
 		if errors.IsMissingAnnotations(err) {
-			return &Config{}, nil
+			return &Config{PathType: *pathType, RuleOrder: *ruleOrder}, nil
 		}
 		return nil, err
 	}
@@ -49,6 +92,8 @@ func (p *conditionsParser) Parse(ing parser.AnnotationInterface) (interface{}, e
 
 	return &Config{
 		Conditions: conditionsByName,
+		PathType:   *pathType,
+		RuleOrder:  *ruleOrder,
 	}, nil
 }
 
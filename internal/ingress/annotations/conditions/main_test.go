@@ -128,6 +128,11 @@ func TestConditionsParse_Invalid(t *testing.T) {
 			conditionsJSON: `[{"Field": "host-header"}]`,
 			expectedErr:    "missing HostHeaderConfig",
 		},
+		{
+			name:           "should error if a host is not supported by host-header condition",
+			conditionsJSON: `[{"Field": "host-header","HostHeaderConfig": {"Values": ["www.example.com", "www.example.com:8080"]}}]`,
+			expectedErr:    `invalid HostHeaderConfig: host "www.example.com:8080" contains ':', only letters, digits, '-', '.', '*' and '?' are supported`,
+		},
 		{
 			name:           "should error if PathPatternConfig absent for path-pattern condition",
 			conditionsJSON: `[{"Field": "path-pattern"}]`,
@@ -153,6 +158,26 @@ func TestConditionsParse_Invalid(t *testing.T) {
 			conditionsJSON: `[{"Field": "source-ip"}]`,
 			expectedErr:    "missing SourceIpConfig",
 		},
+		{
+			name:           "should error if HttpHeaderName absent for http-header condition",
+			conditionsJSON: `[{"Field": "http-header", "HttpHeaderConfig": {"Values": ["v"]}}]`,
+			expectedErr:    "invalid HttpHeaderConfig: HttpHeaderName is required",
+		},
+		{
+			name:           "should error if http-header condition matches the host header",
+			conditionsJSON: `[{"Field": "http-header", "HttpHeaderConfig": {"HttpHeaderName": "Host", "Values": ["www.example.com"]}}]`,
+			expectedErr:    "invalid HttpHeaderConfig: HttpHeaderName cannot be Host, use a host-header condition instead",
+		},
+		{
+			name:           "should error if source-ip condition value isn't a CIDR",
+			conditionsJSON: `[{"Field": "source-ip", "SourceIpConfig": {"Values": ["192.168.0.1"]}}]`,
+			expectedErr:    "invalid SourceIpConfig: 192.168.0.1 is not in CIDR format",
+		},
+		{
+			name:           "should error for unknown condition field",
+			conditionsJSON: `[{"Field": "cookie"}]`,
+			expectedErr:    "unknown condition field: cookie, must be one of host-header, path-pattern, http-header, http-request-method, query-string, source-ip",
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			ing := dummy.NewIngress()
@@ -164,3 +189,88 @@ func TestConditionsParse_Invalid(t *testing.T) {
 		})
 	}
 }
+
+func TestConditionsParse_PathType(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		pathType         string
+		expectedPathType string
+		expectedErr      string
+	}{
+		{
+			name:             "defaults to ImplementationSpecific",
+			expectedPathType: PathTypeImplementationSpecific,
+		},
+		{
+			name:             "Exact",
+			pathType:         "Exact",
+			expectedPathType: PathTypeExact,
+		},
+		{
+			name:             "Prefix",
+			pathType:         "Prefix",
+			expectedPathType: PathTypePrefix,
+		},
+		{
+			name:        "unknown path type",
+			pathType:    "Regex",
+			expectedErr: "path type must be one of `ImplementationSpecific`, `Exact` or `Prefix`",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ing := dummy.NewIngress()
+			data := map[string]string{}
+			if tc.pathType != "" {
+				data[parser.GetAnnotationWithPrefix("path-type")] = tc.pathType
+			}
+			ing.SetAnnotations(data)
+			cfg, err := NewParser().Parse(ing)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedPathType, cfg.(*Config).PathType)
+		})
+	}
+}
+
+func TestConditionsParse_RuleOrder(t *testing.T) {
+	for _, tc := range []struct {
+		name              string
+		ruleOrder         string
+		expectedRuleOrder string
+		expectedErr       string
+	}{
+		{
+			name:              "defaults to the order of the ingress",
+			expectedRuleOrder: RuleOrderIngress,
+		},
+		{
+			name:              "specificity",
+			ruleOrder:         "specificity",
+			expectedRuleOrder: RuleOrderSpecificity,
+		},
+		{
+			name:        "unknown rule order",
+			ruleOrder:   "alphabetical",
+			expectedErr: "rule order must be `ingress` or `specificity`",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ing := dummy.NewIngress()
+			data := map[string]string{}
+			if tc.ruleOrder != "" {
+				data[parser.GetAnnotationWithPrefix("rule-order")] = tc.ruleOrder
+			}
+			ing.SetAnnotations(data)
+			cfg, err := NewParser().Parse(ing)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedRuleOrder, cfg.(*Config).RuleOrder)
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package conditions
 
 import (
+	"net"
+	"strings"
+
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/pkg/errors"
 )
@@ -29,6 +32,28 @@ func (c *HostHeaderConditionConfig) validate() error {
 	if len(c.Values) == 0 {
 		return errors.New("Values cannot be empty")
 	}
+	for _, value := range c.Values {
+		if err := ValidateHostPattern(aws.StringValue(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateHostPattern checks host can be matched by a host-header condition: at most 128 characters among
+// letters, digits, '-', '.' and the wildcards '*' and '?'.
+func ValidateHostPattern(host string) error {
+	if len(host) == 0 || len(host) > 128 {
+		return errors.Errorf("host %q must be 1 to 128 characters long", host)
+	}
+	for _, r := range host {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '.', r == '*', r == '?':
+		default:
+			return errors.Errorf("host %q contains %q, only letters, digits, '-', '.', '*' and '?' are supported", host, r)
+		}
+	}
 	return nil
 }
 
@@ -60,6 +85,12 @@ type HttpHeaderConditionConfig struct {
 }
 
 func (c *HttpHeaderConditionConfig) validate() error {
+	if c.HttpHeaderName == nil || *c.HttpHeaderName == "" {
+		return errors.New("HttpHeaderName is required")
+	}
+	if strings.EqualFold(*c.HttpHeaderName, "host") {
+		return errors.New("HttpHeaderName cannot be Host, use a host-header condition instead")
+	}
 	if len(c.Values) == 0 {
 		return errors.New("Values cannot be empty")
 	}
@@ -167,6 +198,11 @@ func (c *SourceIpConditionConfig) validate() error {
 	if len(c.Values) == 0 {
 		return errors.New("Values cannot be empty")
 	}
+	for _, v := range c.Values {
+		if _, _, err := net.ParseCIDR(aws.StringValue(v)); err != nil {
+			return errors.Errorf("%v is not in CIDR format", aws.StringValue(v))
+		}
+	}
 	return nil
 }
 
@@ -250,6 +286,9 @@ func (c *RuleCondition) validate() error {
 		if err := c.SourceIpConfig.validate(); err != nil {
 			return errors.Wrap(err, "invalid SourceIpConfig")
 		}
+	default:
+		return errors.Errorf("unknown condition field: %v, must be one of %v", aws.StringValue(c.Field),
+			strings.Join([]string{FieldHostHeader, FieldPathPattern, FieldHTTPHeader, FieldHTTPRequestMethod, FieldQueryString, FieldSourceIP}, ", "))
 	}
 	return nil
 }
@@ -18,20 +18,33 @@ package healthcheck
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 const (
 	DefaultPath            = "/"
+	DefaultGRPCPath        = "/AWS.ALB/healthcheck"
 	DefaultPort            = "traffic-port"
 	DefaultIntervalSeconds = 15
 	DefaultTimeoutSeconds  = 5
+
+	// ranges ALBs accept for health check intervals and timeouts
+	minIntervalSeconds = 5
+	maxIntervalSeconds = 300
+	minTimeoutSeconds  = 2
+	maxTimeoutSeconds  = 120
+
+	minPort = 1
+	maxPort = 65535
 )
 
 // Config returns the URL and method to use check the status of
@@ -74,10 +87,18 @@ func (hc healthCheck) Parse(ing parser.AnnotationInterface) (interface{}, error)
 	if err != nil {
 		port = aws.String(DefaultPort)
 	}
+	if !validPort(*port) {
+		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("healthcheck port must be `%v`, a port number or the name of a service port, got %v", DefaultPort, *port))
+	}
 
 	protocol, err := parser.GetStringAnnotation("healthcheck-protocol", ing)
 	if err != nil {
-		protocol = aws.String(cfg.DefaultBackendProtocol)
+		// targets are health checked with the protocol traffic is routed to them with, unless specified otherwise.
+		if protocol, err = parser.GetStringAnnotation("backend-protocol", ing); err != nil {
+			protocol = aws.String(cfg.DefaultBackendProtocol)
+		}
+	} else if *protocol != elbv2.ProtocolEnumHttp && *protocol != elbv2.ProtocolEnumHttps {
+		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("healthcheck protocol must be either `%v` or `%v`", elbv2.ProtocolEnumHttp, elbv2.ProtocolEnumHttps))
 	}
 
 	timeoutSeconds, err := parser.GetInt64Annotation("healthcheck-timeout-seconds", ing)
@@ -88,6 +109,12 @@ func (hc healthCheck) Parse(ing parser.AnnotationInterface) (interface{}, error)
 		timeoutSeconds = aws.Int64(DefaultTimeoutSeconds)
 	}
 
+	if *seconds < minIntervalSeconds || *seconds > maxIntervalSeconds {
+		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("healthcheck interval must be between %d and %d seconds, got %d", minIntervalSeconds, maxIntervalSeconds, *seconds))
+	}
+	if *timeoutSeconds < minTimeoutSeconds || *timeoutSeconds > maxTimeoutSeconds {
+		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("healthcheck timeout must be between %d and %d seconds, got %d", minTimeoutSeconds, maxTimeoutSeconds, *timeoutSeconds))
+	}
 	if *timeoutSeconds >= *seconds {
 		return nil, fmt.Errorf("healthcheck timeout must be less than healthcheck interval. Timeout was: %d. Interval was %d",
 			*timeoutSeconds, *seconds)
@@ -102,6 +129,17 @@ func (hc healthCheck) Parse(ing parser.AnnotationInterface) (interface{}, error)
 	}, nil
 }
 
+// validPort tells whether port is DefaultPort, a port number, or a name the port of a service can have.
+func validPort(port string) bool {
+	if port == DefaultPort {
+		return true
+	}
+	if number, err := strconv.Atoi(port); err == nil {
+		return number >= minPort && number <= maxPort
+	}
+	return len(validation.IsValidPortName(port)) == 0
+}
+
 // Merge merge two config together according to default value in cfg
 func (a *Config) Merge(b *Config, cfg *config.Configuration) *Config {
 	return &Config{
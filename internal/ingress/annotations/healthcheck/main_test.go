@@ -17,6 +17,7 @@ limitations under the License.
 package healthcheck
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -24,6 +25,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
 	api "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
@@ -92,6 +94,159 @@ func TestIngressHealthCheck(t *testing.T) {
 	}
 }
 
+func TestIngressHealthCheckProtocol(t *testing.T) {
+	for _, tc := range []struct {
+		Name             string
+		Protocol         string
+		BackendProtocol  string
+		ExpectedProtocol string
+		ExpectedError    error
+	}{
+		{
+			Name:             "HTTP protocol",
+			Protocol:         "HTTP",
+			ExpectedProtocol: "HTTP",
+		},
+		{
+			Name:             "backend protocol is used by default",
+			BackendProtocol:  "HTTPS",
+			ExpectedProtocol: "HTTPS",
+		},
+		{
+			Name:             "protocol takes precedence over backend protocol",
+			Protocol:         "HTTP",
+			BackendProtocol:  "HTTPS",
+			ExpectedProtocol: "HTTP",
+		},
+		{
+			Name:             "HTTPS protocol",
+			Protocol:         "HTTPS",
+			ExpectedProtocol: "HTTPS",
+		},
+		{
+			Name:          "TCP protocol is rejected",
+			Protocol:      "TCP",
+			ExpectedError: errors.NewInvalidAnnotationContentReason("healthcheck protocol must be either `HTTP` or `HTTPS`"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ing := buildIngress()
+			annos := map[string]string{}
+			if tc.Protocol != "" {
+				annos[parser.GetAnnotationWithPrefix("healthcheck-protocol")] = tc.Protocol
+			}
+			if tc.BackendProtocol != "" {
+				annos[parser.GetAnnotationWithPrefix("backend-protocol")] = tc.BackendProtocol
+			}
+			ing.SetAnnotations(annos)
+
+			hzi, err := NewParser(mockBackend{}).Parse(ing)
+			assert.Equal(t, tc.ExpectedError, err)
+			if tc.ExpectedError == nil {
+				assert.Equal(t, tc.ExpectedProtocol, aws.StringValue(hzi.(*Config).Protocol))
+			}
+		})
+	}
+}
+
+func TestIngressHealthCheckPort(t *testing.T) {
+	for _, tc := range []struct {
+		Name          string
+		Port          string
+		ExpectedPort  string
+		ExpectedError error
+	}{
+		{
+			Name:         "traffic port by default",
+			ExpectedPort: "traffic-port",
+		},
+		{
+			Name:         "traffic port",
+			Port:         "traffic-port",
+			ExpectedPort: "traffic-port",
+		},
+		{
+			Name:         "port number",
+			Port:         "8080",
+			ExpectedPort: "8080",
+		},
+		{
+			Name:         "service port name",
+			Port:         "http-metrics",
+			ExpectedPort: "http-metrics",
+		},
+		{
+			Name:          "port number out of range",
+			Port:          "65536",
+			ExpectedError: errors.NewInvalidAnnotationContentReason("healthcheck port must be `traffic-port`, a port number or the name of a service port, got 65536"),
+		},
+		{
+			Name:          "invalid port name",
+			Port:          "traffic_port",
+			ExpectedError: errors.NewInvalidAnnotationContentReason("healthcheck port must be `traffic-port`, a port number or the name of a service port, got traffic_port"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ing := buildIngress()
+			annos := map[string]string{}
+			if tc.Port != "" {
+				annos[parser.GetAnnotationWithPrefix("healthcheck-port")] = tc.Port
+			}
+			ing.SetAnnotations(annos)
+
+			hzi, err := NewParser(mockBackend{}).Parse(ing)
+			assert.Equal(t, tc.ExpectedError, err)
+			if tc.ExpectedError == nil {
+				assert.Equal(t, tc.ExpectedPort, aws.StringValue(hzi.(*Config).Port))
+			}
+		})
+	}
+}
+
+func TestIngressHealthCheckTiming(t *testing.T) {
+	for _, tc := range []struct {
+		Name          string
+		Interval      string
+		Timeout       string
+		ExpectedError error
+	}{
+		{
+			Name:     "interval and timeout within range",
+			Interval: "300",
+			Timeout:  "120",
+		},
+		{
+			Name:          "interval below range is rejected",
+			Interval:      "4",
+			Timeout:       "2",
+			ExpectedError: errors.NewInvalidAnnotationContentReason("healthcheck interval must be between 5 and 300 seconds, got 4"),
+		},
+		{
+			Name:          "timeout above range is rejected",
+			Interval:      "300",
+			Timeout:       "121",
+			ExpectedError: errors.NewInvalidAnnotationContentReason("healthcheck timeout must be between 2 and 120 seconds, got 121"),
+		},
+		{
+			Name:          "timeout must be less than interval",
+			Interval:      "10",
+			Timeout:       "10",
+			ExpectedError: fmt.Errorf("healthcheck timeout must be less than healthcheck interval. Timeout was: 10. Interval was 10"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ing := buildIngress()
+			ing.SetAnnotations(map[string]string{
+				parser.GetAnnotationWithPrefix("healthcheck-interval-seconds"): tc.Interval,
+				parser.GetAnnotationWithPrefix("healthcheck-timeout-seconds"):  tc.Timeout,
+			})
+
+			_, err := NewParser(mockBackend{}).Parse(ing)
+			assert.Equal(t, tc.ExpectedError, err)
+		})
+	}
+}
+
 func TestMerge(t *testing.T) {
 	for _, tc := range []struct {
 		Source         *Config
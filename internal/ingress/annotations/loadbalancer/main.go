@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/golang/glog"
 
@@ -29,6 +30,7 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
+	extensions "k8s.io/api/extensions/v1beta1"
 )
 
 type PortData struct {
@@ -48,8 +50,33 @@ type Config struct {
 	SecurityGroups []string
 	Subnets        []string
 	Attributes     []*elbv2.LoadBalancerAttribute
+
+	// SSLRedirectPort is the HTTPS port that HTTP listeners redirect all traffic to, nil if HTTP traffic isn't redirected.
+	SSLRedirectPort *int64
+
+	// MutualAuthentication is the mutual TLS configuration of HTTPS listeners, nil if the annotation isn't set.
+	MutualAuthentication *MutualAuthentication
+
+	// DisplayName is the value of the Name tag of the LoadBalancer, nil if the annotation isn't set.
+	DisplayName *string
+}
+
+// MutualAuthentication configures how HTTPS listeners authenticate clients by their certificates.
+type MutualAuthentication struct {
+	Mode          string
+	TrustStoreArn string
 }
 
+// Modes of mutual authentication of HTTPS listeners.
+const (
+	MutualAuthenticationModeOff         = "off"
+	MutualAuthenticationModePassthrough = "passthrough"
+	MutualAuthenticationModeVerify      = "verify"
+)
+
+// maxTagValueLength is the maximum length of the value of an AWS tag, in Unicode characters.
+const maxTagValueLength = 256
+
 type loadBalancer struct {
 	r resolver.Resolver
 }
@@ -59,6 +86,10 @@ const (
 	DefaultScheme        = elbv2.LoadBalancerSchemeEnumInternal
 )
 
+// sslPolicyPrefix starts the names of the predefined ELB security policies. AWS adds new policies over time, so names
+// aren't checked any further, ELBv2 rejects those that don't exist.
+const sslPolicyPrefix = "ELBSecurityPolicy-"
+
 // NewParser creates a new target group annotation parser
 func NewParser(r resolver.Resolver) parser.IngressAnnotation {
 	return loadBalancer{r}
@@ -84,16 +115,43 @@ func (lb loadBalancer) Parse(ing parser.AnnotationInterface) (interface{}, error
 		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("ALB scheme must be either `%v` or `%v`", elbv2.LoadBalancerSchemeEnumInternal, elbv2.LoadBalancerSchemeEnumInternetFacing))
 	}
 
+	if err := validateStaticIPs(ing); err != nil {
+		return nil, err
+	}
+
 	ports, err := parsePorts(ing)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := validateSSLPolicy(ing); err != nil {
+		return nil, err
+	}
+
+	if err := validateHTTPSCertificate(ing, ports, lb.r.GetConfig().DefaultCertificateARN); err != nil {
+		return nil, err
+	}
+
+	sslRedirectPort, err := parseSSLRedirect(ing, ports)
+	if err != nil {
+		return nil, err
+	}
+
 	attributes, err := parseAttributes(ing)
 	if err != nil {
 		return nil, err
 	}
 
+	mutualAuthentication, err := parseMutualAuthentication(ing)
+	if err != nil {
+		return nil, err
+	}
+
+	displayName, err := parseDisplayName(ing)
+	if err != nil {
+		return nil, err
+	}
+
 	securityGroups := parser.GetStringSliceAnnotation("security-groups", ing)
 	subnets := parser.GetStringSliceAnnotation("subnets", ing)
 
@@ -119,9 +177,75 @@ func (lb loadBalancer) Parse(ing parser.AnnotationInterface) (interface{}, error
 
 		Subnets:        subnets,
 		SecurityGroups: securityGroups,
+
+		SSLRedirectPort:      sslRedirectPort,
+		MutualAuthentication: mutualAuthentication,
+		DisplayName:          displayName,
 	}, nil
 }
 
+// parseDisplayName parses the load-balancer-name annotation, the name the AWS console shows for the LoadBalancer through its Name tag.
+// Unlike the name of the LoadBalancer itself, it can be changed at any time, so it's only bounded by the length of tag values.
+func parseDisplayName(ing parser.AnnotationInterface) (*string, error) {
+	displayName, err := parser.GetStringAnnotation("load-balancer-name", ing)
+	if err != nil {
+		return nil, nil
+	}
+	if utf8.RuneCountInString(*displayName) > maxTagValueLength {
+		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("load balancer name must be at most %d characters, got %d", maxTagValueLength, utf8.RuneCountInString(*displayName)))
+	}
+	return displayName, nil
+}
+
+// staticIPAnnotationSuffixes are the annotations, named after those of NLBs, that would give static IP addresses to a LoadBalancer.
+var staticIPAnnotationSuffixes = []string{"eip-allocations", "private-ipv4-addresses", "subnet-mappings"}
+
+// nlbStaticIPAnnotations are the annotations of NLB services giving them static IP addresses, sometimes copied onto ingresses.
+var nlbStaticIPAnnotations = []string{
+	"service.beta.kubernetes.io/aws-load-balancer-eip-allocations",
+	"service.beta.kubernetes.io/aws-load-balancer-private-ipv4-addresses",
+}
+
+// validateStaticIPs rejects the annotations trying to pin the IP addresses of the LoadBalancer: unlike NLBs, ALBs have no
+// static IP addresses, their nodes come and go with their capacity, so those annotations could never be honored.
+func validateStaticIPs(ing parser.AnnotationInterface) error {
+	var names []string
+	for _, suffix := range staticIPAnnotationSuffixes {
+		names = append(names, parser.GetAnnotationWithPrefix(suffix))
+	}
+	names = append(names, nlbStaticIPAnnotations...)
+	for _, name := range names {
+		if _, ok := ing.GetAnnotations()[name]; ok {
+			return errors.NewInvalidAnnotationContentReason(fmt.Sprintf("annotation %v can't be honored, ALBs don't support static IP addresses. "+
+				"Use an NLB, or AWS Global Accelerator in front of the ALB, for static IP addresses", name))
+		}
+	}
+	return nil
+}
+
+// parseMutualAuthentication parses the mutual-authentication annotation, e.g. {"Mode": "verify", "TrustStoreArn": "arn:aws:elasticloadbalancing:..."}.
+func parseMutualAuthentication(ing parser.AnnotationInterface) (*MutualAuthentication, error) {
+	raw, err := parser.GetStringAnnotation("mutual-authentication", ing)
+	if err != nil {
+		return nil, nil
+	}
+	var mutualAuthentication MutualAuthentication
+	if err := json.Unmarshal([]byte(*raw), &mutualAuthentication); err != nil {
+		return nil, errors.NewInvalidAnnotationContent("mutual-authentication", *raw)
+	}
+	switch mutualAuthentication.Mode {
+	case MutualAuthenticationModeOff, MutualAuthenticationModePassthrough:
+	case MutualAuthenticationModeVerify:
+		if mutualAuthentication.TrustStoreArn == "" {
+			return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("mutual authentication mode `%v` requires a TrustStoreArn", MutualAuthenticationModeVerify))
+		}
+	default:
+		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("mutual authentication mode must be one of `%v`, `%v` or `%v`",
+			MutualAuthenticationModeOff, MutualAuthenticationModePassthrough, MutualAuthenticationModeVerify))
+	}
+	return &mutualAuthentication, nil
+}
+
 // parses boolean annotation and returns reference to it or nil if missing
 func parseBoolean(ing parser.AnnotationInterface, key *string) (*bool, error) {
 	value, err := parser.GetBoolAnnotation(aws.StringValue(key), ing)
@@ -133,6 +257,67 @@ func parseBoolean(ing parser.AnnotationInterface, key *string) (*bool, error) {
 	return value, nil
 }
 
+// IsSSLPolicy tells whether policy is named like a predefined ELB security policy.
+func IsSSLPolicy(policy string) bool {
+	return strings.HasPrefix(policy, sslPolicyPrefix) && len(policy) > len(sslPolicyPrefix)
+}
+
+// validateSSLPolicy makes sure the ssl-policy annotation, if present, is named like a predefined ELB security policy.
+func validateSSLPolicy(ing parser.AnnotationInterface) error {
+	sslPolicy, err := parser.GetStringAnnotation("ssl-policy", ing)
+	if err != nil {
+		return nil
+	}
+	if !IsSSLPolicy(*sslPolicy) {
+		return errors.NewInvalidAnnotationContentReason(fmt.Sprintf("unknown SSL policy `%v`, must be a predefined ELB security policy starting with `%v`", *sslPolicy, sslPolicyPrefix))
+	}
+	return nil
+}
+
+// validateHTTPSCertificate makes sure HTTPS listen ports have a certificate: either from the certificate-arn annotation,
+// the default certificate of the controller, or discovered from ACM for the hosts of the ingress, which therefore must have some.
+func validateHTTPSCertificate(ing parser.AnnotationInterface, ports []PortData, defaultCertificateARN string) error {
+	if _, err := parser.GetStringAnnotation("certificate-arn", ing); err == nil || defaultCertificateARN != "" {
+		return nil
+	}
+	ingress, ok := ing.(*extensions.Ingress)
+	if !ok {
+		return nil
+	}
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host != "" {
+			return nil
+		}
+	}
+	for _, tls := range ingress.Spec.TLS {
+		if len(tls.Hosts) != 0 {
+			return nil
+		}
+	}
+	for _, p := range ports {
+		if p.Scheme == elbv2.ProtocolEnumHttps {
+			return errors.NewInvalidAnnotationContentReason(fmt.Sprintf("HTTPS listen port %v needs a certificate-arn, or hosts to discover certificates from ACM for", p.Port))
+		}
+	}
+	return nil
+}
+
+// parseSSLRedirect returns the port of the ssl-redirect annotation, which must be one of the HTTPS listen ports.
+func parseSSLRedirect(ing parser.AnnotationInterface, ports []PortData) (*int64, error) {
+	port, err := parser.GetInt64Annotation("ssl-redirect", ing)
+	if err == errors.ErrMissingAnnotations {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, p := range ports {
+		if p.Scheme == elbv2.ProtocolEnumHttps && p.Port == *port {
+			return port, nil
+		}
+	}
+	return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("ssl-redirect port %v is not an HTTPS listen port", *port))
+}
+
 func parseAttributes(ing parser.AnnotationInterface) ([]*elbv2.LoadBalancerAttribute, error) {
 	var badAttrs []string
 	var lbattrs []*elbv2.LoadBalancerAttribute
@@ -1 +1,282 @@
 package loadbalancer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_validateSSLPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		expectedErr bool
+	}{
+		{
+			name:        "missing annotation",
+			annotations: nil,
+		},
+		{
+			name:        "known policy",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/ssl-policy": "ELBSecurityPolicy-TLS-1-2-2017-01"},
+		},
+		{
+			name:        "policy newer than the controller",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/ssl-policy": "ELBSecurityPolicy-TLS13-1-2-2099-01"},
+		},
+		{
+			name:        "unknown policy",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/ssl-policy": "TLS-1-2-2017-01"},
+			expectedErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ing := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			err := validateSSLPolicy(ing)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "ELBSecurityPolicy-")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_validateHTTPSCertificate(t *testing.T) {
+	for _, tc := range []struct {
+		name                  string
+		annotations           map[string]string
+		spec                  extensions.IngressSpec
+		ports                 []PortData
+		defaultCertificateARN string
+		expectedErr           bool
+	}{
+		{
+			name:  "HTTP only",
+			ports: []PortData{{Port: 80, Scheme: "HTTP"}},
+		},
+		{
+			name:        "HTTPS with certificate-arn",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/certificate-arn": "arn:aws:acm:us-west-2:123456789012:certificate/abc"},
+			ports:       []PortData{{Port: 80, Scheme: "HTTP"}, {Port: 443, Scheme: "HTTPS"}},
+		},
+		{
+			name:  "HTTPS with rule hosts",
+			spec:  extensions.IngressSpec{Rules: []extensions.IngressRule{{Host: "www.example.com"}}},
+			ports: []PortData{{Port: 443, Scheme: "HTTPS"}},
+		},
+		{
+			name:  "HTTPS with TLS hosts",
+			spec:  extensions.IngressSpec{TLS: []extensions.IngressTLS{{Hosts: []string{"www.example.com"}}}},
+			ports: []PortData{{Port: 443, Scheme: "HTTPS"}},
+		},
+		{
+			name:                  "HTTPS with the default certificate",
+			spec:                  extensions.IngressSpec{Rules: []extensions.IngressRule{{Host: ""}}},
+			ports:                 []PortData{{Port: 443, Scheme: "HTTPS"}},
+			defaultCertificateARN: "arn:aws:acm:us-west-2:123456789012:certificate/default",
+		},
+		{
+			name:        "HTTPS without certificate or hosts",
+			spec:        extensions.IngressSpec{Rules: []extensions.IngressRule{{Host: ""}}},
+			ports:       []PortData{{Port: 80, Scheme: "HTTP"}, {Port: 443, Scheme: "HTTPS"}},
+			expectedErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ing := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}, Spec: tc.spec}
+			err := validateHTTPSCertificate(ing, tc.ports, tc.defaultCertificateARN)
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_parseSSLRedirect(t *testing.T) {
+	ports := []PortData{{Port: 80, Scheme: "HTTP"}, {Port: 443, Scheme: "HTTPS"}}
+	for _, tc := range []struct {
+		name         string
+		annotations  map[string]string
+		expectedPort *int64
+		expectedErr  bool
+	}{
+		{
+			name:        "missing annotation",
+			annotations: nil,
+		},
+		{
+			name:         "HTTPS listen port",
+			annotations:  map[string]string{"alb.ingress.kubernetes.io/ssl-redirect": "443"},
+			expectedPort: aws.Int64(443),
+		},
+		{
+			name:        "HTTP listen port",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/ssl-redirect": "80"},
+			expectedErr: true,
+		},
+		{
+			name:        "not a listen port",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/ssl-redirect": "8443"},
+			expectedErr: true,
+		},
+		{
+			name:        "not a number",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/ssl-redirect": "true"},
+			expectedErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ing := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			port, err := parseSSLRedirect(ing, ports)
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedPort, port)
+			}
+		})
+	}
+}
+
+func Test_parseMutualAuthentication(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		expected    *MutualAuthentication
+		expectedErr string
+	}{
+		{
+			name: "missing annotation",
+		},
+		{
+			name:        "off",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/mutual-authentication": `{"Mode": "off"}`},
+			expected:    &MutualAuthentication{Mode: MutualAuthenticationModeOff},
+		},
+		{
+			name:        "not JSON",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/mutual-authentication": "verify"},
+			expectedErr: "the annotation mutual-authentication does not contain a valid value (verify)",
+		},
+		{
+			name:        "unknown mode",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/mutual-authentication": `{"Mode": "strict"}`},
+			expectedErr: "mutual authentication mode must be one of `off`, `passthrough` or `verify`",
+		},
+		{
+			name:        "verify without trust store",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/mutual-authentication": `{"Mode": "verify"}`},
+			expectedErr: "mutual authentication mode `verify` requires a TrustStoreArn",
+		},
+		{
+			name:        "verify",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/mutual-authentication": `{"Mode": "verify", "TrustStoreArn": "arn:aws:elasticloadbalancing:us-west-2:123456789012:truststore/ts/abc"}`},
+			expected: &MutualAuthentication{
+				Mode:          MutualAuthenticationModeVerify,
+				TrustStoreArn: "arn:aws:elasticloadbalancing:us-west-2:123456789012:truststore/ts/abc",
+			},
+		},
+		{
+			name:        "passthrough",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/mutual-authentication": `{"Mode": "passthrough"}`},
+			expected:    &MutualAuthentication{Mode: MutualAuthenticationModePassthrough},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ing := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			got, err := parseMutualAuthentication(ing)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func Test_parseDisplayName(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		expected    *string
+		expectedErr string
+	}{
+		{
+			name: "missing annotation",
+		},
+		{
+			name:        "display name",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/load-balancer-name": "Checkout (production)"},
+			expected:    aws.String("Checkout (production)"),
+		},
+		{
+			name:        "display name of 256 characters",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/load-balancer-name": strings.Repeat("é", 256)},
+			expected:    aws.String(strings.Repeat("é", 256)),
+		},
+		{
+			name:        "display name longer than 256 characters",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/load-balancer-name": strings.Repeat("a", 257)},
+			expectedErr: "load balancer name must be at most 256 characters, got 257",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ing := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			got, err := parseDisplayName(ing)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func Test_validateStaticIPs(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		expectedErr string
+	}{
+		{
+			name:        "no static IP annotation",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/scheme": "internet-facing"},
+		},
+		{
+			name:        "EIP allocations",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/eip-allocations": "eipalloc-1,eipalloc-2"},
+			expectedErr: "annotation alb.ingress.kubernetes.io/eip-allocations can't be honored, ALBs don't support static IP addresses. Use an NLB, or AWS Global Accelerator in front of the ALB, for static IP addresses",
+		},
+		{
+			name:        "private IP addresses",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/private-ipv4-addresses": "10.0.0.10,10.0.1.10"},
+			expectedErr: "annotation alb.ingress.kubernetes.io/private-ipv4-addresses can't be honored, ALBs don't support static IP addresses. Use an NLB, or AWS Global Accelerator in front of the ALB, for static IP addresses",
+		},
+		{
+			name:        "NLB service annotation",
+			annotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-eip-allocations": "eipalloc-1"},
+			expectedErr: "annotation service.beta.kubernetes.io/aws-load-balancer-eip-allocations can't be honored, ALBs don't support static IP addresses. Use an NLB, or AWS Global Accelerator in front of the ALB, for static IP addresses",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ing := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			err := validateStaticIPs(ing)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
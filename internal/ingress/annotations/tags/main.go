@@ -24,6 +24,11 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
 )
 
+const (
+	maxTagKeyLength   = 128
+	maxTagValueLength = 256
+)
+
 type Config struct {
 	LoadBalancer map[string]string
 }
@@ -44,7 +49,7 @@ func (tg targetGroup) Parse(ing parser.AnnotationInterface) (interface{}, error)
 
 	tags := parser.GetStringSliceAnnotation("tags", ing)
 	for _, tag := range tags {
-		parts := strings.Split(tag, "=")
+		parts := strings.SplitN(tag, "=", 2)
 		switch {
 		case tag == "":
 			continue
@@ -52,6 +57,9 @@ func (tg targetGroup) Parse(ing parser.AnnotationInterface) (interface{}, error)
 			badTags = append(badTags, tag)
 			continue
 		}
+		if err := validateTag(parts[0], parts[1]); err != nil {
+			return nil, err
+		}
 		lbtags[parts[0]] = parts[1]
 	}
 
@@ -64,6 +72,20 @@ func (tg targetGroup) Parse(ing parser.AnnotationInterface) (interface{}, error)
 	}, nil
 }
 
+// validateTag checks a tag against the limits AWS places on tags of ELBv2 resources.
+func validateTag(key string, value string) error {
+	if len(key) == 0 || len(key) > maxTagKeyLength {
+		return fmt.Errorf("tag key `%s` must be between 1 and %d characters", key, maxTagKeyLength)
+	}
+	if len(value) > maxTagValueLength {
+		return fmt.Errorf("value of tag `%s` must be at most %d characters", key, maxTagValueLength)
+	}
+	if strings.HasPrefix(strings.ToLower(key), "aws:") {
+		return fmt.Errorf("tag key `%s` uses the reserved `aws:` prefix", key)
+	}
+	return nil
+}
+
 func (a *Config) Merge(b *Config) {
 	if a.LoadBalancer == nil {
 		if b.LoadBalancer != nil {
@@ -1 +1,69 @@
 package tags
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		tags        string
+		expected    map[string]string
+		expectedErr string
+	}{
+		{
+			name:     "key value pairs",
+			tags:     "Team=payments, CostCenter=1234",
+			expected: map[string]string{"Team": "payments", "CostCenter": "1234"},
+		},
+		{
+			name:     "value containing an equal sign",
+			tags:     "Query=a=b",
+			expected: map[string]string{"Query": "a=b"},
+		},
+		{
+			name:     "empty value",
+			tags:     "Team=",
+			expected: map[string]string{"Team": ""},
+		},
+		{
+			name:        "missing value",
+			tags:        "Team",
+			expectedErr: "Unable to parse `Team` into Key=Value pair(s)",
+		},
+		{
+			name:        "key too long",
+			tags:        strings.Repeat("k", 129) + "=v",
+			expectedErr: "must be between 1 and 128 characters",
+		},
+		{
+			name:        "value too long",
+			tags:        "Team=" + strings.Repeat("v", 257),
+			expectedErr: "value of tag `Team` must be at most 256 characters",
+		},
+		{
+			name:        "reserved prefix",
+			tags:        "aws:cloudformation:stack-name=stack",
+			expectedErr: "tag key `aws:cloudformation:stack-name` uses the reserved `aws:` prefix",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ing := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{"alb.ingress.kubernetes.io/tags": tc.tags},
+			}}
+			cfg, err := NewParser(nil).Parse(ing)
+			if tc.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, cfg.(*Config).LoadBalancer)
+		})
+	}
+}
@@ -18,6 +18,9 @@ package targetgroup
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
@@ -32,7 +35,10 @@ import (
 type Config struct {
 	Attributes              []*elbv2.TargetGroupAttribute
 	BackendProtocol         *string
+	BackendProtocolVersion  *string
 	HealthyThresholdCount   *int64
+	IPAddressType           *string
+	Name                    *string
 	SuccessCodes            *string
 	TargetType              *string
 	UnhealthyThresholdCount *int64
@@ -44,9 +50,38 @@ type targetGroup struct {
 
 const (
 	DefaultBackendProtocol         = elbv2.ProtocolEnumHttp
+	DefaultBackendProtocolVersion  = BackendProtocolVersionHTTP1
 	DefaultHealthyThresholdCount   = 2
+	DefaultIPAddressType           = IPAddressTypeIPv4
 	DefaultUnhealthyThresholdCount = 2
 	DefaultSuccessCodes            = "200"
+	DefaultGRPCSuccessCodes        = "12"
+
+	minSuccessCode = 200
+	maxSuccessCode = 499
+
+	minGRPCSuccessCode = 0
+	maxGRPCSuccessCode = 99
+
+	minThresholdCount = 2
+	maxThresholdCount = 10
+)
+
+// nameRegex matches the targetGroup names AWS accepts: up to 32 alphanumeric characters or hyphens,
+// not beginning nor ending with a hyphen.
+var nameRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,30}[a-zA-Z0-9])?$`)
+
+// Protocol versions of targetGroups, the version of HTTP requests are sent to targets with.
+const (
+	BackendProtocolVersionHTTP1 = "HTTP1"
+	BackendProtocolVersionHTTP2 = "HTTP2"
+	BackendProtocolVersionGRPC  = "GRPC"
+)
+
+// IP address types of targetGroups, ipv6 targetGroups route to the IPv6 addresses of pods.
+const (
+	IPAddressTypeIPv4 = "ipv4"
+	IPAddressTypeIPv6 = "ipv6"
 )
 
 // NewParser creates a new target group annotation parser
@@ -72,20 +107,56 @@ func (tg targetGroup) Parse(ing parser.AnnotationInterface) (interface{}, error)
 		backendProtocol = aws.String(DefaultBackendProtocol)
 	}
 
+	if *backendProtocol != elbv2.ProtocolEnumHttp && *backendProtocol != elbv2.ProtocolEnumHttps {
+		return "", errors.NewInvalidAnnotationContent("backend-protocol", *backendProtocol)
+	}
+
+	protocolVersion, err := parser.GetStringAnnotation("backend-protocol-version", ing)
+	if err != nil {
+		protocolVersion = nil
+	} else if *protocolVersion != BackendProtocolVersionHTTP1 && *protocolVersion != BackendProtocolVersionHTTP2 && *protocolVersion != BackendProtocolVersionGRPC {
+		return "", errors.NewInvalidAnnotationContent("backend-protocol-version", *protocolVersion)
+	}
+
+	ipAddressType, err := parser.GetStringAnnotation("target-group-ip-address-type", ing)
+	if err != nil {
+		ipAddressType = aws.String(DefaultIPAddressType)
+	}
+	if *ipAddressType != IPAddressTypeIPv4 && *ipAddressType != IPAddressTypeIPv6 {
+		return nil, errors.NewInvalidAnnotationContent("target-group-ip-address-type", *ipAddressType)
+	}
+
+	name, err := parser.GetStringAnnotation("target-group-name", ing)
+	if err == nil && !nameRegex.MatchString(*name) {
+		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("target group name `%v` must be 1 to 32 alphanumeric characters or hyphens, not beginning nor ending with a hyphen", *name))
+	}
+
 	healthyThresholdCount, err := parser.GetInt64Annotation("healthy-threshold-count", ing)
 	if err != nil {
+		if err != errors.ErrMissingAnnotations {
+			return nil, err
+		}
 		healthyThresholdCount = aws.Int64(DefaultHealthyThresholdCount)
 	}
+	if !validThresholdCount(*healthyThresholdCount) {
+		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("healthy threshold count must be between %d and %d, got %d", minThresholdCount, maxThresholdCount, *healthyThresholdCount))
+	}
 
 	unhealthyThresholdCount, err := parser.GetInt64Annotation("unhealthy-threshold-count", ing)
 	if err != nil {
+		if err != errors.ErrMissingAnnotations {
+			return nil, err
+		}
 		unhealthyThresholdCount = aws.Int64(DefaultUnhealthyThresholdCount)
 	}
+	if !validThresholdCount(*unhealthyThresholdCount) {
+		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("unhealthy threshold count must be between %d and %d, got %d", minThresholdCount, maxThresholdCount, *unhealthyThresholdCount))
+	}
 
 	// support legacy successCodes annotation
 	successCodes, err := parser.GetStringAnnotation("successCodes", ing)
 	if err != nil {
-		successCodes = aws.String(DefaultSuccessCodes)
+		successCodes = nil
 	}
 
 	s, err := parser.GetStringAnnotation("success-codes", ing)
@@ -93,6 +164,24 @@ func (tg targetGroup) Parse(ing parser.AnnotationInterface) (interface{}, error)
 		successCodes = s
 	}
 
+	if successCodes != nil {
+		// the protocol version may be annotated on the ingress and the success codes on the service, so without a version
+		// here the codes only need to be valid for either HTTP or gRPC.
+		validCodes := ValidSuccessCodes(DefaultBackendProtocolVersion, *successCodes) || ValidSuccessCodes(BackendProtocolVersionGRPC, *successCodes)
+		if protocolVersion != nil {
+			validCodes = ValidSuccessCodes(*protocolVersion, *successCodes)
+		}
+		if !validCodes {
+			return nil, errors.NewInvalidAnnotationContent("success-codes", *successCodes)
+		}
+	} else {
+		// the default is replaced by DefaultGRPCSuccessCodes for gRPC targetGroups once merged.
+		successCodes = aws.String(DefaultSuccessCodes)
+	}
+	if protocolVersion == nil {
+		protocolVersion = aws.String(DefaultBackendProtocolVersion)
+	}
+
 	attributes, err := parseAttributes(ing)
 	if err != nil {
 		return nil, err
@@ -101,6 +190,9 @@ func (tg targetGroup) Parse(ing parser.AnnotationInterface) (interface{}, error)
 	return &Config{
 		TargetType:              targetType,
 		BackendProtocol:         backendProtocol,
+		BackendProtocolVersion:  protocolVersion,
+		IPAddressType:           ipAddressType,
+		Name:                    name,
 		HealthyThresholdCount:   healthyThresholdCount,
 		UnhealthyThresholdCount: unhealthyThresholdCount,
 		SuccessCodes:            successCodes,
@@ -108,23 +200,81 @@ func (tg targetGroup) Parse(ing parser.AnnotationInterface) (interface{}, error)
 	}, nil
 }
 
-// Merge merge two config according to defaults in cfg
+// Merge merge two config according to defaults in cfg.
+// Name is only taken from a, the service, since names of targetGroups are unique and an ingress may route to several services.
 func (a *Config) Merge(b *Config, cfg *config.Configuration) *Config {
 	attributes := a.Attributes
 	if attributes == nil {
 		attributes = b.Attributes
 	}
+	attributes = withDefaultAttributes(cfg.Defaults().TargetGroupAttributes, attributes)
 
 	return &Config{
 		Attributes:              attributes,
 		BackendProtocol:         parser.MergeString(a.BackendProtocol, b.BackendProtocol, DefaultBackendProtocol),
+		BackendProtocolVersion:  parser.MergeString(a.BackendProtocolVersion, b.BackendProtocolVersion, DefaultBackendProtocolVersion),
 		TargetType:              parser.MergeString(a.TargetType, b.TargetType, cfg.DefaultTargetType),
+		IPAddressType:           parser.MergeString(a.IPAddressType, b.IPAddressType, DefaultIPAddressType),
+		Name:                    a.Name,
 		SuccessCodes:            parser.MergeString(a.SuccessCodes, b.SuccessCodes, DefaultSuccessCodes),
 		HealthyThresholdCount:   parser.MergeInt64(a.HealthyThresholdCount, b.HealthyThresholdCount, DefaultHealthyThresholdCount),
 		UnhealthyThresholdCount: parser.MergeInt64(a.UnhealthyThresholdCount, b.UnhealthyThresholdCount, DefaultUnhealthyThresholdCount),
 	}
 }
 
+// validThresholdCount tells whether count is within the consecutive health check results ALBs accept as thresholds.
+func validThresholdCount(count int64) bool {
+	return count >= minThresholdCount && count <= maxThresholdCount
+}
+
+// ValidSuccessCodes tells whether codes is a comma separated list of codes, or ranges of them such as 200-299, ALBs
+// accept as health check success codes for targetGroups of protocolVersion: HTTP codes within 200 to 499, or gRPC
+// codes within 0 to 99.
+func ValidSuccessCodes(protocolVersion string, codes string) bool {
+	minCode, maxCode := int64(minSuccessCode), int64(maxSuccessCode)
+	if protocolVersion == BackendProtocolVersionGRPC {
+		minCode, maxCode = minGRPCSuccessCode, maxGRPCSuccessCode
+	}
+	for _, part := range strings.Split(codes, ",") {
+		bounds := strings.Split(strings.TrimSpace(part), "-")
+		if len(bounds) > 2 {
+			return false
+		}
+		prev := int64(-1)
+		for _, bound := range bounds {
+			code, err := strconv.ParseInt(bound, 10, 64)
+			if err != nil || code < minCode || code > maxCode || code <= prev {
+				return false
+			}
+			prev = code
+		}
+	}
+	return true
+}
+
+// withDefaultAttributes returns attributes, completed with the defaults whose keys attributes doesn't set.
+func withDefaultAttributes(defaults map[string]string, attributes []*elbv2.TargetGroupAttribute) []*elbv2.TargetGroupAttribute {
+	if len(defaults) == 0 {
+		return attributes
+	}
+	set := make(map[string]bool, len(attributes))
+	for _, attribute := range attributes {
+		set[aws.StringValue(attribute.Key)] = true
+	}
+	keys := make([]string, 0, len(defaults))
+	for k := range defaults {
+		if !set[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	merged := append([]*elbv2.TargetGroupAttribute{}, attributes...)
+	for _, k := range keys {
+		merged = append(merged, &elbv2.TargetGroupAttribute{Key: aws.String(k), Value: aws.String(defaults[k])})
+	}
+	return merged
+}
+
 func parseAttributes(ing parser.AnnotationInterface) ([]*elbv2.TargetGroupAttribute, error) {
 	var invalid []string
 	var output []*elbv2.TargetGroupAttribute
@@ -154,7 +304,9 @@ func parseAttributes(ing parser.AnnotationInterface) ([]*elbv2.TargetGroupAttrib
 func Dummy() *Config {
 	return &Config{
 		BackendProtocol:         aws.String(elbv2.ProtocolEnumHttp),
+		BackendProtocolVersion:  aws.String(BackendProtocolVersionHTTP1),
 		HealthyThresholdCount:   aws.Int64(2),
+		IPAddressType:           aws.String(IPAddressTypeIPv4),
 		SuccessCodes:            aws.String("200"),
 		TargetType:              aws.String(elbv2.TargetTypeEnumInstance),
 		UnhealthyThresholdCount: aws.Int64(2),
@@ -5,10 +5,319 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
 	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
 )
 
+func TestParseBackendProtocol(t *testing.T) {
+	for _, tc := range []struct {
+		Name             string
+		BackendProtocol  string
+		ExpectedProtocol string
+		ExpectedError    error
+	}{
+		{
+			Name:             "HTTP is used by default",
+			ExpectedProtocol: elbv2.ProtocolEnumHttp,
+		},
+		{
+			Name:             "HTTPS protocol",
+			BackendProtocol:  "HTTPS",
+			ExpectedProtocol: elbv2.ProtocolEnumHttps,
+		},
+		{
+			Name:            "TCP protocol is rejected",
+			BackendProtocol: "TCP",
+			ExpectedError:   errors.NewInvalidAnnotationContent("backend-protocol", "TCP"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ing := &extensions.Ingress{}
+			annos := map[string]string{
+				parser.GetAnnotationWithPrefix("target-type"): elbv2.TargetTypeEnumInstance,
+			}
+			if tc.BackendProtocol != "" {
+				annos[parser.GetAnnotationWithPrefix("backend-protocol")] = tc.BackendProtocol
+			}
+			ing.SetAnnotations(annos)
+
+			tgi, err := NewParser(resolver.Mock{}).Parse(ing)
+			assert.Equal(t, tc.ExpectedError, err)
+			if tc.ExpectedError == nil {
+				assert.Equal(t, tc.ExpectedProtocol, aws.StringValue(tgi.(*Config).BackendProtocol))
+			}
+		})
+	}
+}
+
+func TestParseBackendProtocolVersion(t *testing.T) {
+	for _, tc := range []struct {
+		Name                    string
+		ProtocolVersion         string
+		SuccessCodes            string
+		ExpectedProtocolVersion string
+		ExpectedSuccessCodes    string
+		ExpectedError           error
+	}{
+		{
+			Name:                    "HTTP1 is used by default",
+			ExpectedProtocolVersion: "HTTP1",
+			ExpectedSuccessCodes:    "200",
+		},
+		{
+			Name:                    "HTTP2 protocol version",
+			ProtocolVersion:         "HTTP2",
+			ExpectedProtocolVersion: "HTTP2",
+			ExpectedSuccessCodes:    "200",
+		},
+		{
+			Name:                    "GRPC protocol version keeps the default success codes until merged",
+			ProtocolVersion:         "GRPC",
+			ExpectedProtocolVersion: "GRPC",
+			ExpectedSuccessCodes:    "200",
+		},
+		{
+			Name:                    "GRPC protocol version with gRPC success codes",
+			ProtocolVersion:         "GRPC",
+			SuccessCodes:            "0-5,12",
+			ExpectedProtocolVersion: "GRPC",
+			ExpectedSuccessCodes:    "0-5,12",
+		},
+		{
+			Name:            "GRPC protocol version with HTTP success codes",
+			ProtocolVersion: "GRPC",
+			SuccessCodes:    "200",
+			ExpectedError:   errors.NewInvalidAnnotationContent("success-codes", "200"),
+		},
+		{
+			Name:            "HTTP1 protocol version with gRPC success codes",
+			ProtocolVersion: "HTTP1",
+			SuccessCodes:    "12",
+			ExpectedError:   errors.NewInvalidAnnotationContent("success-codes", "12"),
+		},
+		{
+			Name:            "unknown protocol version",
+			ProtocolVersion: "HTTP3",
+			ExpectedError:   errors.NewInvalidAnnotationContent("backend-protocol-version", "HTTP3"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ing := &extensions.Ingress{}
+			annos := map[string]string{
+				parser.GetAnnotationWithPrefix("target-type"): elbv2.TargetTypeEnumInstance,
+			}
+			if tc.ProtocolVersion != "" {
+				annos[parser.GetAnnotationWithPrefix("backend-protocol-version")] = tc.ProtocolVersion
+			}
+			if tc.SuccessCodes != "" {
+				annos[parser.GetAnnotationWithPrefix("success-codes")] = tc.SuccessCodes
+			}
+			ing.SetAnnotations(annos)
+
+			cfg, err := NewParser(resolver.Mock{}).Parse(ing)
+			assert.Equal(t, tc.ExpectedError, err)
+			if tc.ExpectedError == nil {
+				assert.Equal(t, tc.ExpectedProtocolVersion, aws.StringValue(cfg.(*Config).BackendProtocolVersion))
+				assert.Equal(t, tc.ExpectedSuccessCodes, aws.StringValue(cfg.(*Config).SuccessCodes))
+			}
+		})
+	}
+}
+
+func TestParseIPAddressType(t *testing.T) {
+	for _, tc := range []struct {
+		Name                  string
+		IPAddressType         string
+		ExpectedIPAddressType string
+		ExpectedError         error
+	}{
+		{
+			Name:                  "ipv4 is used by default",
+			ExpectedIPAddressType: IPAddressTypeIPv4,
+		},
+		{
+			Name:                  "ipv6 IP address type",
+			IPAddressType:         "ipv6",
+			ExpectedIPAddressType: IPAddressTypeIPv6,
+		},
+		{
+			Name:          "dualstack IP address type is rejected",
+			IPAddressType: "dualstack",
+			ExpectedError: errors.NewInvalidAnnotationContent("target-group-ip-address-type", "dualstack"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ing := &extensions.Ingress{}
+			annos := map[string]string{
+				parser.GetAnnotationWithPrefix("target-type"): elbv2.TargetTypeEnumIp,
+			}
+			if tc.IPAddressType != "" {
+				annos[parser.GetAnnotationWithPrefix("target-group-ip-address-type")] = tc.IPAddressType
+			}
+			ing.SetAnnotations(annos)
+
+			tgi, err := NewParser(resolver.Mock{}).Parse(ing)
+			assert.Equal(t, tc.ExpectedError, err)
+			if tc.ExpectedError == nil {
+				assert.Equal(t, tc.ExpectedIPAddressType, aws.StringValue(tgi.(*Config).IPAddressType))
+			}
+		})
+	}
+}
+
+func TestParseName(t *testing.T) {
+	for _, tc := range []struct {
+		Name          string
+		TGName        string
+		ExpectedName  *string
+		ExpectedError error
+	}{
+		{
+			Name: "name is generated by default",
+		},
+		{
+			Name:         "explicit name",
+			TGName:       "echoserver-http",
+			ExpectedName: aws.String("echoserver-http"),
+		},
+		{
+			Name:         "name of 32 characters",
+			TGName:       "a1234567890123456789012345678901",
+			ExpectedName: aws.String("a1234567890123456789012345678901"),
+		},
+		{
+			Name:          "name longer than 32 characters is rejected",
+			TGName:        "a12345678901234567890123456789012",
+			ExpectedError: errors.NewInvalidAnnotationContentReason("target group name `a12345678901234567890123456789012` must be 1 to 32 alphanumeric characters or hyphens, not beginning nor ending with a hyphen"),
+		},
+		{
+			Name:          "name ending with a hyphen is rejected",
+			TGName:        "echoserver-",
+			ExpectedError: errors.NewInvalidAnnotationContentReason("target group name `echoserver-` must be 1 to 32 alphanumeric characters or hyphens, not beginning nor ending with a hyphen"),
+		},
+		{
+			Name:          "name with underscores is rejected",
+			TGName:        "echo_server",
+			ExpectedError: errors.NewInvalidAnnotationContentReason("target group name `echo_server` must be 1 to 32 alphanumeric characters or hyphens, not beginning nor ending with a hyphen"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ing := &extensions.Ingress{}
+			annos := map[string]string{
+				parser.GetAnnotationWithPrefix("target-type"): elbv2.TargetTypeEnumInstance,
+			}
+			if tc.TGName != "" {
+				annos[parser.GetAnnotationWithPrefix("target-group-name")] = tc.TGName
+			}
+			ing.SetAnnotations(annos)
+
+			tgi, err := NewParser(resolver.Mock{}).Parse(ing)
+			assert.Equal(t, tc.ExpectedError, err)
+			if tc.ExpectedError == nil {
+				assert.Equal(t, tc.ExpectedName, tgi.(*Config).Name)
+			}
+		})
+	}
+}
+
+func TestParseSuccessCodes(t *testing.T) {
+	for _, tc := range []struct {
+		Name          string
+		SuccessCodes  string
+		ExpectedError error
+	}{
+		{
+			Name:         "single code",
+			SuccessCodes: "200",
+		},
+		{
+			Name:         "list of codes",
+			SuccessCodes: "200,401",
+		},
+		{
+			Name:         "list of codes and ranges",
+			SuccessCodes: "200-299, 401",
+		},
+		{
+			Name:          "code outside of 200-499 is rejected",
+			SuccessCodes:  "500",
+			ExpectedError: errors.NewInvalidAnnotationContent("success-codes", "500"),
+		},
+		{
+			Name:          "descending range is rejected",
+			SuccessCodes:  "299-200",
+			ExpectedError: errors.NewInvalidAnnotationContent("success-codes", "299-200"),
+		},
+		{
+			Name:          "malformed range is rejected",
+			SuccessCodes:  "200-",
+			ExpectedError: errors.NewInvalidAnnotationContent("success-codes", "200-"),
+		},
+		{
+			Name:          "non numeric code is rejected",
+			SuccessCodes:  "ok",
+			ExpectedError: errors.NewInvalidAnnotationContent("success-codes", "ok"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ing := &extensions.Ingress{}
+			ing.SetAnnotations(map[string]string{
+				parser.GetAnnotationWithPrefix("target-type"):   elbv2.TargetTypeEnumInstance,
+				parser.GetAnnotationWithPrefix("success-codes"): tc.SuccessCodes,
+			})
+
+			tgi, err := NewParser(resolver.Mock{}).Parse(ing)
+			assert.Equal(t, tc.ExpectedError, err)
+			if tc.ExpectedError == nil {
+				assert.Equal(t, tc.SuccessCodes, aws.StringValue(tgi.(*Config).SuccessCodes))
+			}
+		})
+	}
+}
+
+func TestParseThresholdCounts(t *testing.T) {
+	for _, tc := range []struct {
+		Name                    string
+		HealthyThresholdCount   string
+		UnhealthyThresholdCount string
+		ExpectedError           error
+	}{
+		{
+			Name:                    "thresholds within range",
+			HealthyThresholdCount:   "10",
+			UnhealthyThresholdCount: "2",
+		},
+		{
+			Name:                    "healthy threshold above range is rejected",
+			HealthyThresholdCount:   "11",
+			UnhealthyThresholdCount: "2",
+			ExpectedError:           errors.NewInvalidAnnotationContentReason("healthy threshold count must be between 2 and 10, got 11"),
+		},
+		{
+			Name:                    "unhealthy threshold below range is rejected",
+			HealthyThresholdCount:   "2",
+			UnhealthyThresholdCount: "1",
+			ExpectedError:           errors.NewInvalidAnnotationContentReason("unhealthy threshold count must be between 2 and 10, got 1"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ing := &extensions.Ingress{}
+			ing.SetAnnotations(map[string]string{
+				parser.GetAnnotationWithPrefix("target-type"):               elbv2.TargetTypeEnumInstance,
+				parser.GetAnnotationWithPrefix("healthy-threshold-count"):   tc.HealthyThresholdCount,
+				parser.GetAnnotationWithPrefix("unhealthy-threshold-count"): tc.UnhealthyThresholdCount,
+			})
+
+			_, err := NewParser(resolver.Mock{}).Parse(ing)
+			assert.Equal(t, tc.ExpectedError, err)
+		})
+	}
+}
+
 func TestMerge(t *testing.T) {
 	for _, tc := range []struct {
 		Source         *Config
@@ -104,3 +413,51 @@ func TestMerge(t *testing.T) {
 		assert.Equal(t, tc.ExpectedResult, actualResult)
 	}
 }
+
+func Test_withDefaultAttributes(t *testing.T) {
+	for _, tc := range []struct {
+		Name       string
+		Defaults   map[string]string
+		Attributes []*elbv2.TargetGroupAttribute
+		Expected   []*elbv2.TargetGroupAttribute
+	}{
+		{
+			Name: "no defaults",
+			Attributes: []*elbv2.TargetGroupAttribute{
+				{Key: aws.String("slow_start.duration_seconds"), Value: aws.String("30")},
+			},
+			Expected: []*elbv2.TargetGroupAttribute{
+				{Key: aws.String("slow_start.duration_seconds"), Value: aws.String("30")},
+			},
+		},
+		{
+			Name: "defaults complete the attributes",
+			Defaults: map[string]string{
+				"stickiness.enabled":                   "true",
+				"deregistration_delay.timeout_seconds": "30",
+			},
+			Expected: []*elbv2.TargetGroupAttribute{
+				{Key: aws.String("deregistration_delay.timeout_seconds"), Value: aws.String("30")},
+				{Key: aws.String("stickiness.enabled"), Value: aws.String("true")},
+			},
+		},
+		{
+			Name: "attributes override defaults",
+			Defaults: map[string]string{
+				"deregistration_delay.timeout_seconds": "30",
+				"slow_start.duration_seconds":          "60",
+			},
+			Attributes: []*elbv2.TargetGroupAttribute{
+				{Key: aws.String("deregistration_delay.timeout_seconds"), Value: aws.String("0")},
+			},
+			Expected: []*elbv2.TargetGroupAttribute{
+				{Key: aws.String("deregistration_delay.timeout_seconds"), Value: aws.String("0")},
+				{Key: aws.String("slow_start.duration_seconds"), Value: aws.String("60")},
+			},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, withDefaultAttributes(tc.Defaults, tc.Attributes))
+		})
+	}
+}
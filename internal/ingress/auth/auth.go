@@ -9,6 +9,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
+	backendpkg "github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
@@ -104,7 +105,7 @@ func (m *defaultModule) NewConfig(ctx context.Context, ingress *extensions.Ingre
 	var serviceAnnos map[string]string
 	if !action.Use(backend.ServicePort.String()) {
 		serviceKey := types.NamespacedName{
-			Namespace: ingress.Namespace,
+			Namespace: backendpkg.ServiceNamespace(ingress, backend.ServiceName),
 			Name:      backend.ServiceName,
 		}
 		service := corev1.Service{}
@@ -120,7 +121,14 @@ func (m *defaultModule) NewConfig(ctx context.Context, ingress *extensions.Ingre
 	if _, err := annotations.LoadInt64Annotation(AnnotationAuthSessionTimeout, &cfg.SessionTimeout, serviceAnnos, ingressAnnos); err != nil {
 		return Config{}, err
 	}
+	switch cfg.OnUnauthenticatedRequest {
+	case OnUnauthenticatedRequestAuthenticate, OnUnauthenticatedRequestAllow, OnUnauthenticatedRequestDeny:
+	default:
+		return Config{}, fmt.Errorf("annotation %s must be one of %s, %s or %s, got %s", AnnotationAuthOnUnauthenticatedRequest,
+			OnUnauthenticatedRequestAuthenticate, OnUnauthenticatedRequestAllow, OnUnauthenticatedRequestDeny, cfg.OnUnauthenticatedRequest)
+	}
 	switch cfg.Type {
+	case TypeNone:
 	case TypeCognito:
 		{
 			exists, err := annotations.LoadJSONAnnotation(AnnotationAuthIDPCognito, &cfg.IDPCognito, serviceAnnos, ingressAnnos)
@@ -141,6 +149,8 @@ func (m *defaultModule) NewConfig(ctx context.Context, ingress *extensions.Ingre
 				return Config{}, errors.New(fmt.Sprintf("annotation %s is required when authType == %s", AnnotationAuthIDPOIDC, TypeOIDC))
 			}
 		}
+	default:
+		return Config{}, fmt.Errorf("annotation %s must be one of %s, %s or %s, got %s", AnnotationAuthType, TypeNone, TypeCognito, TypeOIDC, cfg.Type)
 	}
 
 	return cfg, nil
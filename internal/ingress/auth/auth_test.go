@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -400,6 +401,56 @@ func TestDefaultModule_NewConfig(t *testing.T) {
 				OnUnauthenticatedRequest: OnUnauthenticatedRequestDeny,
 			},
 		},
+		{
+			name: "ingress with unknown auth type",
+			ingress: &extensions.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "namespace",
+					Name:      "ingress",
+					Annotations: map[string]string{
+						parser.GetAnnotationWithPrefix(AnnotationAuthType): "cogntio",
+					},
+				},
+			},
+			backend: extensions.IngressBackend{
+				ServiceName: "service",
+				ServicePort: intstr.FromInt(80),
+			},
+			service: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "namespace",
+					Name:      "service",
+				},
+			},
+			protocol:    "HTTPS",
+			expectedErr: errors.New("annotation auth-type must be one of none, cognito or oidc, got cogntio"),
+		},
+		{
+			name: "ingress with unknown on-unauthenticated-request behavior",
+			ingress: &extensions.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "namespace",
+					Name:      "ingress",
+					Annotations: map[string]string{
+						parser.GetAnnotationWithPrefix(AnnotationAuthType):                     "cognito",
+						parser.GetAnnotationWithPrefix(AnnotationAuthIDPCognito):               "{\"UserPoolArn\": \"UserPoolArn\",\"UserPoolClientId\": \"UserPoolClientId\",\"UserPoolDomain\": \"UserPoolDomain\"}",
+						parser.GetAnnotationWithPrefix(AnnotationAuthOnUnauthenticatedRequest): "redirect",
+					},
+				},
+			},
+			backend: extensions.IngressBackend{
+				ServiceName: "service",
+				ServicePort: intstr.FromInt(80),
+			},
+			service: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "namespace",
+					Name:      "service",
+				},
+			},
+			protocol:    "HTTPS",
+			expectedErr: errors.New("annotation auth-on-unauthenticated-request must be one of authenticate, allow or deny, got redirect"),
+		},
 		{
 			name: "http don't support auth",
 			ingress: &extensions.Ingress{
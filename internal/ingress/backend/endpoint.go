@@ -27,6 +27,7 @@ import (
 	api "k8s.io/api/core/v1"
 	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -65,11 +66,12 @@ func (resolver *endpointResolver) Resolve(ingress *extensions.Ingress, backend *
 
 // For each item in the targets slice, returns the corresponding pod in the result slice at the same index. The result slice is exactly as long as the input slice.
 func (resolver *endpointResolver) ReverseResolve(ingress *extensions.Ingress, backend *extensions.IngressBackend, targets []*elbv2.TargetDescription) ([]*corev1.Pod, error) {
-	service, servicePort, err := findServiceAndPort(resolver.store, ingress.Namespace, backend.ServiceName, backend.ServicePort)
+	namespace := ServiceNamespace(ingress, backend.ServiceName)
+	service, servicePort, err := findServiceAndPort(resolver.store, namespace, backend.ServiceName, backend.ServicePort)
 	if err != nil {
 		return nil, err
 	}
-	serviceKey := ingress.Namespace + "/" + service.Name
+	serviceKey := namespace + "/" + service.Name
 	eps, err := resolver.store.GetServiceEndpoints(serviceKey)
 	if err != nil {
 		return nil, fmt.Errorf("Unable to find service endpoints for %s: %v", serviceKey, err.Error())
@@ -92,7 +94,7 @@ func (resolver *endpointResolver) ReverseResolve(ingress *extensions.Ingress, ba
 					continue
 				}
 
-				podKey := ingress.Namespace + "/" + epAddr.TargetRef.Name
+				podKey := namespace + "/" + epAddr.TargetRef.Name
 				pod, err := resolver.store.GetPod(podKey)
 				if err != nil {
 					continue
@@ -108,7 +110,8 @@ func (resolver *endpointResolver) ReverseResolve(ingress *extensions.Ingress, ba
 }
 
 func (resolver *endpointResolver) resolveInstance(ingress *extensions.Ingress, backend *extensions.IngressBackend) ([]*elbv2.TargetDescription, error) {
-	service, servicePort, err := findServiceAndPort(resolver.store, ingress.Namespace, backend.ServiceName, backend.ServicePort)
+	namespace := ServiceNamespace(ingress, backend.ServiceName)
+	service, servicePort, err := findServiceAndPort(resolver.store, namespace, backend.ServiceName, backend.ServicePort)
 	if err != nil {
 		return nil, err
 	}
@@ -118,8 +121,9 @@ func (resolver *endpointResolver) resolveInstance(ingress *extensions.Ingress, b
 	nodePort := servicePort.NodePort
 
 	var result []*elbv2.TargetDescription
+	cfg := resolver.store.GetConfig()
 	for _, node := range resolver.store.ListNodes() {
-		if !IsNodeSuitableAsTrafficProxy(node) {
+		if !IsNodeSuitableAsTarget(node, cfg.TargetNodeSelector, !cfg.RegisterUnreadyNodes) {
 			continue
 		}
 		instanceID, err := resolver.store.GetNodeInstanceID(node)
@@ -135,11 +139,12 @@ func (resolver *endpointResolver) resolveInstance(ingress *extensions.Ingress, b
 }
 
 func (resolver *endpointResolver) resolveIP(ingress *extensions.Ingress, backend *extensions.IngressBackend) ([]*elbv2.TargetDescription, error) {
-	service, servicePort, err := findServiceAndPort(resolver.store, ingress.Namespace, backend.ServiceName, backend.ServicePort)
+	namespace := ServiceNamespace(ingress, backend.ServiceName)
+	service, servicePort, err := findServiceAndPort(resolver.store, namespace, backend.ServiceName, backend.ServicePort)
 	if err != nil {
 		return nil, err
 	}
-	serviceKey := ingress.Namespace + "/" + service.Name
+	serviceKey := namespace + "/" + service.Name
 	eps, err := resolver.store.GetServiceEndpoints(serviceKey)
 	if err != nil {
 		return nil, fmt.Errorf("Unable to find service endpoints for %s: %v", serviceKey, err.Error())
@@ -166,7 +171,7 @@ func (resolver *endpointResolver) resolveIP(ingress *extensions.Ingress, backend
 					continue
 				}
 
-				podKey := ingress.Namespace + "/" + epAddr.TargetRef.Name
+				podKey := namespace + "/" + epAddr.TargetRef.Name
 				pod, err := resolver.store.GetPod(podKey)
 				if err != nil || !IsPodSuitableAsIPTarget(pod) {
 					continue
@@ -199,17 +204,39 @@ func (resolver *endpointResolver) resolveIP(ingress *extensions.Ingress, backend
 // IsNodeSuitableAsTrafficProxy check whether node is suitable as a traffic proxy.
 // mimic the logic of serviceController: https://github.com/kubernetes/kubernetes/blob/b6b494b4484b51df8dc6b692fab234573da30ab4/pkg/controller/service/controller.go#L605
 func IsNodeSuitableAsTrafficProxy(node *corev1.Node) bool {
-	if node.Spec.Unschedulable {
+	return !isNodeExcluded(node) && isNodeReady(node)
+}
+
+// IsNodeSuitableAsTarget check whether node is suitable as a traffic proxy and matches selector.
+// A nil selector matches every node. Unless checkReadiness is set, NotReady and unschedulable nodes are suitable too.
+func IsNodeSuitableAsTarget(node *corev1.Node, selector labels.Selector, checkReadiness bool) bool {
+	if selector != nil && !selector.Matches(labels.Set(node.Labels)) {
 		return false
 	}
-	if s, ok := node.ObjectMeta.Labels[labelEKSComputeType]; ok && s == "fargate" {
+	if isNodeExcluded(node) {
 		return false
 	}
+	return !checkReadiness || isNodeReady(node)
+}
+
+// isNodeExcluded check whether node is a master, fargate or otherwise excluded from load balancers.
+func isNodeExcluded(node *corev1.Node) bool {
+	if s, ok := node.ObjectMeta.Labels[labelEKSComputeType]; ok && s == "fargate" {
+		return true
+	}
 	for _, label := range []string{labelNodeRoleMaster, labelNodeRoleExcludeBalancer, labelAlphaNodeRoleExcludeBalancer} {
 		if _, hasLabel := node.ObjectMeta.Labels[label]; hasLabel {
-			return false
+			return true
 		}
 	}
+	return false
+}
+
+// isNodeReady check whether node is schedulable and its Ready condition is True.
+func isNodeReady(node *corev1.Node) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
 	for _, cond := range node.Status.Conditions {
 		if cond.Type == corev1.NodeReady && cond.Status == corev1.ConditionTrue {
 			return true
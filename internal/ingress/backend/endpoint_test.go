@@ -32,6 +32,7 @@ import (
 	extensions "k8s.io/api/extensions/v1beta1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -1357,3 +1358,79 @@ func Test_IsNodeSuitableAsTrafficProxy(t *testing.T) {
 		})
 	}
 }
+
+func Test_IsNodeSuitableAsTarget(t *testing.T) {
+	readyNode := func(nodeLabels map[string]string) *api_v1.Node {
+		return &api_v1.Node{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:   "awesome-node",
+				Labels: nodeLabels,
+			},
+			Status: api_v1.NodeStatus{
+				Conditions: []api_v1.NodeCondition{
+					{
+						Type:   api_v1.NodeReady,
+						Status: api_v1.ConditionTrue,
+					},
+				},
+			},
+		}
+	}
+	unreadyNode := readyNode(nil)
+	unreadyNode.Status.Conditions[0].Status = api_v1.ConditionFalse
+	tests := []struct {
+		name           string
+		node           *api_v1.Node
+		selector       string
+		checkReadiness bool
+		want           bool
+	}{
+		{
+			name:           "nil selector",
+			node:           readyNode(nil),
+			checkReadiness: true,
+			want:           true,
+		},
+		{
+			name:           "unready node with readiness check",
+			node:           unreadyNode,
+			checkReadiness: true,
+			want:           false,
+		},
+		{
+			name:           "unready node without readiness check",
+			node:           unreadyNode,
+			checkReadiness: false,
+			want:           true,
+		},
+		{
+			name:     "node matches selector",
+			node:     readyNode(map[string]string{"lifecycle": "on-demand"}),
+			selector: "lifecycle!=spot",
+			want:     true,
+		},
+		{
+			name:     "node doesn't match selector",
+			node:     readyNode(map[string]string{"lifecycle": "spot"}),
+			selector: "lifecycle!=spot",
+			want:     false,
+		},
+		{
+			name:     "master node matches selector",
+			node:     readyNode(map[string]string{"node-role.kubernetes.io/master": ""}),
+			selector: "lifecycle!=spot",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var selector labels.Selector
+			if tt.selector != "" {
+				var err error
+				selector, err = labels.Parse(tt.selector)
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, IsNodeSuitableAsTarget(tt.node, selector, tt.checkReadiness))
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package backend
+
+import (
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	extensions "k8s.io/api/extensions/v1beta1"
+)
+
+// AnnotationServiceNamespace prefixes the annotations routing the backends of an ingress that name a service to the
+// service of that name in another namespace, e.g. backend-service-namespace.echoserver: apps
+const AnnotationServiceNamespace = "backend-service-namespace"
+
+// ServiceNamespace returns the namespace of the service named serviceName that backends of ingress route to:
+// the one set by the backend-service-namespace.<serviceName> annotation, or else the namespace of ingress.
+// Whether ingress may route to other namespaces is up to the caller.
+func ServiceNamespace(ingress *extensions.Ingress, serviceName string) string {
+	if namespace, err := parser.GetStringAnnotation(AnnotationServiceNamespace+"."+serviceName, ingress); err == nil && *namespace != "" {
+		return *namespace
+	}
+	return ingress.Namespace
+}
@@ -0,0 +1,58 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestServiceNamespace(t *testing.T) {
+	for _, tc := range []struct {
+		Name              string
+		Annotations       map[string]string
+		ServiceName       string
+		ExpectedNamespace string
+	}{
+		{
+			Name:              "no annotation",
+			ServiceName:       "echoserver",
+			ExpectedNamespace: "gateway",
+		},
+		{
+			Name: "annotation for the service",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/backend-service-namespace.echoserver": "apps",
+			},
+			ServiceName:       "echoserver",
+			ExpectedNamespace: "apps",
+		},
+		{
+			Name: "annotation for another service",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/backend-service-namespace.echoserver": "apps",
+			},
+			ServiceName:       "other",
+			ExpectedNamespace: "gateway",
+		},
+		{
+			Name: "empty annotation",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/backend-service-namespace.echoserver": "",
+			},
+			ServiceName:       "echoserver",
+			ExpectedNamespace: "gateway",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ingress := &extensions.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "gateway",
+					Annotations: tc.Annotations,
+				},
+			}
+			assert.Equal(t, tc.ExpectedNamespace, ServiceNamespace(ingress, tc.ServiceName))
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	admission "k8s.io/api/admission/v1beta1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AdmissionHandler serves a validating admission webhook for ingresses.
+// It parses the annotations of the ingress under review the same way reconciles do, and denies it if they're invalid,
+// so that misconfigurations are reported at apply time instead of on the next reconcile.
+// Checks that need AWS calls, like whether subnets match the scheme, still only happen during reconcile.
+func (r *Reconciler) AdmissionHandler() http.Handler {
+	extractor := annotations.NewIngressAnnotationExtractor(r.store)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		review := admission.AdmissionReview{}
+		if err := json.NewDecoder(req.Body).Decode(&review); err != nil {
+			http.Error(w, fmt.Sprintf("failed to decode AdmissionReview due to %v", err), http.StatusBadRequest)
+			return
+		}
+		if review.Request == nil {
+			http.Error(w, "AdmissionReview contains no request", http.StatusBadRequest)
+			return
+		}
+
+		review.Response = r.reviewIngress(extractor, review.Request)
+		review.Response.UID = review.Request.UID
+		review.Request = nil
+		b, err := json.Marshal(review)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(b)
+	})
+}
+
+// reviewIngress decides whether the ingress in an admission request is allowed.
+// Ingresses of other ingress classes are always allowed.
+func (r *Reconciler) reviewIngress(extractor annotations.Extractor, req *admission.AdmissionRequest) *admission.AdmissionResponse {
+	ingress := &extensions.Ingress{}
+	if err := json.Unmarshal(req.Object.Raw, ingress); err != nil {
+		return deniedResponse(fmt.Errorf("failed to decode ingress due to %v", err))
+	}
+	if !class.IsValidIngress(r.store.GetConfig().IngressClass, ingress) {
+		return &admission.AdmissionResponse{Allowed: true}
+	}
+	if err := extractor.ExtractIngress(ingress).Error; err != nil {
+		return deniedResponse(fmt.Errorf("invalid annotations on ingress %v/%v: %v", ingress.Namespace, ingress.Name, err))
+	}
+	if _, err := iamRoleARNOf(ingress, r.store.GetConfig()); err != nil {
+		return deniedResponse(fmt.Errorf("invalid annotations on ingress %v/%v: %v", ingress.Namespace, ingress.Name, err))
+	}
+	return &admission.AdmissionResponse{Allowed: true}
+}
+
+func deniedResponse(err error) *admission.AdmissionResponse {
+	return &admission.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: err.Error(),
+			Reason:  metav1.StatusReasonInvalid,
+			Code:    http.StatusUnprocessableEntity,
+		},
+	}
+}
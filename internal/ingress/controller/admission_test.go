@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/stretchr/testify/assert"
+	admission "k8s.io/api/admission/v1beta1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestReconciler_AdmissionHandler(t *testing.T) {
+	for _, tc := range []struct {
+		Name            string
+		Annotations     map[string]string
+		ExpectedAllowed bool
+		ExpectedMessage string
+	}{
+		{
+			Name:            "ingress without annotations",
+			ExpectedAllowed: true,
+		},
+		{
+			Name: "ingress with valid annotations",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/scheme":       "internal",
+				"alb.ingress.kubernetes.io/listen-ports": `[{"HTTP": 8080}]`,
+			},
+			ExpectedAllowed: true,
+		},
+		{
+			Name: "ingress with invalid scheme",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/scheme": "public",
+			},
+			ExpectedAllowed: false,
+			ExpectedMessage: "invalid annotations on ingress ns/ing: ALB scheme must be either `internal` or `internet-facing`",
+		},
+		{
+			Name: "ingress of another ingress class is not validated",
+			Annotations: map[string]string{
+				"kubernetes.io/ingress.class":      "nginx",
+				"alb.ingress.kubernetes.io/scheme": "public",
+			},
+			ExpectedAllowed: true,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ingress := &extensions.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ing", Annotations: tc.Annotations},
+			}
+			raw, err := json.Marshal(ingress)
+			assert.NoError(t, err)
+			body, err := json.Marshal(admission.AdmissionReview{
+				Request: &admission.AdmissionRequest{
+					UID:    types.UID("uid"),
+					Object: runtime.RawExtension{Raw: raw},
+				},
+			})
+			assert.NoError(t, err)
+
+			s := store.NewDummy()
+			s.SetConfig(&config.Configuration{DefaultTargetType: "instance", DefaultBackendProtocol: "HTTP"})
+			r := &Reconciler{store: s}
+			w := httptest.NewRecorder()
+			r.AdmissionHandler().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/validate-ingress", bytes.NewReader(body)))
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			review := admission.AdmissionReview{}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &review))
+			assert.Nil(t, review.Request)
+			assert.Equal(t, types.UID("uid"), review.Response.UID)
+			assert.Equal(t, tc.ExpectedAllowed, review.Response.Allowed)
+			if tc.ExpectedMessage != "" {
+				assert.Equal(t, tc.ExpectedMessage, review.Response.Result.Message)
+			}
+		})
+	}
+}
+
+func TestReconciler_AdmissionHandler_MalformedReview(t *testing.T) {
+	r := &Reconciler{store: store.NewDummy()}
+	w := httptest.NewRecorder()
+	r.AdmissionHandler().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/validate-ingress", bytes.NewReader([]byte(`{}`))))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
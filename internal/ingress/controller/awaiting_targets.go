@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// awaitingLoadBalancer is a LoadBalancer whose ingresses are held back from Ready until enough of its targets are healthy.
+type awaitingLoadBalancer struct {
+	roleARN     string
+	lbArn       string
+	tgArns      []string
+	ingressKeys []types.NamespacedName
+}
+
+// awaitingTargets tracks the awaitingLoadBalancers, keyed by the ingress or ingress group they're reconciled for.
+type awaitingTargets struct {
+	mutex         sync.Mutex
+	loadBalancers map[types.NamespacedName]awaitingLoadBalancer
+}
+
+// track records that the ingresses of the LoadBalancer of key await healthy targets.
+func (a *awaitingTargets) track(key types.NamespacedName, lb awaitingLoadBalancer) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.loadBalancers == nil {
+		a.loadBalancers = make(map[types.NamespacedName]awaitingLoadBalancer)
+	}
+	a.loadBalancers[key] = lb
+}
+
+// forget stops polling the target health of the LoadBalancer of key.
+func (a *awaitingTargets) forget(key types.NamespacedName) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	delete(a.loadBalancers, key)
+}
+
+// get returns the awaitingLoadBalancer of key, if its ingresses still await healthy targets.
+func (a *awaitingTargets) get(key types.NamespacedName) (awaitingLoadBalancer, bool) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	lb, ok := a.loadBalancers[key]
+	return lb, ok
+}
+
+// keys returns the keys of the awaitingLoadBalancers.
+func (a *awaitingTargets) keys() []types.NamespacedName {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	keys := make([]types.NamespacedName, 0, len(a.loadBalancers))
+	for key := range a.loadBalancers {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// TargetHealthPoll marks the ingresses held back from Ready by --min-healthy-percent Ready once enough of their targets
+// are healthy, checking every targetHealthPollInterval. Only target health is described, their LoadBalancers aren't
+// reconciled again, so that ingresses whose targets never become healthy don't keep making every AWS call of a reconcile.
+func (r *Reconciler) TargetHealthPoll() manager.Runnable {
+	return manager.RunnableFunc(func(stop <-chan struct{}) error {
+		ticker := time.NewTicker(targetHealthPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return nil
+			case <-ticker.C:
+				r.pollAwaitingTargets(context.Background())
+			}
+		}
+	})
+}
+
+// pollAwaitingTargets checks the target health of each awaitingLoadBalancer, marking its ingresses Ready once enough
+// targets are healthy.
+func (r *Reconciler) pollAwaitingTargets(ctx context.Context) {
+	for _, key := range r.awaiting.keys() {
+		if err := r.pollAwaitingLoadBalancer(ctx, key); err != nil {
+			glog.Errorf("failed to check the target health of %v due to %v", key, err)
+		}
+	}
+}
+
+// pollAwaitingLoadBalancer checks the target health of the awaitingLoadBalancer of key, serialized with its reconciles.
+func (r *Reconciler) pollAwaitingLoadBalancer(ctx context.Context, key types.NamespacedName) error {
+	lock := r.reconciling.lock
+	if strings.HasPrefix(key.Name, groupKeyPrefix) {
+		lock = r.groups.lock
+	}
+	unlock := lock(key)
+	defer unlock()
+	// a reconcile may have updated or forgotten the LoadBalancer meanwhile.
+	lb, ok := r.awaiting.get(key)
+	if !ok {
+		return nil
+	}
+	ready, err := r.checkTargetHealth(ctx, lb.roleARN, lb.tgArns, lb.ingressKeys...)
+	if err != nil || !ready {
+		return err
+	}
+	for _, ingressKey := range lb.ingressKeys {
+		ingress := &extensions.Ingress{}
+		if err := r.cache.Get(ctx, ingressKey, ingress); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if err := r.updateStatusAnnotations(ctx, ingress, IngressStatusReady, lb.lbArn); err != nil {
+			return err
+		}
+	}
+	r.awaiting.forget(key)
+	return nil
+}
@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestReconciler_pollAwaitingTargets(t *testing.T) {
+	for _, tc := range []struct {
+		Name             string
+		State            string
+		ExpectedStatus   string
+		ExpectedAwaiting bool
+	}{
+		{
+			Name:             "too few healthy targets",
+			State:            elbv2.TargetHealthStateEnumInitial,
+			ExpectedStatus:   IngressStatusProvisioning,
+			ExpectedAwaiting: true,
+		},
+		{
+			Name:           "enough healthy targets",
+			State:          elbv2.TargetHealthStateEnumHealthy,
+			ExpectedStatus: IngressStatusReady,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			ingressKey := types.NamespacedName{Namespace: "ns", Name: "ing"}
+			cloud := &mocks.CloudAPI{}
+			cloud.On("DescribeTargetHealthWithContext", mock.Anything, &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String("tg-1")}).Return(&elbv2.DescribeTargetHealthOutput{
+				TargetHealthDescriptions: []*elbv2.TargetHealthDescription{targetHealthDescription(tc.State)},
+			}, nil)
+			r, c := newGroupTestReconciler(&fakeLBController{}, cloud,
+				ingressWithRule("ing", map[string]string{"alb.ingress.kubernetes.io/status": IngressStatusProvisioning}, "ing.example.com"))
+			r.targetHealth.cloud = cloud
+			r.minHealthyPercent = 100
+			r.awaiting.track(ingressKey, awaitingLoadBalancer{lbArn: "lb-arn", tgArns: []string{"tg-1"}, ingressKeys: []types.NamespacedName{ingressKey}})
+
+			// only target health is described, the LoadBalancer isn't reconciled again.
+			r.pollAwaitingTargets(ctx)
+			stored := &extensions.Ingress{}
+			assert.NoError(t, c.Get(ctx, ingressKey, stored))
+			assert.Equal(t, tc.ExpectedStatus, stored.Annotations["alb.ingress.kubernetes.io/status"])
+			_, awaiting := r.awaiting.get(ingressKey)
+			assert.Equal(t, tc.ExpectedAwaiting, awaiting)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric/collectors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// States of the circuitBreaker, as reported by StatusHandler.
+const (
+	CircuitBreakerClosed = "closed"
+	CircuitBreakerOpen   = "open"
+)
+
+// circuitBreakerProbeTimeout bounds how long the probe of AWS may take.
+const circuitBreakerProbeTimeout = 10 * time.Second
+
+// circuitBreaker pauses reconciles once threshold reconciles failed in a row, e.g. during an AWS outage,
+// so that retrying every ingress doesn't add to the load. Once cooldown elapsed, a read-only probe of AWS decides
+// whether reconciles resume or stay paused for another cooldown. A zero threshold disables it.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	probe     func(ctx context.Context) error
+
+	mutex    sync.Mutex
+	failures int
+	openedAt time.Time
+	// probing is set while a reconcile probes AWS, the others stay paused meanwhile.
+	probing bool
+}
+
+// pausedError is returned instead of reconciling while the circuitBreaker pauses reconciles.
+type pausedError struct {
+	// wait is how long to wait before trying again.
+	wait time.Duration
+}
+
+func (e *pausedError) Error() string {
+	return fmt.Sprintf("reconciles are paused after repeated failures, retry in %v", e.wait.Round(time.Second))
+}
+
+// configure changes threshold and cooldown, e.g. after the defaults ConfigMap changed. They apply from the next reconcile.
+func (b *circuitBreaker) configure(threshold int, cooldown time.Duration) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.threshold = threshold
+	b.cooldown = cooldown
+}
+
+// allow tells whether a reconcile may run at now, or else how long to wait before trying again.
+// AWS is probed without holding the mutex, so that other reconciles and state aren't held up by a slow probe.
+func (b *circuitBreaker) allow(now time.Time) (bool, time.Duration) {
+	b.mutex.Lock()
+	if b.openedAt.IsZero() {
+		b.mutex.Unlock()
+		return true, 0
+	}
+	if elapsed := now.Sub(b.openedAt); elapsed < b.cooldown {
+		b.mutex.Unlock()
+		return false, b.cooldown - elapsed
+	}
+	if b.probing {
+		b.mutex.Unlock()
+		return false, circuitBreakerProbeTimeout
+	}
+	b.probing = true
+	b.mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), circuitBreakerProbeTimeout)
+	err := b.probe(ctx)
+	cancel()
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.probing = false
+	if err != nil {
+		glog.Warningf("AWS still failing due to %v, pausing reconciles for another %v", err, b.cooldown)
+		b.openedAt = now
+		return false, b.cooldown
+	}
+	glog.Infof("AWS recovered, resuming reconciles")
+	b.openedAt = time.Time{}
+	b.failures = 0
+	return true, 0
+}
+
+// record records the outcome of a reconcile at now, opening the breaker after threshold failures in a row.
+// Only failures due to AWS failing or throttling requests are counted: others, such as invalid annotations or a reached
+// AWS quota, don't signal an outage, and pausing reconciles doesn't fix them.
+func (b *circuitBreaker) record(err error, now time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if err == nil {
+		b.failures = 0
+		return
+	}
+	if !aws.Unavailable(err) {
+		return
+	}
+	b.failures++
+	if b.threshold > 0 && b.failures >= b.threshold && b.openedAt.IsZero() {
+		glog.Warningf("%d reconciles failed in a row, pausing reconciles for %v", b.failures, b.cooldown)
+		b.openedAt = now
+	}
+}
+
+// state returns CircuitBreakerOpen while reconciles are paused, CircuitBreakerClosed otherwise.
+func (b *circuitBreaker) state() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.openedAt.IsZero() {
+		return CircuitBreakerClosed
+	}
+	return CircuitBreakerOpen
+}
+
+// CircuitBreakerCollector exposes whether reconciles of r are paused by its circuit breaker as a prometheus gauge.
+func (r *Reconciler) CircuitBreakerCollector() prometheus.Collector {
+	return prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: collectors.PrometheusNamespace,
+		Name:      "circuit_breaker_open",
+		Help:      `Whether reconciles are paused after repeated failures, 1 if they are`,
+	}, func() float64 {
+		if r.breaker.state() == CircuitBreakerOpen {
+			return 1
+		}
+		return 0
+	})
+}
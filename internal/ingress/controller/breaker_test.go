@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+var unavailableErr = awserr.NewRequestFailure(awserr.New("ServiceUnavailable", "service unavailable", nil), 503, "id")
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	probeErr := errors.New("RequestError")
+	probes := 0
+	b := &circuitBreaker{
+		threshold: 2,
+		cooldown:  time.Minute,
+		probe: func(ctx context.Context) error {
+			probes++
+			return probeErr
+		},
+	}
+
+	// a success in between resets the consecutive failures.
+	b.record(unavailableErr, now)
+	b.record(nil, now)
+	b.record(unavailableErr, now)
+	assert.Equal(t, CircuitBreakerClosed, b.state())
+	ok, _ := b.allow(now)
+	assert.True(t, ok)
+
+	b.record(unavailableErr, now)
+	assert.Equal(t, CircuitBreakerOpen, b.state())
+
+	// reconciles are paused during the cooldown, without probing AWS.
+	ok, wait := b.allow(now.Add(20 * time.Second))
+	assert.False(t, ok)
+	assert.Equal(t, 40*time.Second, wait)
+	assert.Equal(t, 0, probes)
+
+	// a failing probe pauses reconciles for another cooldown.
+	ok, wait = b.allow(now.Add(time.Minute))
+	assert.False(t, ok)
+	assert.Equal(t, time.Minute, wait)
+	assert.Equal(t, 1, probes)
+	assert.Equal(t, CircuitBreakerOpen, b.state())
+
+	// a succeeding probe resumes reconciles.
+	probeErr = nil
+	ok, _ = b.allow(now.Add(2 * time.Minute))
+	assert.True(t, ok)
+	assert.Equal(t, 2, probes)
+	assert.Equal(t, CircuitBreakerClosed, b.state())
+}
+
+func TestCircuitBreaker_concurrentProbe(t *testing.T) {
+	now := time.Now()
+	probing := make(chan struct{})
+	release := make(chan struct{})
+	b := &circuitBreaker{
+		threshold: 1,
+		cooldown:  time.Minute,
+		probe: func(ctx context.Context) error {
+			_, ok := ctx.Deadline()
+			assert.True(t, ok, "the probe should be bounded by a timeout")
+			close(probing)
+			<-release
+			return nil
+		},
+	}
+	b.record(unavailableErr, now)
+
+	probed := make(chan bool)
+	go func() {
+		ok, _ := b.allow(now.Add(time.Minute))
+		probed <- ok
+	}()
+	<-probing
+
+	// while a probe runs, the state is served and other reconciles stay paused without probing.
+	assert.Equal(t, CircuitBreakerOpen, b.state())
+	ok, wait := b.allow(now.Add(time.Minute))
+	assert.False(t, ok)
+	assert.Equal(t, circuitBreakerProbeTimeout, wait)
+
+	close(release)
+	assert.True(t, <-probed)
+	assert.Equal(t, CircuitBreakerClosed, b.state())
+}
+
+func TestCircuitBreaker_otherFailures(t *testing.T) {
+	now := time.Now()
+	b := &circuitBreaker{threshold: 2, cooldown: time.Minute}
+	quotaErr := awserr.New(elbv2.ErrCodeTooManyLoadBalancersException, "too many load balancers", nil)
+	accessDeniedErr := awserr.NewRequestFailure(awserr.New("AccessDenied", "not authorized", nil), 403, "id")
+	annotationErr := errors.New("failed to parse annotations")
+	for i := 0; i < 5; i++ {
+		b.record(quotaErr, now)
+		b.record(accessDeniedErr, now)
+		b.record(annotationErr, now)
+	}
+	assert.Equal(t, CircuitBreakerClosed, b.state())
+
+	// other failures neither count towards nor reset the consecutive failures.
+	b.record(unavailableErr, now)
+	b.record(quotaErr, now)
+	b.record(annotationErr, now)
+	b.record(fmt.Errorf("failed to reconcile LoadBalancer due to %v", awserr.New("RequestLimitExceeded", "Request limit exceeded.", nil)), now)
+	assert.Equal(t, CircuitBreakerOpen, b.state())
+}
+
+func TestCircuitBreaker_disabled(t *testing.T) {
+	b := &circuitBreaker{}
+	for i := 0; i < 10; i++ {
+		b.record(unavailableErr, time.Now())
+	}
+	assert.Equal(t, CircuitBreakerClosed, b.state())
+}
+
+func TestReconciler_CircuitBreakerCollector(t *testing.T) {
+	r := &Reconciler{breaker: circuitBreaker{threshold: 1, cooldown: time.Minute}}
+	r.breaker.record(unavailableErr, time.Now())
+
+	metric := &dto.Metric{}
+	assert.NoError(t, r.CircuitBreakerCollector().(prometheus.Metric).Write(metric))
+	assert.Equal(t, float64(1), metric.GetGauge().GetValue())
+}
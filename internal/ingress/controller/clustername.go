@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/generator"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// EventReasonClusterNameMismatch is the reason of the events warning that no LoadBalancer is tagged for the cluster name.
+const EventReasonClusterNameMismatch = "ClusterNameMismatch"
+
+// ClusterNameCheck returns a manager.Runnable that warns, through logs and events on the ingresses, when ingresses
+// report a LoadBalancer in their status but none is tagged for the cluster name in AWS. That's usually a typo in
+// --cluster-name, which leaves the controller unable to find, and manage, the existing LoadBalancers.
+func (r *Reconciler) ClusterNameCheck() manager.Runnable {
+	return manager.RunnableFunc(func(stop <-chan struct{}) error {
+		if !r.cache.WaitForCacheSync(stop) {
+			return nil
+		}
+		if err := r.checkClusterName(context.Background()); err != nil {
+			// not fatal, the check is only meant to catch misconfigurations early.
+			glog.Errorf("failed to check the cluster name due to %v", err)
+		}
+		return nil
+	})
+}
+
+// checkClusterName warns about each ingress with a LoadBalancer in its status when no LoadBalancer is tagged
+// for the cluster name.
+func (r *Reconciler) checkClusterName(ctx context.Context) error {
+	provisioned, err := r.listProvisionedIngresses(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list ingresses due to %v", err)
+	}
+	if len(provisioned) == 0 {
+		return nil
+	}
+
+	clusterName := r.store.GetConfig().ClusterName
+	for _, tagFilters := range []map[string][]string{
+		{generator.V2TagKeyClusterID: {clusterName}},
+		// LoadBalancers created before the ingress.k8s.aws tags were introduced only carry the legacy cluster tag.
+		{"kubernetes.io/cluster/" + clusterName: {"owned"}},
+	} {
+		lbTags, err := r.cloud.GetResourceTagsByFilters(ctx, tagFilters, aws.ResourceTypeEnumELBLoadBalancer)
+		if err != nil {
+			return fmt.Errorf("failed to get LoadBalancers due to %v", err)
+		}
+		if len(lbTags) > 0 {
+			return nil
+		}
+	}
+
+	glog.Warningf("%d ingresses have a LoadBalancer, but none is tagged for cluster %q in AWS. Check that --cluster-name is the one the LoadBalancers were created with.",
+		len(provisioned), clusterName)
+	for _, ing := range provisioned {
+		r.recorder.Eventf(ing, corev1.EventTypeWarning, EventReasonClusterNameMismatch,
+			"no LoadBalancer is tagged for cluster %q in AWS, check the --cluster-name of the controller", clusterName)
+	}
+	return nil
+}
+
+// listProvisionedIngresses returns the live ingresses of the ingress class that report a LoadBalancer in their status.
+// Ingresses assuming an IAM role are left out, as their LoadBalancer may live in another account.
+func (r *Reconciler) listProvisionedIngresses(ctx context.Context) ([]*extensions.Ingress, error) {
+	ingList := &extensions.IngressList{}
+	if err := r.cache.List(ctx, &client.ListOptions{}, ingList); err != nil {
+		return nil, err
+	}
+	ingressClass := r.store.GetConfig().IngressClass
+	var provisioned []*extensions.Ingress
+	for i := range ingList.Items {
+		ing := &ingList.Items[i]
+		if ing.DeletionTimestamp != nil || !class.IsValidIngress(ingressClass, ing) || len(ing.Status.LoadBalancer.Ingress) == 0 {
+			continue
+		}
+		if roleARN, err := iamRoleARNOf(ing, r.store.GetConfig()); err != nil || roleARN != "" {
+			continue
+		}
+		provisioned = append(provisioned, ing)
+	}
+	return provisioned, nil
+}
@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/generator"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func provisionedIngress(name string, annotations map[string]string) *extensions.Ingress {
+	ing := ingressWithRule(name, annotations, "")
+	ing.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{Hostname: name + ".us-west-2.elb.amazonaws.com"}}
+	return ing
+}
+
+func TestReconciler_checkClusterName(t *testing.T) {
+	cfg := config.NewConfiguration()
+	cfg.ClusterName = "cluster"
+	v2Filters := map[string][]string{generator.V2TagKeyClusterID: {"cluster"}}
+	legacyFilters := map[string][]string{"kubernetes.io/cluster/cluster": {"owned"}}
+
+	for _, tc := range []struct {
+		Name           string
+		Ingresses      []*extensions.Ingress
+		V2Tags         map[string]map[string]string
+		LegacyTags     map[string]map[string]string
+		V2TagsErr      error
+		ExpectedEvents []string
+		ExpectedError  error
+	}{
+		{
+			Name:      "ingresses without LoadBalancer aren't checked",
+			Ingresses: []*extensions.Ingress{ingressWithRule("new", nil, "")},
+		},
+		{
+			Name:      "ingresses assuming an IAM role aren't checked",
+			Ingresses: []*extensions.Ingress{provisionedIngress("other-account", map[string]string{"alb.ingress.kubernetes.io/iam-role-arn": "arn:aws:iam::210987654321:role/alb"})},
+		},
+		{
+			Name:      "LoadBalancers tagged for the cluster",
+			Ingresses: []*extensions.Ingress{provisionedIngress("live", nil)},
+			V2Tags:    map[string]map[string]string{"arn-live": lbTagsOfStack("ns/live")},
+		},
+		{
+			Name:       "LoadBalancers with the legacy cluster tag only",
+			Ingresses:  []*extensions.Ingress{provisionedIngress("live", nil)},
+			V2Tags:     map[string]map[string]string{},
+			LegacyTags: map[string]map[string]string{"arn-live": {"kubernetes.io/cluster/cluster": "owned"}},
+		},
+		{
+			Name: "no LoadBalancer tagged for the cluster",
+			Ingresses: []*extensions.Ingress{
+				provisionedIngress("live", nil),
+				provisionedIngress("other-class", map[string]string{"kubernetes.io/ingress.class": "nginx"}),
+				provisionedIngress("other-account", map[string]string{"alb.ingress.kubernetes.io/iam-role-arn": "arn:aws:iam::210987654321:role/alb"}),
+				ingressWithRule("new", nil, ""),
+			},
+			V2Tags:         map[string]map[string]string{},
+			LegacyTags:     map[string]map[string]string{},
+			ExpectedEvents: []string{`Warning ClusterNameMismatch no LoadBalancer is tagged for cluster "cluster" in AWS, check the --cluster-name of the controller`},
+		},
+		{
+			Name:          "failing to get LoadBalancers is reported",
+			Ingresses:     []*extensions.Ingress{provisionedIngress("live", nil)},
+			V2TagsErr:     errors.New("AccessDenied"),
+			ExpectedError: errors.New("failed to get LoadBalancers due to AccessDenied"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			if tc.V2Tags != nil || tc.V2TagsErr != nil {
+				cloud.On("GetResourceTagsByFilters", ctx, v2Filters, aws.ResourceTypeEnumELBLoadBalancer).Return(tc.V2Tags, tc.V2TagsErr)
+			}
+			if tc.LegacyTags != nil {
+				cloud.On("GetResourceTagsByFilters", ctx, legacyFilters, aws.ResourceTypeEnumELBLoadBalancer).Return(tc.LegacyTags, nil)
+			}
+			var objs []runtime.Object
+			for _, ing := range tc.Ingresses {
+				objs = append(objs, ing)
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := &Reconciler{
+				cache:    staticCache{Reader: fake.NewFakeClient(objs...)},
+				store:    store.NewStatic(&cfg),
+				cloud:    cloud,
+				recorder: recorder,
+			}
+
+			err := r.checkClusterName(ctx)
+			assert.Equal(t, tc.ExpectedError, err)
+			close(recorder.Events)
+			var events []string
+			for e := range recorder.Events {
+				events = append(events, e)
+			}
+			assert.Equal(t, tc.ExpectedEvents, events)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
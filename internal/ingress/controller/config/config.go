@@ -5,53 +5,106 @@ import (
 	"fmt"
 	"hash/crc32"
 	"os"
+	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/golang/glog"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
 	"github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 const (
 	defaultIngressClass            = ""
 	defaultAnnotationPrefix        = "alb.ingress.kubernetes.io"
 	defaultALBNamePrefix           = ""
+	defaultTagPrefix               = "kubernetes.io"
 	defaultTargetType              = elbv2.TargetTypeEnumInstance
 	defaultBackendProtocol         = elbv2.ProtocolEnumHttp
+	defaultSSLPolicy               = "ELBSecurityPolicy-2016-08"
 	defaultRestrictScheme          = false
 	defaultRestrictSchemeNamespace = corev1.NamespaceDefault
 	defaultSyncRateLimit           = 0.3
 	defaultMaxConcurrentReconciles = 1
+	defaultCircuitBreakerCooldown  = time.Minute
+	defaultReconcileRetryBaseDelay = 5 * time.Second
+	defaultReconcileRetryMaxDelay  = 5 * time.Minute
 )
 
 var (
 	defaultDefaultTags = map[string]string{}
+
+	targetGroupNamePrefixRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?$`)
 )
 
 // Configuration contains all the settings required by an Ingress controller
 type Configuration struct {
 	ClusterName string
 
-	// IngressClass is the ingress class that this controller will monitor for
+	// IngressClass is the ingress class that this controller will monitor for, or a comma separated list of them
 	IngressClass string
 
-	AnnotationPrefix       string
-	ALBNamePrefix          string
-	DefaultTags            map[string]string
+	AnnotationPrefix string
+	ALBNamePrefix    string
+	// TargetGroupNamePrefix replaces ALBNamePrefix in the names of targetGroups, ALBNamePrefix is used if it's empty
+	TargetGroupNamePrefix string
+	DefaultTags           map[string]string
+	TagPrefix             string
+	// OwnershipTagValue is the value of the ownership tag the controller discovers its resources by, ClusterName if empty
+	OwnershipTagValue      string
 	DefaultTargetType      string
 	DefaultBackendProtocol string
+	// DefaultSSLPolicy and DefaultCertificateARN apply to the HTTPS listeners of ingresses without ssl-policy or certificate-arn annotations
+	DefaultSSLPolicy      string
+	DefaultCertificateARN string
 
 	SyncRateLimit           float32
 	MaxConcurrentReconciles int
+	// ReconcileCoalesceWindow is how long reconciles are delayed to fold the events of a burst into one reconcile
+	ReconcileCoalesceWindow time.Duration
+	// ReconcileRetryBaseDelay is how long a failed reconcile of an ingress waits to be retried, doubling with each
+	// failure in a row up to ReconcileRetryMaxDelay
+	ReconcileRetryBaseDelay time.Duration
+	ReconcileRetryMaxDelay  time.Duration
+	// ReconcileTimeout is how long the reconcile of an ingress may run before it's cancelled, unlimited if 0
+	ReconcileTimeout time.Duration
+	// CircuitBreakerThreshold is how many reconciles must fail in a row to pause reconciles for CircuitBreakerCooldown
+	CircuitBreakerThreshold int
+	CircuitBreakerCooldown  time.Duration
 
 	RestrictScheme          bool
 	RestrictSchemeNamespace string
 
+	// TargetNodeLabels is a label selector restricting which nodes are registered as instance targets
+	TargetNodeLabels string
+	// TargetNodeSelector is TargetNodeLabels parsed by Validate
+	TargetNodeSelector labels.Selector
+	// RegisterUnreadyNodes registers NotReady and unschedulable nodes as instance targets as well
+	RegisterUnreadyNodes bool
+	// MinHealthyPercent is the percentage of targets that must be healthy before the status of an ingress is Ready, disabled if 0
+	MinHealthyPercent int
+
+	// DryRun tells that the AWS session skips mutating calls, it's set from the --dry-run flag of the AWS config
+	DryRun bool
+
+	// CrossNamespaceBackends are the namespaces whose ingresses may route to services of other namespaces, "*" for all
+	CrossNamespaceBackends []string
+
+	// AllowedIAMRoles are the ARNs of the IAM roles ingresses may ask the controller to assume, none if empty
+	AllowedIAMRoles []string
+
 	// InternetFacingIngresses is an dynamic setting that can be updated by configMaps
 	InternetFacingIngresses map[string][]string
 
+	// DefaultsConfigMap is the <namespace>/<name> of a ConfigMap overriding the flags returned by Defaults
+	DefaultsConfigMap string
+	defaults          *defaultsOverrides
+
 	FeatureGate FeatureGate
 }
 
@@ -59,6 +112,7 @@ type Configuration struct {
 func NewConfiguration() Configuration {
 	return Configuration{
 		FeatureGate: NewFeatureGate(),
+		defaults:    &defaultsOverrides{},
 	}
 }
 
@@ -66,7 +120,7 @@ func NewConfiguration() Configuration {
 func (cfg *Configuration) BindFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&cfg.ClusterName, "cluster-name", "", `Kubernetes cluster name (required)`)
 	fs.StringVar(&cfg.IngressClass, "ingress-class", defaultIngressClass,
-		`Name of the ingress class this controller satisfies.
+		`Name of the ingress class this controller satisfies, or a comma separated list of them, e.g. "alb,alb-internal".
 		The class of an Ingress object is set using the annotation "kubernetes.io/ingress.class".
 		All ingress classes are satisfied if this parameter is left empty.`)
 	fs.StringVar(&cfg.AnnotationPrefix, "annotations-prefix", defaultAnnotationPrefix,
@@ -74,20 +128,54 @@ func (cfg *Configuration) BindFlags(fs *pflag.FlagSet) {
 
 	fs.StringVar(&cfg.ALBNamePrefix, "alb-name-prefix", defaultALBNamePrefix,
 		`Prefix to add to ALB resources (11 alphanumeric characters or less)`)
+	fs.StringVar(&cfg.TargetGroupNamePrefix, "target-group-prefix", "",
+		`Prefix to add to target groups instead of --alb-name-prefix (12 alphanumeric characters or hyphens or less, not beginning nor ending with a hyphen)`)
 	fs.StringToStringVar(&cfg.DefaultTags, "default-tags", defaultDefaultTags,
 		`Default tags to add to all ALBs`)
+	fs.StringVar(&cfg.OwnershipTagValue, "ownership-tag-value", "",
+		`Value of the elbv2.k8s.aws/cluster tag the controller discovers its resources by, unique to the controller in the AWS account. Defaults to the cluster name.`)
+	fs.StringVar(&cfg.TagPrefix, "tag-prefix", defaultTagPrefix,
+		`Prefix of the keys of the tags the controller manages and discovers AWS resources by, e.g. "<prefix>/ingress-name". Changing it orphans resources created with the previous prefix.`)
 	fs.StringVar(&cfg.DefaultTargetType, "target-type", defaultTargetType,
 		`Default target type to use for target groups, must be "instance" or "ip"`)
 	fs.StringVar(&cfg.DefaultBackendProtocol, "backend-protocol", defaultBackendProtocol,
 		`Default protocol to use for target groups, must be "HTTP" or "HTTPS"`)
+	fs.StringVar(&cfg.DefaultSSLPolicy, "default-ssl-policy", defaultSSLPolicy,
+		`Default SSL policy of HTTPS listeners, for ingresses without the ssl-policy annotation`)
+	fs.StringVar(&cfg.DefaultCertificateARN, "default-certificate-arn", "",
+		`Default certificate of HTTPS listeners, for ingresses without the certificate-arn annotation. Certificates are discovered from ACM for the hosts of those ingresses if unset.`)
 	fs.Float32Var(&cfg.SyncRateLimit, "sync-rate-limit", defaultSyncRateLimit,
 		`Define the sync frequency upper limit`)
 	fs.IntVar(&cfg.MaxConcurrentReconciles, "max-concurrent-reconciles", defaultMaxConcurrentReconciles,
 		`Define the maximum of number concurrently running reconcile loops`)
+	fs.DurationVar(&cfg.ReconcileCoalesceWindow, "reconcile-coalesce-window", 0,
+		`Delay reconciles by this duration, folding further events for the same ingress in the meantime into a single reconcile. Disabled if 0.`)
+	fs.DurationVar(&cfg.ReconcileRetryBaseDelay, "reconcile-retry-base-delay", defaultReconcileRetryBaseDelay,
+		`How long the reconcile of an ingress that failed waits to be retried. The delay doubles with each failure in a row of that ingress. Disabled if 0, leaving retries to the workqueue's rate limiter.`)
+	fs.DurationVar(&cfg.ReconcileRetryMaxDelay, "reconcile-retry-max-delay", defaultReconcileRetryMaxDelay,
+		`Maximum delay before the reconcile of an ingress that failed is retried.`)
+	fs.DurationVar(&cfg.ReconcileTimeout, "reconcile-timeout", 0,
+		`How long the reconcile of an ingress may run before its pending AWS calls are cancelled and it fails, so a stuck reconcile doesn't hold up a reconcile worker. Disabled if 0.`)
+	fs.IntVar(&cfg.CircuitBreakerThreshold, "circuit-breaker-threshold", 0,
+		`Number of reconciles that must fail in a row, across all ingresses, to pause reconciles until AWS is reachable again. Disabled if 0.`)
+	fs.DurationVar(&cfg.CircuitBreakerCooldown, "circuit-breaker-cooldown", defaultCircuitBreakerCooldown,
+		`How long reconciles are paused before AWS is probed again, when the circuit breaker is open.`)
 	fs.BoolVar(&cfg.RestrictScheme, "restrict-scheme", defaultRestrictScheme,
 		`Restrict the scheme to internal except for whitelisted namespaces`)
 	fs.StringVar(&cfg.RestrictSchemeNamespace, "restrict-scheme-namespace", defaultRestrictSchemeNamespace,
 		`The namespace with the ConfigMap containing the allowed ingresses. Only respected when restrict-scheme is true.`)
+	fs.StringSliceVar(&cfg.CrossNamespaceBackends, "allow-cross-namespace-backends", nil,
+		`Namespaces whose ingresses may route to services of other namespaces through the backend-service-namespace annotation, or "*" for all namespaces. Disabled if empty.`)
+	fs.StringSliceVar(&cfg.AllowedIAMRoles, "allowed-iam-roles", nil,
+		`ARNs of the IAM roles ingresses may ask the controller to assume through the iam-role-arn annotation. Ingresses can't assume any role if empty.`)
+	fs.StringVar(&cfg.DefaultsConfigMap, "defaults-configmap", "",
+		`<namespace>/<name> of a ConfigMap with fleet-wide defaults, such as default-ssl-policy or default-tags, overriding the matching flags. Changes apply from the next reconcile.`)
+	fs.StringVar(&cfg.TargetNodeLabels, "target-node-labels", "",
+		`Label selector restricting which nodes are registered as targets in instance mode, e.g. "lifecycle!=spot". Master and excluded nodes are never registered.`)
+	fs.BoolVar(&cfg.RegisterUnreadyNodes, "register-unready-nodes", false,
+		`Register NotReady and unschedulable nodes as targets in instance mode`)
+	fs.IntVar(&cfg.MinHealthyPercent, "min-healthy-percent", 0,
+		`Percentage of the targets of an ingress that must be healthy before its status is Ready. Target health is polled until then. Disabled if 0.`)
 
 	cfg.FeatureGate.BindFlags(fs)
 }
@@ -121,23 +209,110 @@ func (cfg *Configuration) Validate() error {
 		glog.Warningf("The target type parameter for 'pod' has changed to 'ip' to better match AWS APIs and documentation.")
 		cfg.DefaultTargetType = elbv2.TargetTypeEnumIp
 	}
+	if cfg.DefaultTargetType != elbv2.TargetTypeEnumInstance && cfg.DefaultTargetType != elbv2.TargetTypeEnumIp {
+		return fmt.Errorf("targetType must be either %q or %q, got %q", elbv2.TargetTypeEnumInstance, elbv2.TargetTypeEnumIp, cfg.DefaultTargetType)
+	}
+	if cfg.IngressClass != "" {
+		for _, class := range strings.Split(cfg.IngressClass, ",") {
+			if strings.TrimSpace(class) == "" {
+				return fmt.Errorf("ingressClass %q must not contain empty class names", cfg.IngressClass)
+			}
+		}
+	}
+	if cfg.MaxConcurrentReconciles < 1 {
+		return fmt.Errorf("maxConcurrentReconciles must be at least 1, got %d", cfg.MaxConcurrentReconciles)
+	}
+	if cfg.ReconcileRetryBaseDelay < 0 {
+		return fmt.Errorf("reconcileRetryBaseDelay must not be negative, got %v", cfg.ReconcileRetryBaseDelay)
+	}
+	if cfg.ReconcileRetryBaseDelay > 0 && cfg.ReconcileRetryMaxDelay < cfg.ReconcileRetryBaseDelay {
+		return fmt.Errorf("reconcileRetryMaxDelay must be at least reconcileRetryBaseDelay %v, got %v", cfg.ReconcileRetryBaseDelay, cfg.ReconcileRetryMaxDelay)
+	}
+	if cfg.ReconcileTimeout < 0 {
+		return fmt.Errorf("reconcileTimeout must not be negative, got %v", cfg.ReconcileTimeout)
+	}
+	if cfg.CircuitBreakerThreshold < 0 {
+		return fmt.Errorf("circuitBreakerThreshold must not be negative, got %d", cfg.CircuitBreakerThreshold)
+	}
+	if cfg.CircuitBreakerThreshold > 0 && cfg.CircuitBreakerCooldown <= 0 {
+		return fmt.Errorf("circuitBreakerCooldown must be positive, got %v", cfg.CircuitBreakerCooldown)
+	}
+	if cfg.MinHealthyPercent < 0 || cfg.MinHealthyPercent > 100 {
+		return fmt.Errorf("minHealthyPercent must be between 0 and 100, got %d", cfg.MinHealthyPercent)
+	}
+	if cfg.DefaultCertificateARN != "" && !arn.IsARN(cfg.DefaultCertificateARN) {
+		return fmt.Errorf("defaultCertificateARN %q is not an ARN", cfg.DefaultCertificateARN)
+	}
+	if cfg.ReconcileCoalesceWindow < 0 {
+		return fmt.Errorf("reconcileCoalesceWindow must not be negative, got %v", cfg.ReconcileCoalesceWindow)
+	}
+	for _, roleARN := range cfg.AllowedIAMRoles {
+		if parsed, err := arn.Parse(roleARN); err != nil || parsed.Service != "iam" || !strings.HasPrefix(parsed.Resource, "role/") {
+			return fmt.Errorf("allowedIAMRoles %q must be ARNs of IAM roles", roleARN)
+		}
+	}
+	if cfg.DefaultsConfigMap != "" {
+		if _, err := cfg.defaultsConfigMapKey(); err != nil {
+			return err
+		}
+	}
+	selector, err := labels.Parse(cfg.TargetNodeLabels)
+	if err != nil {
+		return fmt.Errorf("targetNodeLabels %q is not a valid label selector: %v", cfg.TargetNodeLabels, err)
+	}
+	cfg.TargetNodeSelector = selector
 	if len(cfg.ClusterName) == 0 {
 		return fmt.Errorf("clusterName must be specified")
 	}
+	if len(cfg.OwnershipTagValue) == 0 {
+		cfg.OwnershipTagValue = cfg.ClusterName
+	}
+	if len(cfg.OwnershipTagValue) > 256 {
+		return fmt.Errorf("ownershipTagValue must be 256 characters or less, got %d", len(cfg.OwnershipTagValue))
+	}
+	if len(cfg.TagPrefix) == 0 {
+		cfg.TagPrefix = defaultTagPrefix
+	}
+	if strings.HasPrefix(strings.ToLower(cfg.TagPrefix), "aws:") || strings.HasSuffix(cfg.TagPrefix, "/") {
+		return fmt.Errorf("tagPrefix %q must not start with \"aws:\" nor end with \"/\"", cfg.TagPrefix)
+	}
 	if len(cfg.ALBNamePrefix) > 12 {
 		return fmt.Errorf("ALBNamePrefix must be 12 characters or less")
 	}
 	if len(cfg.ALBNamePrefix) == 0 {
 		cfg.ALBNamePrefix = generateALBNamePrefix(cfg.ClusterName)
 	}
+	if len(cfg.TargetGroupNamePrefix) > 12 || (cfg.TargetGroupNamePrefix != "" && !targetGroupNamePrefixRegex.MatchString(cfg.TargetGroupNamePrefix)) {
+		return fmt.Errorf("targetGroupNamePrefix %q must be 12 alphanumeric characters or hyphens or less, not beginning nor ending with a hyphen", cfg.TargetGroupNamePrefix)
+	}
 
 	// TODO: I know, bad smell here:D
 	parser.AnnotationsPrefix = cfg.AnnotationPrefix
 	return nil
 }
 
+// CrossNamespaceBackendsAllowed tells whether ingresses of namespace may route to services of other namespaces.
+func (cfg *Configuration) CrossNamespaceBackendsAllowed(namespace string) bool {
+	for _, allowed := range cfg.CrossNamespaceBackends {
+		if allowed == "*" || allowed == namespace {
+			return true
+		}
+	}
+	return false
+}
+
 func generateALBNamePrefix(clusterName string) string {
 	hash := crc32.New(crc32.MakeTable(0xedb88320))
 	_, _ = hash.Write([]byte(clusterName))
 	return hex.EncodeToString(hash.Sum(nil))
 }
+
+// IAMRoleAllowed tells whether ingresses may ask the controller to assume roleARN.
+func (cfg *Configuration) IAMRoleAllowed(roleARN string) bool {
+	for _, allowed := range cfg.AllowedIAMRoles {
+		if allowed == roleARN {
+			return true
+		}
+	}
+	return false
+}
@@ -1 +1,249 @@
 package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfiguration_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		Name               string
+		Config             Configuration
+		ExpectedTargetType string
+		ExpectedError      error
+	}{
+		{
+			Name: "instance target type",
+			Config: Configuration{
+				ClusterName:             "cluster",
+				MaxConcurrentReconciles: 1,
+				DefaultTargetType:       elbv2.TargetTypeEnumInstance,
+			},
+			ExpectedTargetType: elbv2.TargetTypeEnumInstance,
+		},
+		{
+			Name: "ip target type",
+			Config: Configuration{
+				ClusterName:             "cluster",
+				MaxConcurrentReconciles: 1,
+				DefaultTargetType:       elbv2.TargetTypeEnumIp,
+			},
+			ExpectedTargetType: elbv2.TargetTypeEnumIp,
+		},
+		{
+			Name: "legacy pod target type is converted to ip",
+			Config: Configuration{
+				ClusterName:             "cluster",
+				MaxConcurrentReconciles: 1,
+				DefaultTargetType:       "pod",
+			},
+			ExpectedTargetType: elbv2.TargetTypeEnumIp,
+		},
+		{
+			Name: "unknown target type",
+			Config: Configuration{
+				ClusterName:             "cluster",
+				MaxConcurrentReconciles: 1,
+				DefaultTargetType:       "lambda",
+			},
+			ExpectedTargetType: "lambda",
+			ExpectedError:      errors.New(`targetType must be either "instance" or "ip", got "lambda"`),
+		},
+		{
+			Name: "no reconcile workers",
+			Config: Configuration{
+				ClusterName:             "cluster",
+				MaxConcurrentReconciles: 0,
+				DefaultTargetType:       elbv2.TargetTypeEnumInstance,
+			},
+			ExpectedTargetType: elbv2.TargetTypeEnumInstance,
+			ExpectedError:      errors.New("maxConcurrentReconciles must be at least 1, got 0"),
+		},
+		{
+			Name: "default certificate that isn't an ARN",
+			Config: Configuration{
+				ClusterName:             "cluster",
+				MaxConcurrentReconciles: 1,
+				DefaultTargetType:       elbv2.TargetTypeEnumInstance,
+				DefaultCertificateARN:   "certificate/abc",
+			},
+			ExpectedTargetType: elbv2.TargetTypeEnumInstance,
+			ExpectedError:      errors.New(`defaultCertificateARN "certificate/abc" is not an ARN`),
+		},
+		{
+			Name: "invalid target node labels",
+			Config: Configuration{
+				ClusterName:             "cluster",
+				MaxConcurrentReconciles: 1,
+				DefaultTargetType:       elbv2.TargetTypeEnumInstance,
+				TargetNodeLabels:        "lifecycle in spot",
+			},
+			ExpectedTargetType: elbv2.TargetTypeEnumInstance,
+			ExpectedError:      errors.New(`targetNodeLabels "lifecycle in spot" is not a valid label selector: unable to parse requirement: found 'spot' expected: '('`),
+		},
+		{
+			Name: "list of ingress classes",
+			Config: Configuration{
+				ClusterName:             "cluster",
+				IngressClass:            "alb,alb-internal",
+				MaxConcurrentReconciles: 1,
+				DefaultTargetType:       elbv2.TargetTypeEnumInstance,
+			},
+			ExpectedTargetType: elbv2.TargetTypeEnumInstance,
+		},
+		{
+			Name: "list of ingress classes with an empty class",
+			Config: Configuration{
+				ClusterName:             "cluster",
+				IngressClass:            "alb,",
+				MaxConcurrentReconciles: 1,
+				DefaultTargetType:       elbv2.TargetTypeEnumInstance,
+			},
+			ExpectedTargetType: elbv2.TargetTypeEnumInstance,
+			ExpectedError:      errors.New(`ingressClass "alb," must not contain empty class names`),
+		},
+		{
+			Name: "reserved tag prefix",
+			Config: Configuration{
+				ClusterName:             "cluster",
+				MaxConcurrentReconciles: 1,
+				DefaultTargetType:       elbv2.TargetTypeEnumInstance,
+				TagPrefix:               "aws:alb",
+			},
+			ExpectedTargetType: elbv2.TargetTypeEnumInstance,
+			ExpectedError:      errors.New(`tagPrefix "aws:alb" must not start with "aws:" nor end with "/"`),
+		},
+		{
+			Name: "tag prefix with trailing slash",
+			Config: Configuration{
+				ClusterName:             "cluster",
+				MaxConcurrentReconciles: 1,
+				DefaultTargetType:       elbv2.TargetTypeEnumInstance,
+				TagPrefix:               "example.com/",
+			},
+			ExpectedTargetType: elbv2.TargetTypeEnumInstance,
+			ExpectedError:      errors.New(`tagPrefix "example.com/" must not start with "aws:" nor end with "/"`),
+		},
+		{
+			Name: "reconcile retry max delay below the base delay",
+			Config: Configuration{
+				ClusterName:             "cluster",
+				MaxConcurrentReconciles: 1,
+				DefaultTargetType:       elbv2.TargetTypeEnumInstance,
+				ReconcileRetryBaseDelay: time.Minute,
+				ReconcileRetryMaxDelay:  time.Second,
+			},
+			ExpectedTargetType: elbv2.TargetTypeEnumInstance,
+			ExpectedError:      errors.New("reconcileRetryMaxDelay must be at least reconcileRetryBaseDelay 1m0s, got 1s"),
+		},
+		{
+			Name: "min healthy percent above 100",
+			Config: Configuration{
+				ClusterName:             "cluster",
+				MaxConcurrentReconciles: 1,
+				DefaultTargetType:       elbv2.TargetTypeEnumInstance,
+				MinHealthyPercent:       150,
+			},
+			ExpectedTargetType: elbv2.TargetTypeEnumInstance,
+			ExpectedError:      errors.New("minHealthyPercent must be between 0 and 100, got 150"),
+		},
+		{
+			Name: "ownership tag value too long",
+			Config: Configuration{
+				ClusterName:             "cluster",
+				MaxConcurrentReconciles: 1,
+				DefaultTargetType:       elbv2.TargetTypeEnumInstance,
+				OwnershipTagValue:       strings.Repeat("a", 257),
+			},
+			ExpectedTargetType: elbv2.TargetTypeEnumInstance,
+			ExpectedError:      errors.New("ownershipTagValue must be 256 characters or less, got 257"),
+		},
+		{
+			Name: "negative reconcile timeout",
+			Config: Configuration{
+				ClusterName:             "cluster",
+				MaxConcurrentReconciles: 1,
+				DefaultTargetType:       elbv2.TargetTypeEnumInstance,
+				ReconcileTimeout:        -time.Minute,
+			},
+			ExpectedTargetType: elbv2.TargetTypeEnumInstance,
+			ExpectedError:      errors.New("reconcileTimeout must not be negative, got -1m0s"),
+		},
+		{
+			Name: "target group name prefix",
+			Config: Configuration{
+				ClusterName:             "cluster",
+				MaxConcurrentReconciles: 1,
+				DefaultTargetType:       elbv2.TargetTypeEnumInstance,
+				TargetGroupNamePrefix:   "k8s-tg",
+			},
+			ExpectedTargetType: elbv2.TargetTypeEnumInstance,
+		},
+		{
+			Name: "target group name prefix ending with a hyphen",
+			Config: Configuration{
+				ClusterName:             "cluster",
+				MaxConcurrentReconciles: 1,
+				DefaultTargetType:       elbv2.TargetTypeEnumInstance,
+				TargetGroupNamePrefix:   "k8s-",
+			},
+			ExpectedTargetType: elbv2.TargetTypeEnumInstance,
+			ExpectedError:      errors.New(`targetGroupNamePrefix "k8s-" must be 12 alphanumeric characters or hyphens or less, not beginning nor ending with a hyphen`),
+		},
+		{
+			Name: "allowed IAM roles",
+			Config: Configuration{
+				ClusterName:             "cluster",
+				MaxConcurrentReconciles: 1,
+				DefaultTargetType:       elbv2.TargetTypeEnumInstance,
+				AllowedIAMRoles:         []string{"arn:aws:iam::123456789012:role/alb"},
+			},
+			ExpectedTargetType: elbv2.TargetTypeEnumInstance,
+		},
+		{
+			Name: "allowed IAM role that isn't a role ARN",
+			Config: Configuration{
+				ClusterName:             "cluster",
+				MaxConcurrentReconciles: 1,
+				DefaultTargetType:       elbv2.TargetTypeEnumInstance,
+				AllowedIAMRoles:         []string{"arn:aws:iam::123456789012:user/alb"},
+			},
+			ExpectedTargetType: elbv2.TargetTypeEnumInstance,
+			ExpectedError:      errors.New(`allowedIAMRoles "arn:aws:iam::123456789012:user/alb" must be ARNs of IAM roles`),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			cfg := tc.Config
+			err := cfg.Validate()
+			assert.Equal(t, tc.ExpectedError, err)
+			assert.Equal(t, tc.ExpectedTargetType, cfg.DefaultTargetType)
+		})
+	}
+}
+
+func TestConfiguration_CrossNamespaceBackendsAllowed(t *testing.T) {
+	cfg := Configuration{}
+	assert.False(t, cfg.CrossNamespaceBackendsAllowed("gateway"))
+
+	cfg.CrossNamespaceBackends = []string{"gateway", "edge"}
+	assert.True(t, cfg.CrossNamespaceBackendsAllowed("gateway"))
+	assert.True(t, cfg.CrossNamespaceBackendsAllowed("edge"))
+	assert.False(t, cfg.CrossNamespaceBackendsAllowed("apps"))
+
+	cfg.CrossNamespaceBackends = []string{"*"}
+	assert.True(t, cfg.CrossNamespaceBackendsAllowed("apps"))
+}
+
+func TestConfiguration_IAMRoleAllowed(t *testing.T) {
+	cfg := Configuration{}
+	assert.False(t, cfg.IAMRoleAllowed("arn:aws:iam::123456789012:role/alb"))
+
+	cfg.AllowedIAMRoles = []string{"arn:aws:iam::123456789012:role/alb"}
+	assert.True(t, cfg.IAMRoleAllowed("arn:aws:iam::123456789012:role/alb"))
+	assert.False(t, cfg.IAMRoleAllowed("arn:aws:iam::123456789012:role/other"))
+}
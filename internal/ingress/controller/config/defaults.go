@@ -0,0 +1,204 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Keys of the defaults ConfigMap.
+const (
+	DefaultsKeySSLPolicy               = "default-ssl-policy"
+	DefaultsKeyTags                    = "default-tags"
+	DefaultsKeyLoadBalancerAttributes  = "default-load-balancer-attributes"
+	DefaultsKeyTargetGroupAttributes   = "default-target-group-attributes"
+	DefaultsKeyCircuitBreakerThreshold = "circuit-breaker-threshold"
+	DefaultsKeyCircuitBreakerCooldown  = "circuit-breaker-cooldown"
+)
+
+// Defaults are the fleet-wide defaults ingresses fall back to when they don't set the matching annotations.
+type Defaults struct {
+	SSLPolicy string
+	Tags      map[string]string
+	// LoadBalancerAttributes and TargetGroupAttributes are merged under the attributes set by annotations, key by key.
+	LoadBalancerAttributes map[string]string
+	TargetGroupAttributes  map[string]string
+
+	CircuitBreakerThreshold int
+	CircuitBreakerCooldown  time.Duration
+}
+
+// defaultsOverrides holds the Defaults loaded from the defaults ConfigMap.
+// It's shared by the copies of a Configuration, so they all see ConfigMap changes.
+type defaultsOverrides struct {
+	mutex  sync.RWMutex
+	values map[string]string
+}
+
+// Defaults returns the fleet-wide defaults: the values of the defaults ConfigMap, or else of the matching flags.
+func (cfg *Configuration) Defaults() Defaults {
+	defaults := Defaults{
+		SSLPolicy:               cfg.DefaultSSLPolicy,
+		Tags:                    cfg.DefaultTags,
+		CircuitBreakerThreshold: cfg.CircuitBreakerThreshold,
+		CircuitBreakerCooldown:  cfg.CircuitBreakerCooldown,
+	}
+	if cfg.defaults == nil {
+		return defaults
+	}
+	cfg.defaults.mutex.RLock()
+	defer cfg.defaults.mutex.RUnlock()
+	values := cfg.defaults.values
+	if v, ok := values[DefaultsKeySSLPolicy]; ok {
+		defaults.SSLPolicy = v
+	}
+	if v, ok := values[DefaultsKeyTags]; ok {
+		defaults.Tags, _ = parseKeyValuePairs(v)
+	}
+	if v, ok := values[DefaultsKeyLoadBalancerAttributes]; ok {
+		defaults.LoadBalancerAttributes, _ = parseKeyValuePairs(v)
+	}
+	if v, ok := values[DefaultsKeyTargetGroupAttributes]; ok {
+		defaults.TargetGroupAttributes, _ = parseKeyValuePairs(v)
+	}
+	if v, ok := values[DefaultsKeyCircuitBreakerThreshold]; ok {
+		defaults.CircuitBreakerThreshold, _ = strconv.Atoi(v)
+	}
+	if v, ok := values[DefaultsKeyCircuitBreakerCooldown]; ok {
+		defaults.CircuitBreakerCooldown, _ = time.ParseDuration(v)
+	}
+	return defaults
+}
+
+func (cfg *Configuration) initDefaults(client client.Client) error {
+	key, err := cfg.defaultsConfigMapKey()
+	if err != nil {
+		return err
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := client.Get(context.Background(), key, configMap); err != nil {
+		glog.Warningf("failed to load defaults ConfigMap %v due to %v, falling back to flags", key, err)
+		configMap = nil
+	}
+	cfg.loadDefaults(configMap)
+	return nil
+}
+
+func (cfg *Configuration) watchDefaults(c controller.Controller) error {
+	return c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, &handler.Funcs{
+		CreateFunc: func(e event.CreateEvent, _ workqueue.RateLimitingInterface) {
+			if cfg.isDefaultsConfigMap(e.Meta) {
+				cfg.loadDefaults(e.Object.(*corev1.ConfigMap))
+			}
+		},
+		UpdateFunc: func(e event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+			if cfg.isDefaultsConfigMap(e.MetaNew) {
+				cfg.loadDefaults(e.ObjectNew.(*corev1.ConfigMap))
+			}
+		},
+		DeleteFunc: func(e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+			if cfg.isDefaultsConfigMap(e.Meta) {
+				cfg.loadDefaults(nil)
+			}
+		},
+	})
+}
+
+// loadDefaults replaces the defaults with the valid keys of configMap. Invalid values are logged and skipped,
+// so that their flags keep applying rather than a typo in the ConfigMap breaking every ingress.
+func (cfg *Configuration) loadDefaults(configMap *corev1.ConfigMap) {
+	values := make(map[string]string)
+	if configMap != nil {
+		for key, value := range configMap.Data {
+			value = strings.TrimSpace(value)
+			if err := validateDefault(key, value); err != nil {
+				glog.Errorf("ignoring %v of defaults ConfigMap %v/%v due to %v", key, configMap.Namespace, configMap.Name, err)
+				continue
+			}
+			values[key] = value
+		}
+	}
+	if cfg.defaults == nil {
+		cfg.defaults = &defaultsOverrides{}
+	}
+	cfg.defaults.mutex.Lock()
+	defer cfg.defaults.mutex.Unlock()
+	cfg.defaults.values = values
+}
+
+func validateDefault(key string, value string) error {
+	switch key {
+	case DefaultsKeySSLPolicy:
+		if value == "" {
+			return fmt.Errorf("SSL policy must not be empty")
+		}
+	case DefaultsKeyTags, DefaultsKeyLoadBalancerAttributes, DefaultsKeyTargetGroupAttributes:
+		if _, err := parseKeyValuePairs(value); err != nil {
+			return err
+		}
+	case DefaultsKeyCircuitBreakerThreshold:
+		threshold, err := strconv.Atoi(value)
+		if err != nil || threshold < 0 {
+			return fmt.Errorf("circuit breaker threshold must be a non-negative integer, got %q", value)
+		}
+	case DefaultsKeyCircuitBreakerCooldown:
+		cooldown, err := time.ParseDuration(value)
+		if err != nil || cooldown <= 0 {
+			return fmt.Errorf("circuit breaker cooldown must be a positive duration, got %q", value)
+		}
+	default:
+		return fmt.Errorf("unknown key")
+	}
+	return nil
+}
+
+// parseKeyValuePairs parses a comma separated list of Key=Value pairs.
+func parseKeyValuePairs(s string) (map[string]string, error) {
+	pairs := make(map[string]string)
+	var invalid []string
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		parts := strings.Split(pair, "=")
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			invalid = append(invalid, pair)
+			continue
+		}
+		pairs[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		return nil, fmt.Errorf("unable to parse `%s` into Key=Value pair(s)", strings.Join(invalid, ", "))
+	}
+	return pairs, nil
+}
+
+// defaultsConfigMapKey returns DefaultsConfigMap as a namespace and name.
+func (cfg *Configuration) defaultsConfigMapKey() (types.NamespacedName, error) {
+	parts := strings.Split(cfg.DefaultsConfigMap, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return types.NamespacedName{}, fmt.Errorf("defaultsConfigMap must be <namespace>/<name>, got %q", cfg.DefaultsConfigMap)
+	}
+	return types.NamespacedName{Namespace: parts[0], Name: parts[1]}, nil
+}
+
+func (cfg *Configuration) isDefaultsConfigMap(meta metav1.Object) bool {
+	key, err := cfg.defaultsConfigMapKey()
+	return err == nil && meta.GetNamespace() == key.Namespace && meta.GetName() == key.Name
+}
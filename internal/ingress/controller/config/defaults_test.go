@@ -0,0 +1,103 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConfiguration_Defaults(t *testing.T) {
+	flags := Configuration{
+		DefaultSSLPolicy:        "ELBSecurityPolicy-2016-08",
+		DefaultTags:             map[string]string{"team": "platform"},
+		CircuitBreakerThreshold: 0,
+		CircuitBreakerCooldown:  time.Minute,
+	}
+	for _, tc := range []struct {
+		Name      string
+		ConfigMap *corev1.ConfigMap
+		Expected  Defaults
+	}{
+		{
+			Name: "flags apply without ConfigMap",
+			Expected: Defaults{
+				SSLPolicy:              "ELBSecurityPolicy-2016-08",
+				Tags:                   map[string]string{"team": "platform"},
+				CircuitBreakerCooldown: time.Minute,
+			},
+		},
+		{
+			Name: "ConfigMap overrides flags",
+			ConfigMap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "alb-defaults"},
+				Data: map[string]string{
+					DefaultsKeySSLPolicy:               "ELBSecurityPolicy-TLS-1-2-2017-01",
+					DefaultsKeyTags:                    "team=web, costCenter=42",
+					DefaultsKeyLoadBalancerAttributes:  "idle_timeout.timeout_seconds=120",
+					DefaultsKeyTargetGroupAttributes:   "deregistration_delay.timeout_seconds=30,slow_start.duration_seconds=60",
+					DefaultsKeyCircuitBreakerThreshold: "5",
+					DefaultsKeyCircuitBreakerCooldown:  "2m",
+				},
+			},
+			Expected: Defaults{
+				SSLPolicy:               "ELBSecurityPolicy-TLS-1-2-2017-01",
+				Tags:                    map[string]string{"team": "web", "costCenter": "42"},
+				LoadBalancerAttributes:  map[string]string{"idle_timeout.timeout_seconds": "120"},
+				TargetGroupAttributes:   map[string]string{"deregistration_delay.timeout_seconds": "30", "slow_start.duration_seconds": "60"},
+				CircuitBreakerThreshold: 5,
+				CircuitBreakerCooldown:  2 * time.Minute,
+			},
+		},
+		{
+			Name: "invalid and unknown keys are ignored",
+			ConfigMap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "alb-defaults"},
+				Data: map[string]string{
+					DefaultsKeyTags:                   "team",
+					DefaultsKeyCircuitBreakerCooldown: "-1m",
+					DefaultsKeyTargetGroupAttributes:  "stickiness.enabled=true",
+					"idle-timeout":                    "120",
+				},
+			},
+			Expected: Defaults{
+				SSLPolicy:              "ELBSecurityPolicy-2016-08",
+				Tags:                   map[string]string{"team": "platform"},
+				TargetGroupAttributes:  map[string]string{"stickiness.enabled": "true"},
+				CircuitBreakerCooldown: time.Minute,
+			},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			cfg := flags
+			if tc.ConfigMap != nil {
+				cfg.loadDefaults(tc.ConfigMap)
+			}
+			assert.Equal(t, tc.Expected, cfg.Defaults())
+		})
+	}
+}
+
+func TestConfiguration_loadDefaults(t *testing.T) {
+	cfg := NewConfiguration()
+	cfg.DefaultSSLPolicy = "ELBSecurityPolicy-2016-08"
+	// copies of the configuration, e.g. held by the name and tag generator, see ConfigMap changes too.
+	copied := cfg
+	cfg.loadDefaults(&corev1.ConfigMap{Data: map[string]string{DefaultsKeySSLPolicy: "ELBSecurityPolicy-FS-2018-06"}})
+	assert.Equal(t, "ELBSecurityPolicy-FS-2018-06", cfg.Defaults().SSLPolicy)
+	assert.Equal(t, "ELBSecurityPolicy-FS-2018-06", copied.Defaults().SSLPolicy)
+
+	// deleting the ConfigMap restores the flags.
+	cfg.loadDefaults(nil)
+	assert.Equal(t, "ELBSecurityPolicy-2016-08", cfg.Defaults().SSLPolicy)
+	assert.Equal(t, "ELBSecurityPolicy-2016-08", copied.Defaults().SSLPolicy)
+}
+
+func TestConfiguration_isDefaultsConfigMap(t *testing.T) {
+	cfg := Configuration{DefaultsConfigMap: "kube-system/alb-defaults"}
+	assert.True(t, cfg.isDefaultsConfigMap(&metav1.ObjectMeta{Namespace: "kube-system", Name: "alb-defaults"}))
+	assert.False(t, cfg.isDefaultsConfigMap(&metav1.ObjectMeta{Namespace: "default", Name: "alb-defaults"}))
+	assert.False(t, cfg.isDefaultsConfigMap(&metav1.ObjectMeta{Namespace: "kube-system", Name: "other"}))
+}
@@ -30,6 +30,14 @@ func (cfg *Configuration) BindDynamicSettings(mgr manager.Manager, c controller.
 			return err
 		}
 	}
+	if cfg.DefaultsConfigMap != "" {
+		if err := cfg.initDefaults(mgr.GetClient()); err != nil {
+			return err
+		}
+		if err := cfg.watchDefaults(c); err != nil {
+			return err
+		}
+	}
 	if cfg.FeatureGate.Enabled(WAF) && !cloud.WAFRegionalAvailable() {
 		cfg.FeatureGate.Disable(WAF)
 	}
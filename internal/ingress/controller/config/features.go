@@ -30,6 +30,7 @@ const (
 	WAF            Feature = "waf"
 	WAFV2          Feature = "wafv2"
 	ShieldAdvanced Feature = "shield"
+	Route53        Feature = "route53"
 )
 
 type FeatureGate interface {
@@ -60,6 +61,7 @@ func NewFeatureGate() FeatureGate {
 			WAF:            true,
 			WAFV2:          true,
 			ShieldAdvanced: true,
+			Route53:        false,
 		},
 	}
 }
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/auth"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -22,38 +23,46 @@ import (
 	extensions "k8s.io/api/extensions/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
-func Initialize(config *config.Configuration, mgr manager.Manager, mc metric.Collector, cloud aws.CloudAPI) error {
+// Initialize registers the ingress controller with mgr.
+// The returned Reconciler can be used to wait for in-flight reconciles once mgr has stopped.
+func Initialize(config *config.Configuration, mgr manager.Manager, mc metric.Collector, cloud aws.CloudAPI) (*Reconciler, error) {
 	authModule := auth.NewModule(mgr.GetCache())
 	reconciler, err := newReconciler(config, mgr, mc, cloud, authModule)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	c, err := controller.New("alb-ingress-controller", mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: config.MaxConcurrentReconciles})
 	if err != nil {
-		return err
+		return nil, err
 	}
+	mc.SetReconcileWorkers(config.MaxConcurrentReconciles)
 	if err := config.BindDynamicSettings(mgr, c, cloud); err != nil {
-		return err
+		return nil, err
 	}
 
 	ingressChan := make(chan event.GenericEvent)
 	serviceChan := make(chan event.GenericEvent)
 	if err := authModule.Init(c, ingressChan, serviceChan); err != nil {
-		return fmt.Errorf("failed to init auth module due to %v", err)
+		return nil, fmt.Errorf("failed to init auth module due to %v", err)
+	}
+	coalescer := handlers.NewCoalescer(config.ReconcileCoalesceWindow, mc)
+	if err := watchClusterEvents(c, mgr.GetCache(), ingressChan, serviceChan, config, coalescer, mc); err != nil {
+		return nil, fmt.Errorf("failed to watch cluster events due to %v", err)
 	}
-	if err := watchClusterEvents(c, mgr.GetCache(), ingressChan, serviceChan, config.IngressClass); err != nil {
-		return fmt.Errorf("failed to watch cluster events due to %v", err)
+	// orphaned ingresses no longer exist, so they're enqueued as is rather than filtered by ingress class.
+	if err := c.Watch(&source.Channel{Source: reconciler.orphans}, &handler.EnqueueRequestForObject{}); err != nil {
+		return nil, fmt.Errorf("failed to watch orphaned ingresses due to %v", err)
 	}
 
-	return nil
+	return reconciler, nil
 }
 
-func newReconciler(config *config.Configuration, mgr manager.Manager, mc metric.Collector, cloud aws.CloudAPI, authModule auth.Module) (reconcile.Reconciler, error) {
+func newReconciler(config *config.Configuration, mgr manager.Manager, mc metric.Collector, cloud aws.CloudAPI, authModule auth.Module) (*Reconciler, error) {
 	store, err := store.New(mgr, config)
 	if err != nil {
 		return nil, err
@@ -63,62 +72,87 @@ func newReconciler(config *config.Configuration, mgr manager.Manager, mc metric.
 	tagsController := tags.NewController(cloud)
 	endpointResolver := backend.NewEndpointResolver(store, cloud)
 	tgGroupController := tg.NewGroupController(cloud, store, nameTagGenerator, tagsController, endpointResolver, client)
-	lsGroupController := ls.NewGroupController(store, cloud, authModule)
+	lsGroupController := ls.NewGroupController(store, cloud, authModule, tagsController)
 	sgAssociationController := sg.NewAssociationController(store, cloud, tagsController, nameTagGenerator)
 	lbController := lb.NewController(cloud, store,
 		nameTagGenerator, tgGroupController, lsGroupController, sgAssociationController, tagsController)
 
 	return &Reconciler{
-		client:          client,
-		cache:           mgr.GetCache(),
-		recorder:        mgr.GetRecorder("alb-ingress-controller"),
-		store:           store,
-		lbController:    lbController,
-		metricCollector: mc,
+		client:            client,
+		cache:             mgr.GetCache(),
+		recorder:          mgr.GetRecorder("alb-ingress-controller"),
+		store:             store,
+		cloud:             cloud,
+		lbController:      lbController,
+		metricCollector:   mc,
+		targetHealth:      targetHealth{cloud: cloud},
+		minHealthyPercent: config.MinHealthyPercent,
+		dryRun:            config.DryRun,
+		status:            reconcileStatus{started: time.Now()},
+		breaker: circuitBreaker{
+			threshold: config.CircuitBreakerThreshold,
+			cooldown:  config.CircuitBreakerCooldown,
+			probe:     cloud.ProbeELBV2,
+		},
+		retries: ingressRetries{
+			baseDelay: config.ReconcileRetryBaseDelay,
+			maxDelay:  config.ReconcileRetryMaxDelay,
+		},
+		orphans: make(chan event.GenericEvent),
 	}, nil
 }
 
-func watchClusterEvents(c controller.Controller, cache cache.Cache, ingressChan <-chan event.GenericEvent, serviceChan <-chan event.GenericEvent, ingressClass string) error {
-	if err := c.Watch(&source.Kind{Type: &extensions.Ingress{}}, &handlers.EnqueueRequestsForIngressEvent{
-		IngressClass: ingressClass,
-	}); err != nil {
+// watchClusterEvents watches the objects ingresses depend on, enqueueing the reconciles of the ingresses affected by their events through coalescer.
+func watchClusterEvents(c controller.Controller, cache cache.Cache, ingressChan <-chan event.GenericEvent, serviceChan <-chan event.GenericEvent, config *config.Configuration, coalescer *handlers.Coalescer, mc metric.Collector) error {
+	ingressClass := config.IngressClass
+	crossNamespaceBackends := len(config.CrossNamespaceBackends) > 0
+	if err := c.Watch(&source.Kind{Type: &extensions.Ingress{}}, coalescer.Wrap(&handlers.EnqueueRequestsForIngressEvent{
+		IngressClass:    ingressClass,
+		MetricCollector: mc,
+	})); err != nil {
 		return err
 	}
-	if err := c.Watch(&source.Channel{Source: ingressChan}, &handlers.EnqueueRequestsForIngressEvent{
+	if err := c.Watch(&source.Channel{Source: ingressChan}, coalescer.Wrap(&handlers.EnqueueRequestsForIngressEvent{
 		IngressClass: ingressClass,
-	}); err != nil {
+	})); err != nil {
 		return err
 	}
 
-	if err := c.Watch(&source.Kind{Type: &corev1.Service{}}, &handlers.EnqueueRequestsForServiceEvent{
-		IngressClass: ingressClass,
-		Cache:        cache,
-	}); err != nil {
+	if err := c.Watch(&source.Kind{Type: &corev1.Service{}}, coalescer.Wrap(&handlers.EnqueueRequestsForServiceEvent{
+		IngressClass:           ingressClass,
+		CrossNamespaceBackends: crossNamespaceBackends,
+		Cache:                  cache,
+	})); err != nil {
 		return err
 	}
-	if err := c.Watch(&source.Channel{Source: serviceChan}, &handlers.EnqueueRequestsForServiceEvent{
-		IngressClass: ingressClass,
-		Cache:        cache,
-	}); err != nil {
+	if err := c.Watch(&source.Channel{Source: serviceChan}, coalescer.Wrap(&handlers.EnqueueRequestsForServiceEvent{
+		IngressClass:           ingressClass,
+		CrossNamespaceBackends: crossNamespaceBackends,
+		Cache:                  cache,
+	})); err != nil {
 		return err
 	}
 
-	if err := c.Watch(&source.Kind{Type: &corev1.Endpoints{}}, &handlers.EnqueueRequestsForEndpointsEvent{
-		IngressClass: ingressClass,
-		Cache:        cache,
-	}); err != nil {
+	if err := c.Watch(&source.Kind{Type: &corev1.Endpoints{}}, coalescer.Wrap(&handlers.EnqueueRequestsForEndpointsEvent{
+		IngressClass:           ingressClass,
+		CrossNamespaceBackends: crossNamespaceBackends,
+		Cache:                  cache,
+	})); err != nil {
 		return err
 	}
-	if err := c.Watch(&source.Kind{Type: &corev1.Node{}}, &handlers.EnqueueRequestsForNodeEvent{
-		IngressClass: ingressClass,
-		Cache:        cache,
-	}); err != nil {
+	if err := c.Watch(&source.Kind{Type: &corev1.Node{}}, coalescer.Wrap(&handlers.EnqueueRequestsForNodeEvent{
+		IngressClass:       ingressClass,
+		NodeSelector:       config.TargetNodeSelector,
+		CheckNodeReadiness: !config.RegisterUnreadyNodes,
+		Cache:              cache,
+	})); err != nil {
 		return err
 	}
-	if err := c.Watch(&source.Kind{Type: &corev1.Pod{}}, &handlers.EnqueueRequestsForPodsEvent{
-		IngressClass: ingressClass,
-		Cache:        cache,
-	}); err != nil {
+	if err := c.Watch(&source.Kind{Type: &corev1.Pod{}}, coalescer.Wrap(&handlers.EnqueueRequestsForPodsEvent{
+		IngressClass:           ingressClass,
+		CrossNamespaceBackends: crossNamespaceBackends,
+		Cache:                  cache,
+	})); err != nil {
 		return err
 	}
 
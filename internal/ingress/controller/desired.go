@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/generator"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/ls"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/auth"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// DesiredState is the ALB configuration the controller reconciles an ingress, or an ingress group, towards.
+type DesiredState struct {
+	Ingress      string                  `json:"ingress"`
+	LoadBalancer lb.DesiredLoadBalancer  `json:"loadBalancer"`
+	Listeners    []ls.DesiredListener    `json:"listeners"`
+	TargetGroups []tg.DesiredTargetGroup `json:"targetGroups"`
+}
+
+// staticCache serves a fixed set of objects to the auth module, which only reads secrets through it.
+// Its informers are never used, as no watches are set up.
+type staticCache struct {
+	client.Reader
+	cache.Informers
+}
+
+// BuildDesiredStates computes the desired state of every ingress of cfg.IngressClass among objects, without calling AWS
+// or the API server. Members of an ingress group are merged into a single desired state, like during reconcile.
+// The other objects, e.g. services and secrets, are the ones ingresses would find in the cluster.
+// AWS resources that don't exist yet are referred to by name, and unannotated subnets, securityGroups and
+// certificates, which are discovered from AWS, are left out or replaced by placeholders.
+func BuildDesiredStates(cfg *config.Configuration, objects []runtime.Object) ([]DesiredState, error) {
+	storer := store.NewStatic(cfg, objects...)
+	authModule := auth.NewModule(staticCache{Reader: fake.NewFakeClient(objects...)})
+	nameTagGen := generator.NewNameTagGenerator(*cfg)
+
+	var ingresses []extensions.Ingress
+	for _, obj := range objects {
+		if ing, ok := obj.(*extensions.Ingress); ok {
+			ingresses = append(ingresses, *ing)
+		}
+	}
+
+	var states []DesiredState
+	seenGroups := make(map[types.NamespacedName]bool)
+	for i := range ingresses {
+		ingress := &ingresses[i]
+		var sources albctx.RuleSources
+		if ingress.DeletionTimestamp != nil || !class.IsValidIngress(cfg.IngressClass, ingress) {
+			continue
+		}
+		if groupKey, inGroup := groupKeyOf(ingress); inGroup {
+			if seenGroups[groupKey] {
+				continue
+			}
+			seenGroups[groupKey] = true
+			members, err := selectGroupMembers(cfg.IngressClass, groupKey, ingresses)
+			if err != nil {
+				return nil, err
+			}
+			conflicts, err := groupAnnotationConflicts(members)
+			if err != nil {
+				return nil, err
+			}
+			if len(conflicts) > 0 {
+				return nil, fmt.Errorf("ingresses of group %v disagree on annotations applying to the whole group: %v", groupKey, conflicts)
+			}
+			ingress, sources = mergeGroupMembers(groupKey, members)
+			storer.UpdateIngressAnnotations(ingress)
+		}
+
+		state, err := buildDesiredState(storer, authModule, nameTagGen, ingress, sources)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build desired state of %v due to %v", k8s.MetaNamespaceKey(ingress), err)
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+func buildDesiredState(storer store.Storer, authModule auth.Module, nameTagGen *generator.NameTagGenerator, ingress *extensions.Ingress, sources albctx.RuleSources) (DesiredState, error) {
+	ingressKey := k8s.MetaNamespaceKey(ingress)
+	ctx := albctx.SetEventf(context.Background(), func(eventType string, reason string, messageFmt string, args ...interface{}) {})
+	ctx = albctx.SetRuleSources(ctx, sources)
+	ingressAnnos, err := storer.GetIngressAnnotations(ingressKey)
+	if err != nil {
+		return DesiredState{}, err
+	}
+	if ingressAnnos.Error != nil {
+		return DesiredState{}, ingressAnnos.Error
+	}
+
+	lbState, err := lb.BuildDesiredLoadBalancer(storer, nameTagGen, ingress)
+	if err != nil {
+		return DesiredState{}, err
+	}
+	tgStates, tgGroup, err := tg.BuildDesiredTargetGroups(storer, nameTagGen, ingress)
+	if err != nil {
+		return DesiredState{}, err
+	}
+	lsStates := make([]ls.DesiredListener, 0, len(ingressAnnos.LoadBalancer.Ports))
+	for _, port := range ingressAnnos.LoadBalancer.Ports {
+		lsState, err := ls.BuildDesiredListener(ctx, authModule, ls.ReconcileOptions{
+			Ingress:      ingress,
+			IngressAnnos: ingressAnnos,
+			Port:         port,
+			TGGroup:      tgGroup,
+		})
+		if err != nil {
+			return DesiredState{}, err
+		}
+		lsStates = append(lsStates, lsState)
+	}
+	return DesiredState{
+		Ingress:      ingressKey,
+		LoadBalancer: lbState,
+		Listeners:    lsStates,
+		TargetGroups: tgStates,
+	}, nil
+}
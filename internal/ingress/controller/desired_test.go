@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func ingressWithPath(name string, annotations map[string]string, host string, path string, serviceName string) *extensions.Ingress {
+	ing := ingressWithRule(name, annotations, host)
+	ing.Spec.Rules[0].HTTP = &extensions.HTTPIngressRuleValue{
+		Paths: []extensions.HTTPIngressPath{{
+			Path:    path,
+			Backend: extensions.IngressBackend{ServiceName: serviceName, ServicePort: intstr.FromInt(80)},
+		}},
+	}
+	return ing
+}
+
+func nodePortService(name string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+		Spec: corev1.ServiceSpec{
+			Type:  corev1.ServiceTypeNodePort,
+			Ports: []corev1.ServicePort{{Port: 80, NodePort: 30080}},
+		},
+	}
+}
+
+func TestBuildDesiredStates(t *testing.T) {
+	cfg := config.NewConfiguration()
+	cfg.ClusterName = "cluster"
+	cfg.DefaultTargetType = elbv2.TargetTypeEnumInstance
+	cfg.DefaultBackendProtocol = elbv2.ProtocolEnumHttp
+	objects := []runtime.Object{
+		ingressWithPath("second", map[string]string{
+			"alb.ingress.kubernetes.io/group.name":  "shared",
+			"alb.ingress.kubernetes.io/group.order": "2",
+		}, "b.example.com", "/b", "svc-b"),
+		ingressWithPath("first", map[string]string{
+			"alb.ingress.kubernetes.io/group.name":  "shared",
+			"alb.ingress.kubernetes.io/group.order": "1",
+			"alb.ingress.kubernetes.io/scheme":      "internet-facing",
+		}, "a.example.com", "/a", "svc-a"),
+		ingressWithPath("standalone", nil, "c.example.com", "/c", "svc-a"),
+		ingressWithPath("other-class", map[string]string{
+			"kubernetes.io/ingress.class": "nginx",
+		}, "d.example.com", "/d", "svc-a"),
+		nodePortService("svc-a"),
+		nodePortService("svc-b"),
+	}
+
+	states, err := BuildDesiredStates(&cfg, objects)
+	assert.NoError(t, err)
+	if !assert.Len(t, states, 2) {
+		return
+	}
+
+	group := states[0]
+	assert.Equal(t, "ns/group.shared", group.Ingress)
+	assert.Equal(t, aws.String("internet-facing"), group.LoadBalancer.Scheme)
+	assert.Len(t, group.TargetGroups, 2)
+	if assert.Len(t, group.Listeners, 1) {
+		listener := group.Listeners[0]
+		assert.Equal(t, int64(80), listener.Port)
+		if assert.Len(t, listener.Rules, 2) {
+			// rules of the group are ordered by group.order, and forward to targetGroups referred to by name.
+			assert.Equal(t, []string{"a.example.com"}, aws.StringValueSlice(listener.Rules[0].Conditions[0].HostHeaderConfig.Values))
+			assert.Equal(t, group.TargetGroups[0].Name, aws.StringValue(listener.Rules[0].Actions[0].ForwardConfig.TargetGroups[0].TargetGroupArn))
+			assert.Equal(t, []string{"b.example.com"}, aws.StringValueSlice(listener.Rules[1].Conditions[0].HostHeaderConfig.Values))
+			assert.Equal(t, group.TargetGroups[1].Name, aws.StringValue(listener.Rules[1].Actions[0].ForwardConfig.TargetGroups[0].TargetGroupArn))
+		}
+	}
+
+	standalone := states[1]
+	assert.Equal(t, "ns/standalone", standalone.Ingress)
+	assert.Equal(t, aws.String("internal"), standalone.LoadBalancer.Scheme)
+	if assert.Len(t, standalone.TargetGroups, 1) {
+		assert.Equal(t, "svc-a", standalone.TargetGroups[0].ServiceName)
+		assert.Equal(t, "traffic-port", standalone.TargetGroups[0].HealthCheckPort)
+	}
+}
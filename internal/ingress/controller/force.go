@@ -0,0 +1,183 @@
+package controller
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang/glog"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ForceReconcileResult is the outcome of a reconcile of every ingress forced through ForceReconcileHandler.
+type ForceReconcileResult struct {
+	// Reconciled is the number of ingresses that were reconciled successfully.
+	Reconciled int `json:"reconciled"`
+
+	// Errors are the ingresses whose reconcile failed.
+	Errors []ForceReconcileError `json:"errors"`
+}
+
+// ForceReconcileError is the error of an ingress whose forced reconcile failed.
+type ForceReconcileError struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Error     string `json:"error"`
+}
+
+// ingressLocks serializes reconciles of the same ingress, so that a forced reconcile can't run concurrently
+// with one scheduled by the controller's workqueue. Locks are dropped once no reconcile holds or waits for them.
+type ingressLocks struct {
+	mutex sync.Mutex
+	locks map[types.NamespacedName]*ingressLock
+}
+
+// ingressLock is the lock of an ingress, along with the number of reconciles holding or waiting for it.
+type ingressLock struct {
+	sync.Mutex
+	refs int
+}
+
+// lock blocks until no other reconcile of ingressKey runs. The returned func releases the lock.
+func (l *ingressLocks) lock(ingressKey types.NamespacedName) func() {
+	l.mutex.Lock()
+	if l.locks == nil {
+		l.locks = make(map[types.NamespacedName]*ingressLock)
+	}
+	m, ok := l.locks[ingressKey]
+	if !ok {
+		m = &ingressLock{}
+		l.locks[ingressKey] = m
+	}
+	m.refs++
+	l.mutex.Unlock()
+
+	m.Lock()
+	return func() {
+		m.Unlock()
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+		m.refs--
+		if m.refs == 0 {
+			delete(l.locks, ingressKey)
+		}
+	}
+}
+
+// leadership tracks whether this replica is the leader, the only one running the reconcile loops.
+type leadership struct {
+	mutex   sync.Mutex
+	leading bool
+}
+
+func (l *leadership) set(leading bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.leading = leading
+}
+
+func (l *leadership) isLeader() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.leading
+}
+
+// Leadership records that this replica is the leader while it runs: the manager only starts its runnables once
+// leader election is won, or right away without leader election, and stops the controller once leadership is lost.
+func (r *Reconciler) Leadership() manager.Runnable {
+	return manager.RunnableFunc(func(stop <-chan struct{}) error {
+		r.leader.set(true)
+		<-stop
+		r.leader.set(false)
+		return nil
+	})
+}
+
+// ForceReconcileHandler reconciles every ingress of the ingress class right away when POSTed to,
+// and serves the ForceReconcileResult as JSON. Requests must carry token as a bearer token, and are refused with
+// 503 Service Unavailable by replicas that aren't the leader.
+func (r *Reconciler) ForceReconcileHandler(token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "only POST is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		bearer := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if token == "" || subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+			http.Error(w, "invalid bearer token", http.StatusUnauthorized)
+			return
+		}
+
+		// only the leader reconciles, other replicas would race it on the same AWS resources.
+		if !r.leader.isLeader() {
+			http.Error(w, "this replica isn't the leader, send the request to the leader", http.StatusServiceUnavailable)
+			return
+		}
+
+		result, err := r.forceReconcile(req.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		b, err := json.Marshal(result)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(b)
+	})
+}
+
+// forceReconcile reconciles every live ingress of the ingress class, one after the other.
+func (r *Reconciler) forceReconcile(ctx context.Context) (ForceReconcileResult, error) {
+	keys, err := r.listIngressKeys(ctx)
+	if err != nil {
+		return ForceReconcileResult{}, fmt.Errorf("failed to list ingresses due to %v", err)
+	}
+	glog.Infof("forced reconcile of %d ingresses", len(keys))
+
+	result := ForceReconcileResult{Errors: make([]ForceReconcileError, 0)}
+	for _, key := range keys {
+		// reconciles paused by the circuit breaker fail with a pausedError, other requeues don't fail the reconcile.
+		if _, err := r.reconcile(reconcile.Request{NamespacedName: key}); err != nil {
+			result.Errors = append(result.Errors, ForceReconcileError{Namespace: key.Namespace, Name: key.Name, Error: err.Error()})
+			continue
+		}
+		result.Reconciled++
+	}
+	return result, nil
+}
+
+// listIngressKeys returns the keys of the live ingresses of the ingress class, sorted by namespace and name.
+func (r *Reconciler) listIngressKeys(ctx context.Context) ([]types.NamespacedName, error) {
+	ingList := &extensions.IngressList{}
+	if err := r.cache.List(ctx, &client.ListOptions{}, ingList); err != nil {
+		return nil, err
+	}
+	ingressClass := r.store.GetConfig().IngressClass
+	var keys []types.NamespacedName
+	for i := range ingList.Items {
+		ing := &ingList.Items[i]
+		if ing.DeletionTimestamp != nil || !class.IsValidIngress(ingressClass, ing) {
+			continue
+		}
+		keys = append(keys, types.NamespacedName{Namespace: ing.Namespace, Name: ing.Name})
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+	return keys, nil
+}
@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconciler_ForceReconcileHandler(t *testing.T) {
+	for _, tc := range []struct {
+		Name           string
+		Method         string
+		Authorization  string
+		NotLeader      bool
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		{
+			Name:           "only POST is allowed",
+			Method:         http.MethodGet,
+			Authorization:  "Bearer secret",
+			ExpectedStatus: http.StatusMethodNotAllowed,
+			ExpectedBody:   "only POST is allowed\n",
+		},
+		{
+			Name:           "requests without the token are rejected",
+			Method:         http.MethodPost,
+			ExpectedStatus: http.StatusUnauthorized,
+			ExpectedBody:   "invalid bearer token\n",
+		},
+		{
+			Name:           "requests with another token are rejected",
+			Method:         http.MethodPost,
+			Authorization:  "Bearer other",
+			ExpectedStatus: http.StatusUnauthorized,
+			ExpectedBody:   "invalid bearer token\n",
+		},
+		{
+			Name:           "replicas that aren't the leader refuse to reconcile",
+			Method:         http.MethodPost,
+			Authorization:  "Bearer secret",
+			NotLeader:      true,
+			ExpectedStatus: http.StatusServiceUnavailable,
+			ExpectedBody:   "this replica isn't the leader, send the request to the leader\n",
+		},
+		{
+			Name:           "ingresses are reported as failed while reconciles are paused",
+			Method:         http.MethodPost,
+			Authorization:  "Bearer secret",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   `{"reconciled":0,"errors":[{"namespace":"ns","name":"live","error":"reconciles are paused after repeated failures, retry in 1m0s"}]}`,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			cfg := config.NewConfiguration()
+			r := &Reconciler{
+				cache: staticCache{Reader: fake.NewFakeClient(
+					ingressWithRule("live", nil, ""),
+				)},
+				store:           store.NewStatic(&cfg),
+				metricCollector: metric.DummyCollector{},
+				breaker: circuitBreaker{
+					cooldown: time.Minute,
+					probe:    func(ctx context.Context) error { return errors.New("unavailable") },
+					openedAt: time.Now(),
+				},
+			}
+			r.leader.set(!tc.NotLeader)
+
+			req := httptest.NewRequest(tc.Method, "/reconcile", nil)
+			if tc.Authorization != "" {
+				req.Header.Set("Authorization", tc.Authorization)
+			}
+			w := httptest.NewRecorder()
+			r.ForceReconcileHandler("secret").ServeHTTP(w, req)
+			assert.Equal(t, tc.ExpectedStatus, w.Code)
+			assert.Equal(t, tc.ExpectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestReconciler_listIngressKeys(t *testing.T) {
+	cfg := config.NewConfiguration()
+	r := &Reconciler{
+		cache: staticCache{Reader: fake.NewFakeClient(
+			ingressWithRule("b", nil, ""),
+			ingressWithRule("a", map[string]string{"alb.ingress.kubernetes.io/group.name": "shared"}, ""),
+			ingressWithRule("other-class", map[string]string{"kubernetes.io/ingress.class": "nginx"}, ""),
+		)},
+		store: store.NewStatic(&cfg),
+	}
+
+	keys, err := r.listIngressKeys(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []types.NamespacedName{
+		{Namespace: "ns", Name: "a"},
+		{Namespace: "ns", Name: "b"},
+	}, keys)
+}
+
+func Test_ingressLocks(t *testing.T) {
+	var locks ingressLocks
+	key := types.NamespacedName{Namespace: "ns", Name: "ing"}
+	unlock := locks.lock(key)
+
+	locked := make(chan struct{})
+	released := make(chan struct{})
+	go func() {
+		defer close(released)
+		defer locks.lock(key)()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+		t.Fatal("a second reconcile of the same ingress must wait for the first")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// other ingresses aren't held up.
+	locks.lock(types.NamespacedName{Namespace: "ns", Name: "other"})()
+
+	unlock()
+	<-locked
+	<-released
+
+	// locks are dropped once released.
+	locks.mutex.Lock()
+	defer locks.mutex.Unlock()
+	assert.Empty(t, locks.locks)
+}
+
+func TestReconciler_Leadership(t *testing.T) {
+	r := &Reconciler{}
+	stop := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- r.Leadership().Start(stop)
+	}()
+	assert.Eventually(t, r.leader.isLeader, time.Second, 10*time.Millisecond)
+
+	close(stop)
+	assert.NoError(t, <-done)
+	assert.False(t, r.leader.isLeader())
+}
@@ -0,0 +1,361 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// AnnotationGroupName places an ingress into an ingress group that shares a single LoadBalancer.
+	AnnotationGroupName = "group.name"
+	// AnnotationGroupOrder orders the rules of an ingress within its ingress group, lower values first.
+	AnnotationGroupOrder = "group.order"
+	// AnnotationStatusGroup is set by the controller to the name of the ingress group an ingress was last reconciled into,
+	// so that the group it leaves is still known after a restart.
+	AnnotationStatusGroup = "status.group"
+
+	// EventReasonGroupAnnotationConflict is the reason of the events warning that the members of an ingress group set
+	// an annotation applying to the whole group to different values.
+	EventReasonGroupAnnotationConflict = "GroupAnnotationConflict"
+
+	// groupKeyPrefix keeps the name of a merged ingress group apart from the name of a real ingress.
+	groupKeyPrefix = "group."
+)
+
+// groupMember is an ingress belonging to an ingress group, along with its position in the group.
+type groupMember struct {
+	ingress *extensions.Ingress
+	order   int64
+}
+
+// ingressGroups tracks which ingress group each ingress was last reconciled into,
+// so that deleting an ingress can reconcile the group it left without looking for it.
+type ingressGroups struct {
+	mutex   sync.Mutex
+	members map[types.NamespacedName]types.NamespacedName
+	locks   map[types.NamespacedName]*sync.Mutex
+}
+
+// groupOf returns the group ingressKey was last reconciled into.
+func (g *ingressGroups) groupOf(ingressKey types.NamespacedName) (types.NamespacedName, bool) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	groupKey, ok := g.members[ingressKey]
+	return groupKey, ok
+}
+
+// join records that ingressKey belongs to groupKey.
+func (g *ingressGroups) join(ingressKey types.NamespacedName, groupKey types.NamespacedName) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.members == nil {
+		g.members = make(map[types.NamespacedName]types.NamespacedName)
+	}
+	g.members[ingressKey] = groupKey
+}
+
+// leave records that ingressKey no longer belongs to any group.
+func (g *ingressGroups) leave(ingressKey types.NamespacedName) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	delete(g.members, ingressKey)
+}
+
+// lock serializes reconciles of groupKey, since every member of a group drives the same LoadBalancer.
+// The returned func releases the lock.
+func (g *ingressGroups) lock(groupKey types.NamespacedName) func() {
+	g.mutex.Lock()
+	if g.locks == nil {
+		g.locks = make(map[types.NamespacedName]*sync.Mutex)
+	}
+	l, ok := g.locks[groupKey]
+	if !ok {
+		l = &sync.Mutex{}
+		g.locks[groupKey] = l
+	}
+	g.mutex.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}
+
+// groupKeyOf returns the ingress group key of ingress, or false if ingress isn't part of a group.
+func groupKeyOf(ingress *extensions.Ingress) (types.NamespacedName, bool) {
+	var groupName string
+	if !annotations.LoadStringAnnotation(AnnotationGroupName, &groupName, ingress.Annotations) || groupName == "" {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Namespace: ingress.Namespace, Name: groupKeyPrefix + groupName}, true
+}
+
+// previousGroupKeyOf returns the group ingress was last reconciled into, as recorded by AnnotationStatusGroup,
+// or by the controller itself for ingresses reconciled before the annotation existed.
+func (r *Reconciler) previousGroupKeyOf(ingressKey types.NamespacedName, ingress *extensions.Ingress) (types.NamespacedName, bool) {
+	if groupName := ingress.Annotations[parser.GetAnnotationWithPrefix(AnnotationStatusGroup)]; groupName != "" {
+		return types.NamespacedName{Namespace: ingress.Namespace, Name: groupKeyPrefix + groupName}, true
+	}
+	return r.groups.groupOf(ingressKey)
+}
+
+// listGroupKeys returns the ingress groups of namespace, whether they have live members or only a LoadBalancer left.
+// Only the LoadBalancers in the account of the controller are found, not those in the accounts of IAM roles.
+func (r *Reconciler) listGroupKeys(ctx context.Context, namespace string) ([]types.NamespacedName, error) {
+	ingList := &extensions.IngressList{}
+	if err := r.cache.List(ctx, client.InNamespace(namespace), ingList); err != nil {
+		return nil, err
+	}
+	stackKeys, err := r.listLoadBalancerStacks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[types.NamespacedName]bool)
+	var groupKeys []types.NamespacedName
+	for i := range ingList.Items {
+		if key, ok := groupKeyOf(&ingList.Items[i]); ok && !seen[key] {
+			seen[key] = true
+			groupKeys = append(groupKeys, key)
+		}
+	}
+	for _, key := range stackKeys {
+		if key.Namespace == namespace && strings.HasPrefix(key.Name, groupKeyPrefix) && !seen[key] {
+			seen[key] = true
+			groupKeys = append(groupKeys, key)
+		}
+	}
+	sort.Slice(groupKeys, func(i, j int) bool {
+		return groupKeys[i].Name < groupKeys[j].Name
+	})
+	return groupKeys, nil
+}
+
+// listGroupMembers returns the live ingresses of groupKey, sorted by group order and then by name.
+func (r *Reconciler) listGroupMembers(ctx context.Context, groupKey types.NamespacedName) ([]groupMember, error) {
+	ingList := &extensions.IngressList{}
+	if err := r.cache.List(ctx, client.InNamespace(groupKey.Namespace), ingList); err != nil {
+		return nil, err
+	}
+	return selectGroupMembers(r.store.GetConfig().IngressClass, groupKey, ingList.Items)
+}
+
+// selectGroupMembers returns the ingresses of groupKey among ingresses, sorted by group order and then by name.
+func selectGroupMembers(ingressClass string, groupKey types.NamespacedName, ingresses []extensions.Ingress) ([]groupMember, error) {
+	var members []groupMember
+	for i := range ingresses {
+		ing := &ingresses[i]
+		if ing.DeletionTimestamp != nil || !class.IsValidIngress(ingressClass, ing) {
+			continue
+		}
+		if key, ok := groupKeyOf(ing); !ok || key != groupKey {
+			continue
+		}
+		var order int64
+		if _, err := annotations.LoadInt64Annotation(AnnotationGroupOrder, &order, ing.Annotations); err != nil {
+			return nil, err
+		}
+		members = append(members, groupMember{ingress: ing, order: order})
+	}
+
+	sort.SliceStable(members, func(i, j int) bool {
+		if members[i].order != members[j].order {
+			return members[i].order < members[j].order
+		}
+		return members[i].ingress.Name < members[j].ingress.Name
+	})
+	return members, nil
+}
+
+// annotationConflict is an annotation applying to a whole ingress group that its members set to different values.
+type annotationConflict struct {
+	key     string
+	members []*extensions.Ingress
+}
+
+func (c annotationConflict) String() string {
+	names := make([]string, 0, len(c.members))
+	for _, member := range c.members {
+		names = append(names, member.Name)
+	}
+	return fmt.Sprintf("annotation %v is set to different values by ingresses %v", c.key, strings.Join(names, ", "))
+}
+
+// isRuleAnnotation tells whether key configures the rules of the ingress declaring it rather than its LoadBalancer,
+// so that within an ingress group it only applies to the rules of the member declaring it.
+func isRuleAnnotation(key string) bool {
+	for _, prefix := range []string{"auth-", "actions.", "conditions.", backend.AnnotationServiceNamespace + "."} {
+		if strings.HasPrefix(key, parser.GetAnnotationWithPrefix(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isGroupAnnotation tells whether key applies to the whole ingress group, so that its members must agree on its value.
+// Annotations of other controllers, the group order and the status set by the controller differ between members.
+func isGroupAnnotation(key string) bool {
+	if !strings.HasPrefix(key, parser.GetAnnotationWithPrefix("")) || isRuleAnnotation(key) {
+		return false
+	}
+	return key != parser.GetAnnotationWithPrefix(AnnotationGroupOrder) &&
+		key != parser.GetAnnotationWithPrefix(AnnotationStatus) &&
+		!strings.HasPrefix(key, parser.GetAnnotationWithPrefix(AnnotationStatus+"."))
+}
+
+// servicesOf returns the names of the services ingress routes to, directly or through the forward actions of its
+// annotations.
+func servicesOf(ingress *extensions.Ingress) (sets.String, error) {
+	backends, _, err := tg.ExtractTargetGroupBackends(ingress)
+	if err != nil {
+		return nil, err
+	}
+	services := sets.NewString()
+	for _, b := range backends {
+		services.Insert(b.ServiceName)
+	}
+	return services, nil
+}
+
+// groupAnnotationConflicts returns the annotations applying to the whole ingress group that members set to different
+// values, sorted by key. The conditions.<name> and backend-service-namespace.<name> annotations of a service apply to
+// every rule of the group routing to it, so the members routing to the service must agree on them, unset included.
+func groupAnnotationConflicts(members []groupMember) ([]annotationConflict, error) {
+	values := make(map[string]sets.String)
+	setBy := make(map[string][]*extensions.Ingress)
+	set := func(key string, value string, ingress *extensions.Ingress) {
+		if values[key] == nil {
+			values[key] = sets.NewString()
+		}
+		values[key].Insert(value)
+		setBy[key] = append(setBy[key], ingress)
+	}
+	for _, member := range members {
+		for k, v := range member.ingress.Annotations {
+			if isGroupAnnotation(k) {
+				set(k, v, member.ingress)
+			}
+		}
+		services, err := servicesOf(member.ingress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the services of ingress %v due to %v", member.ingress.Name, err)
+		}
+		for _, service := range services.List() {
+			for _, prefix := range []string{"conditions.", backend.AnnotationServiceNamespace + "."} {
+				k := parser.GetAnnotationWithPrefix(prefix + service)
+				set(k, member.ingress.Annotations[k], member.ingress)
+			}
+		}
+	}
+
+	var conflicts []annotationConflict
+	for key, keyValues := range values {
+		if keyValues.Len() > 1 {
+			conflicts = append(conflicts, annotationConflict{key: key, members: setBy[key]})
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].key < conflicts[j].key
+	})
+	return conflicts, nil
+}
+
+// scopedActionName returns the name the action or conditions named name by the annotations of member are known by in
+// its merged ingress group, so that they don't clash with those of other members.
+// Ingress names can't contain underscores, so scoped names are unique.
+func scopedActionName(member *extensions.Ingress, name string) string {
+	return member.Name + "_" + name
+}
+
+// scopeBackend renames the action b refers to, if any, to its scopedActionName.
+func scopeBackend(member *extensions.Ingress, b *extensions.IngressBackend) {
+	if action.Use(b.ServicePort.String()) {
+		b.ServiceName = scopedActionName(member, b.ServiceName)
+	}
+}
+
+// mergeGroupMembers builds a single ingress out of the members of groupKey, along with the member each of its rules
+// and its default backend come from.
+// Rules and TLS entries are concatenated in member order, and the first member that specifies a default backend wins.
+// Annotations configuring rules only apply to the rules of the member declaring them: actions, and conditions of
+// actions, are renamed to their scopedActionName, authentication annotations are looked up on the member declaring
+// each rule, see albctx.SetRuleSources, and the conditions and namespace of services are only taken from members
+// routing to them. For other annotations the first member that specifies a value wins, groupAnnotationConflicts tells
+// whether members disagree on them.
+func mergeGroupMembers(groupKey types.NamespacedName, members []groupMember) (*extensions.Ingress, albctx.RuleSources) {
+	merged := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   groupKey.Namespace,
+			Name:        groupKey.Name,
+			Annotations: make(map[string]string),
+		},
+	}
+	var sources albctx.RuleSources
+	for _, member := range members {
+		ing := member.ingress
+		actionNames := sets.NewString()
+		// groupAnnotationConflicts already failed on members whose actions can't be parsed.
+		services, _ := servicesOf(ing)
+		for k := range ing.Annotations {
+			if name := strings.TrimPrefix(k, parser.GetAnnotationWithPrefix("actions.")); name != k {
+				actionNames.Insert(name)
+			}
+		}
+		for k, v := range ing.Annotations {
+			switch {
+			case strings.HasPrefix(k, parser.GetAnnotationWithPrefix("auth-")):
+				continue
+			case strings.HasPrefix(k, parser.GetAnnotationWithPrefix("actions.")):
+				name := strings.TrimPrefix(k, parser.GetAnnotationWithPrefix("actions."))
+				k = parser.GetAnnotationWithPrefix("actions." + scopedActionName(ing, name))
+			case strings.HasPrefix(k, parser.GetAnnotationWithPrefix("conditions.")):
+				name := strings.TrimPrefix(k, parser.GetAnnotationWithPrefix("conditions."))
+				if actionNames.Has(name) {
+					k = parser.GetAnnotationWithPrefix("conditions." + scopedActionName(ing, name))
+				} else if !services.Has(name) {
+					continue
+				}
+			case strings.HasPrefix(k, parser.GetAnnotationWithPrefix(backend.AnnotationServiceNamespace+".")):
+				if !services.Has(strings.TrimPrefix(k, parser.GetAnnotationWithPrefix(backend.AnnotationServiceNamespace+"."))) {
+					continue
+				}
+			}
+			if _, ok := merged.Annotations[k]; !ok {
+				merged.Annotations[k] = v
+			}
+		}
+		if merged.Spec.Backend == nil && ing.Spec.Backend != nil {
+			merged.Spec.Backend = ing.Spec.Backend.DeepCopy()
+			scopeBackend(ing, merged.Spec.Backend)
+			sources.Backend = ing
+		}
+		for _, rule := range ing.Spec.Rules {
+			rule := rule.DeepCopy()
+			if rule.HTTP != nil {
+				for i := range rule.HTTP.Paths {
+					scopeBackend(ing, &rule.HTTP.Paths[i].Backend)
+				}
+			}
+			merged.Spec.Rules = append(merged.Spec.Rules, *rule)
+			sources.Rules = append(sources.Rules, ing)
+		}
+		for _, tls := range ing.Spec.TLS {
+			merged.Spec.TLS = append(merged.Spec.TLS, *tls.DeepCopy())
+		}
+	}
+	return merged, sources
+}
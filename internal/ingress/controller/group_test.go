@@ -0,0 +1,407 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/generator"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func ingressWithRule(name string, annotations map[string]string, host string) *extensions.Ingress {
+	return &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, Annotations: annotations},
+		Spec: extensions.IngressSpec{
+			Rules: []extensions.IngressRule{{Host: host}},
+		},
+	}
+}
+
+func Test_groupKeyOf(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		expectedKey types.NamespacedName
+		expectedOK  bool
+	}{
+		{
+			name:        "no group annotation",
+			annotations: nil,
+			expectedOK:  false,
+		},
+		{
+			name:        "empty group name",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/group.name": ""},
+			expectedOK:  false,
+		},
+		{
+			name:        "group name",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/group.name": "shared"},
+			expectedKey: types.NamespacedName{Namespace: "ns", Name: "group.shared"},
+			expectedOK:  true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			key, ok := groupKeyOf(ingressWithRule("ing", tc.annotations, ""))
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedKey, key)
+		})
+	}
+}
+
+func Test_mergeGroupMembers(t *testing.T) {
+	groupKey := types.NamespacedName{Namespace: "ns", Name: "group.shared"}
+	first := ingressWithRule("first", map[string]string{
+		"alb.ingress.kubernetes.io/scheme":              "internet-facing",
+		"alb.ingress.kubernetes.io/auth-type":           "cognito",
+		"alb.ingress.kubernetes.io/actions.redirect":    `{"Type": "redirect", "RedirectConfig": {"StatusCode": "HTTP_301"}}`,
+		"alb.ingress.kubernetes.io/conditions.redirect": `[{"Field": "source-ip"}]`,
+		"alb.ingress.kubernetes.io/conditions.svc":      `[{"Field": "http-request-method"}]`,
+	}, "a.example.com")
+	first.Spec.Rules[0].HTTP = &extensions.HTTPIngressRuleValue{Paths: []extensions.HTTPIngressPath{
+		{Path: "/old", Backend: extensions.IngressBackend{ServiceName: "redirect", ServicePort: intstr.FromString("use-annotation")}},
+	}}
+	second := ingressWithRule("second", map[string]string{
+		"alb.ingress.kubernetes.io/listen-ports":                  `[{"HTTP": 8080}]`,
+		"alb.ingress.kubernetes.io/actions.redirect":              `{"Type": "fixed-response", "FixedResponseConfig": {"StatusCode": "404"}}`,
+		"alb.ingress.kubernetes.io/backend-service-namespace.svc": "apps",
+	}, "b.example.com")
+	second.Spec.Rules[0].HTTP = &extensions.HTTPIngressRuleValue{Paths: []extensions.HTTPIngressPath{
+		{Path: "/svc", Backend: extensions.IngressBackend{ServiceName: "svc", ServicePort: intstr.FromInt(80)}},
+	}}
+	second.Spec.Backend = &extensions.IngressBackend{ServiceName: "redirect", ServicePort: intstr.FromString("use-annotation")}
+	second.Spec.TLS = []extensions.IngressTLS{{Hosts: []string{"b.example.com"}}}
+
+	merged, sources := mergeGroupMembers(groupKey, []groupMember{{ingress: first}, {ingress: second, order: 1}})
+
+	assert.Equal(t, "ns", merged.Namespace)
+	assert.Equal(t, "group.shared", merged.Name)
+	// authentication is looked up on the members, the conditions of svc are dropped since first doesn't route to it.
+	assert.Equal(t, map[string]string{
+		"alb.ingress.kubernetes.io/scheme":                        "internet-facing",
+		"alb.ingress.kubernetes.io/listen-ports":                  `[{"HTTP": 8080}]`,
+		"alb.ingress.kubernetes.io/actions.first_redirect":        `{"Type": "redirect", "RedirectConfig": {"StatusCode": "HTTP_301"}}`,
+		"alb.ingress.kubernetes.io/conditions.first_redirect":     `[{"Field": "source-ip"}]`,
+		"alb.ingress.kubernetes.io/actions.second_redirect":       `{"Type": "fixed-response", "FixedResponseConfig": {"StatusCode": "404"}}`,
+		"alb.ingress.kubernetes.io/backend-service-namespace.svc": "apps",
+	}, merged.Annotations)
+	assert.Equal(t, []extensions.IngressRule{
+		{Host: "a.example.com", IngressRuleValue: extensions.IngressRuleValue{HTTP: &extensions.HTTPIngressRuleValue{Paths: []extensions.HTTPIngressPath{
+			{Path: "/old", Backend: extensions.IngressBackend{ServiceName: "first_redirect", ServicePort: intstr.FromString("use-annotation")}},
+		}}}},
+		{Host: "b.example.com", IngressRuleValue: extensions.IngressRuleValue{HTTP: &extensions.HTTPIngressRuleValue{Paths: []extensions.HTTPIngressPath{
+			{Path: "/svc", Backend: extensions.IngressBackend{ServiceName: "svc", ServicePort: intstr.FromInt(80)}},
+		}}}},
+	}, merged.Spec.Rules)
+	assert.Equal(t, &extensions.IngressBackend{ServiceName: "second_redirect", ServicePort: intstr.FromString("use-annotation")}, merged.Spec.Backend)
+	assert.Equal(t, second.Spec.TLS, merged.Spec.TLS)
+	assert.Equal(t, []*extensions.Ingress{first, second}, sources.Rules)
+	assert.Equal(t, second, sources.Backend)
+	// the members themselves are left untouched.
+	assert.Equal(t, "redirect", first.Spec.Rules[0].HTTP.Paths[0].Backend.ServiceName)
+	assert.Equal(t, "redirect", second.Spec.Backend.ServiceName)
+}
+
+func Test_groupAnnotationConflicts(t *testing.T) {
+	routingTo := func(name string, annotations map[string]string, serviceName string) *extensions.Ingress {
+		ing := ingressWithRule(name, annotations, name+".example.com")
+		ing.Spec.Rules[0].HTTP = &extensions.HTTPIngressRuleValue{Paths: []extensions.HTTPIngressPath{
+			{Backend: extensions.IngressBackend{ServiceName: serviceName, ServicePort: intstr.FromInt(80)}},
+		}}
+		return ing
+	}
+	for _, tc := range []struct {
+		name              string
+		first             map[string]string
+		second            map[string]string
+		secondService     string
+		expectedConflicts map[string][]string
+	}{
+		{
+			name:          "group annotations set by one member or to the same value",
+			first:         map[string]string{"alb.ingress.kubernetes.io/scheme": "internal", "alb.ingress.kubernetes.io/group.order": "1"},
+			second:        map[string]string{"alb.ingress.kubernetes.io/scheme": "internal", "alb.ingress.kubernetes.io/certificate-arn": "arn"},
+			secondService: "svc",
+		},
+		{
+			name: "group annotations set to different values",
+			first: map[string]string{
+				"alb.ingress.kubernetes.io/scheme":          "internal",
+				"alb.ingress.kubernetes.io/certificate-arn": "arn-1",
+			},
+			second: map[string]string{
+				"alb.ingress.kubernetes.io/scheme":          "internet-facing",
+				"alb.ingress.kubernetes.io/certificate-arn": "arn-2",
+			},
+			secondService: "svc",
+			expectedConflicts: map[string][]string{
+				"alb.ingress.kubernetes.io/certificate-arn": {"first", "second"},
+				"alb.ingress.kubernetes.io/scheme":          {"first", "second"},
+			},
+		},
+		{
+			name:          "rule annotations and status differ per member",
+			first:         map[string]string{"alb.ingress.kubernetes.io/auth-type": "cognito", "alb.ingress.kubernetes.io/actions.a": `{"Type": "redirect", "RedirectConfig": {"StatusCode": "HTTP_301"}}`, "alb.ingress.kubernetes.io/status": "Ready"},
+			second:        map[string]string{"alb.ingress.kubernetes.io/auth-type": "none", "alb.ingress.kubernetes.io/actions.a": `{"Type": "fixed-response", "FixedResponseConfig": {"StatusCode": "404"}}`, "alb.ingress.kubernetes.io/status": "Error"},
+			secondService: "svc",
+		},
+		{
+			name:          "namespace of a service set by only one of the members routing to it",
+			first:         map[string]string{"alb.ingress.kubernetes.io/backend-service-namespace.svc": "apps"},
+			second:        nil,
+			secondService: "svc",
+			expectedConflicts: map[string][]string{
+				"alb.ingress.kubernetes.io/backend-service-namespace.svc": {"first", "second"},
+			},
+		},
+		{
+			name:          "conditions of a service the other member doesn't route to",
+			first:         map[string]string{"alb.ingress.kubernetes.io/conditions.svc": `[]`},
+			second:        nil,
+			secondService: "other",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			conflicts, err := groupAnnotationConflicts([]groupMember{
+				{ingress: routingTo("first", tc.first, "svc")},
+				{ingress: routingTo("second", tc.second, tc.secondService)},
+			})
+			assert.NoError(t, err)
+			var got map[string][]string
+			for _, conflict := range conflicts {
+				if got == nil {
+					got = make(map[string][]string)
+				}
+				for _, member := range conflict.members {
+					got[conflict.key] = append(got[conflict.key], member.Name)
+				}
+			}
+			assert.Equal(t, tc.expectedConflicts, got)
+		})
+	}
+}
+
+func TestReconciler_reconcileGroup_conflict(t *testing.T) {
+	ctx := context.Background()
+	lbController := &fakeLBController{}
+	r, _ := newGroupTestReconciler(lbController, nil,
+		ingressWithRule("first", map[string]string{
+			"alb.ingress.kubernetes.io/group.name": "shared",
+			"alb.ingress.kubernetes.io/scheme":     "internal",
+		}, "a.example.com"),
+		ingressWithRule("second", map[string]string{
+			"alb.ingress.kubernetes.io/group.name": "shared",
+			"alb.ingress.kubernetes.io/scheme":     "internet-facing",
+		}, "b.example.com"),
+	)
+
+	err := r.reconcileGroup(ctx, types.NamespacedName{Namespace: "ns", Name: "group.shared"})
+	assert.Error(t, err)
+	assert.Empty(t, lbController.reconciled)
+	recorder := r.recorder.(*record.FakeRecorder)
+	assert.Len(t, recorder.Events, 2)
+	for range []string{"first", "second"} {
+		assert.Equal(t, "Warning GroupAnnotationConflict ingress group shared isn't reconciled until its ingresses agree: "+
+			"annotation alb.ingress.kubernetes.io/scheme is set to different values by ingresses first, second", <-recorder.Events)
+	}
+}
+
+func Test_ingressGroups(t *testing.T) {
+	g := ingressGroups{}
+	ingKey := types.NamespacedName{Namespace: "ns", Name: "ing"}
+	groupKey := types.NamespacedName{Namespace: "ns", Name: "group.shared"}
+
+	_, ok := g.groupOf(ingKey)
+	assert.False(t, ok)
+
+	g.join(ingKey, groupKey)
+	got, ok := g.groupOf(ingKey)
+	assert.True(t, ok)
+	assert.Equal(t, groupKey, got)
+
+	g.leave(ingKey)
+	_, ok = g.groupOf(ingKey)
+	assert.False(t, ok)
+
+	unlock := g.lock(groupKey)
+	unlock()
+	unlock = g.lock(groupKey)
+	unlock()
+}
+
+// fakeLBController records the LoadBalancers it is asked to reconcile and delete.
+type fakeLBController struct {
+	reconciled []string
+	deleted    []string
+}
+
+func (c *fakeLBController) Reconcile(ctx context.Context, ingress *extensions.Ingress) (*lb.LoadBalancer, error) {
+	c.reconciled = append(c.reconciled, ingress.Namespace+"/"+ingress.Name)
+	return &lb.LoadBalancer{Arn: "arn-" + ingress.Name, DNSName: ingress.Name + ".elb.amazonaws.com"}, nil
+}
+
+func (c *fakeLBController) Delete(ctx context.Context, ingressKey types.NamespacedName) error {
+	c.deleted = append(c.deleted, ingressKey.String())
+	return nil
+}
+
+func newGroupTestReconciler(lbController lb.Controller, cloud aws.CloudAPI, objs ...runtime.Object) (*Reconciler, client.Client) {
+	cfg := config.NewConfiguration()
+	cfg.OwnershipTagValue = "owner"
+	c := fake.NewFakeClient(objs...)
+	return &Reconciler{
+		client:       c,
+		cache:        staticCache{Reader: c},
+		recorder:     record.NewFakeRecorder(10),
+		store:        store.NewStatic(&cfg),
+		cloud:        cloud,
+		lbController: lbController,
+	}, c
+}
+
+func TestReconciler_reconcileIngress_groups(t *testing.T) {
+	for _, tc := range []struct {
+		name                string
+		annotations         map[string]string
+		expectedReconciled  []string
+		expectedDeleted     []string
+		expectedStatusGroup string
+	}{
+		{
+			name:                "ingress joining a group gives up its own LoadBalancer",
+			annotations:         map[string]string{"alb.ingress.kubernetes.io/group.name": "shared"},
+			expectedReconciled:  []string{"ns/group.shared"},
+			expectedDeleted:     []string{"ns/ing"},
+			expectedStatusGroup: "shared",
+		},
+		{
+			name: "ingress moving to another group leaves the group recorded on it",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/group.name":   "shared",
+				"alb.ingress.kubernetes.io/status.group": "previous",
+			},
+			expectedReconciled:  []string{"ns/group.shared"},
+			expectedDeleted:     []string{"ns/group.previous"},
+			expectedStatusGroup: "shared",
+		},
+		{
+			name:               "ingress leaving its group gets a LoadBalancer of its own",
+			annotations:        map[string]string{"alb.ingress.kubernetes.io/status.group": "previous"},
+			expectedReconciled: []string{"ns/ing"},
+			expectedDeleted:    []string{"ns/group.previous"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			lbController := &fakeLBController{}
+			r, c := newGroupTestReconciler(lbController, nil, ingressWithRule("ing", tc.annotations, "example.com"))
+			ingress := &extensions.Ingress{}
+			assert.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "ing"}, ingress))
+
+			err := r.reconcileIngress(ctx, types.NamespacedName{Namespace: "ns", Name: "ing"}, ingress)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedReconciled, lbController.reconciled)
+			assert.Equal(t, tc.expectedDeleted, lbController.deleted)
+
+			updated := &extensions.Ingress{}
+			assert.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "ing"}, updated))
+			assert.Equal(t, tc.expectedStatusGroup, updated.Annotations["alb.ingress.kubernetes.io/status.group"])
+		})
+	}
+}
+
+func TestReconciler_deleteIngress_groups(t *testing.T) {
+	tagFilters := map[string][]string{
+		tags.KeyOwnership:            {"owner"},
+		generator.V2TagKeyResourceID: {generator.V2ResourceIDLoadBalancer},
+	}
+
+	for _, tc := range []struct {
+		name               string
+		key                types.NamespacedName
+		knownGroup         string
+		lbTags             map[string]map[string]string
+		expectedReconciled []string
+		expectedDeleted    []string
+	}{
+		{
+			name:               "ingress known to be in a group is dropped from that group only",
+			key:                types.NamespacedName{Namespace: "ns", Name: "deleted"},
+			knownGroup:         "shared",
+			expectedReconciled: []string{"ns/group.shared"},
+		},
+		{
+			name: "ingress of an unknown group is dropped from every group of its namespace",
+			key:  types.NamespacedName{Namespace: "ns", Name: "deleted"},
+			lbTags: map[string]map[string]string{
+				"arn-shared":      lbTagsOfStack("ns/group.shared"),
+				"arn-empty-group": lbTagsOfStack("ns/group.empty"),
+				"arn-ingress":     lbTagsOfStack("ns/live"),
+				"arn-other-ns":    lbTagsOfStack("other/group.empty"),
+			},
+			expectedReconciled: []string{"ns/group.shared"},
+			expectedDeleted:    []string{"ns/deleted", "ns/group.empty"},
+		},
+		{
+			name:            "group whose LoadBalancer outlived it is deleted",
+			key:             types.NamespacedName{Namespace: "ns", Name: "group.empty"},
+			expectedDeleted: []string{"ns/group.empty"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			if tc.lbTags != nil {
+				cloud.On("GetResourceTagsByFilters", mock.Anything, tagFilters, aws.ResourceTypeEnumELBLoadBalancer).Return(tc.lbTags, nil)
+			}
+			lbController := &fakeLBController{}
+			r, _ := newGroupTestReconciler(lbController, cloud,
+				ingressWithRule("live", nil, "live.example.com"),
+				ingressWithRule("member", map[string]string{"alb.ingress.kubernetes.io/group.name": "shared"}, "member.example.com"),
+			)
+			if tc.knownGroup != "" {
+				r.groups.join(tc.key, types.NamespacedName{Namespace: "ns", Name: groupKeyPrefix + tc.knownGroup})
+			}
+
+			assert.NoError(t, r.deleteIngress(ctx, tc.key))
+			assert.Equal(t, tc.expectedReconciled, lbController.reconciled)
+			assert.Equal(t, tc.expectedDeleted, lbController.deleted)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReconciler_reconcileGroup_empty(t *testing.T) {
+	ctx := context.Background()
+	lbController := &fakeLBController{}
+	r, _ := newGroupTestReconciler(lbController, nil)
+	groupKey := types.NamespacedName{Namespace: "ns", Name: "group.empty"}
+	r.store.UpdateIngressAnnotations(&extensions.Ingress{ObjectMeta: metav1.ObjectMeta{
+		Namespace:   "ns",
+		Name:        "group.empty",
+		Annotations: map[string]string{"alb.ingress.kubernetes.io/target-type": "instance"},
+	}})
+	_, err := r.store.GetIngressAnnotations("ns/group.empty")
+	assert.NoError(t, err)
+
+	err = r.reconcileGroup(ctx, groupKey)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ns/group.empty"}, lbController.deleted)
+	_, err = r.store.GetIngressAnnotations("ns/group.empty")
+	assert.Error(t, err)
+}
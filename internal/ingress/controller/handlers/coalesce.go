@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// Coalescer folds bursts of events, e.g. the endpoint and pod updates of a deployment rollout, into a single reconcile.
+// The first event for an ingress schedules its reconcile window later, and further events for it before then are
+// folded into that reconcile and counted by the metric collector.
+type Coalescer struct {
+	window time.Duration
+	mc     metric.Collector
+	now    func() time.Time
+
+	mutex     sync.Mutex
+	scheduled map[interface{}]time.Time
+}
+
+// NewCoalescer creates a Coalescer that delays reconciles by window. A zero window disables coalescing.
+func NewCoalescer(window time.Duration, mc metric.Collector) *Coalescer {
+	return &Coalescer{
+		window:    window,
+		mc:        mc,
+		now:       time.Now,
+		scheduled: make(map[interface{}]time.Time),
+	}
+}
+
+// Wrap returns an EventHandler that enqueues the reconciles of h through the Coalescer.
+func (c *Coalescer) Wrap(h handler.EventHandler) handler.EventHandler {
+	if c.window <= 0 {
+		return h
+	}
+	return &coalescingEventHandler{handler: h, coalescer: c}
+}
+
+// schedule tells whether item needs to be enqueued, or is folded into a reconcile that is already scheduled.
+func (c *Coalescer) schedule(item interface{}) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now := c.now()
+	if deadline, ok := c.scheduled[item]; ok && now.Before(deadline) {
+		c.mc.IncCoalescedEvents()
+		return false
+	}
+	for key, deadline := range c.scheduled {
+		if !now.Before(deadline) {
+			delete(c.scheduled, key)
+		}
+	}
+	c.scheduled[item] = now.Add(c.window)
+	return true
+}
+
+type coalescingEventHandler struct {
+	handler   handler.EventHandler
+	coalescer *Coalescer
+}
+
+func (h *coalescingEventHandler) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	h.handler.Create(e, h.wrapQueue(queue))
+}
+
+func (h *coalescingEventHandler) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	h.handler.Update(e, h.wrapQueue(queue))
+}
+
+func (h *coalescingEventHandler) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	h.handler.Delete(e, h.wrapQueue(queue))
+}
+
+func (h *coalescingEventHandler) Generic(e event.GenericEvent, queue workqueue.RateLimitingInterface) {
+	h.handler.Generic(e, h.wrapQueue(queue))
+}
+
+func (h *coalescingEventHandler) wrapQueue(queue workqueue.RateLimitingInterface) workqueue.RateLimitingInterface {
+	return &coalescingQueue{RateLimitingInterface: queue, coalescer: h.coalescer}
+}
+
+// coalescingQueue delays the items added to it by the window of its Coalescer.
+type coalescingQueue struct {
+	workqueue.RateLimitingInterface
+	coalescer *Coalescer
+}
+
+func (q *coalescingQueue) Add(item interface{}) {
+	if q.coalescer.schedule(item) {
+		q.RateLimitingInterface.AddAfter(item, q.coalescer.window)
+	}
+}
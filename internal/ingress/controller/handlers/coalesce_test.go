@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type countingCollector struct {
+	metric.DummyCollector
+	coalescedEvents int
+}
+
+func (c *countingCollector) IncCoalescedEvents() {
+	c.coalescedEvents++
+}
+
+func ingressCreateEvent(name string) event.CreateEvent {
+	ing := &extensions.Ingress{ObjectMeta: v1.ObjectMeta{Namespace: "ns", Name: name}}
+	return event.CreateEvent{Meta: ing, Object: ing}
+}
+
+func TestCoalescer(t *testing.T) {
+	now := time.Now()
+	mc := &countingCollector{}
+	coalescer := NewCoalescer(20*time.Millisecond, mc)
+	coalescer.now = func() time.Time { return now }
+	h := coalescer.Wrap(&EnqueueRequestsForIngressEvent{})
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+
+	// a burst of events for the same ingress is folded into a single reconcile, after the window.
+	h.Create(ingressCreateEvent("a"), queue)
+	h.Create(ingressCreateEvent("a"), queue)
+	h.Create(ingressCreateEvent("b"), queue)
+	h.Create(ingressCreateEvent("a"), queue)
+	assert.Equal(t, 0, queue.Len())
+	assert.Equal(t, 2, mc.coalescedEvents)
+
+	item, _ := queue.Get()
+	queue.Done(item)
+	other, _ := queue.Get()
+	queue.Done(other)
+	assert.ElementsMatch(t, []interface{}{
+		reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "a"}},
+		reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "b"}},
+	}, []interface{}{item, other})
+
+	// events after the window schedule a new reconcile.
+	now = now.Add(20 * time.Millisecond)
+	h.Create(ingressCreateEvent("a"), queue)
+	assert.Equal(t, 2, mc.coalescedEvents)
+	item, _ = queue.Get()
+	queue.Done(item)
+	assert.Equal(t, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "a"}}, item)
+}
+
+func TestCoalescer_disabled(t *testing.T) {
+	h := &EnqueueRequestsForIngressEvent{}
+	assert.Equal(t, h, NewCoalescer(0, metric.DummyCollector{}).Wrap(h))
+}
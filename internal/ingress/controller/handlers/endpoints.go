@@ -1,18 +1,16 @@
 package handlers
 
 import (
-	"context"
 	"reflect"
 
 	"github.com/golang/glog"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	ingressbackend "github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
 	corev1 "k8s.io/api/core/v1"
-	extensions "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -22,7 +20,9 @@ var _ handler.EventHandler = (*EnqueueRequestsForEndpointsEvent)(nil)
 
 type EnqueueRequestsForEndpointsEvent struct {
 	IngressClass string
-	Cache        cache.Cache
+	// CrossNamespaceBackends tells whether ingresses may route to services of other namespaces
+	CrossNamespaceBackends bool
+	Cache                  cache.Cache
 }
 
 // Create is called in response to an create event - e.g. Pod Creation.
@@ -51,8 +51,8 @@ func (h *EnqueueRequestsForEndpointsEvent) Generic(event.GenericEvent, workqueue
 
 //TODO: this can be further optimized to only reconcile the target group referenced by the endpoints(service) :D
 func (h *EnqueueRequestsForEndpointsEvent) enqueueImpactedIngresses(endpoints *corev1.Endpoints, queue workqueue.RateLimitingInterface) {
-	ingressList := &extensions.IngressList{}
-	if err := h.Cache.List(context.Background(), client.InNamespace(endpoints.Namespace), ingressList); err != nil {
+	ingressList, err := listIngressesRoutingTo(h.Cache, endpoints.Namespace, h.CrossNamespaceBackends)
+	if err != nil {
 		glog.Errorf("failed to fetch impacted ingresses by endpoints due to %v", err)
 		return
 	}
@@ -75,7 +75,7 @@ func (h *EnqueueRequestsForEndpointsEvent) enqueueImpactedIngresses(endpoints *c
 		}
 
 		for _, backend := range backends {
-			if backend.ServiceName == endpoints.Name {
+			if backend.ServiceName == endpoints.Name && ingressbackend.ServiceNamespace(&ingress, backend.ServiceName) == endpoints.Namespace {
 				queue.Add(reconcile.Request{
 					NamespacedName: types.NamespacedName{
 						Namespace: ingress.Namespace,
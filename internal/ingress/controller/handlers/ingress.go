@@ -1,7 +1,14 @@
 package handlers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
 	extensions "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
@@ -10,10 +17,18 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+const annotationIngressClass = "kubernetes.io/ingress.class"
+
+// statusAnnotations are the annotations the controller writes on ingresses itself, to report their status.
+var statusAnnotations = []string{"status", "status.group", "status.iam-role-arn", "status.load-balancer-arn"}
+
 var _ handler.EventHandler = (*EnqueueRequestsForIngressEvent)(nil)
 
 type EnqueueRequestsForIngressEvent struct {
 	IngressClass string
+
+	// MetricCollector counts the updates skipped for leaving the desired state of their ingress unchanged, optional.
+	MetricCollector metric.Collector
 }
 
 // Create is called in response to an create event - e.g. Pod Creation.
@@ -22,9 +37,20 @@ func (h *EnqueueRequestsForIngressEvent) Create(e event.CreateEvent, queue workq
 }
 
 // Update is called in response to an update event -  e.g. Pod Updated.
+// Updates that leave the desired state of the ingress unchanged, e.g. edits of its labels or of unrelated annotations,
+// and the status updates of the controller itself, aren't reconciled. Periodic resyncs, which replay the ingress
+// unchanged, still are.
 func (h *EnqueueRequestsForIngressEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
-	h.enqueueIfIngressClassMatched(e.ObjectOld.(*extensions.Ingress), queue)
-	h.enqueueIfIngressClassMatched(e.ObjectNew.(*extensions.Ingress), queue)
+	ingOld := e.ObjectOld.(*extensions.Ingress)
+	ingNew := e.ObjectNew.(*extensions.Ingress)
+	if ingOld.ResourceVersion != ingNew.ResourceVersion && desiredStateHash(ingOld) == desiredStateHash(ingNew) {
+		if h.MetricCollector != nil && class.IsValidIngress(h.IngressClass, ingNew) {
+			h.MetricCollector.IncSkippedReconciles()
+		}
+		return
+	}
+	h.enqueueIfIngressClassMatched(ingOld, queue)
+	h.enqueueIfIngressClassMatched(ingNew, queue)
 }
 
 // Delete is called in response to a delete event - e.g. Pod Deleted.
@@ -49,3 +75,24 @@ func (h *EnqueueRequestsForIngressEvent) enqueueIfIngressClassMatched(ingress *e
 		},
 	})
 }
+
+// desiredStateHash hashes the parts of ingress its desired state is computed from: its spec, its ingress class and the
+// annotations of the controller, bar the status annotations the controller writes itself.
+func desiredStateHash(ingress *extensions.Ingress) string {
+	annotations := make(map[string]string)
+	for key, value := range ingress.Annotations {
+		if key == annotationIngressClass || strings.HasPrefix(key, parser.AnnotationsPrefix+"/") {
+			annotations[key] = value
+		}
+	}
+	for _, suffix := range statusAnnotations {
+		delete(annotations, parser.GetAnnotationWithPrefix(suffix))
+	}
+	// maps are marshaled with sorted keys, so equal states always hash the same.
+	b, _ := json.Marshal(struct {
+		Spec        extensions.IngressSpec `json:"spec"`
+		Annotations map[string]string      `json:"annotations"`
+	}{ingress.Spec, annotations})
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+type skipCountingCollector struct {
+	countingCollector
+	skippedReconciles int
+}
+
+func (c *skipCountingCollector) IncSkippedReconciles() {
+	c.skippedReconciles++
+}
+
+func TestEnqueueRequestsForIngressEvent_Update(t *testing.T) {
+	base := &extensions.Ingress{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace:       "ns",
+			Name:            "ing",
+			ResourceVersion: "1",
+			Annotations: map[string]string{
+				"kubernetes.io/ingress.class":       "alb",
+				"alb.ingress.kubernetes.io/scheme":  "internal",
+				"alb.ingress.kubernetes.io/status":  "Provisioning",
+				"deployment.kubernetes.io/revision": "1",
+			},
+		},
+		Spec: extensions.IngressSpec{
+			Backend: &extensions.IngressBackend{ServiceName: "echoserver", ServicePort: intstr.FromInt(80)},
+		},
+	}
+	for _, tc := range []struct {
+		Name            string
+		Update          func(ing *extensions.Ingress)
+		ExpectedEnqueue bool
+	}{
+		{
+			Name: "resync",
+			Update: func(ing *extensions.Ingress) {
+				ing.ResourceVersion = "1"
+			},
+			ExpectedEnqueue: true,
+		},
+		{
+			Name: "label",
+			Update: func(ing *extensions.Ingress) {
+				ing.Labels = map[string]string{"team": "checkout"}
+			},
+		},
+		{
+			Name: "unrelated annotation",
+			Update: func(ing *extensions.Ingress) {
+				ing.Annotations["deployment.kubernetes.io/revision"] = "2"
+			},
+		},
+		{
+			Name: "status annotation",
+			Update: func(ing *extensions.Ingress) {
+				ing.Annotations["alb.ingress.kubernetes.io/status"] = "Ready"
+				ing.Annotations["alb.ingress.kubernetes.io/status.load-balancer-arn"] = "arn"
+			},
+		},
+		{
+			Name: "controller annotation",
+			Update: func(ing *extensions.Ingress) {
+				ing.Annotations["alb.ingress.kubernetes.io/scheme"] = "internet-facing"
+			},
+			ExpectedEnqueue: true,
+		},
+		{
+			Name: "adopted LoadBalancer",
+			Update: func(ing *extensions.Ingress) {
+				ing.Annotations["alb.ingress.kubernetes.io/load-balancer-arn"] = "arn"
+			},
+			ExpectedEnqueue: true,
+		},
+		{
+			Name: "ingress class",
+			Update: func(ing *extensions.Ingress) {
+				delete(ing.Annotations, "kubernetes.io/ingress.class")
+			},
+			ExpectedEnqueue: true,
+		},
+		{
+			Name: "spec",
+			Update: func(ing *extensions.Ingress) {
+				ing.Spec.Backend.ServicePort = intstr.FromInt(8080)
+			},
+			ExpectedEnqueue: true,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			updated := base.DeepCopy()
+			updated.ResourceVersion = "2"
+			tc.Update(updated)
+			mc := &skipCountingCollector{}
+			h := &EnqueueRequestsForIngressEvent{MetricCollector: mc}
+			queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			defer queue.ShutDown()
+
+			h.Update(event.UpdateEvent{MetaOld: base, ObjectOld: base, MetaNew: updated, ObjectNew: updated}, queue)
+			if tc.ExpectedEnqueue {
+				assert.Equal(t, 1, queue.Len())
+				assert.Equal(t, 0, mc.skippedReconciles)
+			} else {
+				assert.Equal(t, 0, queue.Len())
+				assert.Equal(t, 1, mc.skippedReconciles)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"context"
+
+	extensions "k8s.io/api/extensions/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// listIngressesRoutingTo lists the ingresses whose backends may route to services of namespace: the ingresses of namespace,
+// or the ingresses of every namespace when crossNamespaceBackends allows some ingresses to route to other namespaces.
+func listIngressesRoutingTo(c cache.Cache, namespace string, crossNamespaceBackends bool) (*extensions.IngressList, error) {
+	opts := client.InNamespace(namespace)
+	if crossNamespaceBackends {
+		opts = &client.ListOptions{}
+	}
+	ingressList := &extensions.IngressList{}
+	if err := c.List(context.Background(), opts, ingressList); err != nil {
+		return nil, err
+	}
+	return ingressList, nil
+}
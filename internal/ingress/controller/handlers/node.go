@@ -5,6 +5,7 @@ import (
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/golang/glog"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
@@ -21,6 +22,9 @@ var _ handler.EventHandler = (*EnqueueRequestsForNodeEvent)(nil)
 
 type EnqueueRequestsForNodeEvent struct {
 	IngressClass string
+	NodeSelector labels.Selector
+	// CheckNodeReadiness makes NotReady and unschedulable nodes unsuitable as targets
+	CheckNodeReadiness bool
 
 	Cache cache.Cache
 }
@@ -28,7 +32,7 @@ type EnqueueRequestsForNodeEvent struct {
 // Create is called in response to an create event - e.g. Pod Creation.
 func (h *EnqueueRequestsForNodeEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
 	node := e.Object.(*corev1.Node)
-	if backend.IsNodeSuitableAsTrafficProxy(node) {
+	if backend.IsNodeSuitableAsTarget(node, h.NodeSelector, h.CheckNodeReadiness) {
 		h.enqueueImpactedIngresses(queue)
 	}
 }
@@ -36,7 +40,7 @@ func (h *EnqueueRequestsForNodeEvent) Create(e event.CreateEvent, queue workqueu
 // Delete is called in response to a delete event - e.g. Pod Deleted.
 func (h *EnqueueRequestsForNodeEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
 	node := e.Object.(*corev1.Node)
-	if backend.IsNodeSuitableAsTrafficProxy(node) {
+	if backend.IsNodeSuitableAsTarget(node, h.NodeSelector, h.CheckNodeReadiness) {
 		h.enqueueImpactedIngresses(queue)
 	}
 }
@@ -45,7 +49,7 @@ func (h *EnqueueRequestsForNodeEvent) Delete(e event.DeleteEvent, queue workqueu
 func (h *EnqueueRequestsForNodeEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
 	nodeOld := e.ObjectOld.(*corev1.Node)
 	nodeNew := e.ObjectNew.(*corev1.Node)
-	if backend.IsNodeSuitableAsTrafficProxy(nodeOld) != backend.IsNodeSuitableAsTrafficProxy(nodeNew) {
+	if backend.IsNodeSuitableAsTarget(nodeOld, h.NodeSelector, h.CheckNodeReadiness) != backend.IsNodeSuitableAsTarget(nodeNew, h.NodeSelector, h.CheckNodeReadiness) {
 		h.enqueueImpactedIngresses(queue)
 	}
 }
@@ -8,11 +8,9 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
 	corev1 "k8s.io/api/core/v1"
-	extensions "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -22,7 +20,9 @@ var _ handler.EventHandler = (*EnqueueRequestsForPodsEvent)(nil)
 
 type EnqueueRequestsForPodsEvent struct {
 	IngressClass string
-	Cache        cache.Cache
+	// CrossNamespaceBackends tells whether ingresses may route to services of other namespaces
+	CrossNamespaceBackends bool
+	Cache                  cache.Cache
 }
 
 // Create is called in response to an create event - e.g. Pod Creation.
@@ -52,8 +52,8 @@ func (h *EnqueueRequestsForPodsEvent) Generic(event.GenericEvent, workqueue.Rate
 }
 
 func (h *EnqueueRequestsForPodsEvent) enqueueImpactedIngresses(pod *corev1.Pod, queue workqueue.RateLimitingInterface) {
-	ingressList := &extensions.IngressList{}
-	if err := h.Cache.List(context.Background(), client.InNamespace(pod.Namespace), ingressList); err != nil {
+	ingressList, err := listIngressesRoutingTo(h.Cache, pod.Namespace, h.CrossNamespaceBackends)
+	if err != nil {
 		glog.Errorf("failed to fetch ingresses impacted by pod %s due to %v", pod.GetName(), err)
 		return
 	}
@@ -80,15 +80,18 @@ func (h *EnqueueRequestsForPodsEvent) enqueueImpactedIngresses(pod *corev1.Pod,
 			break
 		}
 
-		for _, backend := range backends {
+		for _, ingressBackend := range backends {
+			if backend.ServiceNamespace(&ingress, ingressBackend.ServiceName) != pod.Namespace {
+				continue
+			}
 			endpoint := &corev1.Endpoints{}
 			nspname := types.NamespacedName{
-				Namespace: ingress.Namespace,
-				Name:      backend.ServiceName,
+				Namespace: pod.Namespace,
+				Name:      ingressBackend.ServiceName,
 			}
 			if err = h.Cache.Get(context.Background(), nspname, endpoint); err != nil {
 				glog.Errorf("failed to fetch enpoint %s backing ingress %s/%s, ignoring",
-					backend.ServiceName, ingress.Namespace, ingress.Name)
+					ingressBackend.ServiceName, ingress.Namespace, ingress.Name)
 				continue
 			}
 
@@ -1,16 +1,13 @@
 package handlers
 
 import (
-	"context"
-
 	"github.com/golang/glog"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
 	corev1 "k8s.io/api/core/v1"
-	extensions "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -20,6 +17,8 @@ var _ handler.EventHandler = (*EnqueueRequestsForServiceEvent)(nil)
 
 type EnqueueRequestsForServiceEvent struct {
 	IngressClass string
+	// CrossNamespaceBackends tells whether ingresses may route to services of other namespaces
+	CrossNamespaceBackends bool
 
 	Cache cache.Cache
 }
@@ -48,8 +47,8 @@ func (h *EnqueueRequestsForServiceEvent) Generic(e event.GenericEvent, queue wor
 
 //TODO: this can be further optimized to only included ingresses referenced this service :D
 func (h *EnqueueRequestsForServiceEvent) enqueueImpactedIngresses(service *corev1.Service, queue workqueue.RateLimitingInterface) {
-	ingressList := &extensions.IngressList{}
-	if err := h.Cache.List(context.Background(), client.InNamespace(service.Namespace), ingressList); err != nil {
+	ingressList, err := listIngressesRoutingTo(h.Cache, service.Namespace, h.CrossNamespaceBackends)
+	if err != nil {
 		glog.Errorf("failed to fetch impacted ingresses by service due to %v", err)
 		return
 	}
@@ -57,6 +56,9 @@ func (h *EnqueueRequestsForServiceEvent) enqueueImpactedIngresses(service *corev
 		if !class.IsValidIngress(h.IngressClass, &ingress) {
 			continue
 		}
+		if ingress.Namespace != service.Namespace && backend.ServiceNamespace(&ingress, service.Name) != service.Namespace {
+			continue
+		}
 		queue.Add(reconcile.Request{
 			NamespacedName: types.NamespacedName{
 				Namespace: ingress.Namespace,
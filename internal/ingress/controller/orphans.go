@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/generator"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// OrphanCleanup returns a manager.Runnable that looks for LoadBalancers of the cluster whose ingress, or ingress group,
+// no longer exists, e.g. because it was deleted while the controller was down, and reconciles them so they get deleted.
+// Only LoadBalancers of ingresses in namespace are considered, or of all namespaces if namespace is empty.
+func (r *Reconciler) OrphanCleanup(namespace string) manager.Runnable {
+	return manager.RunnableFunc(func(stop <-chan struct{}) error {
+		if !r.cache.WaitForCacheSync(stop) {
+			return nil
+		}
+		keys, err := r.findOrphanedLoadBalancers(context.Background(), namespace)
+		if err != nil {
+			// not fatal, the LoadBalancers are looked for again on the next start.
+			glog.Errorf("failed to look for orphaned LoadBalancers due to %v", err)
+			return nil
+		}
+		for _, key := range keys {
+			glog.Infof("ingress %v no longer exists, deleting its LoadBalancer", key)
+			meta := metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}
+			select {
+			case r.orphans <- event.GenericEvent{Meta: &meta, Object: &extensions.Ingress{ObjectMeta: meta}}:
+			case <-stop:
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+// findOrphanedLoadBalancers returns the keys of the ingresses and ingress groups in namespace that no longer exist,
+// but still have a LoadBalancer tagged as owned by the controller.
+// LoadBalancers are only found in the account of the controller, not in those of the IAM roles ingresses may assume.
+func (r *Reconciler) findOrphanedLoadBalancers(ctx context.Context, namespace string) ([]types.NamespacedName, error) {
+	stackKeys, err := r.listLoadBalancerStacks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []types.NamespacedName
+	for _, key := range stackKeys {
+		if namespace != "" && key.Namespace != namespace {
+			continue
+		}
+		exists, err := r.ingressKeyExists(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+	return keys, nil
+}
+
+// listLoadBalancerStacks returns the keys of the ingresses and ingress groups whose LoadBalancer is tagged as owned by
+// the controller, in the account of the controller.
+func (r *Reconciler) listLoadBalancerStacks(ctx context.Context) ([]types.NamespacedName, error) {
+	lbTags, err := r.cloud.GetResourceTagsByFilters(ctx, map[string][]string{
+		tags.KeyOwnership:            {r.store.GetConfig().OwnershipTagValue},
+		generator.V2TagKeyResourceID: {generator.V2ResourceIDLoadBalancer},
+	}, aws.ResourceTypeEnumELBLoadBalancer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LoadBalancers due to %v", err)
+	}
+
+	var keys []types.NamespacedName
+	for _, tags := range lbTags {
+		parts := strings.SplitN(tags[generator.V2TagKeyStackID], "/", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		keys = append(keys, types.NamespacedName{Namespace: parts[0], Name: parts[1]})
+	}
+	return keys, nil
+}
+
+// ingressKeyExists tells whether key is a live ingress, or an ingress group with at least one member.
+// Ingresses of any ingress class count, so that the LoadBalancers of other controllers in the cluster are left alone.
+func (r *Reconciler) ingressKeyExists(ctx context.Context, key types.NamespacedName) (bool, error) {
+	if strings.HasPrefix(key.Name, groupKeyPrefix) {
+		ingList := &extensions.IngressList{}
+		if err := r.cache.List(ctx, client.InNamespace(key.Namespace), ingList); err != nil {
+			return false, err
+		}
+		for i := range ingList.Items {
+			if groupKey, ok := groupKeyOf(&ingList.Items[i]); ok && groupKey == key {
+				return true, nil
+			}
+		}
+	}
+	if err := r.cache.Get(ctx, key, &extensions.Ingress{}); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
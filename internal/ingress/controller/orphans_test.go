@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/generator"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func lbTagsOfStack(stack string) map[string]string {
+	return map[string]string{
+		tags.KeyOwnership:            "owner",
+		generator.V2TagKeyResourceID: generator.V2ResourceIDLoadBalancer,
+		generator.V2TagKeyStackID:    stack,
+	}
+}
+
+func TestReconciler_findOrphanedLoadBalancers(t *testing.T) {
+	cfg := config.NewConfiguration()
+	cfg.ClusterName = "cluster"
+	cfg.OwnershipTagValue = "owner"
+	tagFilters := map[string][]string{
+		tags.KeyOwnership:            {"owner"},
+		generator.V2TagKeyResourceID: {generator.V2ResourceIDLoadBalancer},
+	}
+
+	for _, tc := range []struct {
+		name          string
+		namespace     string
+		lbTags        map[string]map[string]string
+		lbTagsErr     error
+		expectedKeys  []types.NamespacedName
+		expectedError error
+	}{
+		{
+			name:      "LoadBalancers without a live ingress or group member are orphaned",
+			namespace: "",
+			lbTags: map[string]map[string]string{
+				"arn-live":        lbTagsOfStack("ns/live"),
+				"arn-deleted":     lbTagsOfStack("ns/deleted"),
+				"arn-other-class": lbTagsOfStack("ns/other-class"),
+				"arn-group":       lbTagsOfStack("ns/group.shared"),
+				"arn-empty-group": lbTagsOfStack("ns/group.empty"),
+				"arn-other-ns":    lbTagsOfStack("other/deleted"),
+				"arn-untagged":    {},
+			},
+			expectedKeys: []types.NamespacedName{
+				{Namespace: "ns", Name: "deleted"},
+				{Namespace: "ns", Name: "group.empty"},
+				{Namespace: "other", Name: "deleted"},
+			},
+		},
+		{
+			name:      "LoadBalancers outside the watched namespace are left alone",
+			namespace: "ns",
+			lbTags: map[string]map[string]string{
+				"arn-deleted":  lbTagsOfStack("ns/deleted"),
+				"arn-other-ns": lbTagsOfStack("other/deleted"),
+			},
+			expectedKeys: []types.NamespacedName{
+				{Namespace: "ns", Name: "deleted"},
+			},
+		},
+		{
+			name:          "failing to get LoadBalancers is reported",
+			lbTagsErr:     errors.New("AccessDenied"),
+			expectedError: errors.New("failed to get LoadBalancers due to AccessDenied"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetResourceTagsByFilters", ctx, tagFilters, aws.ResourceTypeEnumELBLoadBalancer).Return(tc.lbTags, tc.lbTagsErr)
+			r := &Reconciler{
+				cache: staticCache{Reader: fake.NewFakeClient(
+					ingressWithRule("live", nil, ""),
+					ingressWithRule("other-class", map[string]string{"kubernetes.io/ingress.class": "nginx"}, ""),
+					ingressWithRule("member", map[string]string{"alb.ingress.kubernetes.io/group.name": "shared"}, ""),
+				)},
+				store: store.NewStatic(&cfg),
+				cloud: cloud,
+			}
+
+			keys, err := r.findOrphanedLoadBalancers(ctx, tc.namespace)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedKeys, keys)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
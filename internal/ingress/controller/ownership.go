@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/glog"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/generator"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// maxAddTagsARNs is how many resources a single AddTags call can tag.
+const maxAddTagsARNs = 20
+
+// OwnershipTagMigration returns a manager.Runnable that adds the ownership tag to the resources of the cluster created
+// before it was introduced. Until then, their LoadBalancers and targetGroups are still found by name by the reconciles
+// of their ingresses, which tag them too, but neither orphan cleanup nor targetGroup garbage collection find them.
+func (r *Reconciler) OwnershipTagMigration() manager.Runnable {
+	return manager.RunnableFunc(func(stop <-chan struct{}) error {
+		if err := MigrateOwnershipTags(context.Background(), r.cloud, r.store.GetConfig()); err != nil {
+			// not fatal, the migration is retried on the next start.
+			glog.Errorf("failed to migrate to the ownership tag due to %v", err)
+		}
+		return nil
+	})
+}
+
+// MigrateOwnershipTags adds the ownership tag to the LoadBalancers and targetGroups tagged for the cluster that don't
+// have one yet, e.g. created by releases before it was introduced, so that they're discovered by it from then on.
+// Resources already tagged as owned by another controller are left alone.
+func MigrateOwnershipTags(ctx context.Context, cloud aws.CloudAPI, cfg *config.Configuration) error {
+	resTags, err := cloud.GetResourceTagsByFilters(ctx, map[string][]string{
+		generator.V2TagKeyClusterID: {cfg.ClusterName},
+	}, aws.ResourceTypeEnumELBLoadBalancer, aws.ResourceTypeEnumELBTargetGroup)
+	if err != nil {
+		return fmt.Errorf("failed to get resources of cluster %v due to %v", cfg.ClusterName, err)
+	}
+	var arns []string
+	for arn, curTags := range resTags {
+		if _, ok := curTags[tags.KeyOwnership]; !ok {
+			arns = append(arns, arn)
+		}
+	}
+	sort.Strings(arns)
+	for start := 0; start < len(arns); start += maxAddTagsARNs {
+		end := start + maxAddTagsARNs
+		if end > len(arns) {
+			end = len(arns)
+		}
+		batch := arns[start:end]
+		if _, err := cloud.AddELBV2TagsWithContext(ctx, &elbv2.AddTagsInput{
+			ResourceArns: aws.StringSlice(batch),
+			Tags:         tags.ConvertToELBV2(map[string]string{tags.KeyOwnership: cfg.OwnershipTagValue}),
+		}); err != nil {
+			return fmt.Errorf("failed to add the ownership tag to %v due to %v", batch, err)
+		}
+	}
+	if len(arns) > 0 {
+		glog.Infof("added the %v=%v ownership tag to %d resources", tags.KeyOwnership, cfg.OwnershipTagValue, len(arns))
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/generator"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateOwnershipTags(t *testing.T) {
+	cfg := &config.Configuration{ClusterName: "cluster", OwnershipTagValue: "owner"}
+	tagFilters := map[string][]string{generator.V2TagKeyClusterID: {"cluster"}}
+	ownershipTags := tags.ConvertToELBV2(map[string]string{tags.KeyOwnership: "owner"})
+
+	manyResTags := make(map[string]map[string]string)
+	var firstBatch, secondBatch []string
+	for i := 0; i < 25; i++ {
+		arn := fmt.Sprintf("arn-%02d", i)
+		manyResTags[arn] = map[string]string{generator.V2TagKeyClusterID: "cluster"}
+		if i < 20 {
+			firstBatch = append(firstBatch, arn)
+		} else {
+			secondBatch = append(secondBatch, arn)
+		}
+	}
+
+	for _, tc := range []struct {
+		Name          string
+		ResTags       map[string]map[string]string
+		ResTagsErr    error
+		ExpectedAdds  [][]string
+		AddErr        error
+		ExpectedError error
+	}{
+		{
+			Name: "resources without the ownership tag are tagged",
+			ResTags: map[string]map[string]string{
+				"lb-arn":       {generator.V2TagKeyClusterID: "cluster"},
+				"tg-arn":       {generator.V2TagKeyClusterID: "cluster"},
+				"migrated-arn": {generator.V2TagKeyClusterID: "cluster", tags.KeyOwnership: "owner"},
+				"foreign-arn":  {generator.V2TagKeyClusterID: "cluster", tags.KeyOwnership: "other-owner"},
+			},
+			ExpectedAdds: [][]string{{"lb-arn", "tg-arn"}},
+		},
+		{
+			Name: "already migrated",
+			ResTags: map[string]map[string]string{
+				"migrated-arn": {generator.V2TagKeyClusterID: "cluster", tags.KeyOwnership: "owner"},
+			},
+		},
+		{
+			Name:         "resources are tagged in batches",
+			ResTags:      manyResTags,
+			ExpectedAdds: [][]string{firstBatch, secondBatch},
+		},
+		{
+			Name:          "failing to get resources",
+			ResTagsErr:    errors.New("AccessDenied"),
+			ExpectedError: errors.New("failed to get resources of cluster cluster due to AccessDenied"),
+		},
+		{
+			Name: "failing to tag resources",
+			ResTags: map[string]map[string]string{
+				"lb-arn": {generator.V2TagKeyClusterID: "cluster"},
+			},
+			ExpectedAdds:  [][]string{{"lb-arn"}},
+			AddErr:        errors.New("AccessDenied"),
+			ExpectedError: errors.New("failed to add the ownership tag to [lb-arn] due to AccessDenied"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetResourceTagsByFilters", ctx, tagFilters, aws.ResourceTypeEnumELBLoadBalancer, aws.ResourceTypeEnumELBTargetGroup).Return(tc.ResTags, tc.ResTagsErr)
+			for _, arns := range tc.ExpectedAdds {
+				cloud.On("AddELBV2TagsWithContext", ctx, &elbv2.AddTagsInput{
+					ResourceArns: aws.StringSlice(arns),
+					Tags:         ownershipTags,
+				}).Return(&elbv2.AddTagsOutput{}, tc.AddErr)
+			}
+
+			err := MigrateOwnershipTags(ctx, cloud, cfg)
+			assert.Equal(t, tc.ExpectedError, err)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
@@ -2,22 +2,53 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+const (
+	// AnnotationStatus is set by the controller to the provisioning status of the LoadBalancer of an ingress.
+	AnnotationStatus = "status"
+	// AnnotationStatusLoadBalancerARN is set by the controller to the ARN of the LoadBalancer of an ingress once it's ready.
+	AnnotationStatusLoadBalancerARN = "status.load-balancer-arn"
+
+	// IngressStatusProvisioning means the LoadBalancer of an ingress is being created for the first time,
+	// or that too few of its targets are healthy yet for --min-healthy-percent.
+	IngressStatusProvisioning = "Provisioning"
+	// IngressStatusReady means the latest reconcile of an ingress succeeded.
+	IngressStatusReady = "Ready"
+	// IngressStatusError means the latest reconcile of an ingress failed, its events tell why.
+	IngressStatusError = "Error"
+
+	// EventReasonAWSQuotaExceeded is the reason of the events warning that a reconcile failed since an AWS service quota was reached.
+	EventReasonAWSQuotaExceeded = "AWSQuotaExceeded"
+	// EventReasonReconcileTimeout is the reason of the events warning that a reconcile was cancelled for running longer than --reconcile-timeout.
+	EventReasonReconcileTimeout = "ReconcileTimeout"
+
+	// targetHealthPollInterval is how often target health is checked while too few of the targets of an ingress are healthy for --min-healthy-percent.
+	targetHealthPollInterval = 30 * time.Second
+)
+
 // Reconciler reconciles an single ingress object
 type Reconciler struct {
 	client   client.Client
@@ -27,61 +58,443 @@ type Reconciler struct {
 	// TODO: move things out of store, and start to rely on functionality provided by client & cache
 	store store.Storer
 
+	cloud        aws.CloudAPI
 	lbController lb.Controller
 
 	metricCollector metric.Collector
+
+	// inflight tracks reconciles that are still running, so shutdown can wait for them to finish
+	inflight inflightReconciles
+
+	// states tracks the latest reconcile outcome of each ingress
+	states ingressStates
+
+	// groups tracks the ingress group membership of each ingress
+	groups ingressGroups
+
+	// roles tracks the IAM role the LoadBalancer of each ingress or ingress group is managed with
+	roles ingressRoles
+
+	// targetHealth tracks the targetGroups of each ingress or ingress group to expose the health of their targets
+	targetHealth targetHealth
+
+	// minHealthyPercent is the percentage of targets that must be healthy before the status of an ingress is Ready, disabled if 0
+	minHealthyPercent int
+
+	// awaiting tracks the LoadBalancers whose ingresses await healthy targets to be Ready
+	awaiting awaitingTargets
+
+	// dryRun tells that mutating AWS calls are skipped, reported on /state
+	dryRun bool
+
+	// resources tracks the AWS resources of each ingress or ingress group to expose how many are managed
+	resources managedResources
+
+	// status tracks when a reconcile last succeeded
+	status reconcileStatus
+
+	// breaker pauses reconciles after repeated failures
+	breaker circuitBreaker
+
+	// retries tracks the backoff of each ingress whose reconciles failed
+	retries ingressRetries
+
+	// reconciling serializes reconciles of the same ingress, whether scheduled or forced
+	reconciling ingressLocks
+
+	// leader tracks whether this replica is the leader, forced reconciles are only run by the leader
+	leader leadership
+
+	// orphans receives the ingresses whose LoadBalancer outlived them, to be reconciled into deletion
+	orphans chan event.GenericEvent
 }
 
 // Reconcile will reconcile the aws resources with k8s state of ingress.
+// Failed reconciles are requeued after the backoff of their ingress, rather than by the workqueue's rate limiter.
 func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	result, err := r.reconcile(request)
+	if _, paused := err.(*pausedError); paused {
+		return result, nil
+	}
+	if err != nil && result.RequeueAfter > 0 {
+		log.New(request.NamespacedName.String()).Errorf("reconcile failed due to %v, retrying in %v", err, result.RequeueAfter)
+		return result, nil
+	}
+	return result, err
+}
+
+// reconcile reconciles the ingress of request, returning the backoff of the ingress along with the error of a failed reconcile.
+// While the circuit breaker pauses reconciles, it returns a pausedError along with when to try again.
+func (r *Reconciler) reconcile(request reconcile.Request) (reconcile.Result, error) {
+	if cfg := r.store.GetConfig(); cfg.DefaultsConfigMap != "" {
+		defaults := cfg.Defaults()
+		r.breaker.configure(defaults.CircuitBreakerThreshold, defaults.CircuitBreakerCooldown)
+	}
+	if ok, wait := r.breaker.allow(time.Now()); !ok {
+		return reconcile.Result{RequeueAfter: wait}, &pausedError{wait: wait}
+	}
+	if !r.inflight.start() {
+		log.New(request.NamespacedName.String()).Infof("skipping reconcile, the controller is shutting down")
+		return reconcile.Result{}, nil
+	}
+	defer r.inflight.done()
+	unlock := r.reconciling.lock(request.NamespacedName)
+	defer unlock()
+	r.metricCollector.IncActiveReconciles()
+	defer r.metricCollector.DecActiveReconciles()
+
 	ctx := context.Background()
+	timeout := r.store.GetConfig().ReconcileTimeout
+	if timeout > 0 {
+		// cancelling ctx cancels the pending AWS calls of the reconcile, so a stuck reconcile frees its worker.
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	defer func() {
+		r.metricCollector.ObserveReconcileLatency(request.Namespace, request.Name, time.Since(start))
+	}()
+
 	ingress := &extensions.Ingress{}
 	if err := r.cache.Get(ctx, request.NamespacedName, ingress); err != nil {
 		if !errors.IsNotFound(err) {
+			if ctx.Err() == context.DeadlineExceeded {
+				err = r.timedOut(request.NamespacedName, timeout, err)
+			}
 			r.metricCollector.IncReconcileErrorCount(request.NamespacedName.String())
-			return reconcile.Result{}, err
+			return reconcile.Result{RequeueAfter: r.retries.failed(request.NamespacedName)}, err
 		}
 
 		if err := r.deleteIngress(ctx, request.NamespacedName); err != nil {
+			if aws.DryRun(err) {
+				return r.stoppedForDryRun(request.NamespacedName, err), nil
+			}
+			if ctx.Err() == context.DeadlineExceeded {
+				err = r.timedOut(request.NamespacedName, timeout, err)
+			}
 			r.metricCollector.IncReconcileErrorCount(request.NamespacedName.String())
-			return reconcile.Result{}, err
+			r.states.record(request.NamespacedName, err)
+			r.breaker.record(err, time.Now())
+			return reconcile.Result{RequeueAfter: r.retries.failed(request.NamespacedName)}, err
 		}
 
+		r.states.forget(request.NamespacedName)
+		r.retries.forget(request.NamespacedName)
+		r.breaker.record(nil, time.Now())
+		r.status.succeeded(time.Now())
 		r.metricCollector.IncReconcileCount()
 		return reconcile.Result{}, nil
 	}
 
 	if err := r.reconcileIngress(ctx, request.NamespacedName, ingress); err != nil {
+		if aws.DryRun(err) {
+			return r.stoppedForDryRun(request.NamespacedName, err), nil
+		}
+		if ctx.Err() == context.DeadlineExceeded {
+			err = r.timedOut(request.NamespacedName, timeout, err)
+			r.recorder.Eventf(ingress, corev1.EventTypeWarning, EventReasonReconcileTimeout, "%v", err)
+		} else if resource, ok := aws.QuotaExceeded(err); ok {
+			r.recorder.Eventf(ingress, corev1.EventTypeWarning, EventReasonAWSQuotaExceeded,
+				"AWS quota exceeded for %v, request a quota increase: %v", resource.Description, err)
+		}
+		// ctx may have timed out, the status is updated regardless.
+		if statusErr := r.updateStatusAnnotations(context.Background(), ingress, IngressStatusError, ""); statusErr != nil {
+			log.New(request.NamespacedName.String()).Errorf("failed to update status annotations due to %v", statusErr)
+		}
 		r.metricCollector.IncReconcileErrorCount(request.NamespacedName.String())
-		return reconcile.Result{}, err
+		r.states.record(request.NamespacedName, err)
+		r.breaker.record(err, time.Now())
+		return reconcile.Result{RequeueAfter: r.retries.failed(request.NamespacedName)}, err
 	}
 
+	r.states.record(request.NamespacedName, nil)
+	r.retries.forget(request.NamespacedName)
+	r.breaker.record(nil, time.Now())
+	r.status.succeeded(time.Now())
 	r.metricCollector.IncReconcileCount()
 	return reconcile.Result{}, nil
 }
 
+// stoppedForDryRun ends the reconcile of ingressKey that stopped at the first change it would have made in dry-run mode.
+// Since every reconcile with pending changes stops there, it isn't counted as a failure, nor retried.
+func (r *Reconciler) stoppedForDryRun(ingressKey types.NamespacedName, err error) reconcile.Result {
+	log.New(ingressKey.String()).Infof("reconcile stopped at the first change in dry-run mode: %v", err)
+	r.states.record(ingressKey, nil)
+	r.retries.forget(ingressKey)
+	return reconcile.Result{}
+}
+
+// timedOut counts the reconcile of ingressKey cancelled by --reconcile-timeout, returning its err as a timeout.
+func (r *Reconciler) timedOut(ingressKey types.NamespacedName, timeout time.Duration, err error) error {
+	r.metricCollector.IncReconcileTimeouts(ingressKey.String())
+	return fmt.Errorf("reconcile timed out after %v: %v", timeout, err)
+}
+
+// inflightReconciles tracks running reconciles. Once draining, it refuses to start new ones, since a reconcile starting
+// while drain waits for the others could outlive it.
+type inflightReconciles struct {
+	mutex    sync.Mutex
+	draining bool
+	running  sync.WaitGroup
+}
+
+// start records a starting reconcile, returning false if draining already began.
+func (f *inflightReconciles) start() bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.draining {
+		return false
+	}
+	f.running.Add(1)
+	return true
+}
+
+// done records the end of a reconcile start let run.
+func (f *inflightReconciles) done() {
+	f.running.Done()
+}
+
+// drain refuses new reconciles and blocks until the running ones are done.
+func (f *inflightReconciles) drain() {
+	f.mutex.Lock()
+	f.draining = true
+	f.mutex.Unlock()
+	f.running.Wait()
+}
+
+// WaitForInflightReconciles refuses new reconciles and blocks until every running reconcile has returned, or until
+// timeout elapses. It returns false if the timeout elapsed before all reconciles finished.
+func (r *Reconciler) WaitForInflightReconciles(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		r.inflight.drain()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// reconcileIngress reconciles the LoadBalancer of ingress. If its status awaits enough healthy targets to be Ready,
+// TargetHealthPoll marks it Ready later on.
 func (r *Reconciler) reconcileIngress(ctx context.Context, ingressKey types.NamespacedName, ingress *extensions.Ingress) error {
 	ctx = r.buildReconcileContext(ctx, ingressKey, ingress)
-	lbInfo, err := r.lbController.Reconcile(ctx, ingress)
+	groupKey, inGroup := groupKeyOf(ingress)
+	prevGroupKey, wasInGroup := r.previousGroupKeyOf(ingressKey, ingress)
+	if wasInGroup && (!inGroup || prevGroupKey != groupKey) {
+		r.groups.leave(ingressKey)
+		if err := r.reconcileGroup(ctx, prevGroupKey); err != nil {
+			return fmt.Errorf("failed to reconcile ingress group %v due to %v", prevGroupKey, err)
+		}
+		if !inGroup {
+			if err := r.updateControllerAnnotation(ctx, ingress, AnnotationStatusGroup, ""); err != nil {
+				return err
+			}
+		}
+	}
+
+	if inGroup {
+		if !wasInGroup {
+			// the ingress may have owned a LoadBalancer of its own before it joined the group.
+			if err := r.deleteLoadBalancer(ctx, ingressKey, ingress); err != nil {
+				return err
+			}
+		}
+		if err := r.updateControllerAnnotation(ctx, ingress, AnnotationStatusGroup, strings.TrimPrefix(groupKey.Name, groupKeyPrefix)); err != nil {
+			return err
+		}
+		r.groups.join(ingressKey, groupKey)
+		return r.reconcileGroup(ctx, groupKey)
+	}
+
+	roleARN, err := iamRoleARNOf(ingress, r.store.GetConfig())
 	if err != nil {
 		return err
 	}
-	if err := r.updateIngressStatus(ctx, ingress, lbInfo); err != nil {
+	if err := r.switchRole(ctx, ingressKey, roleARN, ingress); err != nil {
 		return err
 	}
-
+	if len(ingress.Status.LoadBalancer.Ingress) == 0 {
+		if err := r.updateStatusAnnotations(ctx, ingress, IngressStatusProvisioning, ""); err != nil {
+			return err
+		}
+	}
+	lbInfo, err := r.lbController.Reconcile(albctx.SetIAMRoleARN(ctx, roleARN), ingress)
+	if err != nil {
+		return err
+	}
+	r.targetHealth.track(ingressKey, roleARN, lbInfo.TargetGroupARNs)
+	r.resources.track(ingressKey, lbInfo)
+	ready, err := r.checkTargetHealth(ctx, roleARN, lbInfo.TargetGroupARNs, ingressKey)
+	if err != nil {
+		return err
+	}
+	awaitingTargets := awaitsHealthyTargets(ingress, ready)
+	if err := r.updateIngressStatus(ctx, ingress, lbInfo, awaitingTargets); err != nil {
+		return err
+	}
+	if awaitingTargets {
+		r.awaiting.track(ingressKey, awaitingLoadBalancer{roleARN: roleARN, lbArn: lbInfo.Arn, tgArns: lbInfo.TargetGroupARNs, ingressKeys: []types.NamespacedName{ingressKey}})
+	} else {
+		r.awaiting.forget(ingressKey)
+	}
 	return nil
 }
 
+// deleteIngress cleans up after the ingress of ingressKey once it is gone, deleting its LoadBalancer or dropping its rules
+// from the LoadBalancer of its ingress group. ingressKey may also be an ingress group whose LoadBalancer outlived it.
 func (r *Reconciler) deleteIngress(ctx context.Context, ingressKey types.NamespacedName) error {
 	ctx = r.buildReconcileContext(ctx, ingressKey, nil)
-	if err := r.lbController.Delete(ctx, ingressKey); err != nil {
+	if strings.HasPrefix(ingressKey.Name, groupKeyPrefix) {
+		return r.reconcileGroup(ctx, ingressKey)
+	}
+	if groupKey, ok := r.groups.groupOf(ingressKey); ok {
+		r.groups.leave(ingressKey)
+		return r.reconcileGroup(ctx, groupKey)
+	}
+
+	if err := r.deleteLoadBalancer(ctx, ingressKey); err != nil {
+		return err
+	}
+	// the ingress may still have been part of a group it was reconciled into before the controller restarted,
+	// so each group of its namespace is reconciled to drop its rules.
+	groupKeys, err := r.listGroupKeys(ctx, ingressKey.Namespace)
+	if err != nil {
+		return err
+	}
+	for _, groupKey := range groupKeys {
+		if err := r.reconcileGroup(ctx, groupKey); err != nil {
+			return fmt.Errorf("failed to reconcile ingress group %v due to %v", groupKey, err)
+		}
+	}
+	return nil
+}
+
+// deleteLoadBalancer deletes the LoadBalancer of an ingress or ingress group, using the IAM roles it may have been
+// managed with according to rolesOf.
+func (r *Reconciler) deleteLoadBalancer(ctx context.Context, key types.NamespacedName, members ...*extensions.Ingress) error {
+	for _, roleARN := range r.rolesOf(key, members...) {
+		if err := r.lbController.Delete(albctx.SetIAMRoleARN(ctx, roleARN), key); err != nil {
+			return err
+		}
+	}
+	r.roles.forget(key)
+	r.targetHealth.forget(key)
+	r.awaiting.forget(key)
+	r.resources.forget(key)
+	return nil
+}
+
+// reconcileGroup reconciles the shared LoadBalancer of an ingress group with its current members,
+// deleting the LoadBalancer once the group has no members left. Members whose status awaits enough healthy targets to be
+// Ready are marked Ready later on by TargetHealthPoll. If members set an annotation applying to the whole group to
+// different values, the group isn't reconciled and each of them gets a warning event instead.
+func (r *Reconciler) reconcileGroup(ctx context.Context, groupKey types.NamespacedName) error {
+	unlock := r.groups.lock(groupKey)
+	defer unlock()
+
+	members, err := r.listGroupMembers(ctx, groupKey)
+	if err != nil {
+		return err
+	}
+	if len(members) == 0 {
+		if err := r.deleteLoadBalancer(ctx, groupKey); err != nil {
+			return err
+		}
+		r.store.DeleteIngressAnnotations(&extensions.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Namespace: groupKey.Namespace, Name: groupKey.Name},
+		})
+		return nil
+	}
+
+	conflicts, err := groupAnnotationConflicts(members)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		// reconciling the group with the annotations of either member would silently override the other.
+		for _, conflict := range conflicts {
+			for _, ingress := range conflict.members {
+				r.recorder.Eventf(ingress, corev1.EventTypeWarning, EventReasonGroupAnnotationConflict,
+					"ingress group %v isn't reconciled until its ingresses agree: %v", strings.TrimPrefix(groupKey.Name, groupKeyPrefix), conflict)
+			}
+		}
+		return fmt.Errorf("ingresses of the group disagree on annotations applying to the whole group: %v", conflicts)
+	}
+	merged, sources := mergeGroupMembers(groupKey, members)
+	roleARN, err := iamRoleARNOf(merged, r.store.GetConfig())
+	if err != nil {
+		return err
+	}
+	memberIngresses := make([]*extensions.Ingress, 0, len(members))
+	for _, member := range members {
+		memberIngresses = append(memberIngresses, member.ingress)
+	}
+	if err := r.switchRole(ctx, groupKey, roleARN, memberIngresses...); err != nil {
+		return err
+	}
+	r.store.UpdateIngressAnnotations(merged)
+	lbInfo, err := r.lbController.Reconcile(albctx.SetRuleSources(albctx.SetIAMRoleARN(ctx, roleARN), sources), merged)
+	if err != nil {
+		return err
+	}
+	r.targetHealth.track(groupKey, roleARN, lbInfo.TargetGroupARNs)
+	r.resources.track(groupKey, lbInfo)
+	memberKeys := make([]types.NamespacedName, 0, len(members))
+	for _, member := range members {
+		memberKeys = append(memberKeys, types.NamespacedName{Namespace: member.ingress.Namespace, Name: member.ingress.Name})
+	}
+	ready, err := r.checkTargetHealth(ctx, roleARN, lbInfo.TargetGroupARNs, memberKeys...)
+	if err != nil {
 		return err
 	}
+	var awaitingKeys []types.NamespacedName
+	for _, member := range members {
+		awaitingTargets := awaitsHealthyTargets(member.ingress, ready)
+		if err := r.updateIngressStatus(ctx, member.ingress, lbInfo, awaitingTargets); err != nil {
+			return err
+		}
+		if awaitingTargets {
+			awaitingKeys = append(awaitingKeys, types.NamespacedName{Namespace: member.ingress.Namespace, Name: member.ingress.Name})
+		}
+	}
+	if len(awaitingKeys) > 0 {
+		r.awaiting.track(groupKey, awaitingLoadBalancer{roleARN: roleARN, lbArn: lbInfo.Arn, tgArns: lbInfo.TargetGroupARNs, ingressKeys: awaitingKeys})
+	} else {
+		r.awaiting.forget(groupKey)
+	}
 	return nil
 }
 
-func (r *Reconciler) updateIngressStatus(ctx context.Context, ingress *extensions.Ingress, lbInfo *lb.LoadBalancer) error {
+// checkTargetHealth returns whether at least minHealthyPercent of the targets of tgArns are healthy, recording the
+// percentage in the state of ingressKeys. It is always true when minHealthyPercent is 0, without describing target health.
+func (r *Reconciler) checkTargetHealth(ctx context.Context, roleARN string, tgArns []string, ingressKeys ...types.NamespacedName) (bool, error) {
+	if r.minHealthyPercent == 0 {
+		return true, nil
+	}
+	percent, err := r.targetHealth.healthyPercent(ctx, roleARN, tgArns)
+	if err != nil {
+		return false, err
+	}
+	for _, ingressKey := range ingressKeys {
+		r.states.recordHealthyPercent(ingressKey, percent)
+	}
+	return percent >= float64(r.minHealthyPercent), nil
+}
+
+// awaitsHealthyTargets returns whether the status of ingress is held back from Ready, ready being whether enough of its
+// targets are healthy. An ingress that is already Ready stays so, so that rollouts don't make it flap.
+func awaitsHealthyTargets(ingress *extensions.Ingress, ready bool) bool {
+	return !ready && ingress.Annotations[parser.GetAnnotationWithPrefix(AnnotationStatus)] != IngressStatusReady
+}
+
+// updateIngressStatus publishes the hostname of lbInfo in the status of ingress, and marks it Ready unless awaitingTargets.
+func (r *Reconciler) updateIngressStatus(ctx context.Context, ingress *extensions.Ingress, lbInfo *lb.LoadBalancer, awaitingTargets bool) error {
 	if len(ingress.Status.LoadBalancer.Ingress) != 1 ||
 		ingress.Status.LoadBalancer.Ingress[0].IP != "" ||
 		ingress.Status.LoadBalancer.Ingress[0].Hostname != lbInfo.DNSName {
@@ -90,8 +503,61 @@ func (r *Reconciler) updateIngressStatus(ctx context.Context, ingress *extension
 				Hostname: lbInfo.DNSName,
 			},
 		}
-		return r.client.Status().Update(ctx, ingress)
+		if err := r.client.Status().Update(ctx, ingress); err != nil {
+			return err
+		}
 	}
+	status := IngressStatusReady
+	if awaitingTargets {
+		status = IngressStatusProvisioning
+	}
+	return r.updateStatusAnnotations(ctx, ingress, status, lbInfo.Arn)
+}
+
+// updateStatusAnnotations reports the provisioning status of the LoadBalancer of ingress, and its ARN once known,
+// through annotations, since the ingress status has no room for either. Unchanged annotations aren't written again.
+func (r *Reconciler) updateStatusAnnotations(ctx context.Context, ingress *extensions.Ingress, status string, lbArn string) error {
+	statusKey := parser.GetAnnotationWithPrefix(AnnotationStatus)
+	lbArnKey := parser.GetAnnotationWithPrefix(AnnotationStatusLoadBalancerARN)
+	if ingress.Annotations[statusKey] == status && (lbArn == "" || ingress.Annotations[lbArnKey] == lbArn) {
+		return nil
+	}
+	updated := ingress.DeepCopy()
+	if updated.Annotations == nil {
+		updated.Annotations = make(map[string]string)
+	}
+	updated.Annotations[statusKey] = status
+	if lbArn != "" {
+		updated.Annotations[lbArnKey] = lbArn
+	}
+	if err := r.client.Update(ctx, updated); err != nil {
+		return err
+	}
+	// keep the resourceVersion of ingress current for later updates within this reconcile.
+	updated.DeepCopyInto(ingress)
+	return nil
+}
+
+// updateControllerAnnotation sets the annotation name, one the controller records its own state in, to value on ingress,
+// removing it if value is empty. Unchanged annotations aren't written again.
+func (r *Reconciler) updateControllerAnnotation(ctx context.Context, ingress *extensions.Ingress, name string, value string) error {
+	key := parser.GetAnnotationWithPrefix(name)
+	if ingress.Annotations[key] == value {
+		return nil
+	}
+	updated := ingress.DeepCopy()
+	if value == "" {
+		delete(updated.Annotations, key)
+	} else {
+		if updated.Annotations == nil {
+			updated.Annotations = make(map[string]string)
+		}
+		updated.Annotations[key] = value
+	}
+	if err := r.client.Update(ctx, updated); err != nil {
+		return err
+	}
+	updated.DeepCopyInto(ingress)
 	return nil
 }
 
@@ -101,6 +567,12 @@ func (r *Reconciler) buildReconcileContext(ctx context.Context, ingressKey types
 		ctx = albctx.SetEventf(ctx, func(eventType string, reason string, messageFmt string, args ...interface{}) {
 			r.recorder.Eventf(ingress, eventType, reason, messageFmt, args...)
 		})
+	} else {
+		// the ingress is already gone, so there is nothing to record events against.
+		logger := albctx.GetLogger(ctx)
+		ctx = albctx.SetEventf(ctx, func(eventType string, reason string, messageFmt string, args ...interface{}) {
+			logger.Infof("%v %v: %v", eventType, reason, fmt.Sprintf(messageFmt, args...))
+		})
 	}
 	return ctx
 }
@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconciler_WaitForInflightReconciles(t *testing.T) {
+	t.Run("returns immediately when nothing is in flight", func(t *testing.T) {
+		r := &Reconciler{}
+		assert.True(t, r.WaitForInflightReconciles(time.Second))
+	})
+
+	t.Run("waits for in-flight reconcile to finish", func(t *testing.T) {
+		r := &Reconciler{}
+		assert.True(t, r.inflight.start())
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			r.inflight.done()
+		}()
+		assert.True(t, r.WaitForInflightReconciles(time.Second))
+	})
+
+	t.Run("gives up once timeout elapses", func(t *testing.T) {
+		r := &Reconciler{}
+		assert.True(t, r.inflight.start())
+		defer r.inflight.done()
+		assert.False(t, r.WaitForInflightReconciles(10*time.Millisecond))
+	})
+
+	t.Run("refuses reconciles once draining", func(t *testing.T) {
+		r := &Reconciler{}
+		assert.True(t, r.WaitForInflightReconciles(time.Second))
+		assert.False(t, r.inflight.start())
+	})
+}
+
+func TestReconciler_updateStatusAnnotations(t *testing.T) {
+	for _, tc := range []struct {
+		Name                string
+		Annotations         map[string]string
+		Status              string
+		LBArn               string
+		ExpectedAnnotations map[string]string
+	}{
+		{
+			Name:   "provisioning ingress without annotations",
+			Status: IngressStatusProvisioning,
+			ExpectedAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/status": "Provisioning",
+			},
+		},
+		{
+			Name: "ready ingress records the LoadBalancer ARN",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/scheme": "internal",
+				"alb.ingress.kubernetes.io/status": "Provisioning",
+			},
+			Status: IngressStatusReady,
+			LBArn:  "lb-arn",
+			ExpectedAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/scheme":                   "internal",
+				"alb.ingress.kubernetes.io/status":                   "Ready",
+				"alb.ingress.kubernetes.io/status.load-balancer-arn": "lb-arn",
+			},
+		},
+		{
+			Name: "failed ingress keeps the LoadBalancer ARN",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/status":                   "Ready",
+				"alb.ingress.kubernetes.io/status.load-balancer-arn": "lb-arn",
+			},
+			Status: IngressStatusError,
+			ExpectedAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/status":                   "Error",
+				"alb.ingress.kubernetes.io/status.load-balancer-arn": "lb-arn",
+			},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ing", Annotations: tc.Annotations}}
+			r := &Reconciler{client: fake.NewFakeClient(ingress.DeepCopy())}
+
+			err := r.updateStatusAnnotations(ctx, ingress, tc.Status, tc.LBArn)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ExpectedAnnotations, ingress.Annotations)
+
+			stored := &extensions.Ingress{}
+			assert.NoError(t, r.client.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "ing"}, stored))
+			assert.Equal(t, tc.ExpectedAnnotations, stored.Annotations)
+		})
+	}
+}
+
+func TestReconciler_updateIngressStatus(t *testing.T) {
+	ctx := context.Background()
+	ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ing"}}
+	r := &Reconciler{client: fake.NewFakeClient(ingress.DeepCopy())}
+
+	err := r.updateIngressStatus(ctx, ingress, &lb.LoadBalancer{Arn: "lb-arn", DNSName: "lb.example.com"}, false)
+	assert.NoError(t, err)
+
+	stored := &extensions.Ingress{}
+	assert.NoError(t, r.client.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "ing"}, stored))
+	assert.Equal(t, []corev1.LoadBalancerIngress{{Hostname: "lb.example.com"}}, stored.Status.LoadBalancer.Ingress)
+	assert.Equal(t, map[string]string{
+		"alb.ingress.kubernetes.io/status":                   "Ready",
+		"alb.ingress.kubernetes.io/status.load-balancer-arn": "lb-arn",
+	}, stored.Annotations)
+}
+
+func Test_awaitsHealthyTargets(t *testing.T) {
+	provisioning := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"alb.ingress.kubernetes.io/status": "Provisioning"}}}
+	ready := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"alb.ingress.kubernetes.io/status": "Ready"}}}
+
+	assert.False(t, awaitsHealthyTargets(provisioning, true))
+	assert.True(t, awaitsHealthyTargets(provisioning, false))
+	assert.True(t, awaitsHealthyTargets(&extensions.Ingress{}, false))
+	assert.False(t, awaitsHealthyTargets(ready, false))
+}
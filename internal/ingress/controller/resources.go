@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"sync"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric/collectors"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var (
+	managedLoadBalancersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collectors.PrometheusNamespace, "", "managed_load_balancers"),
+		`Number of LoadBalancers managed by the controller`,
+		[]string{"cluster"}, nil)
+	managedTargetGroupsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collectors.PrometheusNamespace, "", "managed_target_groups"),
+		`Number of targetGroups managed by the controller`,
+		[]string{"cluster"}, nil)
+	managedListenersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collectors.PrometheusNamespace, "", "managed_listeners"),
+		`Number of listeners managed by the controller`,
+		[]string{"cluster"}, nil)
+	managedRulesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collectors.PrometheusNamespace, "", "managed_rules"),
+		`Number of listener rules managed by the controller, not counting default rules`,
+		[]string{"cluster"}, nil)
+)
+
+// resourceCounts is the number of AWS resources making up the LoadBalancer of an ingress or ingress group.
+type resourceCounts struct {
+	targetGroups int
+	listeners    int
+	rules        int
+}
+
+// managedResources tracks the AWS resources of each reconciled ingress or ingress group, to expose their totals
+// as prometheus gauges, e.g. to alert before the account quotas are reached.
+type managedResources struct {
+	mutex  sync.Mutex
+	counts map[types.NamespacedName]resourceCounts
+}
+
+// track records the resources of the LoadBalancer of ingressKey after a successful reconcile.
+func (m *managedResources) track(ingressKey types.NamespacedName, lbInfo *lb.LoadBalancer) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.counts == nil {
+		m.counts = make(map[types.NamespacedName]resourceCounts)
+	}
+	m.counts[ingressKey] = resourceCounts{
+		targetGroups: len(lbInfo.TargetGroupARNs),
+		listeners:    lbInfo.ListenerCount,
+		rules:        lbInfo.RuleCount,
+	}
+}
+
+// forget stops counting the resources of ingressKey once its LoadBalancer is deleted.
+func (m *managedResources) forget(ingressKey types.NamespacedName) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.counts, ingressKey)
+}
+
+// total returns the number of LoadBalancers tracked, and the sum of their resources.
+func (m *managedResources) total() (int, resourceCounts) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	var total resourceCounts
+	for _, counts := range m.counts {
+		total.targetGroups += counts.targetGroups
+		total.listeners += counts.listeners
+		total.rules += counts.rules
+	}
+	return len(m.counts), total
+}
+
+// managedResourcesCollector exposes the managedResources of a Reconciler, labeled by cluster name.
+type managedResourcesCollector struct {
+	resources   *managedResources
+	clusterName string
+}
+
+// Describe implements prometheus.Collector.
+func (c *managedResourcesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- managedLoadBalancersDesc
+	ch <- managedTargetGroupsDesc
+	ch <- managedListenersDesc
+	ch <- managedRulesDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *managedResourcesCollector) Collect(ch chan<- prometheus.Metric) {
+	loadBalancers, total := c.resources.total()
+	ch <- prometheus.MustNewConstMetric(managedLoadBalancersDesc, prometheus.GaugeValue, float64(loadBalancers), c.clusterName)
+	ch <- prometheus.MustNewConstMetric(managedTargetGroupsDesc, prometheus.GaugeValue, float64(total.targetGroups), c.clusterName)
+	ch <- prometheus.MustNewConstMetric(managedListenersDesc, prometheus.GaugeValue, float64(total.listeners), c.clusterName)
+	ch <- prometheus.MustNewConstMetric(managedRulesDesc, prometheus.GaugeValue, float64(total.rules), c.clusterName)
+}
+
+// ManagedResourcesCollector exposes the number of LoadBalancers, targetGroups, listeners and rules managed by r
+// as prometheus gauges. They're updated after each successful reconcile.
+func (r *Reconciler) ManagedResourcesCollector() prometheus.Collector {
+	return &managedResourcesCollector{resources: &r.resources, clusterName: r.store.GetConfig().ClusterName}
+}
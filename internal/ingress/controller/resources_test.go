@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestReconciler_ManagedResourcesCollector(t *testing.T) {
+	cfg := config.NewConfiguration()
+	cfg.ClusterName = "cluster"
+	r := &Reconciler{store: store.NewStatic(&cfg)}
+
+	r.resources.track(types.NamespacedName{Namespace: "ns", Name: "ing"}, &lb.LoadBalancer{
+		TargetGroupARNs: []string{"tg-1", "tg-2"},
+		ListenerCount:   2,
+		RuleCount:       6,
+	})
+	r.resources.track(types.NamespacedName{Namespace: "ns", Name: "group.shared"}, &lb.LoadBalancer{
+		TargetGroupARNs: []string{"tg-3"},
+		ListenerCount:   1,
+		RuleCount:       1,
+	})
+	r.resources.track(types.NamespacedName{Namespace: "ns", Name: "deleted"}, &lb.LoadBalancer{
+		TargetGroupARNs: []string{"tg-4"},
+		ListenerCount:   1,
+	})
+	// reconciling an ingress again replaces its counts.
+	r.resources.track(types.NamespacedName{Namespace: "ns", Name: "ing"}, &lb.LoadBalancer{
+		TargetGroupARNs: []string{"tg-1"},
+		ListenerCount:   2,
+		RuleCount:       4,
+	})
+	r.resources.forget(types.NamespacedName{Namespace: "ns", Name: "deleted"})
+
+	expected := `
+# HELP aws_alb_ingress_controller_managed_listeners Number of listeners managed by the controller
+# TYPE aws_alb_ingress_controller_managed_listeners gauge
+aws_alb_ingress_controller_managed_listeners{cluster="cluster"} 3
+# HELP aws_alb_ingress_controller_managed_load_balancers Number of LoadBalancers managed by the controller
+# TYPE aws_alb_ingress_controller_managed_load_balancers gauge
+aws_alb_ingress_controller_managed_load_balancers{cluster="cluster"} 2
+# HELP aws_alb_ingress_controller_managed_rules Number of listener rules managed by the controller, not counting default rules
+# TYPE aws_alb_ingress_controller_managed_rules gauge
+aws_alb_ingress_controller_managed_rules{cluster="cluster"} 5
+# HELP aws_alb_ingress_controller_managed_target_groups Number of targetGroups managed by the controller
+# TYPE aws_alb_ingress_controller_managed_target_groups gauge
+aws_alb_ingress_controller_managed_target_groups{cluster="cluster"} 2
+`
+	assert.NoError(t, testutil.CollectAndCompare(r.ManagedResourcesCollector(), strings.NewReader(expected)))
+}
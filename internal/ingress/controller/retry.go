@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ingressRetries tracks the reconciles that failed in a row for each ingress, so that a failed ingress is retried
+// after its own exponential backoff, independently of the other ingresses and of the sync period.
+type ingressRetries struct {
+	baseDelay time.Duration
+	maxDelay  time.Duration
+
+	mutex    sync.Mutex
+	failures map[types.NamespacedName]int
+}
+
+// failed records a failed reconcile of ingress and returns how long to wait before retrying it:
+// baseDelay after the first failure, doubling with each further failure up to maxDelay.
+func (r *ingressRetries) failed(ingress types.NamespacedName) time.Duration {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.failures == nil {
+		r.failures = make(map[types.NamespacedName]int)
+	}
+	r.failures[ingress]++
+	delay := r.baseDelay
+	for i := 1; i < r.failures[ingress] && delay < r.maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > r.maxDelay {
+		delay = r.maxDelay
+	}
+	return delay
+}
+
+// forget resets the backoff of ingress, once it reconciled successfully or no longer exists.
+func (r *ingressRetries) forget(ingress types.NamespacedName) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.failures, ingress)
+}
@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func Test_ingressRetries(t *testing.T) {
+	retries := &ingressRetries{baseDelay: time.Second, maxDelay: 5 * time.Second}
+	ing := types.NamespacedName{Namespace: "ns", Name: "ing"}
+	other := types.NamespacedName{Namespace: "ns", Name: "other"}
+
+	assert.Equal(t, time.Second, retries.failed(ing))
+	assert.Equal(t, 2*time.Second, retries.failed(ing))
+	assert.Equal(t, 4*time.Second, retries.failed(ing))
+	assert.Equal(t, 5*time.Second, retries.failed(ing))
+	assert.Equal(t, 5*time.Second, retries.failed(ing))
+
+	// each ingress has its own backoff.
+	assert.Equal(t, time.Second, retries.failed(other))
+
+	// a successful reconcile resets the backoff.
+	retries.forget(ing)
+	assert.Equal(t, time.Second, retries.failed(ing))
+}
+
+// unavailableReader fails every read, like an API server that can't be reached.
+type unavailableReader struct {
+	client.Reader
+}
+
+func (unavailableReader) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	return errors.New("unavailable")
+}
+
+func TestReconciler_Reconcile_retry(t *testing.T) {
+	cfg := config.NewConfiguration()
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "ing"}}
+	r := &Reconciler{
+		cache:           staticCache{Reader: unavailableReader{}},
+		store:           store.NewStatic(&cfg),
+		metricCollector: metric.DummyCollector{},
+		retries:         ingressRetries{baseDelay: time.Second, maxDelay: time.Minute},
+	}
+
+	result, err := r.Reconcile(request)
+	assert.NoError(t, err)
+	assert.Equal(t, reconcile.Result{RequeueAfter: time.Second}, result)
+	result, err = r.Reconcile(request)
+	assert.NoError(t, err)
+	assert.Equal(t, reconcile.Result{RequeueAfter: 2 * time.Second}, result)
+
+	// without a base delay, failed reconciles are left to the workqueue's rate limiter.
+	r.retries = ingressRetries{}
+	result, err = r.Reconcile(request)
+	assert.EqualError(t, err, "unavailable")
+	assert.Equal(t, reconcile.Result{}, result)
+}
+
+func TestReconciler_Reconcile_paused(t *testing.T) {
+	cfg := config.NewConfiguration()
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "ing"}}
+	r := &Reconciler{
+		cache:           staticCache{Reader: unavailableReader{}},
+		store:           store.NewStatic(&cfg),
+		metricCollector: metric.DummyCollector{},
+		breaker:         circuitBreaker{cooldown: time.Minute, openedAt: time.Now()},
+	}
+
+	// paused reconciles are requeued after the cooldown without failing.
+	result, err := r.reconcile(request)
+	assert.IsType(t, &pausedError{}, err)
+	assert.True(t, result.RequeueAfter > 0 && result.RequeueAfter <= time.Minute)
+	result, err = r.Reconcile(request)
+	assert.NoError(t, err)
+	assert.True(t, result.RequeueAfter > 0 && result.RequeueAfter <= time.Minute)
+}
+
+// dryRunLBController fails every deletion like a deletion skipped in dry-run mode.
+type dryRunLBController struct {
+	fakeLBController
+}
+
+func (c *dryRunLBController) Delete(ctx context.Context, ingressKey types.NamespacedName) error {
+	return fmt.Errorf("failed to delete LoadBalancer due to %v", awserr.New(aws.ErrCodeDryRun, "elasticloadbalancing/DeleteLoadBalancer skipped in dry-run mode", nil))
+}
+
+func TestReconciler_Reconcile_dryRun(t *testing.T) {
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "ing"}}
+	r, _ := newGroupTestReconciler(&dryRunLBController{}, nil)
+	r.metricCollector = metric.DummyCollector{}
+	r.retries = ingressRetries{baseDelay: time.Second, maxDelay: time.Minute}
+
+	// reconciles stopped by dry-run mode are neither failures nor retried.
+	result, err := r.Reconcile(request)
+	assert.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+	assert.Equal(t, []IngressState{{Namespace: "ns", Name: "ing"}}, r.states.list())
+	assert.Equal(t, 0, r.breaker.failures)
+}
+
+// stuckReader blocks reads until their context is done, like an API call that never returns.
+type stuckReader struct {
+	client.Reader
+}
+
+func (stuckReader) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestReconciler_Reconcile_timeout(t *testing.T) {
+	cfg := config.NewConfiguration()
+	cfg.ReconcileTimeout = 10 * time.Millisecond
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "ing"}}
+	r := &Reconciler{
+		cache:           staticCache{Reader: stuckReader{}},
+		store:           store.NewStatic(&cfg),
+		metricCollector: metric.DummyCollector{},
+	}
+
+	result, err := r.Reconcile(request)
+	assert.EqualError(t, err, "reconcile timed out after 10ms: context deadline exceeded")
+	assert.Equal(t, reconcile.Result{}, result)
+}
@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// AnnotationIAMRoleARN makes the controller assume an IAM role when managing the LoadBalancer of an ingress,
+	// so that it can live in another AWS account than the controller.
+	AnnotationIAMRoleARN = "iam-role-arn"
+	// AnnotationStatusIAMRoleARN is set by the controller to the IAM role the LoadBalancer of an ingress was last
+	// reconciled with, so that the LoadBalancer can still be found after a restart once the role changes.
+	// It's absent for LoadBalancers managed with the controller's own credentials.
+	AnnotationStatusIAMRoleARN = "status.iam-role-arn"
+)
+
+// ingressRoles tracks the IAM role each ingress or ingress group was last reconciled with,
+// so that its LoadBalancer can still be found once the ingress is deleted or its role changes.
+type ingressRoles struct {
+	mutex sync.Mutex
+	roles map[types.NamespacedName]string
+}
+
+// roleOf returns the IAM role key was last reconciled with, "" meaning the controller's own credentials.
+func (r *ingressRoles) roleOf(key types.NamespacedName) (string, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	roleARN, ok := r.roles[key]
+	return roleARN, ok
+}
+
+// set records that key was reconciled with roleARN.
+func (r *ingressRoles) set(key types.NamespacedName, roleARN string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.roles == nil {
+		r.roles = make(map[types.NamespacedName]string)
+	}
+	r.roles[key] = roleARN
+}
+
+// forget drops the role of key once its LoadBalancer is deleted.
+func (r *ingressRoles) forget(key types.NamespacedName) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.roles, key)
+}
+
+// iamRoleARNOf returns the IAM role ingress asks the controller to assume, or "" if it doesn't.
+// Only the roles of --allowed-iam-roles may be assumed.
+func iamRoleARNOf(ingress *extensions.Ingress, cfg *config.Configuration) (string, error) {
+	var roleARN string
+	if !annotations.LoadStringAnnotation(AnnotationIAMRoleARN, &roleARN, ingress.Annotations) || roleARN == "" {
+		return "", nil
+	}
+	parsed, err := arn.Parse(roleARN)
+	if err != nil || parsed.Service != "iam" || !strings.HasPrefix(parsed.Resource, "role/") {
+		return "", fmt.Errorf("invalid %v annotation %v, must be the ARN of an IAM role", AnnotationIAMRoleARN, roleARN)
+	}
+	if !cfg.IAMRoleAllowed(roleARN) {
+		return "", fmt.Errorf("IAM role %v of the %v annotation isn't allowed by --allowed-iam-roles", roleARN, AnnotationIAMRoleARN)
+	}
+	return roleARN, nil
+}
+
+// rolesOf returns the IAM roles the LoadBalancer of key may have been managed with, "" meaning the controller's own
+// credentials. That is the role the controller remembers, or otherwise the roles recorded on members by
+// AnnotationStatusIAMRoleARN. Without members, e.g. once the ingress is gone, every role the LoadBalancer may have
+// been managed with is returned.
+func (r *Reconciler) rolesOf(key types.NamespacedName, members ...*extensions.Ingress) []string {
+	if roleARN, ok := r.roles.roleOf(key); ok {
+		return []string{roleARN}
+	}
+	if len(members) == 0 {
+		return append([]string{""}, r.store.GetConfig().AllowedIAMRoles...)
+	}
+	roleKey := parser.GetAnnotationWithPrefix(AnnotationStatusIAMRoleARN)
+	var roleARNs []string
+	seen := make(map[string]bool)
+	for _, member := range members {
+		roleARN := member.Annotations[roleKey]
+		if !seen[roleARN] {
+			seen[roleARN] = true
+			roleARNs = append(roleARNs, roleARN)
+		}
+	}
+	return roleARNs
+}
+
+// switchRole records that the LoadBalancer of key is managed with roleARN, on the controller and on members.
+// The LoadBalancers of key managed with other roles are deleted first, since the LoadBalancer moves to the account of roleARN.
+func (r *Reconciler) switchRole(ctx context.Context, key types.NamespacedName, roleARN string, members ...*extensions.Ingress) error {
+	for _, prevRoleARN := range r.rolesOf(key, members...) {
+		if prevRoleARN == roleARN {
+			continue
+		}
+		if err := r.lbController.Delete(albctx.SetIAMRoleARN(ctx, prevRoleARN), key); err != nil {
+			return err
+		}
+	}
+	r.roles.set(key, roleARN)
+	for _, member := range members {
+		if err := r.updateControllerAnnotation(ctx, member, AnnotationStatusIAMRoleARN, roleARN); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_iamRoleARNOf(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		annotations     map[string]string
+		expectedRoleARN string
+		expectedErr     error
+	}{
+		{
+			name: "no role annotation",
+		},
+		{
+			name:            "role annotation",
+			annotations:     map[string]string{"alb.ingress.kubernetes.io/iam-role-arn": "arn:aws:iam::123456789012:role/alb"},
+			expectedRoleARN: "arn:aws:iam::123456789012:role/alb",
+		},
+		{
+			name:        "malformed ARN",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/iam-role-arn": "alb"},
+			expectedErr: errors.New("invalid iam-role-arn annotation alb, must be the ARN of an IAM role"),
+		},
+		{
+			name:        "role that isn't allowed",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/iam-role-arn": "arn:aws:iam::123456789012:role/other"},
+			expectedErr: errors.New("IAM role arn:aws:iam::123456789012:role/other of the iam-role-arn annotation isn't allowed by --allowed-iam-roles"),
+		},
+		{
+			name:        "ARN of another resource",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/iam-role-arn": "arn:aws:iam::123456789012:user/alb"},
+			expectedErr: errors.New("invalid iam-role-arn annotation arn:aws:iam::123456789012:user/alb, must be the ARN of an IAM role"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := config.NewConfiguration()
+			cfg.AllowedIAMRoles = []string{"arn:aws:iam::123456789012:role/alb"}
+			roleARN, err := iamRoleARNOf(&extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}, &cfg)
+			assert.Equal(t, tc.expectedRoleARN, roleARN)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func Test_ingressRoles(t *testing.T) {
+	r := ingressRoles{}
+	key := types.NamespacedName{Namespace: "ns", Name: "ing"}
+
+	_, ok := r.roleOf(key)
+	assert.False(t, ok)
+
+	r.set(key, "")
+	roleARN, ok := r.roleOf(key)
+	assert.True(t, ok)
+	assert.Equal(t, "", roleARN)
+
+	r.set(key, "arn:aws:iam::123456789012:role/alb")
+	roleARN, ok = r.roleOf(key)
+	assert.True(t, ok)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/alb", roleARN)
+
+	r.forget(key)
+	_, ok = r.roleOf(key)
+	assert.False(t, ok)
+}
+
+// roleRecordingLBController records the IAM role of each LoadBalancer it is asked to delete.
+type roleRecordingLBController struct {
+	fakeLBController
+	deletedWithRoles []string
+}
+
+func (c *roleRecordingLBController) Delete(ctx context.Context, ingressKey types.NamespacedName) error {
+	c.deletedWithRoles = append(c.deletedWithRoles, albctx.GetIAMRoleARN(ctx))
+	return c.fakeLBController.Delete(ctx, ingressKey)
+}
+
+func TestReconciler_switchRole(t *testing.T) {
+	const roleA = "arn:aws:iam::111111111111:role/alb"
+	const roleB = "arn:aws:iam::222222222222:role/alb"
+	key := types.NamespacedName{Namespace: "ns", Name: "ing"}
+
+	for _, tc := range []struct {
+		name                string
+		knownRole           *string
+		recordedRole        string
+		roleARN             string
+		expectedDeletedWith []string
+	}{
+		{
+			name:                "role remembered by the controller",
+			knownRole:           aws.String(roleA),
+			recordedRole:        roleB,
+			roleARN:             roleB,
+			expectedDeletedWith: []string{roleA},
+		},
+		{
+			name:                "role recorded on the ingress after a restart",
+			recordedRole:        roleA,
+			roleARN:             roleB,
+			expectedDeletedWith: []string{roleA},
+		},
+		{
+			name:                "no role recorded means the controller's own credentials",
+			roleARN:             roleB,
+			expectedDeletedWith: []string{""},
+		},
+		{
+			name:         "unchanged role",
+			recordedRole: roleA,
+			roleARN:      roleA,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			var annotations map[string]string
+			if tc.recordedRole != "" {
+				annotations = map[string]string{"alb.ingress.kubernetes.io/status.iam-role-arn": tc.recordedRole}
+			}
+			ingress := ingressWithRule("ing", annotations, "")
+			cfg := config.NewConfiguration()
+			lbController := &roleRecordingLBController{}
+			c := fake.NewFakeClient(ingress.DeepCopy())
+			assert.NoError(t, c.Get(ctx, key, ingress))
+			r := &Reconciler{client: c, store: store.NewStatic(&cfg), lbController: lbController}
+			if tc.knownRole != nil {
+				r.roles.set(key, *tc.knownRole)
+			}
+
+			assert.NoError(t, r.switchRole(ctx, key, tc.roleARN, ingress))
+			assert.Equal(t, tc.expectedDeletedWith, lbController.deletedWithRoles)
+			roleARN, _ := r.roles.roleOf(key)
+			assert.Equal(t, tc.roleARN, roleARN)
+
+			updated := &extensions.Ingress{}
+			assert.NoError(t, c.Get(ctx, key, updated))
+			assert.Equal(t, tc.roleARN, updated.Annotations["alb.ingress.kubernetes.io/status.iam-role-arn"])
+		})
+	}
+}
+
+func TestReconciler_deleteLoadBalancer_unknownRole(t *testing.T) {
+	cfg := config.NewConfiguration()
+	cfg.AllowedIAMRoles = []string{"arn:aws:iam::111111111111:role/alb"}
+	lbController := &roleRecordingLBController{}
+	r := &Reconciler{store: store.NewStatic(&cfg), lbController: lbController}
+
+	assert.NoError(t, r.deleteLoadBalancer(context.Background(), types.NamespacedName{Namespace: "ns", Name: "deleted"}))
+	assert.Equal(t, []string{"", "arn:aws:iam::111111111111:role/alb"}, lbController.deletedWithRoles)
+}
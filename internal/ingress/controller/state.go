@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// IngressState is the reconcile outcome of a single ingress.
+type IngressState struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	// ErrorCount is the number of failed reconciles since the controller started.
+	ErrorCount int `json:"errorCount"`
+
+	// LastError is the error of the latest reconcile, it is cleared once a reconcile succeeds.
+	LastError string `json:"lastError,omitempty"`
+
+	// HealthyTargetPercent is the percentage of healthy targets of the ingress as of its latest reconcile,
+	// only tracked when --min-healthy-percent is set.
+	HealthyTargetPercent *float64 `json:"healthyTargetPercent,omitempty"`
+
+	// DryRun tells that the controller runs in dry-run mode, so the changes the ingress needs were only logged.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// ingressStates tracks the reconcile outcome of each ingress, keyed by namespace/name.
+type ingressStates struct {
+	mutex  sync.RWMutex
+	states map[types.NamespacedName]*IngressState
+}
+
+// stateOf returns the state of ingress, creating it if needed. The mutex must be held.
+func (s *ingressStates) stateOf(ingress types.NamespacedName) *IngressState {
+	if s.states == nil {
+		s.states = make(map[types.NamespacedName]*IngressState)
+	}
+	state, ok := s.states[ingress]
+	if !ok {
+		state = &IngressState{Namespace: ingress.Namespace, Name: ingress.Name}
+		s.states[ingress] = state
+	}
+	return state
+}
+
+// record updates the state of ingress with the result of a reconcile.
+func (s *ingressStates) record(ingress types.NamespacedName, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	state := s.stateOf(ingress)
+	if err != nil {
+		state.ErrorCount++
+		state.LastError = err.Error()
+	} else {
+		state.LastError = ""
+	}
+}
+
+// recordHealthyPercent updates the percentage of healthy targets of ingress.
+func (s *ingressStates) recordHealthyPercent(ingress types.NamespacedName, percent float64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.stateOf(ingress).HealthyTargetPercent = &percent
+}
+
+// forget drops the state of an ingress that no longer exists.
+func (s *ingressStates) forget(ingress types.NamespacedName) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.states, ingress)
+}
+
+// list returns a copy of all states, sorted by namespace and name.
+func (s *ingressStates) list() []IngressState {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	result := make([]IngressState, 0, len(s.states))
+	for _, state := range s.states {
+		result = append(result, *state)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// StateHandler serves the reconcile state of every ingress seen by r as JSON.
+// The namespace and name query parameters restrict the output to matching ingresses.
+func (r *Reconciler) StateHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		namespace := req.URL.Query().Get("namespace")
+		name := req.URL.Query().Get("name")
+		states := make([]IngressState, 0)
+		for _, state := range r.states.list() {
+			if (namespace == "" || state.Namespace == namespace) && (name == "" || state.Name == name) {
+				state.DryRun = r.dryRun
+				states = append(states, state)
+			}
+		}
+		b, err := json.Marshal(states)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(b)
+	})
+}
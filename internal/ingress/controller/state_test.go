@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestReconciler_StateHandler(t *testing.T) {
+	ingA := types.NamespacedName{Namespace: "ns-a", Name: "ing"}
+	ingB := types.NamespacedName{Namespace: "ns-b", Name: "ing"}
+	ingC := types.NamespacedName{Namespace: "ns-c", Name: "ing"}
+
+	for _, tc := range []struct {
+		Name         string
+		Record       func(s *ingressStates)
+		ExpectedBody string
+	}{
+		{
+			Name:         "no ingress reconciled yet",
+			Record:       func(s *ingressStates) {},
+			ExpectedBody: `[]`,
+		},
+		{
+			Name: "failures are counted and the latest error is kept",
+			Record: func(s *ingressStates) {
+				s.record(ingB, errors.New("first"))
+				s.record(ingB, errors.New("second"))
+				s.record(ingA, nil)
+			},
+			ExpectedBody: `[{"namespace":"ns-a","name":"ing","errorCount":0},{"namespace":"ns-b","name":"ing","errorCount":2,"lastError":"second"}]`,
+		},
+		{
+			Name: "successful reconcile clears the error but keeps the count",
+			Record: func(s *ingressStates) {
+				s.record(ingA, errors.New("failed"))
+				s.record(ingA, nil)
+			},
+			ExpectedBody: `[{"namespace":"ns-a","name":"ing","errorCount":1}]`,
+		},
+		{
+			Name: "deleted ingresses are forgotten",
+			Record: func(s *ingressStates) {
+				s.record(ingA, nil)
+				s.record(ingC, errors.New("failed"))
+				s.forget(ingC)
+			},
+			ExpectedBody: `[{"namespace":"ns-a","name":"ing","errorCount":0}]`,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			r := &Reconciler{}
+			tc.Record(&r.states)
+
+			w := httptest.NewRecorder()
+			r.StateHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/state", nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			assert.Equal(t, tc.ExpectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestReconciler_StateHandler_DryRun(t *testing.T) {
+	r := &Reconciler{dryRun: true}
+	r.states.record(types.NamespacedName{Namespace: "ns", Name: "ing"}, nil)
+
+	w := httptest.NewRecorder()
+	r.StateHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/state", nil))
+	assert.Equal(t, `[{"namespace":"ns","name":"ing","errorCount":0,"dryRun":true}]`, w.Body.String())
+}
+
+func TestReconciler_StateHandler_Filters(t *testing.T) {
+	r := &Reconciler{}
+	r.states.record(types.NamespacedName{Namespace: "ns-a", Name: "ing-1"}, nil)
+	r.states.record(types.NamespacedName{Namespace: "ns-a", Name: "ing-2"}, errors.New("failed"))
+	r.states.record(types.NamespacedName{Namespace: "ns-b", Name: "ing-1"}, nil)
+
+	for _, tc := range []struct {
+		Name         string
+		Target       string
+		ExpectedBody string
+	}{
+		{
+			Name:         "filter by namespace",
+			Target:       "/state?namespace=ns-a",
+			ExpectedBody: `[{"namespace":"ns-a","name":"ing-1","errorCount":0},{"namespace":"ns-a","name":"ing-2","errorCount":1,"lastError":"failed"}]`,
+		},
+		{
+			Name:         "filter by name",
+			Target:       "/state?name=ing-1",
+			ExpectedBody: `[{"namespace":"ns-a","name":"ing-1","errorCount":0},{"namespace":"ns-b","name":"ing-1","errorCount":0}]`,
+		},
+		{
+			Name:         "filter by namespace and name",
+			Target:       "/state?namespace=ns-b&name=ing-1",
+			ExpectedBody: `[{"namespace":"ns-b","name":"ing-1","errorCount":0}]`,
+		},
+		{
+			Name:         "no match",
+			Target:       "/state?namespace=ns-c",
+			ExpectedBody: `[]`,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.StateHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.Target, nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tc.ExpectedBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric/collectors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ControllerStatus is the reconcile progress of the controller as a whole.
+type ControllerStatus struct {
+	// LastSuccessfulReconcile is when a reconcile last succeeded, nil if none did since the controller started.
+	LastSuccessfulReconcile *time.Time `json:"lastSuccessfulReconcile,omitempty"`
+
+	// LastSuccessfulReconcileAgeSeconds is the time since LastSuccessfulReconcile, or since the controller started if none succeeded.
+	LastSuccessfulReconcileAgeSeconds float64 `json:"lastSuccessfulReconcileAgeSeconds"`
+
+	// SyncPeriod is how often every ingress is reconciled even without changes.
+	SyncPeriod string `json:"syncPeriod"`
+
+	// CircuitBreaker is CircuitBreakerOpen while reconciles are paused after repeated failures, CircuitBreakerClosed otherwise.
+	CircuitBreaker string `json:"circuitBreaker"`
+}
+
+// reconcileStatus tracks when a reconcile last succeeded, so that a controller whose reconciles hang or keep failing
+// can be told apart from one that merely has AWS connectivity.
+type reconcileStatus struct {
+	mutex       sync.Mutex
+	started     time.Time
+	lastSuccess time.Time
+}
+
+// succeeded records a successful reconcile at now.
+func (s *reconcileStatus) succeeded(now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.lastSuccess = now
+}
+
+// lastSuccessAndAge returns when a reconcile last succeeded, zero if none did, and the time elapsed since then,
+// or since the controller started if none did.
+func (s *reconcileStatus) lastSuccessAndAge(now time.Time) (time.Time, time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.lastSuccess.IsZero() {
+		return s.lastSuccess, now.Sub(s.started)
+	}
+	return s.lastSuccess, now.Sub(s.lastSuccess)
+}
+
+// StatusHandler serves the ControllerStatus of r as JSON, syncPeriod being the period every ingress is reconciled at.
+func (r *Reconciler) StatusHandler(syncPeriod time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		lastSuccess, age := r.status.lastSuccessAndAge(time.Now())
+		status := ControllerStatus{
+			LastSuccessfulReconcileAgeSeconds: age.Seconds(),
+			SyncPeriod:                        syncPeriod.String(),
+			CircuitBreaker:                    r.breaker.state(),
+		}
+		if !lastSuccess.IsZero() {
+			status.LastSuccessfulReconcile = &lastSuccess
+		}
+		b, err := json.Marshal(status)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(b)
+	})
+}
+
+// ReconcileAgeCollector exposes the age of the last successful reconcile of r as a prometheus gauge,
+// to alert on a controller that stopped reconciling.
+func (r *Reconciler) ReconcileAgeCollector() prometheus.Collector {
+	return prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: collectors.PrometheusNamespace,
+		Name:      "last_successful_reconcile_age_seconds",
+		Help:      `Time since a reconcile last succeeded, or since the controller started if none did`,
+	}, func() float64 {
+		_, age := r.status.lastSuccessAndAge(time.Now())
+		return age.Seconds()
+	})
+}
@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconciler_StatusHandler(t *testing.T) {
+	started := time.Now().Add(-time.Hour)
+	lastSuccess := time.Now().Add(-time.Minute).Truncate(time.Second)
+	for _, tc := range []struct {
+		Name                    string
+		LastSuccess             time.Time
+		ExpectedLastSuccess     *time.Time
+		ExpectedMinAgeInSeconds float64
+	}{
+		{
+			Name:                    "no reconcile succeeded yet",
+			ExpectedMinAgeInSeconds: time.Hour.Seconds(),
+		},
+		{
+			Name:                    "reconcile succeeded",
+			LastSuccess:             lastSuccess,
+			ExpectedLastSuccess:     &lastSuccess,
+			ExpectedMinAgeInSeconds: time.Minute.Seconds(),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			r := &Reconciler{status: reconcileStatus{started: started}}
+			if !tc.LastSuccess.IsZero() {
+				r.status.succeeded(tc.LastSuccess)
+			}
+
+			w := httptest.NewRecorder()
+			r.StatusHandler(time.Hour).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/status", nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+			status := ControllerStatus{}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+			assert.Equal(t, "1h0m0s", status.SyncPeriod)
+			assert.Equal(t, CircuitBreakerClosed, status.CircuitBreaker)
+			if tc.ExpectedLastSuccess == nil {
+				assert.Nil(t, status.LastSuccessfulReconcile)
+			} else {
+				assert.True(t, tc.ExpectedLastSuccess.Equal(*status.LastSuccessfulReconcile))
+			}
+			assert.True(t, status.LastSuccessfulReconcileAgeSeconds >= tc.ExpectedMinAgeInSeconds)
+			assert.True(t, status.LastSuccessfulReconcileAgeSeconds < tc.ExpectedMinAgeInSeconds+60)
+
+			metric := &dto.Metric{}
+			assert.NoError(t, r.ReconcileAgeCollector().(prometheus.Metric).Write(metric))
+			assert.True(t, metric.GetGauge().GetValue() >= tc.ExpectedMinAgeInSeconds)
+		})
+	}
+}
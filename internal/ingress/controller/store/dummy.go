@@ -66,6 +66,14 @@ func (d Dummy) GetIngressAnnotations(key string) (*annotations.Ingress, error) {
 	return d.GetIngressAnnotationsResponse, nil
 }
 
+// UpdateIngressAnnotations ...
+func (d Dummy) UpdateIngressAnnotations(ing *extensions.Ingress) {
+}
+
+// DeleteIngressAnnotations ...
+func (d Dummy) DeleteIngressAnnotations(ing *extensions.Ingress) {
+}
+
 // Run ...
 func (d Dummy) Run(stopCh chan struct{}) {
 }
@@ -9,6 +9,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	v1 "k8s.io/api/core/v1"
+
+	v1beta1 "k8s.io/api/extensions/v1beta1"
 )
 
 // MockStorer is an autogenerated mock type for the Storer type
@@ -16,6 +18,11 @@ type MockStorer struct {
 	mock.Mock
 }
 
+// DeleteIngressAnnotations provides a mock function with given fields: ing
+func (_m *MockStorer) DeleteIngressAnnotations(ing *v1beta1.Ingress) {
+	_m.Called(ing)
+}
+
 // GetConfig provides a mock function with given fields:
 func (_m *MockStorer) GetConfig() *config.Configuration {
 	ret := _m.Called()
@@ -204,3 +211,8 @@ func (_m *MockStorer) ListNodes() []*v1.Node {
 
 	return r0
 }
+
+// UpdateIngressAnnotations provides a mock function with given fields: ing
+func (_m *MockStorer) UpdateIngressAnnotations(ing *v1beta1.Ingress) {
+	_m.Called(ing)
+}
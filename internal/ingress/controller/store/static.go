@@ -0,0 +1,61 @@
+package store
+
+import (
+	"sync"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+// NewStatic creates an object store holding a fixed set of objects instead of watching the API server,
+// e.g. to compute the desired state of ingresses read from manifest files.
+// Objects other than ingresses, services, endpoints, nodes and pods are ignored.
+func NewStatic(cfg *config.Configuration, objects ...runtime.Object) Storer {
+	store := &k8sStore{
+		informers: &Informer{},
+		listers:   &Lister{},
+		cfg:       cfg,
+		mu:        &sync.Mutex{},
+	}
+	store.ingannotations = annotations.NewIngressAnnotationExtractor(store)
+	store.svcannotations = annotations.NewServiceAnnotationExtractor(store)
+	store.listers.Ingress.Store = cache.NewStore(cache.DeletionHandlingMetaNamespaceKeyFunc)
+	store.listers.Service.Store = cache.NewStore(cache.DeletionHandlingMetaNamespaceKeyFunc)
+	store.listers.Endpoint.Store = cache.NewStore(cache.DeletionHandlingMetaNamespaceKeyFunc)
+	store.listers.Node.Store = cache.NewStore(cache.DeletionHandlingMetaNamespaceKeyFunc)
+	store.listers.Pod.Store = cache.NewStore(cache.DeletionHandlingMetaNamespaceKeyFunc)
+	store.listers.IngressAnnotation.Store = cache.NewStore(cache.DeletionHandlingMetaNamespaceKeyFunc)
+	store.listers.ServiceAnnotation.Store = cache.NewStore(cache.DeletionHandlingMetaNamespaceKeyFunc)
+
+	var ingresses []*extensions.Ingress
+	for _, obj := range objects {
+		switch o := obj.(type) {
+		case *extensions.Ingress:
+			_ = store.listers.Ingress.Add(o)
+			ingresses = append(ingresses, o)
+		case *corev1.Service:
+			_ = store.listers.Service.Add(o)
+		case *corev1.Endpoints:
+			_ = store.listers.Endpoint.Add(o)
+		case *corev1.Node:
+			_ = store.listers.Node.Add(o)
+		case *corev1.Pod:
+			_ = store.listers.Pod.Add(o)
+		}
+	}
+	// annotations are parsed once every object is in, since parsing them may look up pods and nodes.
+	for _, item := range store.listers.Service.List() {
+		store.extractServiceAnnotations(item.(*corev1.Service))
+	}
+	for _, ing := range ingresses {
+		if class.IsValidIngress(cfg.IngressClass, ing) {
+			store.extractIngressAnnotations(ing)
+		}
+	}
+	return store
+}
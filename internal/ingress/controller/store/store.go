@@ -54,6 +54,12 @@ type Storer interface {
 	// GetIngressAnnotations returns the parsed annotations of an Ingress matching key.
 	GetIngressAnnotations(key string) (*annotations.Ingress, error)
 
+	// UpdateIngressAnnotations parses and stores the annotations of an Ingress that is not backed by the informer, e.g. a merged ingress group.
+	UpdateIngressAnnotations(ing *extensions.Ingress)
+
+	// DeleteIngressAnnotations drops the annotations stored by UpdateIngressAnnotations for ing, e.g. once an ingress group is emptied.
+	DeleteIngressAnnotations(ing *extensions.Ingress)
+
 	// GetConfig returns the controller configuration
 	GetConfig() *config.Configuration
 
@@ -276,6 +282,16 @@ func (s k8sStore) GetIngressAnnotations(key string) (*annotations.Ingress, error
 	return ia, nil
 }
 
+// UpdateIngressAnnotations parses and stores the annotations of ing.
+func (s *k8sStore) UpdateIngressAnnotations(ing *extensions.Ingress) {
+	s.extractIngressAnnotations(ing)
+}
+
+// DeleteIngressAnnotations drops the annotations of ing.
+func (s *k8sStore) DeleteIngressAnnotations(ing *extensions.Ingress) {
+	_ = s.listers.IngressAnnotation.Delete(ing)
+}
+
 // GetServiceAnnotations returns the parsed annotations of an Service matching key.
 func (s k8sStore) GetServiceAnnotations(key string, ingress *annotations.Ingress) (*annotations.Service, error) {
 	sa, err := s.listers.ServiceAnnotation.ByKey(key)
@@ -0,0 +1,220 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/glog"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric/collectors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// maxConcurrentTargetHealthDescribes bounds how many targetGroups have their health described in parallel.
+const maxConcurrentTargetHealthDescribes = 10
+
+var (
+	targetGroupHealthyTargetsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collectors.PrometheusNamespace, "", "target_group_healthy_targets"),
+		`Number of healthy targets in a targetGroup managed by the controller`,
+		[]string{"ingress", "target_group"}, nil)
+	targetGroupUnhealthyTargetsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collectors.PrometheusNamespace, "", "target_group_unhealthy_targets"),
+		`Number of unhealthy targets in a targetGroup managed by the controller`,
+		[]string{"ingress", "target_group"}, nil)
+)
+
+// ingressTargetGroups are the targetGroups of an ingress or ingress group, and the IAM role they're managed with.
+type ingressTargetGroups struct {
+	roleARN string
+	arns    []string
+}
+
+// targetGroupHealth is the number of healthy and unhealthy targets of a targetGroup.
+// Targets in other states, like initial or draining, are in neither.
+type targetGroupHealth struct {
+	healthy   int
+	unhealthy int
+}
+
+// targetHealth tracks the targetGroups of each reconciled ingress, and exposes the health of their targets
+// as a prometheus collector. Health is fetched from AWS on scrape, at most once per syncPeriod.
+type targetHealth struct {
+	cloud      aws.CloudAPI
+	syncPeriod time.Duration
+
+	// syncMutex serializes syncs, so that concurrent scrapes don't fetch the same health twice.
+	syncMutex sync.Mutex
+	lastSync  time.Time
+
+	mutex        sync.Mutex
+	targetGroups map[types.NamespacedName]ingressTargetGroups
+	health       map[types.NamespacedName]map[string]targetGroupHealth
+}
+
+// track records the targetGroups of ingressKey after a successful reconcile.
+func (t *targetHealth) track(ingressKey types.NamespacedName, roleARN string, arns []string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.targetGroups == nil {
+		t.targetGroups = make(map[types.NamespacedName]ingressTargetGroups)
+	}
+	t.targetGroups[ingressKey] = ingressTargetGroups{roleARN: roleARN, arns: arns}
+}
+
+// forget stops exposing the health of ingressKey once its LoadBalancer is deleted.
+func (t *targetHealth) forget(ingressKey types.NamespacedName) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	delete(t.targetGroups, ingressKey)
+	delete(t.health, ingressKey)
+}
+
+// sync refreshes the health of all tracked targetGroups if it is older than syncPeriod.
+// A targetGroup whose health can't be fetched keeps its previous counts.
+func (t *targetHealth) sync(ctx context.Context) {
+	t.syncMutex.Lock()
+	defer t.syncMutex.Unlock()
+	if time.Since(t.lastSync) < t.syncPeriod {
+		return
+	}
+
+	t.mutex.Lock()
+	targetGroups := make(map[types.NamespacedName]ingressTargetGroups, len(t.targetGroups))
+	for ingressKey, tgs := range t.targetGroups {
+		targetGroups[ingressKey] = tgs
+	}
+	prevHealth := t.health
+	t.mutex.Unlock()
+
+	// DescribeTargetHealth takes a single targetGroup, so targetGroups are described in parallel instead.
+	type describeJob struct {
+		ingressKey types.NamespacedName
+		roleARN    string
+		tgArn      string
+	}
+	var jobs []describeJob
+	for ingressKey, tgs := range targetGroups {
+		for _, tgArn := range tgs.arns {
+			jobs = append(jobs, describeJob{ingressKey: ingressKey, roleARN: tgs.roleARN, tgArn: tgArn})
+		}
+	}
+	results := make([]*targetGroupHealth, len(jobs))
+	sem := make(chan struct{}, maxConcurrentTargetHealthDescribes)
+	var wg sync.WaitGroup
+	for i := range jobs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			job := jobs[i]
+			resp, err := t.cloud.DescribeTargetHealthWithContext(albctx.SetIAMRoleARN(ctx, job.roleARN), &elbv2.DescribeTargetHealthInput{
+				TargetGroupArn: aws.String(job.tgArn),
+			})
+			if err != nil {
+				glog.Errorf("failed to describe target health of %v due to %v", job.tgArn, err)
+				return
+			}
+			var tgHealth targetGroupHealth
+			for _, desc := range resp.TargetHealthDescriptions {
+				switch aws.StringValue(desc.TargetHealth.State) {
+				case elbv2.TargetHealthStateEnumHealthy:
+					tgHealth.healthy++
+				case elbv2.TargetHealthStateEnumUnhealthy:
+					tgHealth.unhealthy++
+				}
+			}
+			results[i] = &tgHealth
+		}(i)
+	}
+	wg.Wait()
+
+	health := make(map[types.NamespacedName]map[string]targetGroupHealth, len(targetGroups))
+	for ingressKey := range targetGroups {
+		health[ingressKey] = make(map[string]targetGroupHealth)
+	}
+	for i, job := range jobs {
+		if results[i] != nil {
+			health[job.ingressKey][job.tgArn] = *results[i]
+		} else if prev, ok := prevHealth[job.ingressKey][job.tgArn]; ok {
+			health[job.ingressKey][job.tgArn] = prev
+		}
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	for ingressKey := range health {
+		// drop ingresses forgotten while their health was fetched.
+		if _, ok := t.targetGroups[ingressKey]; !ok {
+			delete(health, ingressKey)
+		}
+	}
+	t.health = health
+	t.lastSync = time.Now()
+}
+
+// healthyPercent describes the health of the targets of tgArns, managed with roleARN, and returns the percentage of
+// them that are healthy. Targets in other states than healthy, like initial or draining, count as not healthy.
+// Without any target there is nothing to wait for, so the percentage is 100.
+func (t *targetHealth) healthyPercent(ctx context.Context, roleARN string, tgArns []string) (float64, error) {
+	var healthy, total int
+	for _, tgArn := range tgArns {
+		resp, err := t.cloud.DescribeTargetHealthWithContext(albctx.SetIAMRoleARN(ctx, roleARN), &elbv2.DescribeTargetHealthInput{
+			TargetGroupArn: aws.String(tgArn),
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to describe target health of %v due to %v", tgArn, err)
+		}
+		for _, desc := range resp.TargetHealthDescriptions {
+			total++
+			if aws.StringValue(desc.TargetHealth.State) == elbv2.TargetHealthStateEnumHealthy {
+				healthy++
+			}
+		}
+	}
+	if total == 0 {
+		return 100, nil
+	}
+	return float64(healthy) * 100 / float64(total), nil
+}
+
+// Describe implements prometheus.Collector.
+func (t *targetHealth) Describe(ch chan<- *prometheus.Desc) {
+	ch <- targetGroupHealthyTargetsDesc
+	ch <- targetGroupUnhealthyTargetsDesc
+}
+
+// Collect implements prometheus.Collector.
+func (t *targetHealth) Collect(ch chan<- prometheus.Metric) {
+	t.sync(context.Background())
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	for ingressKey, tgHealth := range t.health {
+		for tgArn, h := range tgHealth {
+			ch <- prometheus.MustNewConstMetric(targetGroupHealthyTargetsDesc, prometheus.GaugeValue, float64(h.healthy), ingressKey.String(), tgArn)
+			ch <- prometheus.MustNewConstMetric(targetGroupUnhealthyTargetsDesc, prometheus.GaugeValue, float64(h.unhealthy), ingressKey.String(), tgArn)
+		}
+	}
+}
+
+// TargetHealthHandler serves the number of healthy and unhealthy targets of every targetGroup managed by r,
+// in the prometheus exposition format. Target health is fetched from AWS at most once per syncPeriod.
+func (r *Reconciler) TargetHealthHandler(syncPeriod time.Duration) http.Handler {
+	r.targetHealth.syncMutex.Lock()
+	r.targetHealth.syncPeriod = syncPeriod
+	r.targetHealth.syncMutex.Unlock()
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(&r.targetHealth)
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func targetHealthDescription(state string) *elbv2.TargetHealthDescription {
+	return &elbv2.TargetHealthDescription{TargetHealth: &elbv2.TargetHealth{State: aws.String(state)}}
+}
+
+func TestReconciler_TargetHealthHandler(t *testing.T) {
+	ingA := types.NamespacedName{Namespace: "ns", Name: "a"}
+	ingB := types.NamespacedName{Namespace: "ns", Name: "b"}
+	ingC := types.NamespacedName{Namespace: "ns", Name: "c"}
+
+	cloud := &mocks.CloudAPI{}
+	cloud.On("DescribeTargetHealthWithContext", mock.Anything, &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String("tg-1")}).Return(&elbv2.DescribeTargetHealthOutput{
+		TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
+			targetHealthDescription(elbv2.TargetHealthStateEnumHealthy),
+			targetHealthDescription(elbv2.TargetHealthStateEnumHealthy),
+			targetHealthDescription(elbv2.TargetHealthStateEnumUnhealthy),
+			targetHealthDescription(elbv2.TargetHealthStateEnumDraining),
+		},
+	}, nil).Once()
+	cloud.On("DescribeTargetHealthWithContext", mock.Anything, &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String("tg-2")}).Return(&elbv2.DescribeTargetHealthOutput{}, nil).Once()
+	cloud.On("DescribeTargetHealthWithContext", mock.Anything, &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String("tg-3")}).Return(nil, errors.New("DescribeTargetHealth")).Once()
+
+	r := &Reconciler{targetHealth: targetHealth{cloud: cloud}}
+	r.targetHealth.track(ingA, "", []string{"tg-1"})
+	r.targetHealth.track(ingB, "arn:aws:iam::123456789012:role/alb", []string{"tg-2", "tg-3"})
+	r.targetHealth.track(ingC, "", []string{"tg-4"})
+	r.targetHealth.forget(ingC)
+	handler := r.TargetHealthHandler(time.Hour)
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/target-health", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		var lines []string
+		for _, line := range strings.Split(w.Body.String(), "\n") {
+			if strings.HasPrefix(line, "aws_alb_ingress_controller_") {
+				lines = append(lines, line)
+			}
+		}
+		assert.Equal(t, []string{
+			`aws_alb_ingress_controller_target_group_healthy_targets{ingress="ns/a",target_group="tg-1"} 2`,
+			`aws_alb_ingress_controller_target_group_healthy_targets{ingress="ns/b",target_group="tg-2"} 0`,
+			`aws_alb_ingress_controller_target_group_unhealthy_targets{ingress="ns/a",target_group="tg-1"} 1`,
+			`aws_alb_ingress_controller_target_group_unhealthy_targets{ingress="ns/b",target_group="tg-2"} 0`,
+		}, lines)
+	}
+	cloud.AssertExpectations(t)
+}
+
+func Test_targetHealth_forget(t *testing.T) {
+	ingA := types.NamespacedName{Namespace: "ns", Name: "a"}
+	cloud := &mocks.CloudAPI{}
+	cloud.On("DescribeTargetHealthWithContext", mock.Anything, mock.Anything).Return(&elbv2.DescribeTargetHealthOutput{}, nil)
+
+	th := &targetHealth{cloud: cloud}
+	th.track(ingA, "", []string{"tg-1"})
+	th.sync(context.Background())
+	assert.Equal(t, map[types.NamespacedName]map[string]targetGroupHealth{ingA: {"tg-1": {}}}, th.health)
+
+	th.forget(ingA)
+	assert.Empty(t, th.health)
+}
+
+func TestReconciler_checkTargetHealth(t *testing.T) {
+	ingA := types.NamespacedName{Namespace: "ns", Name: "a"}
+	cloud := &mocks.CloudAPI{}
+	cloud.On("DescribeTargetHealthWithContext", mock.Anything, &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String("tg-1")}).Return(&elbv2.DescribeTargetHealthOutput{
+		TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
+			targetHealthDescription(elbv2.TargetHealthStateEnumHealthy),
+			targetHealthDescription(elbv2.TargetHealthStateEnumInitial),
+		},
+	}, nil)
+	cloud.On("DescribeTargetHealthWithContext", mock.Anything, &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String("tg-2")}).Return(&elbv2.DescribeTargetHealthOutput{
+		TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
+			targetHealthDescription(elbv2.TargetHealthStateEnumHealthy),
+			targetHealthDescription(elbv2.TargetHealthStateEnumHealthy),
+		},
+	}, nil)
+	cloud.On("DescribeTargetHealthWithContext", mock.Anything, &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String("tg-3")}).Return(nil, errors.New("DescribeTargetHealth"))
+
+	for _, tc := range []struct {
+		Name              string
+		MinHealthyPercent int
+		TgArns            []string
+		ExpectedReady     bool
+		ExpectedPercent   *float64
+		ExpectedError     error
+	}{
+		{
+			Name:          "disabled",
+			TgArns:        []string{"tg-3"},
+			ExpectedReady: true,
+		},
+		{
+			Name:              "enough healthy targets",
+			MinHealthyPercent: 75,
+			TgArns:            []string{"tg-1", "tg-2"},
+			ExpectedReady:     true,
+			ExpectedPercent:   aws.Float64(75),
+		},
+		{
+			Name:              "too few healthy targets",
+			MinHealthyPercent: 80,
+			TgArns:            []string{"tg-1"},
+			ExpectedReady:     false,
+			ExpectedPercent:   aws.Float64(50),
+		},
+		{
+			Name:              "no targets",
+			MinHealthyPercent: 100,
+			ExpectedReady:     true,
+			ExpectedPercent:   aws.Float64(100),
+		},
+		{
+			Name:              "target health can't be described",
+			MinHealthyPercent: 50,
+			TgArns:            []string{"tg-2", "tg-3"},
+			ExpectedReady:     false,
+			ExpectedError:     errors.New("failed to describe target health of tg-3 due to DescribeTargetHealth"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			r := &Reconciler{targetHealth: targetHealth{cloud: cloud}, minHealthyPercent: tc.MinHealthyPercent}
+			ready, err := r.checkTargetHealth(context.Background(), "", tc.TgArns, ingA)
+			assert.Equal(t, tc.ExpectedError, err)
+			assert.Equal(t, tc.ExpectedReady, ready)
+			var percent *float64
+			if states := r.states.list(); len(states) == 1 {
+				percent = states[0].HealthyTargetPercent
+			}
+			assert.Equal(t, tc.ExpectedPercent, percent)
+		})
+	}
+}
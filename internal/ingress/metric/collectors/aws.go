@@ -17,6 +17,8 @@ limitations under the License.
 package collectors
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -24,9 +26,12 @@ import (
 type AWSAPIController struct {
 	prometheus.Collector
 
-	awsAPIRequest *prometheus.CounterVec
-	awsAPIError   *prometheus.CounterVec
-	awsAPIRetry   *prometheus.CounterVec
+	awsAPIRequest  *prometheus.CounterVec
+	awsAPIError    *prometheus.CounterVec
+	awsAPIRetry    *prometheus.CounterVec
+	awsAPIThrottle *prometheus.CounterVec
+	awsAPILatency  *prometheus.HistogramVec
+	awsQuota       *prometheus.CounterVec
 }
 
 // NewAWSAPIController creates a new prometheus collector for the
@@ -57,6 +62,31 @@ func NewAWSAPIController() *AWSAPIController {
 			},
 			[]string{"service", "operation"},
 		),
+		awsAPIThrottle: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "aws_api_throttled",
+				Help:      `Cumulative number of AWS API requests that were throttled`,
+			},
+			[]string{"service", "operation"},
+		),
+		awsAPILatency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "aws_api_latency_seconds",
+				Help:      `Time taken by AWS API requests to complete, including retries`,
+				Buckets:   []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+			},
+			[]string{"service", "operation"},
+		),
+		awsQuota: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "aws_quota_exceeded",
+				Help:      `Cumulative number of AWS API requests that failed since a service quota was reached`,
+			},
+			[]string{"resource"},
+		),
 	}
 }
 
@@ -75,11 +105,29 @@ func (a *AWSAPIController) IncAPIRetryCount(l prometheus.Labels) {
 	a.awsAPIRetry.With(l).Inc()
 }
 
+// IncAPIThrottleCount increment the throttled request counter
+func (a *AWSAPIController) IncAPIThrottleCount(l prometheus.Labels) {
+	a.awsAPIThrottle.With(l).Inc()
+}
+
+// ObserveAPILatency records the time taken by an AWS API request
+func (a *AWSAPIController) ObserveAPILatency(l prometheus.Labels, d time.Duration) {
+	a.awsAPILatency.With(l).Observe(d.Seconds())
+}
+
+// IncAWSQuotaExceeded increment the quota exceeded counter of resource
+func (a *AWSAPIController) IncAWSQuotaExceeded(resource string) {
+	a.awsQuota.With(prometheus.Labels{"resource": resource}).Inc()
+}
+
 // Describe implements prometheus.Collector
 func (a AWSAPIController) Describe(ch chan<- *prometheus.Desc) {
 	a.awsAPIRequest.Describe(ch)
 	a.awsAPIError.Describe(ch)
 	a.awsAPIRetry.Describe(ch)
+	a.awsAPIThrottle.Describe(ch)
+	a.awsAPILatency.Describe(ch)
+	a.awsQuota.Describe(ch)
 }
 
 // Collect implements the prometheus.Collector interface.
@@ -87,4 +135,7 @@ func (a AWSAPIController) Collect(ch chan<- prometheus.Metric) {
 	a.awsAPIRequest.Collect(ch)
 	a.awsAPIError.Collect(ch)
 	a.awsAPIRetry.Collect(ch)
+	a.awsAPIThrottle.Collect(ch)
+	a.awsAPILatency.Collect(ch)
+	a.awsQuota.Collect(ch)
 }
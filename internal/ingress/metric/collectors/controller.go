@@ -18,6 +18,7 @@ package collectors
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/golang/glog"
 	"github.com/prometheus/client_golang/prometheus"
@@ -31,6 +32,12 @@ type Controller struct {
 	reconcileOperation       *prometheus.CounterVec
 	reconcileOperationErrors *prometheus.CounterVec
 	managedIngresses         *prometheus.GaugeVec
+	reconcileLatency         *prometheus.HistogramVec
+	reconcileWorkers         *prometheus.GaugeVec
+	activeReconciles         *prometheus.GaugeVec
+	coalescedEvents          *prometheus.CounterVec
+	skippedReconciles        *prometheus.CounterVec
+	reconcileTimeouts        *prometheus.CounterVec
 
 	labels prometheus.Labels
 }
@@ -68,6 +75,55 @@ func NewController(class string) *Controller {
 			},
 			[]string{"class", "namespace"},
 		),
+		reconcileLatency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "reconcile_latency_seconds",
+				Help:      `Time taken by the Ingress controller to reconcile an ingress`,
+				Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 20, 30, 60},
+			},
+			[]string{"class", "namespace", "ingress"},
+		),
+		reconcileWorkers: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "reconcile_workers",
+				Help:      `Maximum number of ingresses the controller reconciles concurrently`,
+			},
+			[]string{"class"},
+		),
+		activeReconciles: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "active_reconciles",
+				Help:      `Number of ingress reconciles currently running`,
+			},
+			[]string{"class"},
+		),
+		coalescedEvents: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "coalesced_events",
+				Help:      `Cumulative number of events folded into an already scheduled reconcile`,
+			},
+			[]string{"class"},
+		),
+		skippedReconciles: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "skipped_reconciles",
+				Help:      `Cumulative number of ingress updates not reconciled since they left its desired state unchanged`,
+			},
+			[]string{"class"},
+		),
+		reconcileTimeouts: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "reconcile_timeouts",
+				Help:      `Cumulative number of reconciles cancelled for running longer than the reconcile timeout`,
+			},
+			[]string{"class", "ingress"},
+		),
 	}
 
 	return cm
@@ -87,6 +143,50 @@ func (cm *Controller) IncReconcileErrorCount(name string) {
 	cm.reconcileOperationErrors.With(l).Inc()
 }
 
+// IncReconcileTimeouts increment the reconcile timeouts counter
+func (cm *Controller) IncReconcileTimeouts(name string) {
+	l := prometheus.Labels{
+		"class": cm.labels["class"],
+	}
+	l["ingress"] = name
+	cm.reconcileTimeouts.With(l).Inc()
+}
+
+// ObserveReconcileLatency records how long a single reconcile of an ingress took
+func (cm *Controller) ObserveReconcileLatency(namespace string, name string, d time.Duration) {
+	l := prometheus.Labels{
+		"class":     cm.labels["class"],
+		"namespace": namespace,
+		"ingress":   name,
+	}
+	cm.reconcileLatency.With(l).Observe(d.Seconds())
+}
+
+// SetReconcileWorkers sets the size of the reconcile worker pool
+func (cm *Controller) SetReconcileWorkers(n int) {
+	cm.reconcileWorkers.With(cm.labels).Set(float64(n))
+}
+
+// IncActiveReconciles increment the running reconciles gauge
+func (cm *Controller) IncActiveReconciles() {
+	cm.activeReconciles.With(cm.labels).Inc()
+}
+
+// DecActiveReconciles decrement the running reconciles gauge
+func (cm *Controller) DecActiveReconciles() {
+	cm.activeReconciles.With(cm.labels).Dec()
+}
+
+// IncCoalescedEvents increment the coalesced events counter
+func (cm *Controller) IncCoalescedEvents() {
+	cm.coalescedEvents.With(cm.labels).Inc()
+}
+
+// IncSkippedReconciles increment the skipped reconciles counter
+func (cm *Controller) IncSkippedReconciles() {
+	cm.skippedReconciles.With(cm.labels).Inc()
+}
+
 // SetManagedIngresses sets the number of managed ingresses
 func (cm *Controller) SetManagedIngresses(nsmap map[string]int, registry prometheus.Gatherer) {
 	l := prometheus.Labels{
@@ -138,6 +238,12 @@ func (cm Controller) Describe(ch chan<- *prometheus.Desc) {
 	cm.reconcileOperation.Describe(ch)
 	cm.reconcileOperationErrors.Describe(ch)
 	cm.managedIngresses.Describe(ch)
+	cm.reconcileLatency.Describe(ch)
+	cm.reconcileWorkers.Describe(ch)
+	cm.activeReconciles.Describe(ch)
+	cm.coalescedEvents.Describe(ch)
+	cm.skippedReconciles.Describe(ch)
+	cm.reconcileTimeouts.Describe(ch)
 }
 
 // Collect implements the prometheus.Collector interface.
@@ -145,6 +251,12 @@ func (cm Controller) Collect(ch chan<- prometheus.Metric) {
 	cm.reconcileOperation.Collect(ch)
 	cm.reconcileOperationErrors.Collect(ch)
 	cm.managedIngresses.Collect(ch)
+	cm.reconcileLatency.Collect(ch)
+	cm.reconcileWorkers.Collect(ch)
+	cm.activeReconciles.Collect(ch)
+	cm.coalescedEvents.Collect(ch)
+	cm.skippedReconciles.Collect(ch)
+	cm.reconcileTimeouts.Collect(ch)
 }
 
 // RemoveMetrics removes metrics for ingresses that have been removed
@@ -154,4 +266,5 @@ func (cm *Controller) RemoveMetrics(name string) {
 	}
 	l["ingress"] = name
 	cm.reconcileOperationErrors.Delete(l)
+	cm.reconcileTimeouts.Delete(l)
 }
@@ -18,6 +18,7 @@ package collectors
 
 import (
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -64,6 +65,86 @@ func TestControllerCounters(t *testing.T) {
 			`,
 			metrics: []string{"aws_alb_ingress_controller_errors"},
 		},
+		{
+			name: "single reconcile latency observation should land in the matching buckets",
+			test: func(cm *Controller) {
+				cm.ObserveReconcileLatency("namespace", "ingressName", 1500*time.Millisecond)
+			},
+			want: `
+				# HELP aws_alb_ingress_controller_reconcile_latency_seconds Time taken by the Ingress controller to reconcile an ingress
+				# TYPE aws_alb_ingress_controller_reconcile_latency_seconds histogram
+				aws_alb_ingress_controller_reconcile_latency_seconds_bucket{class="alb",ingress="ingressName",namespace="namespace",le="0.05"} 0
+				aws_alb_ingress_controller_reconcile_latency_seconds_bucket{class="alb",ingress="ingressName",namespace="namespace",le="0.1"} 0
+				aws_alb_ingress_controller_reconcile_latency_seconds_bucket{class="alb",ingress="ingressName",namespace="namespace",le="0.25"} 0
+				aws_alb_ingress_controller_reconcile_latency_seconds_bucket{class="alb",ingress="ingressName",namespace="namespace",le="0.5"} 0
+				aws_alb_ingress_controller_reconcile_latency_seconds_bucket{class="alb",ingress="ingressName",namespace="namespace",le="1"} 0
+				aws_alb_ingress_controller_reconcile_latency_seconds_bucket{class="alb",ingress="ingressName",namespace="namespace",le="2.5"} 1
+				aws_alb_ingress_controller_reconcile_latency_seconds_bucket{class="alb",ingress="ingressName",namespace="namespace",le="5"} 1
+				aws_alb_ingress_controller_reconcile_latency_seconds_bucket{class="alb",ingress="ingressName",namespace="namespace",le="10"} 1
+				aws_alb_ingress_controller_reconcile_latency_seconds_bucket{class="alb",ingress="ingressName",namespace="namespace",le="20"} 1
+				aws_alb_ingress_controller_reconcile_latency_seconds_bucket{class="alb",ingress="ingressName",namespace="namespace",le="30"} 1
+				aws_alb_ingress_controller_reconcile_latency_seconds_bucket{class="alb",ingress="ingressName",namespace="namespace",le="60"} 1
+				aws_alb_ingress_controller_reconcile_latency_seconds_bucket{class="alb",ingress="ingressName",namespace="namespace",le="+Inf"} 1
+				aws_alb_ingress_controller_reconcile_latency_seconds_sum{class="alb",ingress="ingressName",namespace="namespace"} 1.5
+				aws_alb_ingress_controller_reconcile_latency_seconds_count{class="alb",ingress="ingressName",namespace="namespace"} 1
+			`,
+			metrics: []string{"aws_alb_ingress_controller_reconcile_latency_seconds"},
+		},
+		{
+			name: "worker pool size and running reconciles are reported",
+			test: func(cm *Controller) {
+				cm.SetReconcileWorkers(10)
+				cm.IncActiveReconciles()
+				cm.IncActiveReconciles()
+				cm.DecActiveReconciles()
+			},
+			want: `
+				# HELP aws_alb_ingress_controller_active_reconciles Number of ingress reconciles currently running
+				# TYPE aws_alb_ingress_controller_active_reconciles gauge
+				aws_alb_ingress_controller_active_reconciles{class="alb"} 1
+				# HELP aws_alb_ingress_controller_reconcile_workers Maximum number of ingresses the controller reconciles concurrently
+				# TYPE aws_alb_ingress_controller_reconcile_workers gauge
+				aws_alb_ingress_controller_reconcile_workers{class="alb"} 10
+			`,
+			metrics: []string{"aws_alb_ingress_controller_active_reconciles", "aws_alb_ingress_controller_reconcile_workers"},
+		},
+		{
+			name: "coalesced events are counted",
+			test: func(cm *Controller) {
+				cm.IncCoalescedEvents()
+				cm.IncCoalescedEvents()
+			},
+			want: `
+				# HELP aws_alb_ingress_controller_coalesced_events Cumulative number of events folded into an already scheduled reconcile
+				# TYPE aws_alb_ingress_controller_coalesced_events counter
+				aws_alb_ingress_controller_coalesced_events{class="alb"} 2
+			`,
+			metrics: []string{"aws_alb_ingress_controller_coalesced_events"},
+		},
+		{
+			name: "skipped reconciles are counted",
+			test: func(cm *Controller) {
+				cm.IncSkippedReconciles()
+			},
+			want: `
+				# HELP aws_alb_ingress_controller_skipped_reconciles Cumulative number of ingress updates not reconciled since they left its desired state unchanged
+				# TYPE aws_alb_ingress_controller_skipped_reconciles counter
+				aws_alb_ingress_controller_skipped_reconciles{class="alb"} 1
+			`,
+			metrics: []string{"aws_alb_ingress_controller_skipped_reconciles"},
+		},
+		{
+			name: "should return reconcile timeouts by ingress",
+			test: func(cm *Controller) {
+				cm.IncReconcileTimeouts("namespace/ingress")
+			},
+			want: `
+				# HELP aws_alb_ingress_controller_reconcile_timeouts Cumulative number of reconciles cancelled for running longer than the reconcile timeout
+				# TYPE aws_alb_ingress_controller_reconcile_timeouts counter
+				aws_alb_ingress_controller_reconcile_timeouts{class="alb",ingress="namespace/ingress"} 1
+			`,
+			metrics: []string{"aws_alb_ingress_controller_reconcile_timeouts"},
+		},
 	}
 
 	for _, c := range cases {
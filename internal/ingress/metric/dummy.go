@@ -17,6 +17,8 @@ limitations under the License.
 package metric
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -29,9 +31,30 @@ func (dc DummyCollector) IncReconcileCount() {}
 // IncReloadErrorCount ...
 func (dc DummyCollector) IncReconcileErrorCount(string) {}
 
+// IncReconcileTimeouts ...
+func (dc DummyCollector) IncReconcileTimeouts(string) {}
+
+// ObserveReconcileLatency ...
+func (dc DummyCollector) ObserveReconcileLatency(string, string, time.Duration) {}
+
+// SetReconcileWorkers ...
+func (dc DummyCollector) SetReconcileWorkers(int) {}
+
+// IncActiveReconciles ...
+func (dc DummyCollector) IncActiveReconciles() {}
+
+// DecActiveReconciles ...
+func (dc DummyCollector) DecActiveReconciles() {}
+
 // SetManagedIngresses ...
 func (dc DummyCollector) SetManagedIngresses(map[string]int) {}
 
+// IncCoalescedEvents ...
+func (dc DummyCollector) IncCoalescedEvents() {}
+
+// IncSkippedReconciles ...
+func (dc DummyCollector) IncSkippedReconciles() {}
+
 // IncAPIRequestCount ...
 func (dc DummyCollector) IncAPIRequestCount(prometheus.Labels) {}
 
@@ -41,6 +64,15 @@ func (dc DummyCollector) IncAPIErrorCount(prometheus.Labels) {}
 // IncAPIRetryCount ...
 func (dc DummyCollector) IncAPIRetryCount(prometheus.Labels) {}
 
+// IncAPIThrottleCount ...
+func (dc DummyCollector) IncAPIThrottleCount(prometheus.Labels) {}
+
+// ObserveAPILatency ...
+func (dc DummyCollector) ObserveAPILatency(prometheus.Labels, time.Duration) {}
+
+// IncAWSQuotaExceeded ...
+func (dc DummyCollector) IncAWSQuotaExceeded(string) {}
+
 // Start ...
 func (dc DummyCollector) Start() {}
 
@@ -17,6 +17,8 @@ limitations under the License.
 package metric
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric/collectors"
@@ -26,11 +28,21 @@ import (
 type Collector interface {
 	IncReconcileCount()
 	IncReconcileErrorCount(string)
+	IncReconcileTimeouts(string)
+	ObserveReconcileLatency(string, string, time.Duration)
+	SetReconcileWorkers(int)
+	IncActiveReconciles()
+	DecActiveReconciles()
 	SetManagedIngresses(map[string]int)
+	IncCoalescedEvents()
+	IncSkippedReconciles()
 
 	IncAPIRequestCount(prometheus.Labels)
 	IncAPIErrorCount(prometheus.Labels)
 	IncAPIRetryCount(prometheus.Labels)
+	IncAPIThrottleCount(prometheus.Labels)
+	ObserveAPILatency(prometheus.Labels, time.Duration)
+	IncAWSQuotaExceeded(string)
 
 	RemoveMetrics(string)
 
@@ -65,10 +77,38 @@ func (c *collector) IncReconcileErrorCount(s string) {
 	c.ingressController.IncReconcileErrorCount(s)
 }
 
+func (c *collector) IncReconcileTimeouts(name string) {
+	c.ingressController.IncReconcileTimeouts(name)
+}
+
+func (c *collector) ObserveReconcileLatency(namespace string, name string, d time.Duration) {
+	c.ingressController.ObserveReconcileLatency(namespace, name, d)
+}
+
+func (c *collector) SetReconcileWorkers(n int) {
+	c.ingressController.SetReconcileWorkers(n)
+}
+
+func (c *collector) IncActiveReconciles() {
+	c.ingressController.IncActiveReconciles()
+}
+
+func (c *collector) DecActiveReconciles() {
+	c.ingressController.DecActiveReconciles()
+}
+
 func (c *collector) SetManagedIngresses(i map[string]int) {
 	c.ingressController.SetManagedIngresses(i, c.registry)
 }
 
+func (c *collector) IncCoalescedEvents() {
+	c.ingressController.IncCoalescedEvents()
+}
+
+func (c *collector) IncSkippedReconciles() {
+	c.ingressController.IncSkippedReconciles()
+}
+
 func (c *collector) IncAPIRequestCount(l prometheus.Labels) {
 	c.awsAPIController.IncAPIRequestCount(l)
 }
@@ -81,6 +121,18 @@ func (c *collector) IncAPIRetryCount(l prometheus.Labels) {
 	c.awsAPIController.IncAPIRetryCount(l)
 }
 
+func (c *collector) IncAPIThrottleCount(l prometheus.Labels) {
+	c.awsAPIController.IncAPIThrottleCount(l)
+}
+
+func (c *collector) ObserveAPILatency(l prometheus.Labels, d time.Duration) {
+	c.awsAPIController.ObserveAPILatency(l, d)
+}
+
+func (c *collector) IncAWSQuotaExceeded(resource string) {
+	c.awsAPIController.IncAWSQuotaExceeded(resource)
+}
+
 func (c *collector) RemoveMetrics(ingressName string) {
 	c.ingressController.RemoveMetrics(ingressName)
 }
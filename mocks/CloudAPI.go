@@ -15,6 +15,8 @@ import (
 
 	resourcegroupstaggingapi "github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 
+	route53 "github.com/aws/aws-sdk-go/service/route53"
+
 	shield "github.com/aws/aws-sdk-go/service/shield"
 
 	waf "github.com/aws/aws-sdk-go/service/waf"
@@ -158,6 +160,29 @@ func (_m *CloudAPI) AuthorizeSecurityGroupIngressWithContext(_a0 context.Context
 	return r0, r1
 }
 
+// ChangeResourceRecordSetsWithContext provides a mock function with given fields: ctx, input
+func (_m *CloudAPI) ChangeResourceRecordSetsWithContext(ctx context.Context, input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 *route53.ChangeResourceRecordSetsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *route53.ChangeResourceRecordSetsInput) *route53.ChangeResourceRecordSetsOutput); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*route53.ChangeResourceRecordSetsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *route53.ChangeResourceRecordSetsInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateEC2TagsWithContext provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) CreateEC2TagsWithContext(_a0 context.Context, _a1 *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
 	ret := _m.Called(_a0, _a1)
@@ -490,6 +515,29 @@ func (_m *CloudAPI) DescribeCertificate(ctx context.Context, certArn string) (*a
 	return r0, r1
 }
 
+// DescribeELBV2AccountLimitsWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) DescribeELBV2AccountLimitsWithContext(_a0 context.Context, _a1 *elbv2.DescribeAccountLimitsInput) (*elbv2.DescribeAccountLimitsOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *elbv2.DescribeAccountLimitsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *elbv2.DescribeAccountLimitsInput) *elbv2.DescribeAccountLimitsOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.DescribeAccountLimitsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *elbv2.DescribeAccountLimitsInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DescribeELBV2TagsWithContext provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) DescribeELBV2TagsWithContext(_a0 context.Context, _a1 *elbv2.DescribeTagsInput) (*elbv2.DescribeTagsOutput, error) {
 	ret := _m.Called(_a0, _a1)
@@ -734,6 +782,29 @@ func (_m *CloudAPI) GetClusterSubnets(_a0 string) ([]*ec2.Subnet, error) {
 	return r0, r1
 }
 
+// GetHostedZoneByID provides a mock function with given fields: ctx, zoneID
+func (_m *CloudAPI) GetHostedZoneByID(ctx context.Context, zoneID string) (*route53.HostedZone, error) {
+	ret := _m.Called(ctx, zoneID)
+
+	var r0 *route53.HostedZone
+	if rf, ok := ret.Get(0).(func(context.Context, string) *route53.HostedZone); ok {
+		r0 = rf(ctx, zoneID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*route53.HostedZone)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, zoneID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetInstancesByIDs provides a mock function with given fields: _a0
 func (_m *CloudAPI) GetInstancesByIDs(_a0 []string) ([]*ec2.Instance, error) {
 	ret := _m.Called(_a0)
@@ -826,20 +897,43 @@ func (_m *CloudAPI) GetProtection(ctx context.Context, resourceArn *string) (*sh
 	return r0, r1
 }
 
-// GetResourcesByFilters provides a mock function with given fields: tagFilters, resourceTypeFilters
-func (_m *CloudAPI) GetResourcesByFilters(tagFilters map[string][]string, resourceTypeFilters ...string) ([]string, error) {
+// GetResourceRecordSet provides a mock function with given fields: ctx, zoneID, name, rrType
+func (_m *CloudAPI) GetResourceRecordSet(ctx context.Context, zoneID string, name string, rrType string) (*route53.ResourceRecordSet, error) {
+	ret := _m.Called(ctx, zoneID, name, rrType)
+
+	var r0 *route53.ResourceRecordSet
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *route53.ResourceRecordSet); ok {
+		r0 = rf(ctx, zoneID, name, rrType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*route53.ResourceRecordSet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, zoneID, name, rrType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetResourcesByFilters provides a mock function with given fields: ctx, tagFilters, resourceTypeFilters
+func (_m *CloudAPI) GetResourcesByFilters(ctx context.Context, tagFilters map[string][]string, resourceTypeFilters ...string) ([]string, error) {
 	_va := make([]interface{}, len(resourceTypeFilters))
 	for _i := range resourceTypeFilters {
 		_va[_i] = resourceTypeFilters[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, tagFilters)
+	_ca = append(_ca, ctx, tagFilters)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	var r0 []string
-	if rf, ok := ret.Get(0).(func(map[string][]string, ...string) []string); ok {
-		r0 = rf(tagFilters, resourceTypeFilters...)
+	if rf, ok := ret.Get(0).(func(context.Context, map[string][]string, ...string) []string); ok {
+		r0 = rf(ctx, tagFilters, resourceTypeFilters...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]string)
@@ -847,8 +941,38 @@ func (_m *CloudAPI) GetResourcesByFilters(tagFilters map[string][]string, resour
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(map[string][]string, ...string) error); ok {
-		r1 = rf(tagFilters, resourceTypeFilters...)
+	if rf, ok := ret.Get(1).(func(context.Context, map[string][]string, ...string) error); ok {
+		r1 = rf(ctx, tagFilters, resourceTypeFilters...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetResourceTagsByFilters provides a mock function with given fields: ctx, tagFilters, resourceTypeFilters
+func (_m *CloudAPI) GetResourceTagsByFilters(ctx context.Context, tagFilters map[string][]string, resourceTypeFilters ...string) (map[string]map[string]string, error) {
+	_va := make([]interface{}, len(resourceTypeFilters))
+	for _i := range resourceTypeFilters {
+		_va[_i] = resourceTypeFilters[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, tagFilters)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 map[string]map[string]string
+	if rf, ok := ret.Get(0).(func(context.Context, map[string][]string, ...string) map[string]map[string]string); ok {
+		r0 = rf(ctx, tagFilters, resourceTypeFilters...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]map[string]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, map[string][]string, ...string) error); ok {
+		r1 = rf(ctx, tagFilters, resourceTypeFilters...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -971,6 +1095,29 @@ func (_m *CloudAPI) GetSubnetsByNameOrID(_a0 context.Context, _a1 []string) ([]*
 	return r0, r1
 }
 
+// GetSubnetsByTags provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) GetSubnetsByTags(_a0 context.Context, _a1 map[string]string) ([]*ec2.Subnet, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []*ec2.Subnet
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]string) []*ec2.Subnet); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*ec2.Subnet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, map[string]string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSubscriptionStatus provides a mock function with given fields: ctx
 func (_m *CloudAPI) GetSubscriptionStatus(ctx context.Context) (*shield.GetSubscriptionStateOutput, error) {
 	ret := _m.Called(ctx)
@@ -1017,6 +1164,29 @@ func (_m *CloudAPI) GetTargetGroupByArn(_a0 context.Context, _a1 string) (*elbv2
 	return r0, r1
 }
 
+// GetTargetGroupsByArns provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) GetTargetGroupsByArns(_a0 context.Context, _a1 []string) ([]*elbv2.TargetGroup, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []*elbv2.TargetGroup
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*elbv2.TargetGroup); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*elbv2.TargetGroup)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTargetGroupByName provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) GetTargetGroupByName(_a0 context.Context, _a1 string) (*elbv2.TargetGroup, error) {
 	ret := _m.Called(_a0, _a1)
@@ -1169,6 +1339,29 @@ func (_m *CloudAPI) ListListenersByLoadBalancer(_a0 context.Context, _a1 string)
 	return r0, r1
 }
 
+// ListResourceRecordSetsByZone provides a mock function with given fields: ctx, zoneID
+func (_m *CloudAPI) ListResourceRecordSetsByZone(ctx context.Context, zoneID string) ([]*route53.ResourceRecordSet, error) {
+	ret := _m.Called(ctx, zoneID)
+
+	var r0 []*route53.ResourceRecordSet
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*route53.ResourceRecordSet); ok {
+		r0 = rf(ctx, zoneID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*route53.ResourceRecordSet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, zoneID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ModifyListenerWithContext provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) ModifyListenerWithContext(_a0 context.Context, _a1 *elbv2.ModifyListenerInput) (*elbv2.ModifyListenerOutput, error) {
 	ret := _m.Called(_a0, _a1)
@@ -1537,6 +1730,20 @@ func (_m *CloudAPI) StatusELBV2() func() error {
 	return r0
 }
 
+// ProbeELBV2 provides a mock function with given fields: _a0
+func (_m *CloudAPI) ProbeELBV2(_a0 context.Context) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StatusIAM provides a mock function with given fields:
 func (_m *CloudAPI) StatusIAM() func() error {
 	ret := _m.Called()
@@ -1553,6 +1760,38 @@ func (_m *CloudAPI) StatusIAM() func() error {
 	return r0
 }
 
+// StatusRoute53 provides a mock function with given fields:
+func (_m *CloudAPI) StatusRoute53() func() error {
+	ret := _m.Called()
+
+	var r0 func() error
+	if rf, ok := ret.Get(0).(func() func() error); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func() error)
+		}
+	}
+
+	return r0
+}
+
+// StatusWAFV2 provides a mock function with given fields:
+func (_m *CloudAPI) StatusWAFV2() func() error {
+	ret := _m.Called()
+
+	var r0 func() error
+	if rf, ok := ret.Get(0).(func() func() error); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func() error)
+		}
+	}
+
+	return r0
+}
+
 // TagResourcesWithContext provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) TagResourcesWithContext(_a0 context.Context, _a1 *resourcegroupstaggingapi.TagResourcesInput) (*resourcegroupstaggingapi.TagResourcesOutput, error) {
 	ret := _m.Called(_a0, _a1)
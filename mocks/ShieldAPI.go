@@ -172,6 +172,162 @@ func (_m *ShieldAPI) AssociateDRTRoleWithContext(_a0 context.Context, _a1 *shiel
 	return r0, r1
 }
 
+// AssociateHealthCheck provides a mock function with given fields: _a0
+func (_m *ShieldAPI) AssociateHealthCheck(_a0 *shield.AssociateHealthCheckInput) (*shield.AssociateHealthCheckOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *shield.AssociateHealthCheckOutput
+	if rf, ok := ret.Get(0).(func(*shield.AssociateHealthCheckInput) *shield.AssociateHealthCheckOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.AssociateHealthCheckOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*shield.AssociateHealthCheckInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AssociateHealthCheckRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) AssociateHealthCheckRequest(_a0 *shield.AssociateHealthCheckInput) (*request.Request, *shield.AssociateHealthCheckOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*shield.AssociateHealthCheckInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *shield.AssociateHealthCheckOutput
+	if rf, ok := ret.Get(1).(func(*shield.AssociateHealthCheckInput) *shield.AssociateHealthCheckOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*shield.AssociateHealthCheckOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// AssociateHealthCheckWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) AssociateHealthCheckWithContext(_a0 context.Context, _a1 *shield.AssociateHealthCheckInput, _a2 ...request.Option) (*shield.AssociateHealthCheckOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shield.AssociateHealthCheckOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.AssociateHealthCheckInput, ...request.Option) *shield.AssociateHealthCheckOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.AssociateHealthCheckOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.AssociateHealthCheckInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AssociateProactiveEngagementDetails provides a mock function with given fields: _a0
+func (_m *ShieldAPI) AssociateProactiveEngagementDetails(_a0 *shield.AssociateProactiveEngagementDetailsInput) (*shield.AssociateProactiveEngagementDetailsOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *shield.AssociateProactiveEngagementDetailsOutput
+	if rf, ok := ret.Get(0).(func(*shield.AssociateProactiveEngagementDetailsInput) *shield.AssociateProactiveEngagementDetailsOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.AssociateProactiveEngagementDetailsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*shield.AssociateProactiveEngagementDetailsInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AssociateProactiveEngagementDetailsRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) AssociateProactiveEngagementDetailsRequest(_a0 *shield.AssociateProactiveEngagementDetailsInput) (*request.Request, *shield.AssociateProactiveEngagementDetailsOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*shield.AssociateProactiveEngagementDetailsInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *shield.AssociateProactiveEngagementDetailsOutput
+	if rf, ok := ret.Get(1).(func(*shield.AssociateProactiveEngagementDetailsInput) *shield.AssociateProactiveEngagementDetailsOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*shield.AssociateProactiveEngagementDetailsOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// AssociateProactiveEngagementDetailsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) AssociateProactiveEngagementDetailsWithContext(_a0 context.Context, _a1 *shield.AssociateProactiveEngagementDetailsInput, _a2 ...request.Option) (*shield.AssociateProactiveEngagementDetailsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shield.AssociateProactiveEngagementDetailsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.AssociateProactiveEngagementDetailsInput, ...request.Option) *shield.AssociateProactiveEngagementDetailsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.AssociateProactiveEngagementDetailsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.AssociateProactiveEngagementDetailsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateProtection provides a mock function with given fields: _a0
 func (_m *ShieldAPI) CreateProtection(_a0 *shield.CreateProtectionInput) (*shield.CreateProtectionOutput, error) {
 	ret := _m.Called(_a0)
@@ -195,6 +351,84 @@ func (_m *ShieldAPI) CreateProtection(_a0 *shield.CreateProtectionInput) (*shiel
 	return r0, r1
 }
 
+// CreateProtectionGroup provides a mock function with given fields: _a0
+func (_m *ShieldAPI) CreateProtectionGroup(_a0 *shield.CreateProtectionGroupInput) (*shield.CreateProtectionGroupOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *shield.CreateProtectionGroupOutput
+	if rf, ok := ret.Get(0).(func(*shield.CreateProtectionGroupInput) *shield.CreateProtectionGroupOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.CreateProtectionGroupOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*shield.CreateProtectionGroupInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateProtectionGroupRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) CreateProtectionGroupRequest(_a0 *shield.CreateProtectionGroupInput) (*request.Request, *shield.CreateProtectionGroupOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*shield.CreateProtectionGroupInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *shield.CreateProtectionGroupOutput
+	if rf, ok := ret.Get(1).(func(*shield.CreateProtectionGroupInput) *shield.CreateProtectionGroupOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*shield.CreateProtectionGroupOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// CreateProtectionGroupWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) CreateProtectionGroupWithContext(_a0 context.Context, _a1 *shield.CreateProtectionGroupInput, _a2 ...request.Option) (*shield.CreateProtectionGroupOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shield.CreateProtectionGroupOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.CreateProtectionGroupInput, ...request.Option) *shield.CreateProtectionGroupOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.CreateProtectionGroupOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.CreateProtectionGroupInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateProtectionRequest provides a mock function with given fields: _a0
 func (_m *ShieldAPI) CreateProtectionRequest(_a0 *shield.CreateProtectionInput) (*request.Request, *shield.CreateProtectionOutput) {
 	ret := _m.Called(_a0)
@@ -351,6 +585,84 @@ func (_m *ShieldAPI) DeleteProtection(_a0 *shield.DeleteProtectionInput) (*shiel
 	return r0, r1
 }
 
+// DeleteProtectionGroup provides a mock function with given fields: _a0
+func (_m *ShieldAPI) DeleteProtectionGroup(_a0 *shield.DeleteProtectionGroupInput) (*shield.DeleteProtectionGroupOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *shield.DeleteProtectionGroupOutput
+	if rf, ok := ret.Get(0).(func(*shield.DeleteProtectionGroupInput) *shield.DeleteProtectionGroupOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DeleteProtectionGroupOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*shield.DeleteProtectionGroupInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteProtectionGroupRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) DeleteProtectionGroupRequest(_a0 *shield.DeleteProtectionGroupInput) (*request.Request, *shield.DeleteProtectionGroupOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*shield.DeleteProtectionGroupInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *shield.DeleteProtectionGroupOutput
+	if rf, ok := ret.Get(1).(func(*shield.DeleteProtectionGroupInput) *shield.DeleteProtectionGroupOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*shield.DeleteProtectionGroupOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteProtectionGroupWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) DeleteProtectionGroupWithContext(_a0 context.Context, _a1 *shield.DeleteProtectionGroupInput, _a2 ...request.Option) (*shield.DeleteProtectionGroupOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shield.DeleteProtectionGroupOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.DeleteProtectionGroupInput, ...request.Option) *shield.DeleteProtectionGroupOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DeleteProtectionGroupOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.DeleteProtectionGroupInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteProtectionRequest provides a mock function with given fields: _a0
 func (_m *ShieldAPI) DeleteProtectionRequest(_a0 *shield.DeleteProtectionInput) (*request.Request, *shield.DeleteProtectionOutput) {
 	ret := _m.Called(_a0)
@@ -532,23 +844,101 @@ func (_m *ShieldAPI) DescribeAttackRequest(_a0 *shield.DescribeAttackInput) (*re
 	return r0, r1
 }
 
-// DescribeAttackWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *ShieldAPI) DescribeAttackWithContext(_a0 context.Context, _a1 *shield.DescribeAttackInput, _a2 ...request.Option) (*shield.DescribeAttackOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
+// DescribeAttackStatistics provides a mock function with given fields: _a0
+func (_m *ShieldAPI) DescribeAttackStatistics(_a0 *shield.DescribeAttackStatisticsInput) (*shield.DescribeAttackStatisticsOutput, error) {
+	ret := _m.Called(_a0)
 
-	var r0 *shield.DescribeAttackOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *shield.DescribeAttackInput, ...request.Option) *shield.DescribeAttackOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
+	var r0 *shield.DescribeAttackStatisticsOutput
+	if rf, ok := ret.Get(0).(func(*shield.DescribeAttackStatisticsInput) *shield.DescribeAttackStatisticsOutput); ok {
+		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*shield.DescribeAttackOutput)
+			r0 = ret.Get(0).(*shield.DescribeAttackStatisticsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*shield.DescribeAttackStatisticsInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeAttackStatisticsRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) DescribeAttackStatisticsRequest(_a0 *shield.DescribeAttackStatisticsInput) (*request.Request, *shield.DescribeAttackStatisticsOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*shield.DescribeAttackStatisticsInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *shield.DescribeAttackStatisticsOutput
+	if rf, ok := ret.Get(1).(func(*shield.DescribeAttackStatisticsInput) *shield.DescribeAttackStatisticsOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*shield.DescribeAttackStatisticsOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DescribeAttackStatisticsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) DescribeAttackStatisticsWithContext(_a0 context.Context, _a1 *shield.DescribeAttackStatisticsInput, _a2 ...request.Option) (*shield.DescribeAttackStatisticsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shield.DescribeAttackStatisticsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.DescribeAttackStatisticsInput, ...request.Option) *shield.DescribeAttackStatisticsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DescribeAttackStatisticsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.DescribeAttackStatisticsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeAttackWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) DescribeAttackWithContext(_a0 context.Context, _a1 *shield.DescribeAttackInput, _a2 ...request.Option) (*shield.DescribeAttackOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shield.DescribeAttackOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.DescribeAttackInput, ...request.Option) *shield.DescribeAttackOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DescribeAttackOutput)
 		}
 	}
 
@@ -621,17 +1011,1066 @@ func (_m *ShieldAPI) DescribeDRTAccessWithContext(_a0 context.Context, _a1 *shie
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *shield.DescribeDRTAccessOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *shield.DescribeDRTAccessInput, ...request.Option) *shield.DescribeDRTAccessOutput); ok {
+	var r0 *shield.DescribeDRTAccessOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.DescribeDRTAccessInput, ...request.Option) *shield.DescribeDRTAccessOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DescribeDRTAccessOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.DescribeDRTAccessInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeEmergencyContactSettings provides a mock function with given fields: _a0
+func (_m *ShieldAPI) DescribeEmergencyContactSettings(_a0 *shield.DescribeEmergencyContactSettingsInput) (*shield.DescribeEmergencyContactSettingsOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *shield.DescribeEmergencyContactSettingsOutput
+	if rf, ok := ret.Get(0).(func(*shield.DescribeEmergencyContactSettingsInput) *shield.DescribeEmergencyContactSettingsOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DescribeEmergencyContactSettingsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*shield.DescribeEmergencyContactSettingsInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeEmergencyContactSettingsRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) DescribeEmergencyContactSettingsRequest(_a0 *shield.DescribeEmergencyContactSettingsInput) (*request.Request, *shield.DescribeEmergencyContactSettingsOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*shield.DescribeEmergencyContactSettingsInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *shield.DescribeEmergencyContactSettingsOutput
+	if rf, ok := ret.Get(1).(func(*shield.DescribeEmergencyContactSettingsInput) *shield.DescribeEmergencyContactSettingsOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*shield.DescribeEmergencyContactSettingsOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DescribeEmergencyContactSettingsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) DescribeEmergencyContactSettingsWithContext(_a0 context.Context, _a1 *shield.DescribeEmergencyContactSettingsInput, _a2 ...request.Option) (*shield.DescribeEmergencyContactSettingsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shield.DescribeEmergencyContactSettingsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.DescribeEmergencyContactSettingsInput, ...request.Option) *shield.DescribeEmergencyContactSettingsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DescribeEmergencyContactSettingsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.DescribeEmergencyContactSettingsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeProtection provides a mock function with given fields: _a0
+func (_m *ShieldAPI) DescribeProtection(_a0 *shield.DescribeProtectionInput) (*shield.DescribeProtectionOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *shield.DescribeProtectionOutput
+	if rf, ok := ret.Get(0).(func(*shield.DescribeProtectionInput) *shield.DescribeProtectionOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DescribeProtectionOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*shield.DescribeProtectionInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeProtectionGroup provides a mock function with given fields: _a0
+func (_m *ShieldAPI) DescribeProtectionGroup(_a0 *shield.DescribeProtectionGroupInput) (*shield.DescribeProtectionGroupOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *shield.DescribeProtectionGroupOutput
+	if rf, ok := ret.Get(0).(func(*shield.DescribeProtectionGroupInput) *shield.DescribeProtectionGroupOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DescribeProtectionGroupOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*shield.DescribeProtectionGroupInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeProtectionGroupRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) DescribeProtectionGroupRequest(_a0 *shield.DescribeProtectionGroupInput) (*request.Request, *shield.DescribeProtectionGroupOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*shield.DescribeProtectionGroupInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *shield.DescribeProtectionGroupOutput
+	if rf, ok := ret.Get(1).(func(*shield.DescribeProtectionGroupInput) *shield.DescribeProtectionGroupOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*shield.DescribeProtectionGroupOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DescribeProtectionGroupWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) DescribeProtectionGroupWithContext(_a0 context.Context, _a1 *shield.DescribeProtectionGroupInput, _a2 ...request.Option) (*shield.DescribeProtectionGroupOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shield.DescribeProtectionGroupOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.DescribeProtectionGroupInput, ...request.Option) *shield.DescribeProtectionGroupOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DescribeProtectionGroupOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.DescribeProtectionGroupInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeProtectionRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) DescribeProtectionRequest(_a0 *shield.DescribeProtectionInput) (*request.Request, *shield.DescribeProtectionOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*shield.DescribeProtectionInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *shield.DescribeProtectionOutput
+	if rf, ok := ret.Get(1).(func(*shield.DescribeProtectionInput) *shield.DescribeProtectionOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*shield.DescribeProtectionOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DescribeProtectionWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) DescribeProtectionWithContext(_a0 context.Context, _a1 *shield.DescribeProtectionInput, _a2 ...request.Option) (*shield.DescribeProtectionOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shield.DescribeProtectionOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.DescribeProtectionInput, ...request.Option) *shield.DescribeProtectionOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DescribeProtectionOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.DescribeProtectionInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeSubscription provides a mock function with given fields: _a0
+func (_m *ShieldAPI) DescribeSubscription(_a0 *shield.DescribeSubscriptionInput) (*shield.DescribeSubscriptionOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *shield.DescribeSubscriptionOutput
+	if rf, ok := ret.Get(0).(func(*shield.DescribeSubscriptionInput) *shield.DescribeSubscriptionOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DescribeSubscriptionOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*shield.DescribeSubscriptionInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeSubscriptionRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) DescribeSubscriptionRequest(_a0 *shield.DescribeSubscriptionInput) (*request.Request, *shield.DescribeSubscriptionOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*shield.DescribeSubscriptionInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *shield.DescribeSubscriptionOutput
+	if rf, ok := ret.Get(1).(func(*shield.DescribeSubscriptionInput) *shield.DescribeSubscriptionOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*shield.DescribeSubscriptionOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DescribeSubscriptionWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) DescribeSubscriptionWithContext(_a0 context.Context, _a1 *shield.DescribeSubscriptionInput, _a2 ...request.Option) (*shield.DescribeSubscriptionOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shield.DescribeSubscriptionOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.DescribeSubscriptionInput, ...request.Option) *shield.DescribeSubscriptionOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DescribeSubscriptionOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.DescribeSubscriptionInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisableApplicationLayerAutomaticResponse provides a mock function with given fields: _a0
+func (_m *ShieldAPI) DisableApplicationLayerAutomaticResponse(_a0 *shield.DisableApplicationLayerAutomaticResponseInput) (*shield.DisableApplicationLayerAutomaticResponseOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *shield.DisableApplicationLayerAutomaticResponseOutput
+	if rf, ok := ret.Get(0).(func(*shield.DisableApplicationLayerAutomaticResponseInput) *shield.DisableApplicationLayerAutomaticResponseOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DisableApplicationLayerAutomaticResponseOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*shield.DisableApplicationLayerAutomaticResponseInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisableApplicationLayerAutomaticResponseRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) DisableApplicationLayerAutomaticResponseRequest(_a0 *shield.DisableApplicationLayerAutomaticResponseInput) (*request.Request, *shield.DisableApplicationLayerAutomaticResponseOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*shield.DisableApplicationLayerAutomaticResponseInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *shield.DisableApplicationLayerAutomaticResponseOutput
+	if rf, ok := ret.Get(1).(func(*shield.DisableApplicationLayerAutomaticResponseInput) *shield.DisableApplicationLayerAutomaticResponseOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*shield.DisableApplicationLayerAutomaticResponseOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DisableApplicationLayerAutomaticResponseWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) DisableApplicationLayerAutomaticResponseWithContext(_a0 context.Context, _a1 *shield.DisableApplicationLayerAutomaticResponseInput, _a2 ...request.Option) (*shield.DisableApplicationLayerAutomaticResponseOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shield.DisableApplicationLayerAutomaticResponseOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.DisableApplicationLayerAutomaticResponseInput, ...request.Option) *shield.DisableApplicationLayerAutomaticResponseOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DisableApplicationLayerAutomaticResponseOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.DisableApplicationLayerAutomaticResponseInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisableProactiveEngagement provides a mock function with given fields: _a0
+func (_m *ShieldAPI) DisableProactiveEngagement(_a0 *shield.DisableProactiveEngagementInput) (*shield.DisableProactiveEngagementOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *shield.DisableProactiveEngagementOutput
+	if rf, ok := ret.Get(0).(func(*shield.DisableProactiveEngagementInput) *shield.DisableProactiveEngagementOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DisableProactiveEngagementOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*shield.DisableProactiveEngagementInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisableProactiveEngagementRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) DisableProactiveEngagementRequest(_a0 *shield.DisableProactiveEngagementInput) (*request.Request, *shield.DisableProactiveEngagementOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*shield.DisableProactiveEngagementInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *shield.DisableProactiveEngagementOutput
+	if rf, ok := ret.Get(1).(func(*shield.DisableProactiveEngagementInput) *shield.DisableProactiveEngagementOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*shield.DisableProactiveEngagementOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DisableProactiveEngagementWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) DisableProactiveEngagementWithContext(_a0 context.Context, _a1 *shield.DisableProactiveEngagementInput, _a2 ...request.Option) (*shield.DisableProactiveEngagementOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shield.DisableProactiveEngagementOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.DisableProactiveEngagementInput, ...request.Option) *shield.DisableProactiveEngagementOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DisableProactiveEngagementOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.DisableProactiveEngagementInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisassociateDRTLogBucket provides a mock function with given fields: _a0
+func (_m *ShieldAPI) DisassociateDRTLogBucket(_a0 *shield.DisassociateDRTLogBucketInput) (*shield.DisassociateDRTLogBucketOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *shield.DisassociateDRTLogBucketOutput
+	if rf, ok := ret.Get(0).(func(*shield.DisassociateDRTLogBucketInput) *shield.DisassociateDRTLogBucketOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DisassociateDRTLogBucketOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*shield.DisassociateDRTLogBucketInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisassociateDRTLogBucketRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) DisassociateDRTLogBucketRequest(_a0 *shield.DisassociateDRTLogBucketInput) (*request.Request, *shield.DisassociateDRTLogBucketOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*shield.DisassociateDRTLogBucketInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *shield.DisassociateDRTLogBucketOutput
+	if rf, ok := ret.Get(1).(func(*shield.DisassociateDRTLogBucketInput) *shield.DisassociateDRTLogBucketOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*shield.DisassociateDRTLogBucketOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DisassociateDRTLogBucketWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) DisassociateDRTLogBucketWithContext(_a0 context.Context, _a1 *shield.DisassociateDRTLogBucketInput, _a2 ...request.Option) (*shield.DisassociateDRTLogBucketOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shield.DisassociateDRTLogBucketOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.DisassociateDRTLogBucketInput, ...request.Option) *shield.DisassociateDRTLogBucketOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DisassociateDRTLogBucketOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.DisassociateDRTLogBucketInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisassociateDRTRole provides a mock function with given fields: _a0
+func (_m *ShieldAPI) DisassociateDRTRole(_a0 *shield.DisassociateDRTRoleInput) (*shield.DisassociateDRTRoleOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *shield.DisassociateDRTRoleOutput
+	if rf, ok := ret.Get(0).(func(*shield.DisassociateDRTRoleInput) *shield.DisassociateDRTRoleOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DisassociateDRTRoleOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*shield.DisassociateDRTRoleInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisassociateDRTRoleRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) DisassociateDRTRoleRequest(_a0 *shield.DisassociateDRTRoleInput) (*request.Request, *shield.DisassociateDRTRoleOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*shield.DisassociateDRTRoleInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *shield.DisassociateDRTRoleOutput
+	if rf, ok := ret.Get(1).(func(*shield.DisassociateDRTRoleInput) *shield.DisassociateDRTRoleOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*shield.DisassociateDRTRoleOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DisassociateDRTRoleWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) DisassociateDRTRoleWithContext(_a0 context.Context, _a1 *shield.DisassociateDRTRoleInput, _a2 ...request.Option) (*shield.DisassociateDRTRoleOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shield.DisassociateDRTRoleOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.DisassociateDRTRoleInput, ...request.Option) *shield.DisassociateDRTRoleOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DisassociateDRTRoleOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.DisassociateDRTRoleInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisassociateHealthCheck provides a mock function with given fields: _a0
+func (_m *ShieldAPI) DisassociateHealthCheck(_a0 *shield.DisassociateHealthCheckInput) (*shield.DisassociateHealthCheckOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *shield.DisassociateHealthCheckOutput
+	if rf, ok := ret.Get(0).(func(*shield.DisassociateHealthCheckInput) *shield.DisassociateHealthCheckOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DisassociateHealthCheckOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*shield.DisassociateHealthCheckInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisassociateHealthCheckRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) DisassociateHealthCheckRequest(_a0 *shield.DisassociateHealthCheckInput) (*request.Request, *shield.DisassociateHealthCheckOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*shield.DisassociateHealthCheckInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *shield.DisassociateHealthCheckOutput
+	if rf, ok := ret.Get(1).(func(*shield.DisassociateHealthCheckInput) *shield.DisassociateHealthCheckOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*shield.DisassociateHealthCheckOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DisassociateHealthCheckWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) DisassociateHealthCheckWithContext(_a0 context.Context, _a1 *shield.DisassociateHealthCheckInput, _a2 ...request.Option) (*shield.DisassociateHealthCheckOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shield.DisassociateHealthCheckOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.DisassociateHealthCheckInput, ...request.Option) *shield.DisassociateHealthCheckOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DisassociateHealthCheckOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.DisassociateHealthCheckInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnableApplicationLayerAutomaticResponse provides a mock function with given fields: _a0
+func (_m *ShieldAPI) EnableApplicationLayerAutomaticResponse(_a0 *shield.EnableApplicationLayerAutomaticResponseInput) (*shield.EnableApplicationLayerAutomaticResponseOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *shield.EnableApplicationLayerAutomaticResponseOutput
+	if rf, ok := ret.Get(0).(func(*shield.EnableApplicationLayerAutomaticResponseInput) *shield.EnableApplicationLayerAutomaticResponseOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.EnableApplicationLayerAutomaticResponseOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*shield.EnableApplicationLayerAutomaticResponseInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnableApplicationLayerAutomaticResponseRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) EnableApplicationLayerAutomaticResponseRequest(_a0 *shield.EnableApplicationLayerAutomaticResponseInput) (*request.Request, *shield.EnableApplicationLayerAutomaticResponseOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*shield.EnableApplicationLayerAutomaticResponseInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *shield.EnableApplicationLayerAutomaticResponseOutput
+	if rf, ok := ret.Get(1).(func(*shield.EnableApplicationLayerAutomaticResponseInput) *shield.EnableApplicationLayerAutomaticResponseOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*shield.EnableApplicationLayerAutomaticResponseOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// EnableApplicationLayerAutomaticResponseWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) EnableApplicationLayerAutomaticResponseWithContext(_a0 context.Context, _a1 *shield.EnableApplicationLayerAutomaticResponseInput, _a2 ...request.Option) (*shield.EnableApplicationLayerAutomaticResponseOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shield.EnableApplicationLayerAutomaticResponseOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.EnableApplicationLayerAutomaticResponseInput, ...request.Option) *shield.EnableApplicationLayerAutomaticResponseOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.EnableApplicationLayerAutomaticResponseOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.EnableApplicationLayerAutomaticResponseInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnableProactiveEngagement provides a mock function with given fields: _a0
+func (_m *ShieldAPI) EnableProactiveEngagement(_a0 *shield.EnableProactiveEngagementInput) (*shield.EnableProactiveEngagementOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *shield.EnableProactiveEngagementOutput
+	if rf, ok := ret.Get(0).(func(*shield.EnableProactiveEngagementInput) *shield.EnableProactiveEngagementOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.EnableProactiveEngagementOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*shield.EnableProactiveEngagementInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnableProactiveEngagementRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) EnableProactiveEngagementRequest(_a0 *shield.EnableProactiveEngagementInput) (*request.Request, *shield.EnableProactiveEngagementOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*shield.EnableProactiveEngagementInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *shield.EnableProactiveEngagementOutput
+	if rf, ok := ret.Get(1).(func(*shield.EnableProactiveEngagementInput) *shield.EnableProactiveEngagementOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*shield.EnableProactiveEngagementOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// EnableProactiveEngagementWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) EnableProactiveEngagementWithContext(_a0 context.Context, _a1 *shield.EnableProactiveEngagementInput, _a2 ...request.Option) (*shield.EnableProactiveEngagementOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shield.EnableProactiveEngagementOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.EnableProactiveEngagementInput, ...request.Option) *shield.EnableProactiveEngagementOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.EnableProactiveEngagementOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.EnableProactiveEngagementInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubscriptionState provides a mock function with given fields: _a0
+func (_m *ShieldAPI) GetSubscriptionState(_a0 *shield.GetSubscriptionStateInput) (*shield.GetSubscriptionStateOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *shield.GetSubscriptionStateOutput
+	if rf, ok := ret.Get(0).(func(*shield.GetSubscriptionStateInput) *shield.GetSubscriptionStateOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.GetSubscriptionStateOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*shield.GetSubscriptionStateInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubscriptionStateRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) GetSubscriptionStateRequest(_a0 *shield.GetSubscriptionStateInput) (*request.Request, *shield.GetSubscriptionStateOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*shield.GetSubscriptionStateInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *shield.GetSubscriptionStateOutput
+	if rf, ok := ret.Get(1).(func(*shield.GetSubscriptionStateInput) *shield.GetSubscriptionStateOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*shield.GetSubscriptionStateOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetSubscriptionStateWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) GetSubscriptionStateWithContext(_a0 context.Context, _a1 *shield.GetSubscriptionStateInput, _a2 ...request.Option) (*shield.GetSubscriptionStateOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shield.GetSubscriptionStateOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.GetSubscriptionStateInput, ...request.Option) *shield.GetSubscriptionStateOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.GetSubscriptionStateOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.GetSubscriptionStateInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAttacks provides a mock function with given fields: _a0
+func (_m *ShieldAPI) ListAttacks(_a0 *shield.ListAttacksInput) (*shield.ListAttacksOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *shield.ListAttacksOutput
+	if rf, ok := ret.Get(0).(func(*shield.ListAttacksInput) *shield.ListAttacksOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.ListAttacksOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*shield.ListAttacksInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAttacksPages provides a mock function with given fields: _a0, _a1
+func (_m *ShieldAPI) ListAttacksPages(_a0 *shield.ListAttacksInput, _a1 func(*shield.ListAttacksOutput, bool) bool) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*shield.ListAttacksInput, func(*shield.ListAttacksOutput, bool) bool) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListAttacksPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *ShieldAPI) ListAttacksPagesWithContext(_a0 context.Context, _a1 *shield.ListAttacksInput, _a2 func(*shield.ListAttacksOutput, bool) bool, _a3 ...request.Option) error {
+	_va := make([]interface{}, len(_a3))
+	for _i := range _a3 {
+		_va[_i] = _a3[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1, _a2)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.ListAttacksInput, func(*shield.ListAttacksOutput, bool) bool, ...request.Option) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListAttacksRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) ListAttacksRequest(_a0 *shield.ListAttacksInput) (*request.Request, *shield.ListAttacksOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*shield.ListAttacksInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *shield.ListAttacksOutput
+	if rf, ok := ret.Get(1).(func(*shield.ListAttacksInput) *shield.ListAttacksOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*shield.ListAttacksOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListAttacksWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) ListAttacksWithContext(_a0 context.Context, _a1 *shield.ListAttacksInput, _a2 ...request.Option) (*shield.ListAttacksOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shield.ListAttacksOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.ListAttacksInput, ...request.Option) *shield.ListAttacksOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*shield.DescribeDRTAccessOutput)
+			r0 = ret.Get(0).(*shield.ListAttacksOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *shield.DescribeDRTAccessInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.ListAttacksInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -640,21 +2079,21 @@ func (_m *ShieldAPI) DescribeDRTAccessWithContext(_a0 context.Context, _a1 *shie
 	return r0, r1
 }
 
-// DescribeEmergencyContactSettings provides a mock function with given fields: _a0
-func (_m *ShieldAPI) DescribeEmergencyContactSettings(_a0 *shield.DescribeEmergencyContactSettingsInput) (*shield.DescribeEmergencyContactSettingsOutput, error) {
+// ListProtectionGroups provides a mock function with given fields: _a0
+func (_m *ShieldAPI) ListProtectionGroups(_a0 *shield.ListProtectionGroupsInput) (*shield.ListProtectionGroupsOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *shield.DescribeEmergencyContactSettingsOutput
-	if rf, ok := ret.Get(0).(func(*shield.DescribeEmergencyContactSettingsInput) *shield.DescribeEmergencyContactSettingsOutput); ok {
+	var r0 *shield.ListProtectionGroupsOutput
+	if rf, ok := ret.Get(0).(func(*shield.ListProtectionGroupsInput) *shield.ListProtectionGroupsOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*shield.DescribeEmergencyContactSettingsOutput)
+			r0 = ret.Get(0).(*shield.ListProtectionGroupsOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*shield.DescribeEmergencyContactSettingsInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*shield.ListProtectionGroupsInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -663,12 +2102,47 @@ func (_m *ShieldAPI) DescribeEmergencyContactSettings(_a0 *shield.DescribeEmerge
 	return r0, r1
 }
 
-// DescribeEmergencyContactSettingsRequest provides a mock function with given fields: _a0
-func (_m *ShieldAPI) DescribeEmergencyContactSettingsRequest(_a0 *shield.DescribeEmergencyContactSettingsInput) (*request.Request, *shield.DescribeEmergencyContactSettingsOutput) {
+// ListProtectionGroupsPages provides a mock function with given fields: _a0, _a1
+func (_m *ShieldAPI) ListProtectionGroupsPages(_a0 *shield.ListProtectionGroupsInput, _a1 func(*shield.ListProtectionGroupsOutput, bool) bool) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*shield.ListProtectionGroupsInput, func(*shield.ListProtectionGroupsOutput, bool) bool) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListProtectionGroupsPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *ShieldAPI) ListProtectionGroupsPagesWithContext(_a0 context.Context, _a1 *shield.ListProtectionGroupsInput, _a2 func(*shield.ListProtectionGroupsOutput, bool) bool, _a3 ...request.Option) error {
+	_va := make([]interface{}, len(_a3))
+	for _i := range _a3 {
+		_va[_i] = _a3[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1, _a2)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.ListProtectionGroupsInput, func(*shield.ListProtectionGroupsOutput, bool) bool, ...request.Option) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListProtectionGroupsRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) ListProtectionGroupsRequest(_a0 *shield.ListProtectionGroupsInput) (*request.Request, *shield.ListProtectionGroupsOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*shield.DescribeEmergencyContactSettingsInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*shield.ListProtectionGroupsInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -676,20 +2150,20 @@ func (_m *ShieldAPI) DescribeEmergencyContactSettingsRequest(_a0 *shield.Describ
 		}
 	}
 
-	var r1 *shield.DescribeEmergencyContactSettingsOutput
-	if rf, ok := ret.Get(1).(func(*shield.DescribeEmergencyContactSettingsInput) *shield.DescribeEmergencyContactSettingsOutput); ok {
+	var r1 *shield.ListProtectionGroupsOutput
+	if rf, ok := ret.Get(1).(func(*shield.ListProtectionGroupsInput) *shield.ListProtectionGroupsOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*shield.DescribeEmergencyContactSettingsOutput)
+			r1 = ret.Get(1).(*shield.ListProtectionGroupsOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// DescribeEmergencyContactSettingsWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *ShieldAPI) DescribeEmergencyContactSettingsWithContext(_a0 context.Context, _a1 *shield.DescribeEmergencyContactSettingsInput, _a2 ...request.Option) (*shield.DescribeEmergencyContactSettingsOutput, error) {
+// ListProtectionGroupsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) ListProtectionGroupsWithContext(_a0 context.Context, _a1 *shield.ListProtectionGroupsInput, _a2 ...request.Option) (*shield.ListProtectionGroupsOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -699,17 +2173,17 @@ func (_m *ShieldAPI) DescribeEmergencyContactSettingsWithContext(_a0 context.Con
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *shield.DescribeEmergencyContactSettingsOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *shield.DescribeEmergencyContactSettingsInput, ...request.Option) *shield.DescribeEmergencyContactSettingsOutput); ok {
+	var r0 *shield.ListProtectionGroupsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.ListProtectionGroupsInput, ...request.Option) *shield.ListProtectionGroupsOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*shield.DescribeEmergencyContactSettingsOutput)
+			r0 = ret.Get(0).(*shield.ListProtectionGroupsOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *shield.DescribeEmergencyContactSettingsInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.ListProtectionGroupsInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -718,21 +2192,21 @@ func (_m *ShieldAPI) DescribeEmergencyContactSettingsWithContext(_a0 context.Con
 	return r0, r1
 }
 
-// DescribeProtection provides a mock function with given fields: _a0
-func (_m *ShieldAPI) DescribeProtection(_a0 *shield.DescribeProtectionInput) (*shield.DescribeProtectionOutput, error) {
+// ListProtections provides a mock function with given fields: _a0
+func (_m *ShieldAPI) ListProtections(_a0 *shield.ListProtectionsInput) (*shield.ListProtectionsOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *shield.DescribeProtectionOutput
-	if rf, ok := ret.Get(0).(func(*shield.DescribeProtectionInput) *shield.DescribeProtectionOutput); ok {
+	var r0 *shield.ListProtectionsOutput
+	if rf, ok := ret.Get(0).(func(*shield.ListProtectionsInput) *shield.ListProtectionsOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*shield.DescribeProtectionOutput)
+			r0 = ret.Get(0).(*shield.ListProtectionsOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*shield.DescribeProtectionInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*shield.ListProtectionsInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -741,12 +2215,47 @@ func (_m *ShieldAPI) DescribeProtection(_a0 *shield.DescribeProtectionInput) (*s
 	return r0, r1
 }
 
-// DescribeProtectionRequest provides a mock function with given fields: _a0
-func (_m *ShieldAPI) DescribeProtectionRequest(_a0 *shield.DescribeProtectionInput) (*request.Request, *shield.DescribeProtectionOutput) {
+// ListProtectionsPages provides a mock function with given fields: _a0, _a1
+func (_m *ShieldAPI) ListProtectionsPages(_a0 *shield.ListProtectionsInput, _a1 func(*shield.ListProtectionsOutput, bool) bool) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*shield.ListProtectionsInput, func(*shield.ListProtectionsOutput, bool) bool) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListProtectionsPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *ShieldAPI) ListProtectionsPagesWithContext(_a0 context.Context, _a1 *shield.ListProtectionsInput, _a2 func(*shield.ListProtectionsOutput, bool) bool, _a3 ...request.Option) error {
+	_va := make([]interface{}, len(_a3))
+	for _i := range _a3 {
+		_va[_i] = _a3[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1, _a2)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.ListProtectionsInput, func(*shield.ListProtectionsOutput, bool) bool, ...request.Option) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListProtectionsRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) ListProtectionsRequest(_a0 *shield.ListProtectionsInput) (*request.Request, *shield.ListProtectionsOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*shield.DescribeProtectionInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*shield.ListProtectionsInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -754,20 +2263,20 @@ func (_m *ShieldAPI) DescribeProtectionRequest(_a0 *shield.DescribeProtectionInp
 		}
 	}
 
-	var r1 *shield.DescribeProtectionOutput
-	if rf, ok := ret.Get(1).(func(*shield.DescribeProtectionInput) *shield.DescribeProtectionOutput); ok {
+	var r1 *shield.ListProtectionsOutput
+	if rf, ok := ret.Get(1).(func(*shield.ListProtectionsInput) *shield.ListProtectionsOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*shield.DescribeProtectionOutput)
+			r1 = ret.Get(1).(*shield.ListProtectionsOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// DescribeProtectionWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *ShieldAPI) DescribeProtectionWithContext(_a0 context.Context, _a1 *shield.DescribeProtectionInput, _a2 ...request.Option) (*shield.DescribeProtectionOutput, error) {
+// ListProtectionsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) ListProtectionsWithContext(_a0 context.Context, _a1 *shield.ListProtectionsInput, _a2 ...request.Option) (*shield.ListProtectionsOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -777,17 +2286,17 @@ func (_m *ShieldAPI) DescribeProtectionWithContext(_a0 context.Context, _a1 *shi
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *shield.DescribeProtectionOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *shield.DescribeProtectionInput, ...request.Option) *shield.DescribeProtectionOutput); ok {
+	var r0 *shield.ListProtectionsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.ListProtectionsInput, ...request.Option) *shield.ListProtectionsOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*shield.DescribeProtectionOutput)
+			r0 = ret.Get(0).(*shield.ListProtectionsOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *shield.DescribeProtectionInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.ListProtectionsInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -796,21 +2305,21 @@ func (_m *ShieldAPI) DescribeProtectionWithContext(_a0 context.Context, _a1 *shi
 	return r0, r1
 }
 
-// DescribeSubscription provides a mock function with given fields: _a0
-func (_m *ShieldAPI) DescribeSubscription(_a0 *shield.DescribeSubscriptionInput) (*shield.DescribeSubscriptionOutput, error) {
+// ListResourcesInProtectionGroup provides a mock function with given fields: _a0
+func (_m *ShieldAPI) ListResourcesInProtectionGroup(_a0 *shield.ListResourcesInProtectionGroupInput) (*shield.ListResourcesInProtectionGroupOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *shield.DescribeSubscriptionOutput
-	if rf, ok := ret.Get(0).(func(*shield.DescribeSubscriptionInput) *shield.DescribeSubscriptionOutput); ok {
+	var r0 *shield.ListResourcesInProtectionGroupOutput
+	if rf, ok := ret.Get(0).(func(*shield.ListResourcesInProtectionGroupInput) *shield.ListResourcesInProtectionGroupOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*shield.DescribeSubscriptionOutput)
+			r0 = ret.Get(0).(*shield.ListResourcesInProtectionGroupOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*shield.DescribeSubscriptionInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*shield.ListResourcesInProtectionGroupInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -819,12 +2328,47 @@ func (_m *ShieldAPI) DescribeSubscription(_a0 *shield.DescribeSubscriptionInput)
 	return r0, r1
 }
 
-// DescribeSubscriptionRequest provides a mock function with given fields: _a0
-func (_m *ShieldAPI) DescribeSubscriptionRequest(_a0 *shield.DescribeSubscriptionInput) (*request.Request, *shield.DescribeSubscriptionOutput) {
+// ListResourcesInProtectionGroupPages provides a mock function with given fields: _a0, _a1
+func (_m *ShieldAPI) ListResourcesInProtectionGroupPages(_a0 *shield.ListResourcesInProtectionGroupInput, _a1 func(*shield.ListResourcesInProtectionGroupOutput, bool) bool) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*shield.ListResourcesInProtectionGroupInput, func(*shield.ListResourcesInProtectionGroupOutput, bool) bool) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListResourcesInProtectionGroupPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *ShieldAPI) ListResourcesInProtectionGroupPagesWithContext(_a0 context.Context, _a1 *shield.ListResourcesInProtectionGroupInput, _a2 func(*shield.ListResourcesInProtectionGroupOutput, bool) bool, _a3 ...request.Option) error {
+	_va := make([]interface{}, len(_a3))
+	for _i := range _a3 {
+		_va[_i] = _a3[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1, _a2)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.ListResourcesInProtectionGroupInput, func(*shield.ListResourcesInProtectionGroupOutput, bool) bool, ...request.Option) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListResourcesInProtectionGroupRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) ListResourcesInProtectionGroupRequest(_a0 *shield.ListResourcesInProtectionGroupInput) (*request.Request, *shield.ListResourcesInProtectionGroupOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*shield.DescribeSubscriptionInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*shield.ListResourcesInProtectionGroupInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -832,20 +2376,20 @@ func (_m *ShieldAPI) DescribeSubscriptionRequest(_a0 *shield.DescribeSubscriptio
 		}
 	}
 
-	var r1 *shield.DescribeSubscriptionOutput
-	if rf, ok := ret.Get(1).(func(*shield.DescribeSubscriptionInput) *shield.DescribeSubscriptionOutput); ok {
+	var r1 *shield.ListResourcesInProtectionGroupOutput
+	if rf, ok := ret.Get(1).(func(*shield.ListResourcesInProtectionGroupInput) *shield.ListResourcesInProtectionGroupOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*shield.DescribeSubscriptionOutput)
+			r1 = ret.Get(1).(*shield.ListResourcesInProtectionGroupOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// DescribeSubscriptionWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *ShieldAPI) DescribeSubscriptionWithContext(_a0 context.Context, _a1 *shield.DescribeSubscriptionInput, _a2 ...request.Option) (*shield.DescribeSubscriptionOutput, error) {
+// ListResourcesInProtectionGroupWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) ListResourcesInProtectionGroupWithContext(_a0 context.Context, _a1 *shield.ListResourcesInProtectionGroupInput, _a2 ...request.Option) (*shield.ListResourcesInProtectionGroupOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -855,17 +2399,17 @@ func (_m *ShieldAPI) DescribeSubscriptionWithContext(_a0 context.Context, _a1 *s
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *shield.DescribeSubscriptionOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *shield.DescribeSubscriptionInput, ...request.Option) *shield.DescribeSubscriptionOutput); ok {
+	var r0 *shield.ListResourcesInProtectionGroupOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.ListResourcesInProtectionGroupInput, ...request.Option) *shield.ListResourcesInProtectionGroupOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*shield.DescribeSubscriptionOutput)
+			r0 = ret.Get(0).(*shield.ListResourcesInProtectionGroupOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *shield.DescribeSubscriptionInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.ListResourcesInProtectionGroupInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -874,21 +2418,21 @@ func (_m *ShieldAPI) DescribeSubscriptionWithContext(_a0 context.Context, _a1 *s
 	return r0, r1
 }
 
-// DisassociateDRTLogBucket provides a mock function with given fields: _a0
-func (_m *ShieldAPI) DisassociateDRTLogBucket(_a0 *shield.DisassociateDRTLogBucketInput) (*shield.DisassociateDRTLogBucketOutput, error) {
+// ListTagsForResource provides a mock function with given fields: _a0
+func (_m *ShieldAPI) ListTagsForResource(_a0 *shield.ListTagsForResourceInput) (*shield.ListTagsForResourceOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *shield.DisassociateDRTLogBucketOutput
-	if rf, ok := ret.Get(0).(func(*shield.DisassociateDRTLogBucketInput) *shield.DisassociateDRTLogBucketOutput); ok {
+	var r0 *shield.ListTagsForResourceOutput
+	if rf, ok := ret.Get(0).(func(*shield.ListTagsForResourceInput) *shield.ListTagsForResourceOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*shield.DisassociateDRTLogBucketOutput)
+			r0 = ret.Get(0).(*shield.ListTagsForResourceOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*shield.DisassociateDRTLogBucketInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*shield.ListTagsForResourceInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -897,12 +2441,12 @@ func (_m *ShieldAPI) DisassociateDRTLogBucket(_a0 *shield.DisassociateDRTLogBuck
 	return r0, r1
 }
 
-// DisassociateDRTLogBucketRequest provides a mock function with given fields: _a0
-func (_m *ShieldAPI) DisassociateDRTLogBucketRequest(_a0 *shield.DisassociateDRTLogBucketInput) (*request.Request, *shield.DisassociateDRTLogBucketOutput) {
+// ListTagsForResourceRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) ListTagsForResourceRequest(_a0 *shield.ListTagsForResourceInput) (*request.Request, *shield.ListTagsForResourceOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*shield.DisassociateDRTLogBucketInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*shield.ListTagsForResourceInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -910,20 +2454,20 @@ func (_m *ShieldAPI) DisassociateDRTLogBucketRequest(_a0 *shield.DisassociateDRT
 		}
 	}
 
-	var r1 *shield.DisassociateDRTLogBucketOutput
-	if rf, ok := ret.Get(1).(func(*shield.DisassociateDRTLogBucketInput) *shield.DisassociateDRTLogBucketOutput); ok {
+	var r1 *shield.ListTagsForResourceOutput
+	if rf, ok := ret.Get(1).(func(*shield.ListTagsForResourceInput) *shield.ListTagsForResourceOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*shield.DisassociateDRTLogBucketOutput)
+			r1 = ret.Get(1).(*shield.ListTagsForResourceOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// DisassociateDRTLogBucketWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *ShieldAPI) DisassociateDRTLogBucketWithContext(_a0 context.Context, _a1 *shield.DisassociateDRTLogBucketInput, _a2 ...request.Option) (*shield.DisassociateDRTLogBucketOutput, error) {
+// ListTagsForResourceWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) ListTagsForResourceWithContext(_a0 context.Context, _a1 *shield.ListTagsForResourceInput, _a2 ...request.Option) (*shield.ListTagsForResourceOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -933,17 +2477,17 @@ func (_m *ShieldAPI) DisassociateDRTLogBucketWithContext(_a0 context.Context, _a
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *shield.DisassociateDRTLogBucketOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *shield.DisassociateDRTLogBucketInput, ...request.Option) *shield.DisassociateDRTLogBucketOutput); ok {
+	var r0 *shield.ListTagsForResourceOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.ListTagsForResourceInput, ...request.Option) *shield.ListTagsForResourceOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*shield.DisassociateDRTLogBucketOutput)
+			r0 = ret.Get(0).(*shield.ListTagsForResourceOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *shield.DisassociateDRTLogBucketInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.ListTagsForResourceInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -952,21 +2496,21 @@ func (_m *ShieldAPI) DisassociateDRTLogBucketWithContext(_a0 context.Context, _a
 	return r0, r1
 }
 
-// DisassociateDRTRole provides a mock function with given fields: _a0
-func (_m *ShieldAPI) DisassociateDRTRole(_a0 *shield.DisassociateDRTRoleInput) (*shield.DisassociateDRTRoleOutput, error) {
+// TagResource provides a mock function with given fields: _a0
+func (_m *ShieldAPI) TagResource(_a0 *shield.TagResourceInput) (*shield.TagResourceOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *shield.DisassociateDRTRoleOutput
-	if rf, ok := ret.Get(0).(func(*shield.DisassociateDRTRoleInput) *shield.DisassociateDRTRoleOutput); ok {
+	var r0 *shield.TagResourceOutput
+	if rf, ok := ret.Get(0).(func(*shield.TagResourceInput) *shield.TagResourceOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*shield.DisassociateDRTRoleOutput)
+			r0 = ret.Get(0).(*shield.TagResourceOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*shield.DisassociateDRTRoleInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*shield.TagResourceInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -975,12 +2519,12 @@ func (_m *ShieldAPI) DisassociateDRTRole(_a0 *shield.DisassociateDRTRoleInput) (
 	return r0, r1
 }
 
-// DisassociateDRTRoleRequest provides a mock function with given fields: _a0
-func (_m *ShieldAPI) DisassociateDRTRoleRequest(_a0 *shield.DisassociateDRTRoleInput) (*request.Request, *shield.DisassociateDRTRoleOutput) {
+// TagResourceRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) TagResourceRequest(_a0 *shield.TagResourceInput) (*request.Request, *shield.TagResourceOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*shield.DisassociateDRTRoleInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*shield.TagResourceInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -988,20 +2532,20 @@ func (_m *ShieldAPI) DisassociateDRTRoleRequest(_a0 *shield.DisassociateDRTRoleI
 		}
 	}
 
-	var r1 *shield.DisassociateDRTRoleOutput
-	if rf, ok := ret.Get(1).(func(*shield.DisassociateDRTRoleInput) *shield.DisassociateDRTRoleOutput); ok {
+	var r1 *shield.TagResourceOutput
+	if rf, ok := ret.Get(1).(func(*shield.TagResourceInput) *shield.TagResourceOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*shield.DisassociateDRTRoleOutput)
+			r1 = ret.Get(1).(*shield.TagResourceOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// DisassociateDRTRoleWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *ShieldAPI) DisassociateDRTRoleWithContext(_a0 context.Context, _a1 *shield.DisassociateDRTRoleInput, _a2 ...request.Option) (*shield.DisassociateDRTRoleOutput, error) {
+// TagResourceWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) TagResourceWithContext(_a0 context.Context, _a1 *shield.TagResourceInput, _a2 ...request.Option) (*shield.TagResourceOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -1011,17 +2555,17 @@ func (_m *ShieldAPI) DisassociateDRTRoleWithContext(_a0 context.Context, _a1 *sh
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *shield.DisassociateDRTRoleOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *shield.DisassociateDRTRoleInput, ...request.Option) *shield.DisassociateDRTRoleOutput); ok {
+	var r0 *shield.TagResourceOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.TagResourceInput, ...request.Option) *shield.TagResourceOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*shield.DisassociateDRTRoleOutput)
+			r0 = ret.Get(0).(*shield.TagResourceOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *shield.DisassociateDRTRoleInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.TagResourceInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -1030,21 +2574,21 @@ func (_m *ShieldAPI) DisassociateDRTRoleWithContext(_a0 context.Context, _a1 *sh
 	return r0, r1
 }
 
-// GetSubscriptionState provides a mock function with given fields: _a0
-func (_m *ShieldAPI) GetSubscriptionState(_a0 *shield.GetSubscriptionStateInput) (*shield.GetSubscriptionStateOutput, error) {
+// UntagResource provides a mock function with given fields: _a0
+func (_m *ShieldAPI) UntagResource(_a0 *shield.UntagResourceInput) (*shield.UntagResourceOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *shield.GetSubscriptionStateOutput
-	if rf, ok := ret.Get(0).(func(*shield.GetSubscriptionStateInput) *shield.GetSubscriptionStateOutput); ok {
+	var r0 *shield.UntagResourceOutput
+	if rf, ok := ret.Get(0).(func(*shield.UntagResourceInput) *shield.UntagResourceOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*shield.GetSubscriptionStateOutput)
+			r0 = ret.Get(0).(*shield.UntagResourceOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*shield.GetSubscriptionStateInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*shield.UntagResourceInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -1053,12 +2597,12 @@ func (_m *ShieldAPI) GetSubscriptionState(_a0 *shield.GetSubscriptionStateInput)
 	return r0, r1
 }
 
-// GetSubscriptionStateRequest provides a mock function with given fields: _a0
-func (_m *ShieldAPI) GetSubscriptionStateRequest(_a0 *shield.GetSubscriptionStateInput) (*request.Request, *shield.GetSubscriptionStateOutput) {
+// UntagResourceRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) UntagResourceRequest(_a0 *shield.UntagResourceInput) (*request.Request, *shield.UntagResourceOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*shield.GetSubscriptionStateInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*shield.UntagResourceInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -1066,20 +2610,20 @@ func (_m *ShieldAPI) GetSubscriptionStateRequest(_a0 *shield.GetSubscriptionStat
 		}
 	}
 
-	var r1 *shield.GetSubscriptionStateOutput
-	if rf, ok := ret.Get(1).(func(*shield.GetSubscriptionStateInput) *shield.GetSubscriptionStateOutput); ok {
+	var r1 *shield.UntagResourceOutput
+	if rf, ok := ret.Get(1).(func(*shield.UntagResourceInput) *shield.UntagResourceOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*shield.GetSubscriptionStateOutput)
+			r1 = ret.Get(1).(*shield.UntagResourceOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// GetSubscriptionStateWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *ShieldAPI) GetSubscriptionStateWithContext(_a0 context.Context, _a1 *shield.GetSubscriptionStateInput, _a2 ...request.Option) (*shield.GetSubscriptionStateOutput, error) {
+// UntagResourceWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) UntagResourceWithContext(_a0 context.Context, _a1 *shield.UntagResourceInput, _a2 ...request.Option) (*shield.UntagResourceOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -1089,17 +2633,17 @@ func (_m *ShieldAPI) GetSubscriptionStateWithContext(_a0 context.Context, _a1 *s
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *shield.GetSubscriptionStateOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *shield.GetSubscriptionStateInput, ...request.Option) *shield.GetSubscriptionStateOutput); ok {
+	var r0 *shield.UntagResourceOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.UntagResourceInput, ...request.Option) *shield.UntagResourceOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*shield.GetSubscriptionStateOutput)
+			r0 = ret.Get(0).(*shield.UntagResourceOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *shield.GetSubscriptionStateInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.UntagResourceInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -1108,21 +2652,21 @@ func (_m *ShieldAPI) GetSubscriptionStateWithContext(_a0 context.Context, _a1 *s
 	return r0, r1
 }
 
-// ListAttacks provides a mock function with given fields: _a0
-func (_m *ShieldAPI) ListAttacks(_a0 *shield.ListAttacksInput) (*shield.ListAttacksOutput, error) {
+// UpdateApplicationLayerAutomaticResponse provides a mock function with given fields: _a0
+func (_m *ShieldAPI) UpdateApplicationLayerAutomaticResponse(_a0 *shield.UpdateApplicationLayerAutomaticResponseInput) (*shield.UpdateApplicationLayerAutomaticResponseOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *shield.ListAttacksOutput
-	if rf, ok := ret.Get(0).(func(*shield.ListAttacksInput) *shield.ListAttacksOutput); ok {
+	var r0 *shield.UpdateApplicationLayerAutomaticResponseOutput
+	if rf, ok := ret.Get(0).(func(*shield.UpdateApplicationLayerAutomaticResponseInput) *shield.UpdateApplicationLayerAutomaticResponseOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*shield.ListAttacksOutput)
+			r0 = ret.Get(0).(*shield.UpdateApplicationLayerAutomaticResponseOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*shield.ListAttacksInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*shield.UpdateApplicationLayerAutomaticResponseInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -1131,12 +2675,12 @@ func (_m *ShieldAPI) ListAttacks(_a0 *shield.ListAttacksInput) (*shield.ListAtta
 	return r0, r1
 }
 
-// ListAttacksRequest provides a mock function with given fields: _a0
-func (_m *ShieldAPI) ListAttacksRequest(_a0 *shield.ListAttacksInput) (*request.Request, *shield.ListAttacksOutput) {
+// UpdateApplicationLayerAutomaticResponseRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) UpdateApplicationLayerAutomaticResponseRequest(_a0 *shield.UpdateApplicationLayerAutomaticResponseInput) (*request.Request, *shield.UpdateApplicationLayerAutomaticResponseOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*shield.ListAttacksInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*shield.UpdateApplicationLayerAutomaticResponseInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -1144,20 +2688,20 @@ func (_m *ShieldAPI) ListAttacksRequest(_a0 *shield.ListAttacksInput) (*request.
 		}
 	}
 
-	var r1 *shield.ListAttacksOutput
-	if rf, ok := ret.Get(1).(func(*shield.ListAttacksInput) *shield.ListAttacksOutput); ok {
+	var r1 *shield.UpdateApplicationLayerAutomaticResponseOutput
+	if rf, ok := ret.Get(1).(func(*shield.UpdateApplicationLayerAutomaticResponseInput) *shield.UpdateApplicationLayerAutomaticResponseOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*shield.ListAttacksOutput)
+			r1 = ret.Get(1).(*shield.UpdateApplicationLayerAutomaticResponseOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// ListAttacksWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *ShieldAPI) ListAttacksWithContext(_a0 context.Context, _a1 *shield.ListAttacksInput, _a2 ...request.Option) (*shield.ListAttacksOutput, error) {
+// UpdateApplicationLayerAutomaticResponseWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) UpdateApplicationLayerAutomaticResponseWithContext(_a0 context.Context, _a1 *shield.UpdateApplicationLayerAutomaticResponseInput, _a2 ...request.Option) (*shield.UpdateApplicationLayerAutomaticResponseOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -1167,17 +2711,17 @@ func (_m *ShieldAPI) ListAttacksWithContext(_a0 context.Context, _a1 *shield.Lis
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *shield.ListAttacksOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *shield.ListAttacksInput, ...request.Option) *shield.ListAttacksOutput); ok {
+	var r0 *shield.UpdateApplicationLayerAutomaticResponseOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.UpdateApplicationLayerAutomaticResponseInput, ...request.Option) *shield.UpdateApplicationLayerAutomaticResponseOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*shield.ListAttacksOutput)
+			r0 = ret.Get(0).(*shield.UpdateApplicationLayerAutomaticResponseOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *shield.ListAttacksInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.UpdateApplicationLayerAutomaticResponseInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -1186,21 +2730,21 @@ func (_m *ShieldAPI) ListAttacksWithContext(_a0 context.Context, _a1 *shield.Lis
 	return r0, r1
 }
 
-// ListProtections provides a mock function with given fields: _a0
-func (_m *ShieldAPI) ListProtections(_a0 *shield.ListProtectionsInput) (*shield.ListProtectionsOutput, error) {
+// UpdateEmergencyContactSettings provides a mock function with given fields: _a0
+func (_m *ShieldAPI) UpdateEmergencyContactSettings(_a0 *shield.UpdateEmergencyContactSettingsInput) (*shield.UpdateEmergencyContactSettingsOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *shield.ListProtectionsOutput
-	if rf, ok := ret.Get(0).(func(*shield.ListProtectionsInput) *shield.ListProtectionsOutput); ok {
+	var r0 *shield.UpdateEmergencyContactSettingsOutput
+	if rf, ok := ret.Get(0).(func(*shield.UpdateEmergencyContactSettingsInput) *shield.UpdateEmergencyContactSettingsOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*shield.ListProtectionsOutput)
+			r0 = ret.Get(0).(*shield.UpdateEmergencyContactSettingsOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*shield.ListProtectionsInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*shield.UpdateEmergencyContactSettingsInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -1209,12 +2753,12 @@ func (_m *ShieldAPI) ListProtections(_a0 *shield.ListProtectionsInput) (*shield.
 	return r0, r1
 }
 
-// ListProtectionsRequest provides a mock function with given fields: _a0
-func (_m *ShieldAPI) ListProtectionsRequest(_a0 *shield.ListProtectionsInput) (*request.Request, *shield.ListProtectionsOutput) {
+// UpdateEmergencyContactSettingsRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) UpdateEmergencyContactSettingsRequest(_a0 *shield.UpdateEmergencyContactSettingsInput) (*request.Request, *shield.UpdateEmergencyContactSettingsOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*shield.ListProtectionsInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*shield.UpdateEmergencyContactSettingsInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -1222,20 +2766,20 @@ func (_m *ShieldAPI) ListProtectionsRequest(_a0 *shield.ListProtectionsInput) (*
 		}
 	}
 
-	var r1 *shield.ListProtectionsOutput
-	if rf, ok := ret.Get(1).(func(*shield.ListProtectionsInput) *shield.ListProtectionsOutput); ok {
+	var r1 *shield.UpdateEmergencyContactSettingsOutput
+	if rf, ok := ret.Get(1).(func(*shield.UpdateEmergencyContactSettingsInput) *shield.UpdateEmergencyContactSettingsOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*shield.ListProtectionsOutput)
+			r1 = ret.Get(1).(*shield.UpdateEmergencyContactSettingsOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// ListProtectionsWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *ShieldAPI) ListProtectionsWithContext(_a0 context.Context, _a1 *shield.ListProtectionsInput, _a2 ...request.Option) (*shield.ListProtectionsOutput, error) {
+// UpdateEmergencyContactSettingsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) UpdateEmergencyContactSettingsWithContext(_a0 context.Context, _a1 *shield.UpdateEmergencyContactSettingsInput, _a2 ...request.Option) (*shield.UpdateEmergencyContactSettingsOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -1245,17 +2789,17 @@ func (_m *ShieldAPI) ListProtectionsWithContext(_a0 context.Context, _a1 *shield
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *shield.ListProtectionsOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *shield.ListProtectionsInput, ...request.Option) *shield.ListProtectionsOutput); ok {
+	var r0 *shield.UpdateEmergencyContactSettingsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.UpdateEmergencyContactSettingsInput, ...request.Option) *shield.UpdateEmergencyContactSettingsOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*shield.ListProtectionsOutput)
+			r0 = ret.Get(0).(*shield.UpdateEmergencyContactSettingsOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *shield.ListProtectionsInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.UpdateEmergencyContactSettingsInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -1264,21 +2808,21 @@ func (_m *ShieldAPI) ListProtectionsWithContext(_a0 context.Context, _a1 *shield
 	return r0, r1
 }
 
-// UpdateEmergencyContactSettings provides a mock function with given fields: _a0
-func (_m *ShieldAPI) UpdateEmergencyContactSettings(_a0 *shield.UpdateEmergencyContactSettingsInput) (*shield.UpdateEmergencyContactSettingsOutput, error) {
+// UpdateProtectionGroup provides a mock function with given fields: _a0
+func (_m *ShieldAPI) UpdateProtectionGroup(_a0 *shield.UpdateProtectionGroupInput) (*shield.UpdateProtectionGroupOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *shield.UpdateEmergencyContactSettingsOutput
-	if rf, ok := ret.Get(0).(func(*shield.UpdateEmergencyContactSettingsInput) *shield.UpdateEmergencyContactSettingsOutput); ok {
+	var r0 *shield.UpdateProtectionGroupOutput
+	if rf, ok := ret.Get(0).(func(*shield.UpdateProtectionGroupInput) *shield.UpdateProtectionGroupOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*shield.UpdateEmergencyContactSettingsOutput)
+			r0 = ret.Get(0).(*shield.UpdateProtectionGroupOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*shield.UpdateEmergencyContactSettingsInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*shield.UpdateProtectionGroupInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -1287,12 +2831,12 @@ func (_m *ShieldAPI) UpdateEmergencyContactSettings(_a0 *shield.UpdateEmergencyC
 	return r0, r1
 }
 
-// UpdateEmergencyContactSettingsRequest provides a mock function with given fields: _a0
-func (_m *ShieldAPI) UpdateEmergencyContactSettingsRequest(_a0 *shield.UpdateEmergencyContactSettingsInput) (*request.Request, *shield.UpdateEmergencyContactSettingsOutput) {
+// UpdateProtectionGroupRequest provides a mock function with given fields: _a0
+func (_m *ShieldAPI) UpdateProtectionGroupRequest(_a0 *shield.UpdateProtectionGroupInput) (*request.Request, *shield.UpdateProtectionGroupOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*shield.UpdateEmergencyContactSettingsInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*shield.UpdateProtectionGroupInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -1300,20 +2844,20 @@ func (_m *ShieldAPI) UpdateEmergencyContactSettingsRequest(_a0 *shield.UpdateEme
 		}
 	}
 
-	var r1 *shield.UpdateEmergencyContactSettingsOutput
-	if rf, ok := ret.Get(1).(func(*shield.UpdateEmergencyContactSettingsInput) *shield.UpdateEmergencyContactSettingsOutput); ok {
+	var r1 *shield.UpdateProtectionGroupOutput
+	if rf, ok := ret.Get(1).(func(*shield.UpdateProtectionGroupInput) *shield.UpdateProtectionGroupOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*shield.UpdateEmergencyContactSettingsOutput)
+			r1 = ret.Get(1).(*shield.UpdateProtectionGroupOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// UpdateEmergencyContactSettingsWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *ShieldAPI) UpdateEmergencyContactSettingsWithContext(_a0 context.Context, _a1 *shield.UpdateEmergencyContactSettingsInput, _a2 ...request.Option) (*shield.UpdateEmergencyContactSettingsOutput, error) {
+// UpdateProtectionGroupWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ShieldAPI) UpdateProtectionGroupWithContext(_a0 context.Context, _a1 *shield.UpdateProtectionGroupInput, _a2 ...request.Option) (*shield.UpdateProtectionGroupOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -1323,17 +2867,17 @@ func (_m *ShieldAPI) UpdateEmergencyContactSettingsWithContext(_a0 context.Conte
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *shield.UpdateEmergencyContactSettingsOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *shield.UpdateEmergencyContactSettingsInput, ...request.Option) *shield.UpdateEmergencyContactSettingsOutput); ok {
+	var r0 *shield.UpdateProtectionGroupOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *shield.UpdateProtectionGroupInput, ...request.Option) *shield.UpdateProtectionGroupOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*shield.UpdateEmergencyContactSettingsOutput)
+			r0 = ret.Get(0).(*shield.UpdateProtectionGroupOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *shield.UpdateEmergencyContactSettingsInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *shield.UpdateProtectionGroupInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -899,6 +899,84 @@ func (_m *WAFRegionalAPI) CreateWebACL(_a0 *waf.CreateWebACLInput) (*waf.CreateW
 	return r0, r1
 }
 
+// CreateWebACLMigrationStack provides a mock function with given fields: _a0
+func (_m *WAFRegionalAPI) CreateWebACLMigrationStack(_a0 *waf.CreateWebACLMigrationStackInput) (*waf.CreateWebACLMigrationStackOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *waf.CreateWebACLMigrationStackOutput
+	if rf, ok := ret.Get(0).(func(*waf.CreateWebACLMigrationStackInput) *waf.CreateWebACLMigrationStackOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*waf.CreateWebACLMigrationStackOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*waf.CreateWebACLMigrationStackInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateWebACLMigrationStackRequest provides a mock function with given fields: _a0
+func (_m *WAFRegionalAPI) CreateWebACLMigrationStackRequest(_a0 *waf.CreateWebACLMigrationStackInput) (*request.Request, *waf.CreateWebACLMigrationStackOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*waf.CreateWebACLMigrationStackInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *waf.CreateWebACLMigrationStackOutput
+	if rf, ok := ret.Get(1).(func(*waf.CreateWebACLMigrationStackInput) *waf.CreateWebACLMigrationStackOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*waf.CreateWebACLMigrationStackOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// CreateWebACLMigrationStackWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFRegionalAPI) CreateWebACLMigrationStackWithContext(_a0 context.Context, _a1 *waf.CreateWebACLMigrationStackInput, _a2 ...request.Option) (*waf.CreateWebACLMigrationStackOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *waf.CreateWebACLMigrationStackOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *waf.CreateWebACLMigrationStackInput, ...request.Option) *waf.CreateWebACLMigrationStackOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*waf.CreateWebACLMigrationStackOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *waf.CreateWebACLMigrationStackInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateWebACLRequest provides a mock function with given fields: _a0
 func (_m *WAFRegionalAPI) CreateWebACLRequest(_a0 *waf.CreateWebACLInput) (*request.Request, *waf.CreateWebACLOutput) {
 	ret := _m.Called(_a0)
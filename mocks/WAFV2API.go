@@ -172,6 +172,84 @@ func (_m *WAFV2API) CheckCapacityWithContext(_a0 context.Context, _a1 *wafv2.Che
 	return r0, r1
 }
 
+// CreateAPIKey provides a mock function with given fields: _a0
+func (_m *WAFV2API) CreateAPIKey(_a0 *wafv2.CreateAPIKeyInput) (*wafv2.CreateAPIKeyOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *wafv2.CreateAPIKeyOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.CreateAPIKeyInput) *wafv2.CreateAPIKeyOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.CreateAPIKeyOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*wafv2.CreateAPIKeyInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAPIKeyRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) CreateAPIKeyRequest(_a0 *wafv2.CreateAPIKeyInput) (*request.Request, *wafv2.CreateAPIKeyOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*wafv2.CreateAPIKeyInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *wafv2.CreateAPIKeyOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.CreateAPIKeyInput) *wafv2.CreateAPIKeyOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*wafv2.CreateAPIKeyOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// CreateAPIKeyWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) CreateAPIKeyWithContext(_a0 context.Context, _a1 *wafv2.CreateAPIKeyInput, _a2 ...request.Option) (*wafv2.CreateAPIKeyOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *wafv2.CreateAPIKeyOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.CreateAPIKeyInput, ...request.Option) *wafv2.CreateAPIKeyOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.CreateAPIKeyOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.CreateAPIKeyInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateIPSet provides a mock function with given fields: _a0
 func (_m *WAFV2API) CreateIPSet(_a0 *wafv2.CreateIPSetInput) (*wafv2.CreateIPSetOutput, error) {
 	ret := _m.Called(_a0)
@@ -484,6 +562,162 @@ func (_m *WAFV2API) CreateWebACLWithContext(_a0 context.Context, _a1 *wafv2.Crea
 	return r0, r1
 }
 
+// DeleteAPIKey provides a mock function with given fields: _a0
+func (_m *WAFV2API) DeleteAPIKey(_a0 *wafv2.DeleteAPIKeyInput) (*wafv2.DeleteAPIKeyOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *wafv2.DeleteAPIKeyOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.DeleteAPIKeyInput) *wafv2.DeleteAPIKeyOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.DeleteAPIKeyOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*wafv2.DeleteAPIKeyInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteAPIKeyRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) DeleteAPIKeyRequest(_a0 *wafv2.DeleteAPIKeyInput) (*request.Request, *wafv2.DeleteAPIKeyOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*wafv2.DeleteAPIKeyInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *wafv2.DeleteAPIKeyOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.DeleteAPIKeyInput) *wafv2.DeleteAPIKeyOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*wafv2.DeleteAPIKeyOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteAPIKeyWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) DeleteAPIKeyWithContext(_a0 context.Context, _a1 *wafv2.DeleteAPIKeyInput, _a2 ...request.Option) (*wafv2.DeleteAPIKeyOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *wafv2.DeleteAPIKeyOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.DeleteAPIKeyInput, ...request.Option) *wafv2.DeleteAPIKeyOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.DeleteAPIKeyOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.DeleteAPIKeyInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteFirewallManagerRuleGroups provides a mock function with given fields: _a0
+func (_m *WAFV2API) DeleteFirewallManagerRuleGroups(_a0 *wafv2.DeleteFirewallManagerRuleGroupsInput) (*wafv2.DeleteFirewallManagerRuleGroupsOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *wafv2.DeleteFirewallManagerRuleGroupsOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.DeleteFirewallManagerRuleGroupsInput) *wafv2.DeleteFirewallManagerRuleGroupsOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.DeleteFirewallManagerRuleGroupsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*wafv2.DeleteFirewallManagerRuleGroupsInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteFirewallManagerRuleGroupsRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) DeleteFirewallManagerRuleGroupsRequest(_a0 *wafv2.DeleteFirewallManagerRuleGroupsInput) (*request.Request, *wafv2.DeleteFirewallManagerRuleGroupsOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*wafv2.DeleteFirewallManagerRuleGroupsInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *wafv2.DeleteFirewallManagerRuleGroupsOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.DeleteFirewallManagerRuleGroupsInput) *wafv2.DeleteFirewallManagerRuleGroupsOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*wafv2.DeleteFirewallManagerRuleGroupsOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteFirewallManagerRuleGroupsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) DeleteFirewallManagerRuleGroupsWithContext(_a0 context.Context, _a1 *wafv2.DeleteFirewallManagerRuleGroupsInput, _a2 ...request.Option) (*wafv2.DeleteFirewallManagerRuleGroupsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *wafv2.DeleteFirewallManagerRuleGroupsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.DeleteFirewallManagerRuleGroupsInput, ...request.Option) *wafv2.DeleteFirewallManagerRuleGroupsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.DeleteFirewallManagerRuleGroupsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.DeleteFirewallManagerRuleGroupsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteIPSet provides a mock function with given fields: _a0
 func (_m *WAFV2API) DeleteIPSet(_a0 *wafv2.DeleteIPSetInput) (*wafv2.DeleteIPSetOutput, error) {
 	ret := _m.Called(_a0)
@@ -640,6 +874,84 @@ func (_m *WAFV2API) DeleteLoggingConfigurationWithContext(_a0 context.Context, _
 	return r0, r1
 }
 
+// DeletePermissionPolicy provides a mock function with given fields: _a0
+func (_m *WAFV2API) DeletePermissionPolicy(_a0 *wafv2.DeletePermissionPolicyInput) (*wafv2.DeletePermissionPolicyOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *wafv2.DeletePermissionPolicyOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.DeletePermissionPolicyInput) *wafv2.DeletePermissionPolicyOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.DeletePermissionPolicyOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*wafv2.DeletePermissionPolicyInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeletePermissionPolicyRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) DeletePermissionPolicyRequest(_a0 *wafv2.DeletePermissionPolicyInput) (*request.Request, *wafv2.DeletePermissionPolicyOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*wafv2.DeletePermissionPolicyInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *wafv2.DeletePermissionPolicyOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.DeletePermissionPolicyInput) *wafv2.DeletePermissionPolicyOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*wafv2.DeletePermissionPolicyOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeletePermissionPolicyWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) DeletePermissionPolicyWithContext(_a0 context.Context, _a1 *wafv2.DeletePermissionPolicyInput, _a2 ...request.Option) (*wafv2.DeletePermissionPolicyOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *wafv2.DeletePermissionPolicyOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.DeletePermissionPolicyInput, ...request.Option) *wafv2.DeletePermissionPolicyOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.DeletePermissionPolicyOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.DeletePermissionPolicyInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteRegexPatternSet provides a mock function with given fields: _a0
 func (_m *WAFV2API) DeleteRegexPatternSet(_a0 *wafv2.DeleteRegexPatternSetInput) (*wafv2.DeleteRegexPatternSetOutput, error) {
 	ret := _m.Called(_a0)
@@ -874,21 +1186,21 @@ func (_m *WAFV2API) DeleteWebACLWithContext(_a0 context.Context, _a1 *wafv2.Dele
 	return r0, r1
 }
 
-// DescribeManagedRuleGroup provides a mock function with given fields: _a0
-func (_m *WAFV2API) DescribeManagedRuleGroup(_a0 *wafv2.DescribeManagedRuleGroupInput) (*wafv2.DescribeManagedRuleGroupOutput, error) {
+// DescribeAllManagedProducts provides a mock function with given fields: _a0
+func (_m *WAFV2API) DescribeAllManagedProducts(_a0 *wafv2.DescribeAllManagedProductsInput) (*wafv2.DescribeAllManagedProductsOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *wafv2.DescribeManagedRuleGroupOutput
-	if rf, ok := ret.Get(0).(func(*wafv2.DescribeManagedRuleGroupInput) *wafv2.DescribeManagedRuleGroupOutput); ok {
+	var r0 *wafv2.DescribeAllManagedProductsOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.DescribeAllManagedProductsInput) *wafv2.DescribeAllManagedProductsOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.DescribeManagedRuleGroupOutput)
+			r0 = ret.Get(0).(*wafv2.DescribeAllManagedProductsOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*wafv2.DescribeManagedRuleGroupInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*wafv2.DescribeAllManagedProductsInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -897,12 +1209,12 @@ func (_m *WAFV2API) DescribeManagedRuleGroup(_a0 *wafv2.DescribeManagedRuleGroup
 	return r0, r1
 }
 
-// DescribeManagedRuleGroupRequest provides a mock function with given fields: _a0
-func (_m *WAFV2API) DescribeManagedRuleGroupRequest(_a0 *wafv2.DescribeManagedRuleGroupInput) (*request.Request, *wafv2.DescribeManagedRuleGroupOutput) {
+// DescribeAllManagedProductsRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) DescribeAllManagedProductsRequest(_a0 *wafv2.DescribeAllManagedProductsInput) (*request.Request, *wafv2.DescribeAllManagedProductsOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*wafv2.DescribeManagedRuleGroupInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*wafv2.DescribeAllManagedProductsInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -910,20 +1222,20 @@ func (_m *WAFV2API) DescribeManagedRuleGroupRequest(_a0 *wafv2.DescribeManagedRu
 		}
 	}
 
-	var r1 *wafv2.DescribeManagedRuleGroupOutput
-	if rf, ok := ret.Get(1).(func(*wafv2.DescribeManagedRuleGroupInput) *wafv2.DescribeManagedRuleGroupOutput); ok {
+	var r1 *wafv2.DescribeAllManagedProductsOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.DescribeAllManagedProductsInput) *wafv2.DescribeAllManagedProductsOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*wafv2.DescribeManagedRuleGroupOutput)
+			r1 = ret.Get(1).(*wafv2.DescribeAllManagedProductsOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// DescribeManagedRuleGroupWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *WAFV2API) DescribeManagedRuleGroupWithContext(_a0 context.Context, _a1 *wafv2.DescribeManagedRuleGroupInput, _a2 ...request.Option) (*wafv2.DescribeManagedRuleGroupOutput, error) {
+// DescribeAllManagedProductsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) DescribeAllManagedProductsWithContext(_a0 context.Context, _a1 *wafv2.DescribeAllManagedProductsInput, _a2 ...request.Option) (*wafv2.DescribeAllManagedProductsOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -933,17 +1245,17 @@ func (_m *WAFV2API) DescribeManagedRuleGroupWithContext(_a0 context.Context, _a1
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *wafv2.DescribeManagedRuleGroupOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.DescribeManagedRuleGroupInput, ...request.Option) *wafv2.DescribeManagedRuleGroupOutput); ok {
+	var r0 *wafv2.DescribeAllManagedProductsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.DescribeAllManagedProductsInput, ...request.Option) *wafv2.DescribeAllManagedProductsOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.DescribeManagedRuleGroupOutput)
+			r0 = ret.Get(0).(*wafv2.DescribeAllManagedProductsOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.DescribeManagedRuleGroupInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.DescribeAllManagedProductsInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -952,21 +1264,21 @@ func (_m *WAFV2API) DescribeManagedRuleGroupWithContext(_a0 context.Context, _a1
 	return r0, r1
 }
 
-// DisassociateWebACL provides a mock function with given fields: _a0
-func (_m *WAFV2API) DisassociateWebACL(_a0 *wafv2.DisassociateWebACLInput) (*wafv2.DisassociateWebACLOutput, error) {
+// DescribeManagedProductsByVendor provides a mock function with given fields: _a0
+func (_m *WAFV2API) DescribeManagedProductsByVendor(_a0 *wafv2.DescribeManagedProductsByVendorInput) (*wafv2.DescribeManagedProductsByVendorOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *wafv2.DisassociateWebACLOutput
-	if rf, ok := ret.Get(0).(func(*wafv2.DisassociateWebACLInput) *wafv2.DisassociateWebACLOutput); ok {
+	var r0 *wafv2.DescribeManagedProductsByVendorOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.DescribeManagedProductsByVendorInput) *wafv2.DescribeManagedProductsByVendorOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.DisassociateWebACLOutput)
+			r0 = ret.Get(0).(*wafv2.DescribeManagedProductsByVendorOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*wafv2.DisassociateWebACLInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*wafv2.DescribeManagedProductsByVendorInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -975,12 +1287,12 @@ func (_m *WAFV2API) DisassociateWebACL(_a0 *wafv2.DisassociateWebACLInput) (*waf
 	return r0, r1
 }
 
-// DisassociateWebACLRequest provides a mock function with given fields: _a0
-func (_m *WAFV2API) DisassociateWebACLRequest(_a0 *wafv2.DisassociateWebACLInput) (*request.Request, *wafv2.DisassociateWebACLOutput) {
+// DescribeManagedProductsByVendorRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) DescribeManagedProductsByVendorRequest(_a0 *wafv2.DescribeManagedProductsByVendorInput) (*request.Request, *wafv2.DescribeManagedProductsByVendorOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*wafv2.DisassociateWebACLInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*wafv2.DescribeManagedProductsByVendorInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -988,20 +1300,20 @@ func (_m *WAFV2API) DisassociateWebACLRequest(_a0 *wafv2.DisassociateWebACLInput
 		}
 	}
 
-	var r1 *wafv2.DisassociateWebACLOutput
-	if rf, ok := ret.Get(1).(func(*wafv2.DisassociateWebACLInput) *wafv2.DisassociateWebACLOutput); ok {
+	var r1 *wafv2.DescribeManagedProductsByVendorOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.DescribeManagedProductsByVendorInput) *wafv2.DescribeManagedProductsByVendorOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*wafv2.DisassociateWebACLOutput)
+			r1 = ret.Get(1).(*wafv2.DescribeManagedProductsByVendorOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// DisassociateWebACLWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *WAFV2API) DisassociateWebACLWithContext(_a0 context.Context, _a1 *wafv2.DisassociateWebACLInput, _a2 ...request.Option) (*wafv2.DisassociateWebACLOutput, error) {
+// DescribeManagedProductsByVendorWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) DescribeManagedProductsByVendorWithContext(_a0 context.Context, _a1 *wafv2.DescribeManagedProductsByVendorInput, _a2 ...request.Option) (*wafv2.DescribeManagedProductsByVendorOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -1011,17 +1323,17 @@ func (_m *WAFV2API) DisassociateWebACLWithContext(_a0 context.Context, _a1 *wafv
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *wafv2.DisassociateWebACLOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.DisassociateWebACLInput, ...request.Option) *wafv2.DisassociateWebACLOutput); ok {
+	var r0 *wafv2.DescribeManagedProductsByVendorOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.DescribeManagedProductsByVendorInput, ...request.Option) *wafv2.DescribeManagedProductsByVendorOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.DisassociateWebACLOutput)
+			r0 = ret.Get(0).(*wafv2.DescribeManagedProductsByVendorOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.DisassociateWebACLInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.DescribeManagedProductsByVendorInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -1030,21 +1342,21 @@ func (_m *WAFV2API) DisassociateWebACLWithContext(_a0 context.Context, _a1 *wafv
 	return r0, r1
 }
 
-// GetIPSet provides a mock function with given fields: _a0
-func (_m *WAFV2API) GetIPSet(_a0 *wafv2.GetIPSetInput) (*wafv2.GetIPSetOutput, error) {
+// DescribeManagedRuleGroup provides a mock function with given fields: _a0
+func (_m *WAFV2API) DescribeManagedRuleGroup(_a0 *wafv2.DescribeManagedRuleGroupInput) (*wafv2.DescribeManagedRuleGroupOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *wafv2.GetIPSetOutput
-	if rf, ok := ret.Get(0).(func(*wafv2.GetIPSetInput) *wafv2.GetIPSetOutput); ok {
+	var r0 *wafv2.DescribeManagedRuleGroupOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.DescribeManagedRuleGroupInput) *wafv2.DescribeManagedRuleGroupOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.GetIPSetOutput)
+			r0 = ret.Get(0).(*wafv2.DescribeManagedRuleGroupOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*wafv2.GetIPSetInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*wafv2.DescribeManagedRuleGroupInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -1053,12 +1365,12 @@ func (_m *WAFV2API) GetIPSet(_a0 *wafv2.GetIPSetInput) (*wafv2.GetIPSetOutput, e
 	return r0, r1
 }
 
-// GetIPSetRequest provides a mock function with given fields: _a0
-func (_m *WAFV2API) GetIPSetRequest(_a0 *wafv2.GetIPSetInput) (*request.Request, *wafv2.GetIPSetOutput) {
+// DescribeManagedRuleGroupRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) DescribeManagedRuleGroupRequest(_a0 *wafv2.DescribeManagedRuleGroupInput) (*request.Request, *wafv2.DescribeManagedRuleGroupOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*wafv2.GetIPSetInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*wafv2.DescribeManagedRuleGroupInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -1066,20 +1378,20 @@ func (_m *WAFV2API) GetIPSetRequest(_a0 *wafv2.GetIPSetInput) (*request.Request,
 		}
 	}
 
-	var r1 *wafv2.GetIPSetOutput
-	if rf, ok := ret.Get(1).(func(*wafv2.GetIPSetInput) *wafv2.GetIPSetOutput); ok {
+	var r1 *wafv2.DescribeManagedRuleGroupOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.DescribeManagedRuleGroupInput) *wafv2.DescribeManagedRuleGroupOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*wafv2.GetIPSetOutput)
+			r1 = ret.Get(1).(*wafv2.DescribeManagedRuleGroupOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// GetIPSetWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *WAFV2API) GetIPSetWithContext(_a0 context.Context, _a1 *wafv2.GetIPSetInput, _a2 ...request.Option) (*wafv2.GetIPSetOutput, error) {
+// DescribeManagedRuleGroupWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) DescribeManagedRuleGroupWithContext(_a0 context.Context, _a1 *wafv2.DescribeManagedRuleGroupInput, _a2 ...request.Option) (*wafv2.DescribeManagedRuleGroupOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -1089,17 +1401,17 @@ func (_m *WAFV2API) GetIPSetWithContext(_a0 context.Context, _a1 *wafv2.GetIPSet
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *wafv2.GetIPSetOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.GetIPSetInput, ...request.Option) *wafv2.GetIPSetOutput); ok {
+	var r0 *wafv2.DescribeManagedRuleGroupOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.DescribeManagedRuleGroupInput, ...request.Option) *wafv2.DescribeManagedRuleGroupOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.GetIPSetOutput)
+			r0 = ret.Get(0).(*wafv2.DescribeManagedRuleGroupOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.GetIPSetInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.DescribeManagedRuleGroupInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -1108,21 +1420,21 @@ func (_m *WAFV2API) GetIPSetWithContext(_a0 context.Context, _a1 *wafv2.GetIPSet
 	return r0, r1
 }
 
-// GetLoggingConfiguration provides a mock function with given fields: _a0
-func (_m *WAFV2API) GetLoggingConfiguration(_a0 *wafv2.GetLoggingConfigurationInput) (*wafv2.GetLoggingConfigurationOutput, error) {
+// DisassociateWebACL provides a mock function with given fields: _a0
+func (_m *WAFV2API) DisassociateWebACL(_a0 *wafv2.DisassociateWebACLInput) (*wafv2.DisassociateWebACLOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *wafv2.GetLoggingConfigurationOutput
-	if rf, ok := ret.Get(0).(func(*wafv2.GetLoggingConfigurationInput) *wafv2.GetLoggingConfigurationOutput); ok {
+	var r0 *wafv2.DisassociateWebACLOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.DisassociateWebACLInput) *wafv2.DisassociateWebACLOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.GetLoggingConfigurationOutput)
+			r0 = ret.Get(0).(*wafv2.DisassociateWebACLOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*wafv2.GetLoggingConfigurationInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*wafv2.DisassociateWebACLInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -1131,12 +1443,12 @@ func (_m *WAFV2API) GetLoggingConfiguration(_a0 *wafv2.GetLoggingConfigurationIn
 	return r0, r1
 }
 
-// GetLoggingConfigurationRequest provides a mock function with given fields: _a0
-func (_m *WAFV2API) GetLoggingConfigurationRequest(_a0 *wafv2.GetLoggingConfigurationInput) (*request.Request, *wafv2.GetLoggingConfigurationOutput) {
+// DisassociateWebACLRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) DisassociateWebACLRequest(_a0 *wafv2.DisassociateWebACLInput) (*request.Request, *wafv2.DisassociateWebACLOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*wafv2.GetLoggingConfigurationInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*wafv2.DisassociateWebACLInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -1144,20 +1456,20 @@ func (_m *WAFV2API) GetLoggingConfigurationRequest(_a0 *wafv2.GetLoggingConfigur
 		}
 	}
 
-	var r1 *wafv2.GetLoggingConfigurationOutput
-	if rf, ok := ret.Get(1).(func(*wafv2.GetLoggingConfigurationInput) *wafv2.GetLoggingConfigurationOutput); ok {
+	var r1 *wafv2.DisassociateWebACLOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.DisassociateWebACLInput) *wafv2.DisassociateWebACLOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*wafv2.GetLoggingConfigurationOutput)
+			r1 = ret.Get(1).(*wafv2.DisassociateWebACLOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// GetLoggingConfigurationWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *WAFV2API) GetLoggingConfigurationWithContext(_a0 context.Context, _a1 *wafv2.GetLoggingConfigurationInput, _a2 ...request.Option) (*wafv2.GetLoggingConfigurationOutput, error) {
+// DisassociateWebACLWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) DisassociateWebACLWithContext(_a0 context.Context, _a1 *wafv2.DisassociateWebACLInput, _a2 ...request.Option) (*wafv2.DisassociateWebACLOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -1167,17 +1479,17 @@ func (_m *WAFV2API) GetLoggingConfigurationWithContext(_a0 context.Context, _a1
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *wafv2.GetLoggingConfigurationOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.GetLoggingConfigurationInput, ...request.Option) *wafv2.GetLoggingConfigurationOutput); ok {
+	var r0 *wafv2.DisassociateWebACLOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.DisassociateWebACLInput, ...request.Option) *wafv2.DisassociateWebACLOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.GetLoggingConfigurationOutput)
+			r0 = ret.Get(0).(*wafv2.DisassociateWebACLOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.GetLoggingConfigurationInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.DisassociateWebACLInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -1186,21 +1498,21 @@ func (_m *WAFV2API) GetLoggingConfigurationWithContext(_a0 context.Context, _a1
 	return r0, r1
 }
 
-// GetRateBasedStatementManagedKeys provides a mock function with given fields: _a0
-func (_m *WAFV2API) GetRateBasedStatementManagedKeys(_a0 *wafv2.GetRateBasedStatementManagedKeysInput) (*wafv2.GetRateBasedStatementManagedKeysOutput, error) {
+// GenerateMobileSdkReleaseUrl provides a mock function with given fields: _a0
+func (_m *WAFV2API) GenerateMobileSdkReleaseUrl(_a0 *wafv2.GenerateMobileSdkReleaseUrlInput) (*wafv2.GenerateMobileSdkReleaseUrlOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *wafv2.GetRateBasedStatementManagedKeysOutput
-	if rf, ok := ret.Get(0).(func(*wafv2.GetRateBasedStatementManagedKeysInput) *wafv2.GetRateBasedStatementManagedKeysOutput); ok {
+	var r0 *wafv2.GenerateMobileSdkReleaseUrlOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.GenerateMobileSdkReleaseUrlInput) *wafv2.GenerateMobileSdkReleaseUrlOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.GetRateBasedStatementManagedKeysOutput)
+			r0 = ret.Get(0).(*wafv2.GenerateMobileSdkReleaseUrlOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*wafv2.GetRateBasedStatementManagedKeysInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*wafv2.GenerateMobileSdkReleaseUrlInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -1209,12 +1521,12 @@ func (_m *WAFV2API) GetRateBasedStatementManagedKeys(_a0 *wafv2.GetRateBasedStat
 	return r0, r1
 }
 
-// GetRateBasedStatementManagedKeysRequest provides a mock function with given fields: _a0
-func (_m *WAFV2API) GetRateBasedStatementManagedKeysRequest(_a0 *wafv2.GetRateBasedStatementManagedKeysInput) (*request.Request, *wafv2.GetRateBasedStatementManagedKeysOutput) {
+// GenerateMobileSdkReleaseUrlRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) GenerateMobileSdkReleaseUrlRequest(_a0 *wafv2.GenerateMobileSdkReleaseUrlInput) (*request.Request, *wafv2.GenerateMobileSdkReleaseUrlOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*wafv2.GetRateBasedStatementManagedKeysInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*wafv2.GenerateMobileSdkReleaseUrlInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -1222,20 +1534,20 @@ func (_m *WAFV2API) GetRateBasedStatementManagedKeysRequest(_a0 *wafv2.GetRateBa
 		}
 	}
 
-	var r1 *wafv2.GetRateBasedStatementManagedKeysOutput
-	if rf, ok := ret.Get(1).(func(*wafv2.GetRateBasedStatementManagedKeysInput) *wafv2.GetRateBasedStatementManagedKeysOutput); ok {
+	var r1 *wafv2.GenerateMobileSdkReleaseUrlOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.GenerateMobileSdkReleaseUrlInput) *wafv2.GenerateMobileSdkReleaseUrlOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*wafv2.GetRateBasedStatementManagedKeysOutput)
+			r1 = ret.Get(1).(*wafv2.GenerateMobileSdkReleaseUrlOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// GetRateBasedStatementManagedKeysWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *WAFV2API) GetRateBasedStatementManagedKeysWithContext(_a0 context.Context, _a1 *wafv2.GetRateBasedStatementManagedKeysInput, _a2 ...request.Option) (*wafv2.GetRateBasedStatementManagedKeysOutput, error) {
+// GenerateMobileSdkReleaseUrlWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) GenerateMobileSdkReleaseUrlWithContext(_a0 context.Context, _a1 *wafv2.GenerateMobileSdkReleaseUrlInput, _a2 ...request.Option) (*wafv2.GenerateMobileSdkReleaseUrlOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -1245,17 +1557,17 @@ func (_m *WAFV2API) GetRateBasedStatementManagedKeysWithContext(_a0 context.Cont
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *wafv2.GetRateBasedStatementManagedKeysOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.GetRateBasedStatementManagedKeysInput, ...request.Option) *wafv2.GetRateBasedStatementManagedKeysOutput); ok {
+	var r0 *wafv2.GenerateMobileSdkReleaseUrlOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.GenerateMobileSdkReleaseUrlInput, ...request.Option) *wafv2.GenerateMobileSdkReleaseUrlOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.GetRateBasedStatementManagedKeysOutput)
+			r0 = ret.Get(0).(*wafv2.GenerateMobileSdkReleaseUrlOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.GetRateBasedStatementManagedKeysInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.GenerateMobileSdkReleaseUrlInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -1264,21 +1576,21 @@ func (_m *WAFV2API) GetRateBasedStatementManagedKeysWithContext(_a0 context.Cont
 	return r0, r1
 }
 
-// GetRegexPatternSet provides a mock function with given fields: _a0
-func (_m *WAFV2API) GetRegexPatternSet(_a0 *wafv2.GetRegexPatternSetInput) (*wafv2.GetRegexPatternSetOutput, error) {
+// GetDecryptedAPIKey provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetDecryptedAPIKey(_a0 *wafv2.GetDecryptedAPIKeyInput) (*wafv2.GetDecryptedAPIKeyOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *wafv2.GetRegexPatternSetOutput
-	if rf, ok := ret.Get(0).(func(*wafv2.GetRegexPatternSetInput) *wafv2.GetRegexPatternSetOutput); ok {
+	var r0 *wafv2.GetDecryptedAPIKeyOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.GetDecryptedAPIKeyInput) *wafv2.GetDecryptedAPIKeyOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.GetRegexPatternSetOutput)
+			r0 = ret.Get(0).(*wafv2.GetDecryptedAPIKeyOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*wafv2.GetRegexPatternSetInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*wafv2.GetDecryptedAPIKeyInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -1287,12 +1599,12 @@ func (_m *WAFV2API) GetRegexPatternSet(_a0 *wafv2.GetRegexPatternSetInput) (*waf
 	return r0, r1
 }
 
-// GetRegexPatternSetRequest provides a mock function with given fields: _a0
-func (_m *WAFV2API) GetRegexPatternSetRequest(_a0 *wafv2.GetRegexPatternSetInput) (*request.Request, *wafv2.GetRegexPatternSetOutput) {
+// GetDecryptedAPIKeyRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetDecryptedAPIKeyRequest(_a0 *wafv2.GetDecryptedAPIKeyInput) (*request.Request, *wafv2.GetDecryptedAPIKeyOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*wafv2.GetRegexPatternSetInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*wafv2.GetDecryptedAPIKeyInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -1300,20 +1612,20 @@ func (_m *WAFV2API) GetRegexPatternSetRequest(_a0 *wafv2.GetRegexPatternSetInput
 		}
 	}
 
-	var r1 *wafv2.GetRegexPatternSetOutput
-	if rf, ok := ret.Get(1).(func(*wafv2.GetRegexPatternSetInput) *wafv2.GetRegexPatternSetOutput); ok {
+	var r1 *wafv2.GetDecryptedAPIKeyOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.GetDecryptedAPIKeyInput) *wafv2.GetDecryptedAPIKeyOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*wafv2.GetRegexPatternSetOutput)
+			r1 = ret.Get(1).(*wafv2.GetDecryptedAPIKeyOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// GetRegexPatternSetWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *WAFV2API) GetRegexPatternSetWithContext(_a0 context.Context, _a1 *wafv2.GetRegexPatternSetInput, _a2 ...request.Option) (*wafv2.GetRegexPatternSetOutput, error) {
+// GetDecryptedAPIKeyWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) GetDecryptedAPIKeyWithContext(_a0 context.Context, _a1 *wafv2.GetDecryptedAPIKeyInput, _a2 ...request.Option) (*wafv2.GetDecryptedAPIKeyOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -1323,17 +1635,17 @@ func (_m *WAFV2API) GetRegexPatternSetWithContext(_a0 context.Context, _a1 *wafv
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *wafv2.GetRegexPatternSetOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.GetRegexPatternSetInput, ...request.Option) *wafv2.GetRegexPatternSetOutput); ok {
+	var r0 *wafv2.GetDecryptedAPIKeyOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.GetDecryptedAPIKeyInput, ...request.Option) *wafv2.GetDecryptedAPIKeyOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.GetRegexPatternSetOutput)
+			r0 = ret.Get(0).(*wafv2.GetDecryptedAPIKeyOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.GetRegexPatternSetInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.GetDecryptedAPIKeyInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -1342,21 +1654,21 @@ func (_m *WAFV2API) GetRegexPatternSetWithContext(_a0 context.Context, _a1 *wafv
 	return r0, r1
 }
 
-// GetRuleGroup provides a mock function with given fields: _a0
-func (_m *WAFV2API) GetRuleGroup(_a0 *wafv2.GetRuleGroupInput) (*wafv2.GetRuleGroupOutput, error) {
+// GetIPSet provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetIPSet(_a0 *wafv2.GetIPSetInput) (*wafv2.GetIPSetOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *wafv2.GetRuleGroupOutput
-	if rf, ok := ret.Get(0).(func(*wafv2.GetRuleGroupInput) *wafv2.GetRuleGroupOutput); ok {
+	var r0 *wafv2.GetIPSetOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.GetIPSetInput) *wafv2.GetIPSetOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.GetRuleGroupOutput)
+			r0 = ret.Get(0).(*wafv2.GetIPSetOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*wafv2.GetRuleGroupInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*wafv2.GetIPSetInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -1365,12 +1677,12 @@ func (_m *WAFV2API) GetRuleGroup(_a0 *wafv2.GetRuleGroupInput) (*wafv2.GetRuleGr
 	return r0, r1
 }
 
-// GetRuleGroupRequest provides a mock function with given fields: _a0
-func (_m *WAFV2API) GetRuleGroupRequest(_a0 *wafv2.GetRuleGroupInput) (*request.Request, *wafv2.GetRuleGroupOutput) {
+// GetIPSetRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetIPSetRequest(_a0 *wafv2.GetIPSetInput) (*request.Request, *wafv2.GetIPSetOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*wafv2.GetRuleGroupInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*wafv2.GetIPSetInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -1378,20 +1690,20 @@ func (_m *WAFV2API) GetRuleGroupRequest(_a0 *wafv2.GetRuleGroupInput) (*request.
 		}
 	}
 
-	var r1 *wafv2.GetRuleGroupOutput
-	if rf, ok := ret.Get(1).(func(*wafv2.GetRuleGroupInput) *wafv2.GetRuleGroupOutput); ok {
+	var r1 *wafv2.GetIPSetOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.GetIPSetInput) *wafv2.GetIPSetOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*wafv2.GetRuleGroupOutput)
+			r1 = ret.Get(1).(*wafv2.GetIPSetOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// GetRuleGroupWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *WAFV2API) GetRuleGroupWithContext(_a0 context.Context, _a1 *wafv2.GetRuleGroupInput, _a2 ...request.Option) (*wafv2.GetRuleGroupOutput, error) {
+// GetIPSetWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) GetIPSetWithContext(_a0 context.Context, _a1 *wafv2.GetIPSetInput, _a2 ...request.Option) (*wafv2.GetIPSetOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -1401,17 +1713,17 @@ func (_m *WAFV2API) GetRuleGroupWithContext(_a0 context.Context, _a1 *wafv2.GetR
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *wafv2.GetRuleGroupOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.GetRuleGroupInput, ...request.Option) *wafv2.GetRuleGroupOutput); ok {
+	var r0 *wafv2.GetIPSetOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.GetIPSetInput, ...request.Option) *wafv2.GetIPSetOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.GetRuleGroupOutput)
+			r0 = ret.Get(0).(*wafv2.GetIPSetOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.GetRuleGroupInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.GetIPSetInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -1420,21 +1732,21 @@ func (_m *WAFV2API) GetRuleGroupWithContext(_a0 context.Context, _a1 *wafv2.GetR
 	return r0, r1
 }
 
-// GetSampledRequests provides a mock function with given fields: _a0
-func (_m *WAFV2API) GetSampledRequests(_a0 *wafv2.GetSampledRequestsInput) (*wafv2.GetSampledRequestsOutput, error) {
+// GetLoggingConfiguration provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetLoggingConfiguration(_a0 *wafv2.GetLoggingConfigurationInput) (*wafv2.GetLoggingConfigurationOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *wafv2.GetSampledRequestsOutput
-	if rf, ok := ret.Get(0).(func(*wafv2.GetSampledRequestsInput) *wafv2.GetSampledRequestsOutput); ok {
+	var r0 *wafv2.GetLoggingConfigurationOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.GetLoggingConfigurationInput) *wafv2.GetLoggingConfigurationOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.GetSampledRequestsOutput)
+			r0 = ret.Get(0).(*wafv2.GetLoggingConfigurationOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*wafv2.GetSampledRequestsInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*wafv2.GetLoggingConfigurationInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -1443,12 +1755,12 @@ func (_m *WAFV2API) GetSampledRequests(_a0 *wafv2.GetSampledRequestsInput) (*waf
 	return r0, r1
 }
 
-// GetSampledRequestsRequest provides a mock function with given fields: _a0
-func (_m *WAFV2API) GetSampledRequestsRequest(_a0 *wafv2.GetSampledRequestsInput) (*request.Request, *wafv2.GetSampledRequestsOutput) {
+// GetLoggingConfigurationRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetLoggingConfigurationRequest(_a0 *wafv2.GetLoggingConfigurationInput) (*request.Request, *wafv2.GetLoggingConfigurationOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*wafv2.GetSampledRequestsInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*wafv2.GetLoggingConfigurationInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -1456,20 +1768,20 @@ func (_m *WAFV2API) GetSampledRequestsRequest(_a0 *wafv2.GetSampledRequestsInput
 		}
 	}
 
-	var r1 *wafv2.GetSampledRequestsOutput
-	if rf, ok := ret.Get(1).(func(*wafv2.GetSampledRequestsInput) *wafv2.GetSampledRequestsOutput); ok {
+	var r1 *wafv2.GetLoggingConfigurationOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.GetLoggingConfigurationInput) *wafv2.GetLoggingConfigurationOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*wafv2.GetSampledRequestsOutput)
+			r1 = ret.Get(1).(*wafv2.GetLoggingConfigurationOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// GetSampledRequestsWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *WAFV2API) GetSampledRequestsWithContext(_a0 context.Context, _a1 *wafv2.GetSampledRequestsInput, _a2 ...request.Option) (*wafv2.GetSampledRequestsOutput, error) {
+// GetLoggingConfigurationWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) GetLoggingConfigurationWithContext(_a0 context.Context, _a1 *wafv2.GetLoggingConfigurationInput, _a2 ...request.Option) (*wafv2.GetLoggingConfigurationOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -1479,17 +1791,17 @@ func (_m *WAFV2API) GetSampledRequestsWithContext(_a0 context.Context, _a1 *wafv
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *wafv2.GetSampledRequestsOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.GetSampledRequestsInput, ...request.Option) *wafv2.GetSampledRequestsOutput); ok {
+	var r0 *wafv2.GetLoggingConfigurationOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.GetLoggingConfigurationInput, ...request.Option) *wafv2.GetLoggingConfigurationOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.GetSampledRequestsOutput)
+			r0 = ret.Get(0).(*wafv2.GetLoggingConfigurationOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.GetSampledRequestsInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.GetLoggingConfigurationInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -1498,44 +1810,21 @@ func (_m *WAFV2API) GetSampledRequestsWithContext(_a0 context.Context, _a1 *wafv
 	return r0, r1
 }
 
-// GetWebACL provides a mock function with given fields: _a0
-func (_m *WAFV2API) GetWebACL(_a0 *wafv2.GetWebACLInput) (*wafv2.GetWebACLOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *wafv2.GetWebACLOutput
-	if rf, ok := ret.Get(0).(func(*wafv2.GetWebACLInput) *wafv2.GetWebACLOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.GetWebACLOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*wafv2.GetWebACLInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// GetWebACLForResource provides a mock function with given fields: _a0
-func (_m *WAFV2API) GetWebACLForResource(_a0 *wafv2.GetWebACLForResourceInput) (*wafv2.GetWebACLForResourceOutput, error) {
+// GetManagedRuleSet provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetManagedRuleSet(_a0 *wafv2.GetManagedRuleSetInput) (*wafv2.GetManagedRuleSetOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *wafv2.GetWebACLForResourceOutput
-	if rf, ok := ret.Get(0).(func(*wafv2.GetWebACLForResourceInput) *wafv2.GetWebACLForResourceOutput); ok {
+	var r0 *wafv2.GetManagedRuleSetOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.GetManagedRuleSetInput) *wafv2.GetManagedRuleSetOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.GetWebACLForResourceOutput)
+			r0 = ret.Get(0).(*wafv2.GetManagedRuleSetOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*wafv2.GetWebACLForResourceInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*wafv2.GetManagedRuleSetInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -1544,12 +1833,12 @@ func (_m *WAFV2API) GetWebACLForResource(_a0 *wafv2.GetWebACLForResourceInput) (
 	return r0, r1
 }
 
-// GetWebACLForResourceRequest provides a mock function with given fields: _a0
-func (_m *WAFV2API) GetWebACLForResourceRequest(_a0 *wafv2.GetWebACLForResourceInput) (*request.Request, *wafv2.GetWebACLForResourceOutput) {
+// GetManagedRuleSetRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetManagedRuleSetRequest(_a0 *wafv2.GetManagedRuleSetInput) (*request.Request, *wafv2.GetManagedRuleSetOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*wafv2.GetWebACLForResourceInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*wafv2.GetManagedRuleSetInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -1557,20 +1846,20 @@ func (_m *WAFV2API) GetWebACLForResourceRequest(_a0 *wafv2.GetWebACLForResourceI
 		}
 	}
 
-	var r1 *wafv2.GetWebACLForResourceOutput
-	if rf, ok := ret.Get(1).(func(*wafv2.GetWebACLForResourceInput) *wafv2.GetWebACLForResourceOutput); ok {
+	var r1 *wafv2.GetManagedRuleSetOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.GetManagedRuleSetInput) *wafv2.GetManagedRuleSetOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*wafv2.GetWebACLForResourceOutput)
+			r1 = ret.Get(1).(*wafv2.GetManagedRuleSetOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// GetWebACLForResourceWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *WAFV2API) GetWebACLForResourceWithContext(_a0 context.Context, _a1 *wafv2.GetWebACLForResourceInput, _a2 ...request.Option) (*wafv2.GetWebACLForResourceOutput, error) {
+// GetManagedRuleSetWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) GetManagedRuleSetWithContext(_a0 context.Context, _a1 *wafv2.GetManagedRuleSetInput, _a2 ...request.Option) (*wafv2.GetManagedRuleSetOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -1580,17 +1869,17 @@ func (_m *WAFV2API) GetWebACLForResourceWithContext(_a0 context.Context, _a1 *wa
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *wafv2.GetWebACLForResourceOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.GetWebACLForResourceInput, ...request.Option) *wafv2.GetWebACLForResourceOutput); ok {
+	var r0 *wafv2.GetManagedRuleSetOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.GetManagedRuleSetInput, ...request.Option) *wafv2.GetManagedRuleSetOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.GetWebACLForResourceOutput)
+			r0 = ret.Get(0).(*wafv2.GetManagedRuleSetOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.GetWebACLForResourceInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.GetManagedRuleSetInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -1599,34 +1888,681 @@ func (_m *WAFV2API) GetWebACLForResourceWithContext(_a0 context.Context, _a1 *wa
 	return r0, r1
 }
 
-// GetWebACLRequest provides a mock function with given fields: _a0
-func (_m *WAFV2API) GetWebACLRequest(_a0 *wafv2.GetWebACLInput) (*request.Request, *wafv2.GetWebACLOutput) {
+// GetMobileSdkRelease provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetMobileSdkRelease(_a0 *wafv2.GetMobileSdkReleaseInput) (*wafv2.GetMobileSdkReleaseOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*wafv2.GetWebACLInput) *request.Request); ok {
+	var r0 *wafv2.GetMobileSdkReleaseOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.GetMobileSdkReleaseInput) *wafv2.GetMobileSdkReleaseOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*request.Request)
+			r0 = ret.Get(0).(*wafv2.GetMobileSdkReleaseOutput)
 		}
 	}
 
-	var r1 *wafv2.GetWebACLOutput
-	if rf, ok := ret.Get(1).(func(*wafv2.GetWebACLInput) *wafv2.GetWebACLOutput); ok {
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*wafv2.GetMobileSdkReleaseInput) error); ok {
 		r1 = rf(_a0)
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*wafv2.GetWebACLOutput)
-		}
+		r1 = ret.Error(1)
 	}
 
 	return r0, r1
 }
 
-// GetWebACLWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *WAFV2API) GetWebACLWithContext(_a0 context.Context, _a1 *wafv2.GetWebACLInput, _a2 ...request.Option) (*wafv2.GetWebACLOutput, error) {
-	_va := make([]interface{}, len(_a2))
+// GetMobileSdkReleaseRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetMobileSdkReleaseRequest(_a0 *wafv2.GetMobileSdkReleaseInput) (*request.Request, *wafv2.GetMobileSdkReleaseOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*wafv2.GetMobileSdkReleaseInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *wafv2.GetMobileSdkReleaseOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.GetMobileSdkReleaseInput) *wafv2.GetMobileSdkReleaseOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*wafv2.GetMobileSdkReleaseOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetMobileSdkReleaseWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) GetMobileSdkReleaseWithContext(_a0 context.Context, _a1 *wafv2.GetMobileSdkReleaseInput, _a2 ...request.Option) (*wafv2.GetMobileSdkReleaseOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *wafv2.GetMobileSdkReleaseOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.GetMobileSdkReleaseInput, ...request.Option) *wafv2.GetMobileSdkReleaseOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.GetMobileSdkReleaseOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.GetMobileSdkReleaseInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPermissionPolicy provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetPermissionPolicy(_a0 *wafv2.GetPermissionPolicyInput) (*wafv2.GetPermissionPolicyOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *wafv2.GetPermissionPolicyOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.GetPermissionPolicyInput) *wafv2.GetPermissionPolicyOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.GetPermissionPolicyOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*wafv2.GetPermissionPolicyInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPermissionPolicyRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetPermissionPolicyRequest(_a0 *wafv2.GetPermissionPolicyInput) (*request.Request, *wafv2.GetPermissionPolicyOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*wafv2.GetPermissionPolicyInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *wafv2.GetPermissionPolicyOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.GetPermissionPolicyInput) *wafv2.GetPermissionPolicyOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*wafv2.GetPermissionPolicyOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetPermissionPolicyWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) GetPermissionPolicyWithContext(_a0 context.Context, _a1 *wafv2.GetPermissionPolicyInput, _a2 ...request.Option) (*wafv2.GetPermissionPolicyOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *wafv2.GetPermissionPolicyOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.GetPermissionPolicyInput, ...request.Option) *wafv2.GetPermissionPolicyOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.GetPermissionPolicyOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.GetPermissionPolicyInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRateBasedStatementManagedKeys provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetRateBasedStatementManagedKeys(_a0 *wafv2.GetRateBasedStatementManagedKeysInput) (*wafv2.GetRateBasedStatementManagedKeysOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *wafv2.GetRateBasedStatementManagedKeysOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.GetRateBasedStatementManagedKeysInput) *wafv2.GetRateBasedStatementManagedKeysOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.GetRateBasedStatementManagedKeysOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*wafv2.GetRateBasedStatementManagedKeysInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRateBasedStatementManagedKeysRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetRateBasedStatementManagedKeysRequest(_a0 *wafv2.GetRateBasedStatementManagedKeysInput) (*request.Request, *wafv2.GetRateBasedStatementManagedKeysOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*wafv2.GetRateBasedStatementManagedKeysInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *wafv2.GetRateBasedStatementManagedKeysOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.GetRateBasedStatementManagedKeysInput) *wafv2.GetRateBasedStatementManagedKeysOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*wafv2.GetRateBasedStatementManagedKeysOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetRateBasedStatementManagedKeysWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) GetRateBasedStatementManagedKeysWithContext(_a0 context.Context, _a1 *wafv2.GetRateBasedStatementManagedKeysInput, _a2 ...request.Option) (*wafv2.GetRateBasedStatementManagedKeysOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *wafv2.GetRateBasedStatementManagedKeysOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.GetRateBasedStatementManagedKeysInput, ...request.Option) *wafv2.GetRateBasedStatementManagedKeysOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.GetRateBasedStatementManagedKeysOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.GetRateBasedStatementManagedKeysInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRegexPatternSet provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetRegexPatternSet(_a0 *wafv2.GetRegexPatternSetInput) (*wafv2.GetRegexPatternSetOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *wafv2.GetRegexPatternSetOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.GetRegexPatternSetInput) *wafv2.GetRegexPatternSetOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.GetRegexPatternSetOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*wafv2.GetRegexPatternSetInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRegexPatternSetRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetRegexPatternSetRequest(_a0 *wafv2.GetRegexPatternSetInput) (*request.Request, *wafv2.GetRegexPatternSetOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*wafv2.GetRegexPatternSetInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *wafv2.GetRegexPatternSetOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.GetRegexPatternSetInput) *wafv2.GetRegexPatternSetOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*wafv2.GetRegexPatternSetOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetRegexPatternSetWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) GetRegexPatternSetWithContext(_a0 context.Context, _a1 *wafv2.GetRegexPatternSetInput, _a2 ...request.Option) (*wafv2.GetRegexPatternSetOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *wafv2.GetRegexPatternSetOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.GetRegexPatternSetInput, ...request.Option) *wafv2.GetRegexPatternSetOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.GetRegexPatternSetOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.GetRegexPatternSetInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRuleGroup provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetRuleGroup(_a0 *wafv2.GetRuleGroupInput) (*wafv2.GetRuleGroupOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *wafv2.GetRuleGroupOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.GetRuleGroupInput) *wafv2.GetRuleGroupOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.GetRuleGroupOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*wafv2.GetRuleGroupInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRuleGroupRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetRuleGroupRequest(_a0 *wafv2.GetRuleGroupInput) (*request.Request, *wafv2.GetRuleGroupOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*wafv2.GetRuleGroupInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *wafv2.GetRuleGroupOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.GetRuleGroupInput) *wafv2.GetRuleGroupOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*wafv2.GetRuleGroupOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetRuleGroupWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) GetRuleGroupWithContext(_a0 context.Context, _a1 *wafv2.GetRuleGroupInput, _a2 ...request.Option) (*wafv2.GetRuleGroupOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *wafv2.GetRuleGroupOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.GetRuleGroupInput, ...request.Option) *wafv2.GetRuleGroupOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.GetRuleGroupOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.GetRuleGroupInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSampledRequests provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetSampledRequests(_a0 *wafv2.GetSampledRequestsInput) (*wafv2.GetSampledRequestsOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *wafv2.GetSampledRequestsOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.GetSampledRequestsInput) *wafv2.GetSampledRequestsOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.GetSampledRequestsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*wafv2.GetSampledRequestsInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSampledRequestsRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetSampledRequestsRequest(_a0 *wafv2.GetSampledRequestsInput) (*request.Request, *wafv2.GetSampledRequestsOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*wafv2.GetSampledRequestsInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *wafv2.GetSampledRequestsOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.GetSampledRequestsInput) *wafv2.GetSampledRequestsOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*wafv2.GetSampledRequestsOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetSampledRequestsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) GetSampledRequestsWithContext(_a0 context.Context, _a1 *wafv2.GetSampledRequestsInput, _a2 ...request.Option) (*wafv2.GetSampledRequestsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *wafv2.GetSampledRequestsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.GetSampledRequestsInput, ...request.Option) *wafv2.GetSampledRequestsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.GetSampledRequestsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.GetSampledRequestsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebACL provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetWebACL(_a0 *wafv2.GetWebACLInput) (*wafv2.GetWebACLOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *wafv2.GetWebACLOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.GetWebACLInput) *wafv2.GetWebACLOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.GetWebACLOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*wafv2.GetWebACLInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebACLForResource provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetWebACLForResource(_a0 *wafv2.GetWebACLForResourceInput) (*wafv2.GetWebACLForResourceOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *wafv2.GetWebACLForResourceOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.GetWebACLForResourceInput) *wafv2.GetWebACLForResourceOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.GetWebACLForResourceOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*wafv2.GetWebACLForResourceInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebACLForResourceRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetWebACLForResourceRequest(_a0 *wafv2.GetWebACLForResourceInput) (*request.Request, *wafv2.GetWebACLForResourceOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*wafv2.GetWebACLForResourceInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *wafv2.GetWebACLForResourceOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.GetWebACLForResourceInput) *wafv2.GetWebACLForResourceOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*wafv2.GetWebACLForResourceOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetWebACLForResourceWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) GetWebACLForResourceWithContext(_a0 context.Context, _a1 *wafv2.GetWebACLForResourceInput, _a2 ...request.Option) (*wafv2.GetWebACLForResourceOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *wafv2.GetWebACLForResourceOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.GetWebACLForResourceInput, ...request.Option) *wafv2.GetWebACLForResourceOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.GetWebACLForResourceOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.GetWebACLForResourceInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebACLRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) GetWebACLRequest(_a0 *wafv2.GetWebACLInput) (*request.Request, *wafv2.GetWebACLOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*wafv2.GetWebACLInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *wafv2.GetWebACLOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.GetWebACLInput) *wafv2.GetWebACLOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*wafv2.GetWebACLOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetWebACLWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) GetWebACLWithContext(_a0 context.Context, _a1 *wafv2.GetWebACLInput, _a2 ...request.Option) (*wafv2.GetWebACLOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *wafv2.GetWebACLOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.GetWebACLInput, ...request.Option) *wafv2.GetWebACLOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.GetWebACLOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.GetWebACLInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAPIKeys provides a mock function with given fields: _a0
+func (_m *WAFV2API) ListAPIKeys(_a0 *wafv2.ListAPIKeysInput) (*wafv2.ListAPIKeysOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *wafv2.ListAPIKeysOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.ListAPIKeysInput) *wafv2.ListAPIKeysOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.ListAPIKeysOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*wafv2.ListAPIKeysInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAPIKeysRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) ListAPIKeysRequest(_a0 *wafv2.ListAPIKeysInput) (*request.Request, *wafv2.ListAPIKeysOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*wafv2.ListAPIKeysInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *wafv2.ListAPIKeysOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.ListAPIKeysInput) *wafv2.ListAPIKeysOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*wafv2.ListAPIKeysOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListAPIKeysWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) ListAPIKeysWithContext(_a0 context.Context, _a1 *wafv2.ListAPIKeysInput, _a2 ...request.Option) (*wafv2.ListAPIKeysOutput, error) {
+	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
 	}
@@ -1635,17 +2571,251 @@ func (_m *WAFV2API) GetWebACLWithContext(_a0 context.Context, _a1 *wafv2.GetWebA
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *wafv2.GetWebACLOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.GetWebACLInput, ...request.Option) *wafv2.GetWebACLOutput); ok {
+	var r0 *wafv2.ListAPIKeysOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.ListAPIKeysInput, ...request.Option) *wafv2.ListAPIKeysOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.ListAPIKeysOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.ListAPIKeysInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAvailableManagedRuleGroupVersions provides a mock function with given fields: _a0
+func (_m *WAFV2API) ListAvailableManagedRuleGroupVersions(_a0 *wafv2.ListAvailableManagedRuleGroupVersionsInput) (*wafv2.ListAvailableManagedRuleGroupVersionsOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *wafv2.ListAvailableManagedRuleGroupVersionsOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.ListAvailableManagedRuleGroupVersionsInput) *wafv2.ListAvailableManagedRuleGroupVersionsOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.ListAvailableManagedRuleGroupVersionsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*wafv2.ListAvailableManagedRuleGroupVersionsInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAvailableManagedRuleGroupVersionsRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) ListAvailableManagedRuleGroupVersionsRequest(_a0 *wafv2.ListAvailableManagedRuleGroupVersionsInput) (*request.Request, *wafv2.ListAvailableManagedRuleGroupVersionsOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*wafv2.ListAvailableManagedRuleGroupVersionsInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *wafv2.ListAvailableManagedRuleGroupVersionsOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.ListAvailableManagedRuleGroupVersionsInput) *wafv2.ListAvailableManagedRuleGroupVersionsOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*wafv2.ListAvailableManagedRuleGroupVersionsOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListAvailableManagedRuleGroupVersionsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) ListAvailableManagedRuleGroupVersionsWithContext(_a0 context.Context, _a1 *wafv2.ListAvailableManagedRuleGroupVersionsInput, _a2 ...request.Option) (*wafv2.ListAvailableManagedRuleGroupVersionsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *wafv2.ListAvailableManagedRuleGroupVersionsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.ListAvailableManagedRuleGroupVersionsInput, ...request.Option) *wafv2.ListAvailableManagedRuleGroupVersionsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.ListAvailableManagedRuleGroupVersionsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.ListAvailableManagedRuleGroupVersionsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAvailableManagedRuleGroups provides a mock function with given fields: _a0
+func (_m *WAFV2API) ListAvailableManagedRuleGroups(_a0 *wafv2.ListAvailableManagedRuleGroupsInput) (*wafv2.ListAvailableManagedRuleGroupsOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *wafv2.ListAvailableManagedRuleGroupsOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.ListAvailableManagedRuleGroupsInput) *wafv2.ListAvailableManagedRuleGroupsOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.ListAvailableManagedRuleGroupsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*wafv2.ListAvailableManagedRuleGroupsInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAvailableManagedRuleGroupsRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) ListAvailableManagedRuleGroupsRequest(_a0 *wafv2.ListAvailableManagedRuleGroupsInput) (*request.Request, *wafv2.ListAvailableManagedRuleGroupsOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*wafv2.ListAvailableManagedRuleGroupsInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *wafv2.ListAvailableManagedRuleGroupsOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.ListAvailableManagedRuleGroupsInput) *wafv2.ListAvailableManagedRuleGroupsOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*wafv2.ListAvailableManagedRuleGroupsOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListAvailableManagedRuleGroupsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) ListAvailableManagedRuleGroupsWithContext(_a0 context.Context, _a1 *wafv2.ListAvailableManagedRuleGroupsInput, _a2 ...request.Option) (*wafv2.ListAvailableManagedRuleGroupsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *wafv2.ListAvailableManagedRuleGroupsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.ListAvailableManagedRuleGroupsInput, ...request.Option) *wafv2.ListAvailableManagedRuleGroupsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.ListAvailableManagedRuleGroupsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.ListAvailableManagedRuleGroupsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListIPSets provides a mock function with given fields: _a0
+func (_m *WAFV2API) ListIPSets(_a0 *wafv2.ListIPSetsInput) (*wafv2.ListIPSetsOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *wafv2.ListIPSetsOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.ListIPSetsInput) *wafv2.ListIPSetsOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.ListIPSetsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*wafv2.ListIPSetsInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListIPSetsRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) ListIPSetsRequest(_a0 *wafv2.ListIPSetsInput) (*request.Request, *wafv2.ListIPSetsOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*wafv2.ListIPSetsInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *wafv2.ListIPSetsOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.ListIPSetsInput) *wafv2.ListIPSetsOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*wafv2.ListIPSetsOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListIPSetsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) ListIPSetsWithContext(_a0 context.Context, _a1 *wafv2.ListIPSetsInput, _a2 ...request.Option) (*wafv2.ListIPSetsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *wafv2.ListIPSetsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.ListIPSetsInput, ...request.Option) *wafv2.ListIPSetsOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.GetWebACLOutput)
+			r0 = ret.Get(0).(*wafv2.ListIPSetsOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.GetWebACLInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.ListIPSetsInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -1654,21 +2824,21 @@ func (_m *WAFV2API) GetWebACLWithContext(_a0 context.Context, _a1 *wafv2.GetWebA
 	return r0, r1
 }
 
-// ListAvailableManagedRuleGroups provides a mock function with given fields: _a0
-func (_m *WAFV2API) ListAvailableManagedRuleGroups(_a0 *wafv2.ListAvailableManagedRuleGroupsInput) (*wafv2.ListAvailableManagedRuleGroupsOutput, error) {
+// ListLoggingConfigurations provides a mock function with given fields: _a0
+func (_m *WAFV2API) ListLoggingConfigurations(_a0 *wafv2.ListLoggingConfigurationsInput) (*wafv2.ListLoggingConfigurationsOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *wafv2.ListAvailableManagedRuleGroupsOutput
-	if rf, ok := ret.Get(0).(func(*wafv2.ListAvailableManagedRuleGroupsInput) *wafv2.ListAvailableManagedRuleGroupsOutput); ok {
+	var r0 *wafv2.ListLoggingConfigurationsOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.ListLoggingConfigurationsInput) *wafv2.ListLoggingConfigurationsOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.ListAvailableManagedRuleGroupsOutput)
+			r0 = ret.Get(0).(*wafv2.ListLoggingConfigurationsOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*wafv2.ListAvailableManagedRuleGroupsInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*wafv2.ListLoggingConfigurationsInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -1677,12 +2847,12 @@ func (_m *WAFV2API) ListAvailableManagedRuleGroups(_a0 *wafv2.ListAvailableManag
 	return r0, r1
 }
 
-// ListAvailableManagedRuleGroupsRequest provides a mock function with given fields: _a0
-func (_m *WAFV2API) ListAvailableManagedRuleGroupsRequest(_a0 *wafv2.ListAvailableManagedRuleGroupsInput) (*request.Request, *wafv2.ListAvailableManagedRuleGroupsOutput) {
+// ListLoggingConfigurationsRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) ListLoggingConfigurationsRequest(_a0 *wafv2.ListLoggingConfigurationsInput) (*request.Request, *wafv2.ListLoggingConfigurationsOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*wafv2.ListAvailableManagedRuleGroupsInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*wafv2.ListLoggingConfigurationsInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -1690,20 +2860,20 @@ func (_m *WAFV2API) ListAvailableManagedRuleGroupsRequest(_a0 *wafv2.ListAvailab
 		}
 	}
 
-	var r1 *wafv2.ListAvailableManagedRuleGroupsOutput
-	if rf, ok := ret.Get(1).(func(*wafv2.ListAvailableManagedRuleGroupsInput) *wafv2.ListAvailableManagedRuleGroupsOutput); ok {
+	var r1 *wafv2.ListLoggingConfigurationsOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.ListLoggingConfigurationsInput) *wafv2.ListLoggingConfigurationsOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*wafv2.ListAvailableManagedRuleGroupsOutput)
+			r1 = ret.Get(1).(*wafv2.ListLoggingConfigurationsOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// ListAvailableManagedRuleGroupsWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *WAFV2API) ListAvailableManagedRuleGroupsWithContext(_a0 context.Context, _a1 *wafv2.ListAvailableManagedRuleGroupsInput, _a2 ...request.Option) (*wafv2.ListAvailableManagedRuleGroupsOutput, error) {
+// ListLoggingConfigurationsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) ListLoggingConfigurationsWithContext(_a0 context.Context, _a1 *wafv2.ListLoggingConfigurationsInput, _a2 ...request.Option) (*wafv2.ListLoggingConfigurationsOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -1713,17 +2883,17 @@ func (_m *WAFV2API) ListAvailableManagedRuleGroupsWithContext(_a0 context.Contex
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *wafv2.ListAvailableManagedRuleGroupsOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.ListAvailableManagedRuleGroupsInput, ...request.Option) *wafv2.ListAvailableManagedRuleGroupsOutput); ok {
+	var r0 *wafv2.ListLoggingConfigurationsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.ListLoggingConfigurationsInput, ...request.Option) *wafv2.ListLoggingConfigurationsOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.ListAvailableManagedRuleGroupsOutput)
+			r0 = ret.Get(0).(*wafv2.ListLoggingConfigurationsOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.ListAvailableManagedRuleGroupsInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.ListLoggingConfigurationsInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -1732,21 +2902,21 @@ func (_m *WAFV2API) ListAvailableManagedRuleGroupsWithContext(_a0 context.Contex
 	return r0, r1
 }
 
-// ListIPSets provides a mock function with given fields: _a0
-func (_m *WAFV2API) ListIPSets(_a0 *wafv2.ListIPSetsInput) (*wafv2.ListIPSetsOutput, error) {
+// ListManagedRuleSets provides a mock function with given fields: _a0
+func (_m *WAFV2API) ListManagedRuleSets(_a0 *wafv2.ListManagedRuleSetsInput) (*wafv2.ListManagedRuleSetsOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *wafv2.ListIPSetsOutput
-	if rf, ok := ret.Get(0).(func(*wafv2.ListIPSetsInput) *wafv2.ListIPSetsOutput); ok {
+	var r0 *wafv2.ListManagedRuleSetsOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.ListManagedRuleSetsInput) *wafv2.ListManagedRuleSetsOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.ListIPSetsOutput)
+			r0 = ret.Get(0).(*wafv2.ListManagedRuleSetsOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*wafv2.ListIPSetsInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*wafv2.ListManagedRuleSetsInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -1755,12 +2925,12 @@ func (_m *WAFV2API) ListIPSets(_a0 *wafv2.ListIPSetsInput) (*wafv2.ListIPSetsOut
 	return r0, r1
 }
 
-// ListIPSetsRequest provides a mock function with given fields: _a0
-func (_m *WAFV2API) ListIPSetsRequest(_a0 *wafv2.ListIPSetsInput) (*request.Request, *wafv2.ListIPSetsOutput) {
+// ListManagedRuleSetsRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) ListManagedRuleSetsRequest(_a0 *wafv2.ListManagedRuleSetsInput) (*request.Request, *wafv2.ListManagedRuleSetsOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*wafv2.ListIPSetsInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*wafv2.ListManagedRuleSetsInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -1768,20 +2938,20 @@ func (_m *WAFV2API) ListIPSetsRequest(_a0 *wafv2.ListIPSetsInput) (*request.Requ
 		}
 	}
 
-	var r1 *wafv2.ListIPSetsOutput
-	if rf, ok := ret.Get(1).(func(*wafv2.ListIPSetsInput) *wafv2.ListIPSetsOutput); ok {
+	var r1 *wafv2.ListManagedRuleSetsOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.ListManagedRuleSetsInput) *wafv2.ListManagedRuleSetsOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*wafv2.ListIPSetsOutput)
+			r1 = ret.Get(1).(*wafv2.ListManagedRuleSetsOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// ListIPSetsWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *WAFV2API) ListIPSetsWithContext(_a0 context.Context, _a1 *wafv2.ListIPSetsInput, _a2 ...request.Option) (*wafv2.ListIPSetsOutput, error) {
+// ListManagedRuleSetsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) ListManagedRuleSetsWithContext(_a0 context.Context, _a1 *wafv2.ListManagedRuleSetsInput, _a2 ...request.Option) (*wafv2.ListManagedRuleSetsOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -1791,17 +2961,17 @@ func (_m *WAFV2API) ListIPSetsWithContext(_a0 context.Context, _a1 *wafv2.ListIP
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *wafv2.ListIPSetsOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.ListIPSetsInput, ...request.Option) *wafv2.ListIPSetsOutput); ok {
+	var r0 *wafv2.ListManagedRuleSetsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.ListManagedRuleSetsInput, ...request.Option) *wafv2.ListManagedRuleSetsOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.ListIPSetsOutput)
+			r0 = ret.Get(0).(*wafv2.ListManagedRuleSetsOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.ListIPSetsInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.ListManagedRuleSetsInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -1810,21 +2980,21 @@ func (_m *WAFV2API) ListIPSetsWithContext(_a0 context.Context, _a1 *wafv2.ListIP
 	return r0, r1
 }
 
-// ListLoggingConfigurations provides a mock function with given fields: _a0
-func (_m *WAFV2API) ListLoggingConfigurations(_a0 *wafv2.ListLoggingConfigurationsInput) (*wafv2.ListLoggingConfigurationsOutput, error) {
+// ListMobileSdkReleases provides a mock function with given fields: _a0
+func (_m *WAFV2API) ListMobileSdkReleases(_a0 *wafv2.ListMobileSdkReleasesInput) (*wafv2.ListMobileSdkReleasesOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *wafv2.ListLoggingConfigurationsOutput
-	if rf, ok := ret.Get(0).(func(*wafv2.ListLoggingConfigurationsInput) *wafv2.ListLoggingConfigurationsOutput); ok {
+	var r0 *wafv2.ListMobileSdkReleasesOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.ListMobileSdkReleasesInput) *wafv2.ListMobileSdkReleasesOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.ListLoggingConfigurationsOutput)
+			r0 = ret.Get(0).(*wafv2.ListMobileSdkReleasesOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*wafv2.ListLoggingConfigurationsInput) error); ok {
+	if rf, ok := ret.Get(1).(func(*wafv2.ListMobileSdkReleasesInput) error); ok {
 		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
@@ -1833,12 +3003,12 @@ func (_m *WAFV2API) ListLoggingConfigurations(_a0 *wafv2.ListLoggingConfiguratio
 	return r0, r1
 }
 
-// ListLoggingConfigurationsRequest provides a mock function with given fields: _a0
-func (_m *WAFV2API) ListLoggingConfigurationsRequest(_a0 *wafv2.ListLoggingConfigurationsInput) (*request.Request, *wafv2.ListLoggingConfigurationsOutput) {
+// ListMobileSdkReleasesRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) ListMobileSdkReleasesRequest(_a0 *wafv2.ListMobileSdkReleasesInput) (*request.Request, *wafv2.ListMobileSdkReleasesOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*wafv2.ListLoggingConfigurationsInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*wafv2.ListMobileSdkReleasesInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -1846,20 +3016,20 @@ func (_m *WAFV2API) ListLoggingConfigurationsRequest(_a0 *wafv2.ListLoggingConfi
 		}
 	}
 
-	var r1 *wafv2.ListLoggingConfigurationsOutput
-	if rf, ok := ret.Get(1).(func(*wafv2.ListLoggingConfigurationsInput) *wafv2.ListLoggingConfigurationsOutput); ok {
+	var r1 *wafv2.ListMobileSdkReleasesOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.ListMobileSdkReleasesInput) *wafv2.ListMobileSdkReleasesOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*wafv2.ListLoggingConfigurationsOutput)
+			r1 = ret.Get(1).(*wafv2.ListMobileSdkReleasesOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// ListLoggingConfigurationsWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *WAFV2API) ListLoggingConfigurationsWithContext(_a0 context.Context, _a1 *wafv2.ListLoggingConfigurationsInput, _a2 ...request.Option) (*wafv2.ListLoggingConfigurationsOutput, error) {
+// ListMobileSdkReleasesWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) ListMobileSdkReleasesWithContext(_a0 context.Context, _a1 *wafv2.ListMobileSdkReleasesInput, _a2 ...request.Option) (*wafv2.ListMobileSdkReleasesOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -1869,17 +3039,17 @@ func (_m *WAFV2API) ListLoggingConfigurationsWithContext(_a0 context.Context, _a
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *wafv2.ListLoggingConfigurationsOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.ListLoggingConfigurationsInput, ...request.Option) *wafv2.ListLoggingConfigurationsOutput); ok {
+	var r0 *wafv2.ListMobileSdkReleasesOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.ListMobileSdkReleasesInput, ...request.Option) *wafv2.ListMobileSdkReleasesOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*wafv2.ListLoggingConfigurationsOutput)
+			r0 = ret.Get(0).(*wafv2.ListMobileSdkReleasesOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.ListLoggingConfigurationsInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.ListMobileSdkReleasesInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -2356,6 +3526,162 @@ func (_m *WAFV2API) PutLoggingConfigurationWithContext(_a0 context.Context, _a1
 	return r0, r1
 }
 
+// PutManagedRuleSetVersions provides a mock function with given fields: _a0
+func (_m *WAFV2API) PutManagedRuleSetVersions(_a0 *wafv2.PutManagedRuleSetVersionsInput) (*wafv2.PutManagedRuleSetVersionsOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *wafv2.PutManagedRuleSetVersionsOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.PutManagedRuleSetVersionsInput) *wafv2.PutManagedRuleSetVersionsOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.PutManagedRuleSetVersionsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*wafv2.PutManagedRuleSetVersionsInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PutManagedRuleSetVersionsRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) PutManagedRuleSetVersionsRequest(_a0 *wafv2.PutManagedRuleSetVersionsInput) (*request.Request, *wafv2.PutManagedRuleSetVersionsOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*wafv2.PutManagedRuleSetVersionsInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *wafv2.PutManagedRuleSetVersionsOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.PutManagedRuleSetVersionsInput) *wafv2.PutManagedRuleSetVersionsOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*wafv2.PutManagedRuleSetVersionsOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// PutManagedRuleSetVersionsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) PutManagedRuleSetVersionsWithContext(_a0 context.Context, _a1 *wafv2.PutManagedRuleSetVersionsInput, _a2 ...request.Option) (*wafv2.PutManagedRuleSetVersionsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *wafv2.PutManagedRuleSetVersionsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.PutManagedRuleSetVersionsInput, ...request.Option) *wafv2.PutManagedRuleSetVersionsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.PutManagedRuleSetVersionsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.PutManagedRuleSetVersionsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PutPermissionPolicy provides a mock function with given fields: _a0
+func (_m *WAFV2API) PutPermissionPolicy(_a0 *wafv2.PutPermissionPolicyInput) (*wafv2.PutPermissionPolicyOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *wafv2.PutPermissionPolicyOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.PutPermissionPolicyInput) *wafv2.PutPermissionPolicyOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.PutPermissionPolicyOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*wafv2.PutPermissionPolicyInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PutPermissionPolicyRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) PutPermissionPolicyRequest(_a0 *wafv2.PutPermissionPolicyInput) (*request.Request, *wafv2.PutPermissionPolicyOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*wafv2.PutPermissionPolicyInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *wafv2.PutPermissionPolicyOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.PutPermissionPolicyInput) *wafv2.PutPermissionPolicyOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*wafv2.PutPermissionPolicyOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// PutPermissionPolicyWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) PutPermissionPolicyWithContext(_a0 context.Context, _a1 *wafv2.PutPermissionPolicyInput, _a2 ...request.Option) (*wafv2.PutPermissionPolicyOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *wafv2.PutPermissionPolicyOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.PutPermissionPolicyInput, ...request.Option) *wafv2.PutPermissionPolicyOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.PutPermissionPolicyOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.PutPermissionPolicyInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // TagResource provides a mock function with given fields: _a0
 func (_m *WAFV2API) TagResource(_a0 *wafv2.TagResourceInput) (*wafv2.TagResourceOutput, error) {
 	ret := _m.Called(_a0)
@@ -2590,6 +3916,84 @@ func (_m *WAFV2API) UpdateIPSetWithContext(_a0 context.Context, _a1 *wafv2.Updat
 	return r0, r1
 }
 
+// UpdateManagedRuleSetVersionExpiryDate provides a mock function with given fields: _a0
+func (_m *WAFV2API) UpdateManagedRuleSetVersionExpiryDate(_a0 *wafv2.UpdateManagedRuleSetVersionExpiryDateInput) (*wafv2.UpdateManagedRuleSetVersionExpiryDateOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *wafv2.UpdateManagedRuleSetVersionExpiryDateOutput
+	if rf, ok := ret.Get(0).(func(*wafv2.UpdateManagedRuleSetVersionExpiryDateInput) *wafv2.UpdateManagedRuleSetVersionExpiryDateOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.UpdateManagedRuleSetVersionExpiryDateOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*wafv2.UpdateManagedRuleSetVersionExpiryDateInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateManagedRuleSetVersionExpiryDateRequest provides a mock function with given fields: _a0
+func (_m *WAFV2API) UpdateManagedRuleSetVersionExpiryDateRequest(_a0 *wafv2.UpdateManagedRuleSetVersionExpiryDateInput) (*request.Request, *wafv2.UpdateManagedRuleSetVersionExpiryDateOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*wafv2.UpdateManagedRuleSetVersionExpiryDateInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *wafv2.UpdateManagedRuleSetVersionExpiryDateOutput
+	if rf, ok := ret.Get(1).(func(*wafv2.UpdateManagedRuleSetVersionExpiryDateInput) *wafv2.UpdateManagedRuleSetVersionExpiryDateOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*wafv2.UpdateManagedRuleSetVersionExpiryDateOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// UpdateManagedRuleSetVersionExpiryDateWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *WAFV2API) UpdateManagedRuleSetVersionExpiryDateWithContext(_a0 context.Context, _a1 *wafv2.UpdateManagedRuleSetVersionExpiryDateInput, _a2 ...request.Option) (*wafv2.UpdateManagedRuleSetVersionExpiryDateOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *wafv2.UpdateManagedRuleSetVersionExpiryDateOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *wafv2.UpdateManagedRuleSetVersionExpiryDateInput, ...request.Option) *wafv2.UpdateManagedRuleSetVersionExpiryDateOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.UpdateManagedRuleSetVersionExpiryDateOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *wafv2.UpdateManagedRuleSetVersionExpiryDateInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateRegexPatternSet provides a mock function with given fields: _a0
 func (_m *WAFV2API) UpdateRegexPatternSet(_a0 *wafv2.UpdateRegexPatternSetInput) (*wafv2.UpdateRegexPatternSetOutput, error) {
 	ret := _m.Called(_a0)
@@ -2,18 +2,80 @@
 package log
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/awsutil"
 	"github.com/golang/glog"
 )
 
+// Formats Loggers can write their messages in.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+var (
+	logFormat = FormatText
+
+	// jsonOutput is where messages are written to in the JSON format, glog's headers would break the JSON.
+	jsonOutput      io.Writer = os.Stderr
+	jsonOutputMutex sync.Mutex
+)
+
+// SetFormat sets the format every Logger writes its messages in, FormatText through glog or FormatJSON.
+// It is meant to be called once, before anything is logged.
+func SetFormat(format string) error {
+	switch format {
+	case FormatText, FormatJSON:
+		logFormat = format
+		return nil
+	}
+	return fmt.Errorf("log format must be either %q or %q, got %q", FormatText, FormatJSON, format)
+}
+
 type Logger struct {
 	name string
 }
 
+// jsonEntry is a message in the JSON format. Messages of Loggers named after an ingress key carry its namespace and name,
+// the other Loggers' name is their component.
+type jsonEntry struct {
+	Time      string `json:"time"`
+	Level     string `json:"level"`
+	Component string `json:"component,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Ingress   string `json:"ingress,omitempty"`
+	Message   string `json:"message"`
+}
+
+func (l *Logger) writeJSON(level string, format string, args ...interface{}) {
+	entry := jsonEntry{
+		Time:    time.Now().UTC().Format(time.RFC3339Nano),
+		Level:   level,
+		Message: fmt.Sprintf(format, args...),
+	}
+	if parts := strings.SplitN(l.name, "/", 2); len(parts) == 2 {
+		entry.Namespace, entry.Ingress = parts[0], parts[1]
+	} else {
+		entry.Component = l.name
+	}
+	b, err := json.Marshal(entry)
+	if err != nil {
+		glog.Errorf("failed to encode log message due to %v", err)
+		return
+	}
+	jsonOutputMutex.Lock()
+	defer jsonOutputMutex.Unlock()
+	_, _ = jsonOutput.Write(append(b, '\n'))
+}
+
 // New creates a new Logger.
 // The name appears in the log lines.
 func New(name string) *Logger {
@@ -22,36 +84,70 @@ func New(name string) *Logger {
 
 // Debugf will print debug messages if debug logging is enabled
 func (l *Logger) Debugf(format string, args ...interface{}) {
+	if logFormat == FormatJSON {
+		if glog.V(2) {
+			l.writeJSON("debug", format, args...)
+		}
+		return
+	}
 	debugf(format, l.name, 2, args...)
 }
 
 // DebugLevelf will print debug messages if debug logging is enabled
 func (l *Logger) DebugLevelf(level int, format string, args ...interface{}) {
+	if logFormat == FormatJSON {
+		if glog.V(2) {
+			l.writeJSON("debug", format, args...)
+		}
+		return
+	}
 	debugf(format, l.name, level, args...)
 }
 
 // Infof will print info level messages
 func (l *Logger) Infof(format string, args ...interface{}) {
+	if logFormat == FormatJSON {
+		l.writeJSON("info", format, args...)
+		return
+	}
 	infof(format, l.name, args...)
 }
 
 // Warnf will print warning level messages
 func (l *Logger) Warnf(format string, args ...interface{}) {
+	if logFormat == FormatJSON {
+		l.writeJSON("warning", format, args...)
+		return
+	}
 	warnf(format, l.name, args...)
 }
 
 // Errorf will print error level messages
 func (l *Logger) Errorf(format string, args ...interface{}) {
+	if logFormat == FormatJSON {
+		l.writeJSON("error", format, args...)
+		return
+	}
 	errorf(format, l.name, args...)
 }
 
 // Fatalf will print error level messages
 func (l *Logger) Fatalf(format string, args ...interface{}) {
+	if logFormat == FormatJSON {
+		l.writeJSON("fatal", format, args...)
+		glog.Flush()
+		os.Exit(255)
+	}
 	fatalf(format, l.name, args...)
 }
 
 // Exitf will print error level messages and exit
 func (l *Logger) Exitf(format string, args ...interface{}) {
+	if logFormat == FormatJSON {
+		l.writeJSON("fatal", format, args...)
+		glog.Flush()
+		os.Exit(1)
+	}
 	exitf(format, l.name, args...)
 }
 
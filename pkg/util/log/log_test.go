@@ -1 +1,62 @@
 package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetFormat(t *testing.T) {
+	defer func() { logFormat = FormatText }()
+
+	assert.NoError(t, SetFormat(FormatJSON))
+	assert.Equal(t, FormatJSON, logFormat)
+	assert.Equal(t, errors.New(`log format must be either "text" or "json", got "xml"`), SetFormat("xml"))
+	assert.Equal(t, FormatJSON, logFormat)
+}
+
+func TestLogger_JSON(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		logger   *Logger
+		log      func(l *Logger)
+		expected jsonEntry
+	}{
+		{
+			name:   "ingress loggers carry the ingress",
+			logger: New("ns/ingress"),
+			log: func(l *Logger) {
+				l.Infof("creating %v", "targetGroup")
+			},
+			expected: jsonEntry{Level: "info", Namespace: "ns", Ingress: "ingress", Message: "creating targetGroup"},
+		},
+		{
+			name:   "other loggers carry their component",
+			logger: New("util"),
+			log: func(l *Logger) {
+				l.Errorf("failed\ndue to %v", "AccessDenied")
+			},
+			expected: jsonEntry{Level: "error", Component: "util", Message: "failed\ndue to AccessDenied"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			jsonOutput, logFormat = buf, FormatJSON
+			defer func() {
+				jsonOutput, logFormat = os.Stderr, FormatText
+			}()
+
+			tc.log(tc.logger)
+
+			var entry jsonEntry
+			assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			assert.NotEmpty(t, entry.Time)
+			entry.Time = ""
+			assert.Equal(t, tc.expected, entry)
+		})
+	}
+}
@@ -29,15 +29,15 @@ func GetAWSResourcesByIngress(cloud aws.CloudAPI, clusterName string, namespace
 		generator.TagKeyIngressName: {ingressName},
 	}
 
-	albs, err := cloud.GetResourcesByFilters(ingResFilter, aws.ResourceTypeEnumELBLoadBalancer)
+	albs, err := cloud.GetResourcesByFilters(context.Background(), ingResFilter, aws.ResourceTypeEnumELBLoadBalancer)
 	if err != nil {
 		return AWSResources{}, err
 	}
-	tgs, err := cloud.GetResourcesByFilters(ingResFilter, aws.ResourceTypeEnumELBTargetGroup)
+	tgs, err := cloud.GetResourcesByFilters(context.Background(), ingResFilter, aws.ResourceTypeEnumELBTargetGroup)
 	if err != nil {
 		return AWSResources{}, err
 	}
-	sgs, err := cloud.GetResourcesByFilters(sgResFilter, aws.ResourceTypeEnumEC2SecurityGroup)
+	sgs, err := cloud.GetResourcesByFilters(context.Background(), sgResFilter, aws.ResourceTypeEnumEC2SecurityGroup)
 	if err != nil {
 		return AWSResources{}, err
 	}